@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// printer writes log records as text or NDJSON.
+type printer struct {
+	w    io.Writer
+	json bool
+}
+
+// print writes one record: as JSON, or as
+// "TIMESTAMP LEVEL [service] message {metadata}".
+func (p *printer) print(rec *logwell.LogRecord) error {
+	if p.json {
+		b, err := json.Marshal(rec)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(p.w, "%s\n", b)
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString(rec.Timestamp.UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+	fmt.Fprintf(&b, " %-5s", strings.ToUpper(string(rec.Level)))
+	if rec.Service != "" {
+		fmt.Fprintf(&b, " [%s]", rec.Service)
+	}
+	b.WriteByte(' ')
+	b.WriteString(rec.Message)
+	if len(rec.Metadata) > 0 {
+		meta, err := json.Marshal(rec.Metadata)
+		if err == nil {
+			b.WriteByte(' ')
+			b.Write(meta)
+		}
+	}
+	b.WriteByte('\n')
+	_, err := io.WriteString(p.w, b.String())
+	return err
+}
+
+// parseLevels parses a comma-separated level list.
+func parseLevels(s string) ([]logwell.LogLevel, error) {
+	var levels []logwell.LogLevel
+	for name := range strings.SplitSeq(s, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		level, err := logwell.ParseLevel(name)
+		if err != nil {
+			return nil, usageError{err.Error()}
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+// parseTime parses a time flag: a duration before now ("90m", "2h") or an
+// RFC 3339 timestamp. Empty is the zero time.
+func parseTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, usagef("invalid time %q: want a duration such as 1h or an RFC 3339 timestamp", s)
+}
@@ -0,0 +1,181 @@
+// Command logwell sends, searches, and tails Logwell logs from a shell.
+//
+// Usage:
+//
+//	logwell send [flags] message...
+//	logwell send --stdin [flags] < app.log
+//	logwell search [flags] [text]
+//	logwell tail [flags]
+//
+// send authenticates with a project API key. search and tail read logs
+// with a dashboard session token, like logwell.QueryClient. Settings come
+// from flags or the environment:
+//
+//	LOGWELL_ENDPOINT   server URL (all commands)
+//	LOGWELL_API_KEY    project API key (send)
+//	LOGWELL_PROJECT    project ID (search, tail)
+//	LOGWELL_SESSION    better-auth.session_token cookie value (search, tail)
+//
+// Examples:
+//
+//	logwell send --level warn --meta orderId=o-1 "payment retried"
+//	tail -F app.log | logwell send --stdin --parser json --service api
+//	logwell search "timeout" --since 1h --level error,fatal
+//	logwell tail --filter level=error --filter service=api
+//
+// Run a command with -h for its flags.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+const usage = `Usage: logwell <command> [flags] [args]
+
+Commands:
+  send     send a log, or one per line of stdin
+  search   search stored logs
+  tail     follow new logs as they arrive
+
+Run "logwell <command> -h" for the flags of a command.
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], &env{
+		stdin:  os.Stdin,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+	})
+	stop()
+	os.Exit(code)
+}
+
+// env holds the process environment, replaced in tests.
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+}
+
+// run executes the command in args and returns the exit code.
+func run(ctx context.Context, args []string, e *env) int {
+	if len(args) == 0 {
+		fmt.Fprint(e.stderr, usage)
+		return exitUsage
+	}
+
+	var err error
+	switch args[0] {
+	case "send":
+		err = runSend(ctx, args[1:], e)
+	case "search":
+		err = runSearch(ctx, args[1:], e)
+	case "tail":
+		err = runTail(ctx, args[1:], e)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(e.stdout, usage)
+		return exitOK
+	default:
+		fmt.Fprintf(e.stderr, "logwell: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+
+	var usageErr usageError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(e.stderr, "logwell %s: %v\n", args[0], err)
+		return exitUsage
+	case errors.Is(err, context.Canceled):
+		return exitOK
+	default:
+		fmt.Fprintf(e.stderr, "logwell %s: %v\n", args[0], err)
+		return exitError
+	}
+}
+
+// usageError reports invalid arguments.
+type usageError struct{ msg string }
+
+func (e usageError) Error() string { return e.msg }
+
+// usagef returns a usageError.
+func usagef(format string, args ...any) error {
+	return usageError{fmt.Sprintf(format, args...)}
+}
+
+// parseFlags parses args with fs, allowing flags after positional
+// arguments ("search timeout --since 1h"), and returns the positional
+// arguments. Arguments after "--" are all positional.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, usageError{err.Error()}
+		}
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		if len(args) > len(rest) && args[len(args)-len(rest)-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// stringList is a repeatable flag.
+type stringList []string
+
+func (l *stringList) String() string { return strings.Join(*l, ",") }
+
+func (l *stringList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// newFlagSet returns a flag set for a command that writes help to stderr.
+func newFlagSet(name, synopsis string, e *env) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(e.stderr, "Usage: logwell %s %s\n\nFlags:\n", name, synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// setting returns the flag value, or the environment variable when the
+// flag is empty, or an error naming both.
+func setting(e *env, flagValue, flagName, envName string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	if v := e.getenv(envName); v != "" {
+		return v, nil
+	}
+	return "", usagef("-%s or %s is required", flagName, envName)
+}
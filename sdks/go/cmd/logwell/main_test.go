@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+const testAPIKey = "lw_00000000000000000000000000000000"
+
+// runCLI runs the command with args and the given environment variables
+// and returns the exit code, stdout, and stderr.
+func runCLI(t *testing.T, stdin string, vars map[string]string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &env{
+		stdin:  strings.NewReader(stdin),
+		stdout: &stdout,
+		stderr: &stderr,
+		getenv: func(key string) string { return vars[key] },
+	})
+	return code, stdout.String(), stderr.String()
+}
+
+// ingestServer records the entries sent to /v1/ingest.
+type ingestServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	entries []map[string]any
+}
+
+func newIngestServer(t *testing.T) *ingestServer {
+	s := &ingestServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []map[string]any
+		if r.URL.Path != "/v1/ingest" || json.NewDecoder(r.Body).Decode(&batch) != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.mu.Lock()
+		s.entries = append(s.entries, batch...)
+		s.mu.Unlock()
+		fmt.Fprintf(w, `{"accepted":%d}`, len(batch))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestSend(t *testing.T) {
+	srv := newIngestServer(t)
+	vars := map[string]string{"LOGWELL_ENDPOINT": srv.URL, "LOGWELL_API_KEY": testAPIKey}
+
+	code, _, stderr := runCLI(t, "", vars, "send", "--level", "warn", "payment", "retried", "--service", "billing", "--meta", "orderId=o-1")
+	if code != exitOK {
+		t.Fatalf("exit code = %d, stderr = %q", code, stderr)
+	}
+	if len(srv.entries) != 1 {
+		t.Fatalf("sent %d entries, want 1", len(srv.entries))
+	}
+	e := srv.entries[0]
+	if e["level"] != "warn" || e["message"] != "payment retried" || e["service"] != "billing" {
+		t.Errorf("entry = %v", e)
+	}
+	if meta, _ := e["metadata"].(map[string]any); meta["orderId"] != "o-1" {
+		t.Errorf("metadata = %v", e["metadata"])
+	}
+}
+
+func TestSendStdin(t *testing.T) {
+	srv := newIngestServer(t)
+	vars := map[string]string{"LOGWELL_ENDPOINT": srv.URL, "LOGWELL_API_KEY": testAPIKey}
+	input := `{"level":"error","msg":"disk full"}` + "\n\nnot json\r\n"
+
+	code, _, stderr := runCLI(t, input, vars, "send", "--stdin", "--parser", "json", "--level", "debug")
+	if code != exitOK {
+		t.Fatalf("exit code = %d, stderr = %q", code, stderr)
+	}
+	if len(srv.entries) != 2 {
+		t.Fatalf("sent %v, want 2 entries", srv.entries)
+	}
+	if e := srv.entries[0]; e["level"] != "error" || e["message"] != "disk full" {
+		t.Errorf("parsed entry = %v", e)
+	}
+	if e := srv.entries[1]; e["level"] != "debug" || e["message"] != "not json" {
+		t.Errorf("unparsed entry = %v, want sent at --level", e)
+	}
+}
+
+func TestSendStdinBackpressure(t *testing.T) {
+	srv := newIngestServer(t)
+	vars := map[string]string{"LOGWELL_ENDPOINT": srv.URL, "LOGWELL_API_KEY": testAPIKey}
+	input := strings.Repeat("line\n", 3*logwell.DefaultMaxQueueSize)
+
+	code, _, stderr := runCLI(t, input, vars, "send", "--stdin")
+	if code != exitOK {
+		t.Fatalf("exit code = %d, stderr = %q", code, stderr)
+	}
+	if n := len(srv.entries); n != 3*logwell.DefaultMaxQueueSize {
+		t.Errorf("sent %d entries, want all %d", n, 3*logwell.DefaultMaxQueueSize)
+	}
+}
+
+func TestSendFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+	vars := map[string]string{"LOGWELL_ENDPOINT": srv.URL, "LOGWELL_API_KEY": testAPIKey}
+
+	code, _, stderr := runCLI(t, "", vars, "send", "hello")
+	if code != exitError || stderr == "" {
+		t.Errorf("exit code = %d, stderr = %q; want a reported failure", code, stderr)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	vars := map[string]string{"LOGWELL_ENDPOINT": "http://localhost:3000", "LOGWELL_API_KEY": testAPIKey}
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "Usage"},
+		{[]string{"frobnicate"}, "unknown command"},
+		{[]string{"send"}, "a message or --stdin is required"},
+		{[]string{"send", "--stdin", "msg"}, "cannot be combined"},
+		{[]string{"send", "--level", "loud", "msg"}, "invalid level"},
+		{[]string{"send", "--meta", "novalue", "msg"}, "want key=value"},
+		{[]string{"send", "--stdin", "--parser", "xml"}, "unknown parser"},
+		{[]string{"send", "--bogus"}, "flag provided but not defined"},
+		{[]string{"search", "--since", "yesterday"}, "invalid time"},
+		{[]string{"search"}, "LOGWELL_PROJECT"},
+		{[]string{"tail", "--filter", "host=web-1"}, "unknown --filter key"},
+		{[]string{"tail", "extra"}, "unexpected argument"},
+	}
+	for _, tt := range tests {
+		code, _, stderr := runCLI(t, "", vars, tt.args...)
+		if code != exitUsage || !strings.Contains(stderr, tt.want) {
+			t.Errorf("%q: exit code = %d, stderr = %q; want %d and %q", tt.args, code, stderr, exitUsage, tt.want)
+		}
+	}
+	if code, _, stderr := runCLI(t, "", nil, "send", "msg"); code != exitUsage || !strings.Contains(stderr, "LOGWELL_ENDPOINT") {
+		t.Errorf("send without endpoint: exit code = %d, stderr = %q", code, stderr)
+	}
+}
+
+func TestHelp(t *testing.T) {
+	if code, stdout, _ := runCLI(t, "", nil, "help"); code != exitOK || !strings.Contains(stdout, "Commands:") {
+		t.Errorf("help: exit code = %d, stdout = %q", code, stdout)
+	}
+	if code, _, stderr := runCLI(t, "", nil, "search", "-h"); code != exitOK || !strings.Contains(stderr, "-since") {
+		t.Errorf("search -h: exit code = %d, stderr = %q", code, stderr)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	var query string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/projects/proj_1/logs" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.RawQuery
+		fmt.Fprint(w, `{"logs":[
+			{"id":"1","level":"error","message":"upstream timeout","serviceName":"api","metadata":{"ms":812},"timestamp":"2026-01-05T10:20:30.123Z"},
+			{"id":"2","level":"warn","message":"slow timeout","timestamp":"2026-01-05T10:20:29Z"},
+			{"id":"3","level":"error","message":"third","timestamp":"2026-01-05T10:20:28Z"}
+		],"has_more":false}`)
+	}))
+	defer srv.Close()
+	vars := map[string]string{"LOGWELL_ENDPOINT": srv.URL, "LOGWELL_PROJECT": "proj_1", "LOGWELL_SESSION": "token"}
+
+	code, stdout, stderr := runCLI(t, "", vars, "search", "timeout", "--since", "1h", "--level", "error,warn", "--limit", "2")
+	if code != exitOK {
+		t.Fatalf("exit code = %d, stderr = %q", code, stderr)
+	}
+	want := "2026-01-05T10:20:30.123Z ERROR [api] upstream timeout {\"ms\":812}\n" +
+		"2026-01-05T10:20:29.000Z WARN  slow timeout\n"
+	if stdout != want {
+		t.Errorf("stdout =\n%s\nwant\n%s", stdout, want)
+	}
+	for _, param := range []string{"search=timeout", "level=error%2Cwarn", "from=", "limit=2"} {
+		if !strings.Contains(query, param) {
+			t.Errorf("query = %q, want %s", query, param)
+		}
+	}
+
+	code, stdout, _ = runCLI(t, "", vars, "search", "--json", "--limit", "1")
+	var rec logwell.LogRecord
+	if code != exitOK || json.Unmarshal([]byte(stdout), &rec) != nil || rec.ID != "1" {
+		t.Errorf("search --json: exit code = %d, stdout = %q", code, stdout)
+	}
+}
+
+func TestParseFlags(t *testing.T) {
+	fs := newFlagSet("test", "", &env{stderr: &bytes.Buffer{}})
+	level := fs.String("level", "", "")
+	positional, err := parseFlags(fs, []string{"a", "--level", "warn", "b", "--", "--not-a-flag"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *level != "warn" || strings.Join(positional, " ") != "a b --not-a-flag" {
+		t.Errorf("level = %q, positional = %q", *level, positional)
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	if got, _ := parseTime("90m", now); !got.Equal(now.Add(-90 * time.Minute)) {
+		t.Errorf("parseTime(90m) = %v", got)
+	}
+	if got, _ := parseTime("2026-01-01T00:00:00Z", now); !got.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("parseTime(RFC 3339) = %v", got)
+	}
+	if got, err := parseTime("", now); err != nil || !got.IsZero() {
+		t.Errorf("parseTime(\"\") = %v, %v", got, err)
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	f, err := parseFilters([]string{"level=error,fatal", "service=api", "text=timed out"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Levels) != 2 || f.Levels[1] != logwell.LevelFatal || f.Service != "api" || f.Text != "timed out" {
+		t.Errorf("filter = %+v", f)
+	}
+}
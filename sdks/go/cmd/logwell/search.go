@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strings"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// runSearch implements "logwell search".
+func runSearch(ctx context.Context, args []string, e *env) error {
+	fs := newFlagSet("search", "[flags] [text]", e)
+	rf := addReadFlags(fs)
+	since := fs.String("since", "", "only logs after this time: a duration ago (1h) or an RFC 3339 timestamp")
+	until := fs.String("until", "", "only logs before this time: a duration ago or an RFC 3339 timestamp")
+	levels := fs.String("level", "", "comma-separated levels to match, such as error,fatal")
+	service := fs.String("service", "", "only logs from this service")
+	limit := fs.Int("limit", 100, "maximum number of logs to print; 0 for all")
+	asJSON := fs.Bool("json", false, "print logs as NDJSON")
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	query := logwell.Query{Text: strings.Join(positional, " "), Service: *service}
+	if query.TimeRange.From, err = parseTime(*since, now); err != nil {
+		return err
+	}
+	if query.TimeRange.To, err = parseTime(*until, now); err != nil {
+		return err
+	}
+	if query.Levels, err = parseLevels(*levels); err != nil {
+		return err
+	}
+	if *limit < 0 {
+		return usagef("-limit must not be negative")
+	}
+	if *limit > 0 {
+		query.Limit = min(*limit, 500)
+	}
+	qc, err := rf.queryClient(e)
+	if err != nil {
+		return err
+	}
+
+	p := &printer{w: e.stdout, json: *asJSON}
+	printed := 0
+	for rec, err := range qc.SearchAll(ctx, query) {
+		if err != nil {
+			return err
+		}
+		if err := p.print(&rec); err != nil {
+			return err
+		}
+		if printed++; printed == *limit {
+			break
+		}
+	}
+	return nil
+}
+
+// readFlags are the connection flags of the read commands.
+type readFlags struct {
+	endpoint, project, session *string
+}
+
+// addReadFlags defines the connection flags on fs.
+func addReadFlags(fs *flag.FlagSet) *readFlags {
+	return &readFlags{
+		endpoint: fs.String("endpoint", "", "server URL (default $LOGWELL_ENDPOINT)"),
+		project:  fs.String("project", "", "project ID (default $LOGWELL_PROJECT)"),
+		session:  fs.String("session", "", "dashboard session token (default $LOGWELL_SESSION)"),
+	}
+}
+
+// queryClient returns a QueryClient from the flags and environment.
+func (rf *readFlags) queryClient(e *env) (*logwell.QueryClient, error) {
+	endpoint, err := setting(e, *rf.endpoint, "endpoint", "LOGWELL_ENDPOINT")
+	if err != nil {
+		return nil, err
+	}
+	project, err := setting(e, *rf.project, "project", "LOGWELL_PROJECT")
+	if err != nil {
+		return nil, err
+	}
+	session, err := setting(e, *rf.session, "session", "LOGWELL_SESSION")
+	if err != nil {
+		return nil, err
+	}
+	return logwell.NewQueryClient(endpoint, project, logwell.WithSessionToken(session))
+}
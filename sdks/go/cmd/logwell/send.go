@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwellparse"
+)
+
+// maxStdinLine bounds one line read by send --stdin.
+const maxStdinLine = 1 << 20
+
+// stdinFlushEvery is how many stdin lines send queues before flushing
+// synchronously, so piping a large file applies backpressure instead of
+// overflowing the queue.
+const stdinFlushEvery = logwell.DefaultMaxQueueSize / 2
+
+// parsers are the --parser choices of send --stdin.
+var parsers = map[string]func(level logwell.LogLevel) logwellparse.Parser{
+	"plain":    logwellparse.Plain,
+	"json":     func(logwell.LogLevel) logwellparse.Parser { return logwellparse.JSON() },
+	"logfmt":   func(logwell.LogLevel) logwellparse.Parser { return logwellparse.Logfmt() },
+	"combined": func(logwell.LogLevel) logwellparse.Parser { return logwellparse.Combined() },
+}
+
+// runSend implements "logwell send".
+func runSend(ctx context.Context, args []string, e *env) error {
+	fs := newFlagSet("send", "[flags] message... | --stdin", e)
+	endpoint := fs.String("endpoint", "", "server URL (default $LOGWELL_ENDPOINT)")
+	apiKey := fs.String("api-key", "", "project API key (default $LOGWELL_API_KEY)")
+	levelName := fs.String("level", "info", "level: debug, info, warn, error, or fatal")
+	service := fs.String("service", "", "service name")
+	var meta stringList
+	fs.Var(&meta, "meta", "metadata `key=value`; repeatable")
+	stdin := fs.Bool("stdin", false, "send one log per line of standard input")
+	parserName := fs.String("parser", "plain", "with --stdin, line format: plain, json, logfmt, or combined; unparsable lines are sent as plain")
+	timeout := fs.Duration("timeout", 30*time.Second, "how long to wait for delivery when done")
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	level, err := logwell.ParseLevel(*levelName)
+	if err != nil {
+		return usageError{err.Error()}
+	}
+	metadata, err := parseMeta(meta)
+	if err != nil {
+		return err
+	}
+	newParser, ok := parsers[*parserName]
+	if !ok {
+		return usagef("unknown parser %q", *parserName)
+	}
+	switch {
+	case *stdin && len(positional) > 0:
+		return usagef("a message cannot be combined with --stdin")
+	case !*stdin && len(positional) == 0:
+		return usagef("a message or --stdin is required")
+	}
+	endpointURL, err := setting(e, *endpoint, "endpoint", "LOGWELL_ENDPOINT")
+	if err != nil {
+		return err
+	}
+	key, err := setting(e, *apiKey, "api-key", "LOGWELL_API_KEY")
+	if err != nil {
+		return err
+	}
+
+	opts := []logwell.Option{logwell.WithFatalBehavior(logwell.LogOnly)}
+	if *service != "" {
+		opts = append(opts, logwell.WithService(*service))
+	}
+	if len(metadata) > 0 {
+		opts = append(opts, logwell.WithMetadata(metadata))
+	}
+	client, err := logwell.New(endpointURL, key, opts...)
+	if err != nil {
+		return err
+	}
+
+	if *stdin {
+		err = sendLines(ctx, client, newParser(level), level, e)
+	} else {
+		client.Log(logwell.LogEntry{Level: level, Message: strings.Join(positional, " ")})
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), *timeout)
+	defer cancel()
+	if shutdownErr := client.Shutdown(shutdownCtx); shutdownErr != nil && err == nil {
+		err = shutdownErr
+	}
+	if dropped := client.Stats().Dropped; dropped > 0 && err == nil {
+		err = fmt.Errorf("%d logs dropped: queue full", dropped)
+	}
+	return err
+}
+
+// sendLines logs each line of stdin until EOF or ctx is done.
+// Lines the parser rejects are sent whole at level.
+func sendLines(ctx context.Context, client *logwell.Client, parser logwellparse.Parser, level logwell.LogLevel, e *env) error {
+	scanner := bufio.NewScanner(e.stdin)
+	scanner.Buffer(make([]byte, 64<<10), maxStdinLine)
+	for n := 1; scanner.Scan(); n++ {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if n%stdinFlushEvery == 0 {
+			if err := client.Flush(ctx); err != nil && ctx.Err() == nil {
+				return err
+			}
+		}
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		entry, err := parser.Parse(line)
+		if err != nil {
+			entry = logwell.LogEntry{Level: level, Message: line}
+		}
+		client.Log(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("reading stdin: %w", err)
+	}
+	return nil
+}
+
+// parseMeta converts key=value flags to metadata.
+func parseMeta(pairs []string) (logwell.M, error) {
+	meta := logwell.M{}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, usagef("invalid --meta %q: want key=value", pair)
+		}
+		meta[key] = value
+	}
+	return meta, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// runTail implements "logwell tail".
+func runTail(ctx context.Context, args []string, e *env) error {
+	fs := newFlagSet("tail", "[flags]", e)
+	rf := addReadFlags(fs)
+	var filters stringList
+	fs.Var(&filters, "filter", "`key=value` filter on level (comma-separated), service, or text; repeatable")
+	asJSON := fs.Bool("json", false, "print logs as NDJSON")
+
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 0 {
+		return usagef("unexpected argument %q", positional[0])
+	}
+	filter, err := parseFilters(filters)
+	if err != nil {
+		return err
+	}
+	qc, err := rf.queryClient(e)
+	if err != nil {
+		return err
+	}
+
+	var streamErr error
+	logs, err := qc.Stream(ctx, filter, logwell.WithStreamOnError(func(err error) {
+		streamErr = err
+		fmt.Fprintf(e.stderr, "logwell tail: %v; reconnecting\n", err)
+	}))
+	if err != nil {
+		return err
+	}
+	p := &printer{w: e.stdout, json: *asJSON}
+	for rec := range logs {
+		if err := p.print(&rec); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return streamErr
+}
+
+// parseFilters converts key=value flags to a stream filter.
+func parseFilters(filters []string) (logwell.StreamFilter, error) {
+	var f logwell.StreamFilter
+	for _, filter := range filters {
+		key, value, ok := strings.Cut(filter, "=")
+		if !ok {
+			return f, usagef("invalid --filter %q: want key=value", filter)
+		}
+		switch key {
+		case "level":
+			levels, err := parseLevels(value)
+			if err != nil {
+				return f, err
+			}
+			f.Levels = append(f.Levels, levels...)
+		case "service":
+			f.Service = value
+		case "text":
+			f.Text = value
+		default:
+			return f, usagef("unknown --filter key %q: want level, service, or text", key)
+		}
+	}
+	return f, nil
+}
@@ -0,0 +1,15 @@
+// Package logwelldocker forwards the logs of Docker containers to
+// Logwell, for single-host deployments that want container logs without
+// running a full log agent.
+//
+// # Usage
+//
+//	err := logwelldocker.Follow(ctx, client)
+//
+// Follow talks to the Docker Engine API (the socket in DOCKER_HOST, or
+// /var/run/docker.sock), attaches to every running container and to each
+// container that starts later, and forwards their stdout and stderr lines
+// through the client's batching pipeline. Entries carry the container's
+// name, ID, image, and labels as metadata. Use WithLabelFilter to forward
+// only opted-in containers.
+package logwelldocker
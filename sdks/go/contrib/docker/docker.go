@@ -0,0 +1,434 @@
+package logwelldocker
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// DefaultHost is the Docker daemon address used when neither WithHost nor
+// DOCKER_HOST is set.
+const DefaultHost = "unix:///var/run/docker.sock"
+
+// ComposeServiceLabel is the label Docker Compose sets to the service
+// name; when present it is used as the entry's service.
+const ComposeServiceLabel = "com.docker.compose.service"
+
+// maxLineSize bounds one log line from a TTY container. Longer lines are
+// split.
+const maxLineSize = 64 << 10
+
+// Option configures Follow.
+type Option func(*config)
+
+type config struct {
+	host        string
+	httpClient  *http.Client
+	labels      []string
+	stdoutLevel logwell.LogLevel
+	stderrLevel logwell.LogLevel
+	onError     func(error)
+}
+
+// WithHost sets the Docker daemon address, as "unix:///path/to.sock" or
+// "tcp://host:port". Default: DOCKER_HOST, or DefaultHost.
+func WithHost(host string) Option {
+	return func(c *config) {
+		c.host = host
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to reach the daemon, for TLS or
+// custom dialing. Its Timeout must be zero, since log streams stay open.
+// Default: a client dialing the WithHost address.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		c.httpClient = client
+	}
+}
+
+// WithLabelFilter forwards only containers with the given labels, each
+// "key" or "key=value" (for example "logwell.enable=true"), using the
+// daemon's label filter. Default: all containers.
+func WithLabelFilter(labels ...string) Option {
+	return func(c *config) {
+		c.labels = append(c.labels, labels...)
+	}
+}
+
+// WithLevels sets the levels of lines written to stdout and stderr.
+// Default: INFO and ERROR.
+func WithLevels(stdout, stderr logwell.LogLevel) Option {
+	return func(c *config) {
+		c.stdoutLevel, c.stderrLevel = stdout, stderr
+	}
+}
+
+// WithOnError sets a callback for errors attaching to or reading a
+// container's logs. They are otherwise ignored; the container is attached
+// again only if it restarts.
+func WithOnError(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// Follow forwards the stdout and stderr of the host's containers through
+// client until ctx is done, then flushes the client and returns ctx's
+// error. It returns earlier with an error if the Docker daemon cannot be
+// reached or its event stream ends.
+//
+// Containers already running are forwarded from the moment Follow starts;
+// containers started later are forwarded from their start, so a restarted
+// container's earlier runs are not sent again. Each line
+// becomes an entry carrying the container's name, ID, image, and labels as
+// metadata, and the container's Compose service (or name) as the service.
+// Docker's timestamp for the line is kept.
+//
+// Example:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+//	defer stop()
+//	err := logwelldocker.Follow(ctx, client, logwelldocker.WithLabelFilter("logwell.enable=true"))
+func Follow(ctx context.Context, client *logwell.Client, opts ...Option) error {
+	cfg := &config{stdoutLevel: logwell.LevelInfo, stderrLevel: logwell.LevelError}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	d, err := newDaemon(cfg)
+	if err != nil {
+		return err
+	}
+
+	f := &follower{cfg: cfg, daemon: d, client: client, attached: make(map[string]struct{})}
+	err = f.run(ctx)
+	f.wg.Wait()
+	_ = client.Flush(context.WithoutCancel(ctx))
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// follower attaches to container log streams.
+type follower struct {
+	cfg    *config
+	daemon *daemon
+	client *logwell.Client
+
+	mu       sync.Mutex
+	attached map[string]struct{}
+	wg       sync.WaitGroup
+}
+
+// run subscribes to container start events, attaches to the running
+// containers, and then attaches to each container that starts.
+func (f *follower) run(ctx context.Context) error {
+	// Subscribe before listing so no container starts unseen in between.
+	events, err := f.daemon.get(ctx, "/events", url.Values{"filters": {f.filters(map[string][]string{
+		"type":  {"container"},
+		"event": {"start"},
+	})}})
+	if err != nil {
+		return err
+	}
+	defer events.Close()
+
+	list, err := f.daemon.get(ctx, "/containers/json", url.Values{"filters": {f.filters(nil)}})
+	if err != nil {
+		return err
+	}
+	var running []struct{ ID string }
+	err = json.NewDecoder(list).Decode(&running)
+	list.Close()
+	if err != nil {
+		return fmt.Errorf("logwelldocker: listing containers: %w", err)
+	}
+	since := time.Now()
+	for _, c := range running {
+		f.attach(ctx, c.ID, since)
+	}
+
+	dec := json.NewDecoder(events)
+	for {
+		var event struct {
+			Action   string
+			Actor    struct{ ID string }
+			Time     int64 `json:"time"`
+			TimeNano int64 `json:"timeNano"`
+		}
+		if err := dec.Decode(&event); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("logwelldocker: reading events: %w", err)
+		}
+		if event.Action == "start" {
+			// Logs from before the start belong to earlier runs of a
+			// restarted container, which the json-file driver keeps.
+			since := time.Time{}
+			switch {
+			case event.TimeNano > 0:
+				since = time.Unix(0, event.TimeNano)
+			case event.Time > 0:
+				since = time.Unix(event.Time, 0)
+			}
+			f.attach(ctx, event.Actor.ID, since)
+		}
+	}
+}
+
+// filters encodes the label filter plus extra as a daemon filters value.
+func (f *follower) filters(extra map[string][]string) string {
+	filters := map[string][]string{}
+	for k, v := range extra {
+		filters[k] = v
+	}
+	if len(f.cfg.labels) > 0 {
+		filters["label"] = f.cfg.labels
+	}
+	b, _ := json.Marshal(filters)
+	return string(b)
+}
+
+// attach forwards the logs of container id written at or after since (all
+// of them when since is zero) until the stream ends. A container already
+// attached is skipped.
+func (f *follower) attach(ctx context.Context, id string, since time.Time) {
+	f.mu.Lock()
+	if _, ok := f.attached[id]; ok {
+		f.mu.Unlock()
+		return
+	}
+	f.attached[id] = struct{}{}
+	f.wg.Add(1)
+	f.mu.Unlock()
+
+	go func() {
+		defer f.wg.Done()
+		defer func() {
+			f.mu.Lock()
+			delete(f.attached, id)
+			f.mu.Unlock()
+		}()
+		if err := f.stream(ctx, id, since); err != nil && ctx.Err() == nil && f.cfg.onError != nil {
+			f.cfg.onError(err)
+		}
+	}()
+}
+
+// container is the part of a container inspection Follow uses.
+type container struct {
+	ID     string
+	Name   string
+	Config struct {
+		Image  string
+		Labels map[string]string
+		Tty    bool
+	}
+}
+
+// stream forwards one container's logs.
+func (f *follower) stream(ctx context.Context, id string, since time.Time) error {
+	body, err := f.daemon.get(ctx, "/containers/"+url.PathEscape(id)+"/json", nil)
+	if err != nil {
+		return err
+	}
+	var c container
+	err = json.NewDecoder(body).Decode(&c)
+	body.Close()
+	if err != nil {
+		return fmt.Errorf("logwelldocker: inspecting %s: %w", id, err)
+	}
+
+	query := url.Values{"follow": {"1"}, "stdout": {"1"}, "stderr": {"1"}, "timestamps": {"1"}}
+	if !since.IsZero() {
+		query.Set("since", fmt.Sprintf("%d.%09d", since.Unix(), since.Nanosecond()))
+	}
+	logs, err := f.daemon.get(ctx, "/containers/"+url.PathEscape(id)+"/logs", query)
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+
+	logger := f.client.With(containerMetadata(&c))
+	emit := func(stream, line string) {
+		level := f.cfg.stdoutLevel
+		if stream == "stderr" {
+			level = f.cfg.stderrLevel
+		}
+		logger.Log(lineEntry(line, level, stream, serviceName(&c)))
+	}
+	if c.Config.Tty {
+		err = readLines(logs, emit)
+	} else {
+		err = readFrames(logs, emit)
+	}
+	if err != nil {
+		return fmt.Errorf("logwelldocker: reading logs of %s: %w", c.Name, err)
+	}
+	return nil
+}
+
+// containerMetadata describes c.
+func containerMetadata(c *container) logwell.M {
+	meta := logwell.M{
+		"container":   strings.TrimPrefix(c.Name, "/"),
+		"containerId": c.ID[:min(12, len(c.ID))],
+		"image":       c.Config.Image,
+	}
+	if len(c.Config.Labels) > 0 {
+		meta["labels"] = c.Config.Labels
+	}
+	return meta
+}
+
+// serviceName returns the Compose service of c, or its name.
+func serviceName(c *container) string {
+	if s := c.Config.Labels[ComposeServiceLabel]; s != "" {
+		return s
+	}
+	return strings.TrimPrefix(c.Name, "/")
+}
+
+// lineEntry converts a timestamped log line to an entry.
+func lineEntry(line string, level logwell.LogLevel, stream, service string) logwell.LogEntry {
+	line = strings.TrimRight(line, "\r\n")
+	e := logwell.LogEntry{Level: level, Service: service, Metadata: logwell.M{"stream": stream}}
+	if ts, rest, ok := strings.Cut(line, " "); ok {
+		if t, err := time.Parse(time.RFC3339Nano, ts); err == nil {
+			e.Timestamp = t.UTC().Format("2006-01-02T15:04:05.000Z07:00")
+			line = rest
+		}
+	}
+	e.Message = line
+	return e
+}
+
+// readFrames reads the multiplexed stream of a container without a TTY:
+// frames with an 8-byte header giving the stream and payload length, each
+// holding one line. Payloads longer than maxLineSize are split.
+func readFrames(r io.Reader, emit func(stream, line string)) error {
+	var header [8]byte
+	buf := make([]byte, maxLineSize)
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		stream := "stdout"
+		if header[0] == 2 {
+			stream = "stderr"
+		}
+		for n := int(binary.BigEndian.Uint32(header[4:])); n > 0; {
+			chunk := buf[:min(n, maxLineSize)]
+			if _, err := io.ReadFull(r, chunk); err != nil {
+				return err
+			}
+			emit(stream, string(chunk))
+			n -= len(chunk)
+		}
+	}
+}
+
+// readLines reads the raw stream of a container with a TTY, which merges
+// stdout and stderr.
+func readLines(r io.Reader, emit func(stream, line string)) error {
+	br := bufio.NewReaderSize(r, maxLineSize)
+	for {
+		line, err := br.ReadSlice('\n')
+		if len(line) > 0 {
+			emit("stdout", string(line))
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+			return err
+		}
+	}
+}
+
+// daemon is a minimal Docker Engine API client.
+type daemon struct {
+	base   string
+	client *http.Client
+}
+
+// newDaemon returns a client for the daemon cfg selects.
+func newDaemon(cfg *config) (*daemon, error) {
+	host := cfg.host
+	if host == "" {
+		host = os.Getenv("DOCKER_HOST")
+	}
+	if host == "" {
+		host = DefaultHost
+	}
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("logwelldocker: invalid host %q: %w", host, err)
+	}
+
+	d := &daemon{client: cfg.httpClient}
+	switch u.Scheme {
+	case "unix":
+		d.base = "http://docker"
+		if d.client == nil {
+			socket := u.Path
+			d.client = &http.Client{Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, "unix", socket)
+				},
+			}}
+		}
+	case "tcp", "http":
+		d.base = "http://" + u.Host
+	case "https":
+		d.base = "https://" + u.Host
+	default:
+		return nil, fmt.Errorf("logwelldocker: unsupported host scheme %q", u.Scheme)
+	}
+	if d.client == nil {
+		d.client = &http.Client{}
+	}
+	return d, nil
+}
+
+// get requests path and returns the body of a successful response.
+func (d *daemon) get(ctx context.Context, path string, query url.Values) (io.ReadCloser, error) {
+	u := d.base + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("logwelldocker: %w", err)
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("logwelldocker: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var body struct{ Message string }
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("logwelldocker: GET %s: %s: %s", path, resp.Status, body.Message)
+	}
+	return resp.Body, nil
+}
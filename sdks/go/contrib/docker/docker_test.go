@@ -0,0 +1,256 @@
+package logwelldocker
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwelltest"
+)
+
+// frame encodes a multiplexed log frame.
+func frame(stream byte, payload string) []byte {
+	header := make([]byte, 8)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	return append(header, payload...)
+}
+
+// fakeDaemon serves the Engine API endpoints Follow uses.
+type fakeDaemon struct {
+	containers map[string]container
+	logs       map[string][]byte
+	events     chan string
+
+	mu      sync.Mutex
+	queries map[string]string
+}
+
+func newFakeDaemon(t *testing.T) (*fakeDaemon, string) {
+	d := &fakeDaemon{
+		containers: make(map[string]container),
+		logs:       make(map[string][]byte),
+		events:     make(chan string, 1),
+		queries:    make(map[string]string),
+	}
+	srv := httptest.NewServer(d)
+	t.Cleanup(srv.Close)
+	return d, "tcp://" + strings.TrimPrefix(srv.URL, "http://")
+}
+
+func (d *fakeDaemon) add(id, name string, tty bool, labels map[string]string, logs []byte) {
+	c := container{ID: id, Name: "/" + name}
+	c.Config.Image = "example/" + name + ":1.0"
+	c.Config.Labels = labels
+	c.Config.Tty = tty
+	d.containers[id] = c
+	d.logs[id] = logs
+}
+
+func (d *fakeDaemon) query(path string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.queries[path]
+}
+
+func (d *fakeDaemon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	d.queries[r.URL.Path] = r.URL.RawQuery
+	d.mu.Unlock()
+
+	switch path := r.URL.Path; {
+	case path == "/events":
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case id := <-d.events:
+				fmt.Fprintf(w, `{"Type":"container","Action":"start","Actor":{"ID":%q},"time":1767608460,"timeNano":1767608460123456789}`+"\n", id)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	case path == "/containers/json":
+		list := []map[string]string{}
+		for id := range d.containers {
+			if id != "new1" {
+				list = append(list, map[string]string{"ID": id})
+			}
+		}
+		json.NewEncoder(w).Encode(list)
+	case strings.HasSuffix(path, "/json"):
+		c, ok := d.containers[strings.Split(path, "/")[2]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message":"No such container"}`)
+			return
+		}
+		json.NewEncoder(w).Encode(c)
+	case strings.HasSuffix(path, "/logs"):
+		w.Write(d.logs[strings.Split(path, "/")[2]])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// waitEntries polls rec until it holds n entries.
+func waitEntries(t *testing.T, rec *logwelltest.Recorder, n int) []logwell.LogEntry {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entries := rec.Entries()
+		if len(entries) >= n || time.Now().After(deadline) {
+			if len(entries) != n {
+				t.Fatalf("recorded %d entries, want %d: %+v", len(entries), n, entries)
+			}
+			return entries
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	d, host := newFakeDaemon(t)
+	var logs bytes.Buffer
+	logs.Write(frame(1, "2026-01-05T10:20:30.123456789Z listening on :8080\n"))
+	logs.Write(frame(2, "2026-01-05T10:20:31Z connection refused\n"))
+	d.add("old1aaaaaaaaaaaa", "api", false, map[string]string{ComposeServiceLabel: "api-svc", "tier": "web"}, logs.Bytes())
+	d.add("new1", "worker", true, nil, []byte("2026-01-05T10:21:00Z job done\r\n2026-01-05T10:21:01Z job failed\r\n"))
+
+	rec := logwelltest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- Follow(ctx, rec.Client, WithHost(host), WithLabelFilter("logwell.enable")) }()
+
+	first := waitEntries(t, rec, 2)
+	d.events <- "new1"
+	entries := waitEntries(t, rec, 4)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Follow = %v, want context.Canceled", err)
+	}
+
+	e := first[0]
+	if e.Level != logwell.LevelInfo || e.Message != "listening on :8080" || e.Timestamp != "2026-01-05T10:20:30.123Z" {
+		t.Errorf("entry = %+v", e)
+	}
+	if e.Service != "api-svc" {
+		t.Errorf("service = %q, want the Compose service", e.Service)
+	}
+	want := map[string]any{"container": "api", "containerId": "old1aaaaaaaa", "image": "example/api:1.0", "stream": "stdout"}
+	for key, v := range want {
+		if e.Metadata[key] != v {
+			t.Errorf("metadata[%q] = %v, want %v", key, e.Metadata[key], v)
+		}
+	}
+	if labels, _ := e.Metadata["labels"].(map[string]any); labels["tier"] != "web" {
+		t.Errorf("labels = %v", e.Metadata["labels"])
+	}
+	if e := first[1]; e.Level != logwell.LevelError || e.Metadata["stream"] != "stderr" || e.Message != "connection refused" {
+		t.Errorf("stderr entry = %+v", e)
+	}
+
+	for i, msg := range []string{"job done", "job failed"} {
+		e := entries[2+i]
+		if e.Message != msg || e.Service != "worker" || e.Metadata["stream"] != "stdout" {
+			t.Errorf("tty entry %d = %+v", i, e)
+		}
+	}
+
+	if q := d.query("/containers/old1aaaaaaaaaaaa/logs"); !strings.Contains(q, "since=") {
+		t.Errorf("running container logs query = %q, want since", q)
+	}
+	if q := d.query("/containers/new1/logs"); !strings.Contains(q, "since=1767608460.123456789") {
+		t.Errorf("started container logs query = %q, want since the start event", q)
+	}
+	if q := d.query("/containers/json"); !strings.Contains(q, "logwell.enable") {
+		t.Errorf("list query = %q, want label filter", q)
+	}
+}
+
+func TestFollowLevels(t *testing.T) {
+	d, host := newFakeDaemon(t)
+	d.add("c1", "api", false, nil, append(frame(1, "out\n"), frame(2, "err\n")...))
+
+	rec := logwelltest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go Follow(ctx, rec.Client, WithHost(host), WithLevels(logwell.LevelDebug, logwell.LevelWarn))
+
+	entries := waitEntries(t, rec, 2)
+	if entries[0].Level != logwell.LevelDebug || entries[1].Level != logwell.LevelWarn {
+		t.Errorf("levels = %s, %s; want debug, warn", entries[0].Level, entries[1].Level)
+	}
+}
+
+func TestFollowOnError(t *testing.T) {
+	d, host := newFakeDaemon(t)
+	errs := make(chan error, 1)
+
+	rec := logwelltest.NewRecorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go Follow(ctx, rec.Client, WithHost(host), WithOnError(func(err error) { errs <- err }))
+
+	d.events <- "missing"
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "No such container") {
+			t.Errorf("error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error reported")
+	}
+}
+
+func TestFollowUnreachable(t *testing.T) {
+	_, host := newFakeDaemon(t)
+	err := Follow(context.Background(), logwelltest.NewRecorder().Client, WithHost(host+"0"))
+	if err == nil {
+		t.Error("Follow with unreachable daemon: want error")
+	}
+	if err := Follow(context.Background(), logwelltest.NewRecorder().Client, WithHost("ftp://docker")); err == nil {
+		t.Error("Follow with unsupported scheme: want error")
+	}
+}
+
+func TestReadLinesSplitsLongLines(t *testing.T) {
+	var lines []string
+	input := strings.Repeat("x", maxLineSize+10) + "\nshort\n"
+	if err := readLines(strings.NewReader(input), func(_, line string) { lines = append(lines, line) }); err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 3 || len(lines[0]) != maxLineSize || lines[2] != "short\n" {
+		t.Errorf("got %d lines, want the long line split in two", len(lines))
+	}
+}
+
+func TestReadFramesSplitsLongPayloads(t *testing.T) {
+	var lines []string
+	input := append(frame(1, strings.Repeat("x", maxLineSize+10)), frame(2, "short\n")...)
+	err := readFrames(bytes.NewReader(input), func(_, line string) { lines = append(lines, line) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lines) != 3 || len(lines[0]) != maxLineSize || len(lines[1]) != 10 || lines[2] != "short\n" {
+		t.Errorf("got %d lines, want the long payload split in two", len(lines))
+	}
+
+	// A header announcing a huge payload is read in bounded chunks.
+	header := frame(1, "")
+	binary.BigEndian.PutUint32(header[4:], 1<<32-1)
+	if err := readFrames(bytes.NewReader(header), func(string, string) {}); !errors.Is(err, io.EOF) {
+		t.Errorf("readFrames = %v, want io.EOF for the truncated payload", err)
+	}
+}
@@ -0,0 +1,16 @@
+// Package logwellgin provides Gin middleware that logs every request through a
+// Logwell client and exposes a request-scoped child logger to handlers.
+//
+// # Usage
+//
+//	router := gin.New()
+//	router.Use(logwellgin.Middleware(client))
+//
+//	router.GET("/orders/:id", func(c *gin.Context) {
+//		log := logwellgin.Logger(c)
+//		log.Info("Loading order", logwell.M{"orderId": c.Param("id")})
+//	})
+//
+// The middleware also recovers panics: the panic value and stack trace are
+// logged at Error level and the request is aborted with 500 Internal Server Error.
+package logwellgin
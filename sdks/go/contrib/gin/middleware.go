@@ -0,0 +1,157 @@
+package logwellgin
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// ContextKey is the gin.Context key under which the request-scoped logger is stored.
+const ContextKey = "logwell.logger"
+
+// DefaultRequestIDHeader is the header read for the request ID by default.
+const DefaultRequestIDHeader = "X-Request-ID"
+
+// Option configures the middleware.
+type Option func(*config)
+
+type config struct {
+	requestIDHeader string
+	skipPaths       map[string]struct{}
+	recoverPanics   bool
+}
+
+// WithRequestIDHeader sets the header used to read the request ID attached to
+// the request-scoped logger. An empty string disables request ID extraction.
+// Default: "X-Request-ID".
+func WithRequestIDHeader(header string) Option {
+	return func(c *config) {
+		c.requestIDHeader = header
+	}
+}
+
+// WithSkipPaths disables request logging for the given paths (e.g. "/healthz").
+// Handlers on skipped paths still receive a request-scoped logger.
+func WithSkipPaths(paths ...string) Option {
+	return func(c *config) {
+		for _, p := range paths {
+			c.skipPaths[p] = struct{}{}
+		}
+	}
+}
+
+// WithRecovery enables or disables panic recovery. When disabled, panics
+// propagate to outer middleware (e.g. gin.Recovery). Default: true.
+func WithRecovery(enabled bool) Option {
+	return func(c *config) {
+		c.recoverPanics = enabled
+	}
+}
+
+// Middleware returns a gin.HandlerFunc that logs each request through client.
+//
+// Requests completing with a 5xx status log at Error, 4xx at Warn, and
+// everything else at Info. The logged metadata includes method, path, route,
+// status, latency, client IP, and the response size.
+func Middleware(client *logwell.Client, opts ...Option) gin.HandlerFunc {
+	cfg := &config{
+		requestIDHeader: DefaultRequestIDHeader,
+		skipPaths:       make(map[string]struct{}),
+		recoverPanics:   true,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		reqLogger := client
+		if cfg.requestIDHeader != "" {
+			if requestID := c.GetHeader(cfg.requestIDHeader); requestID != "" {
+				reqLogger = client.Child(logwell.ChildWithMetadata(logwell.M{"requestId": requestID}))
+			}
+		}
+		c.Set(ContextKey, reqLogger)
+		c.Request = c.Request.WithContext(logwell.NewContext(c.Request.Context(), reqLogger))
+
+		if cfg.recoverPanics {
+			defer func() {
+				if r := recover(); r != nil {
+					if r == http.ErrAbortHandler {
+						// Deliberate connection abort; let net/http handle it.
+						panic(r)
+					}
+					reqLogger.Error(fmt.Sprintf("panic recovered: %v", r), logwell.M{
+						"panic":  fmt.Sprint(r),
+						"stack":  string(debug.Stack()),
+						"method": c.Request.Method,
+						"path":   c.Request.URL.Path,
+					})
+					c.AbortWithStatus(http.StatusInternalServerError)
+					if _, skip := cfg.skipPaths[c.Request.URL.Path]; !skip {
+						logRequest(c, reqLogger, start)
+					}
+				}
+			}()
+		}
+
+		c.Next()
+
+		if _, skip := cfg.skipPaths[c.Request.URL.Path]; skip {
+			return
+		}
+		logRequest(c, reqLogger, start)
+	}
+}
+
+// Logger returns the request-scoped logger stored by Middleware. If the
+// middleware is not installed, it returns nil.
+//
+// The same logger is also available from the request context via
+// logwell.FromContext(c.Request.Context()).
+func Logger(c *gin.Context) *logwell.Client {
+	if v, ok := c.Get(ContextKey); ok {
+		if l, ok := v.(*logwell.Client); ok {
+			return l
+		}
+	}
+	return nil
+}
+
+// logRequest logs the completed request at a level derived from its status.
+func logRequest(c *gin.Context, logger *logwell.Client, start time.Time) {
+	status := c.Writer.Status()
+	meta := logwell.M{
+		"method":     c.Request.Method,
+		"path":       c.Request.URL.Path,
+		"status":     status,
+		"durationMs": float64(time.Since(start).Microseconds()) / 1000,
+		"clientIp":   c.ClientIP(),
+		"bytes":      c.Writer.Size(),
+	}
+	if route := c.FullPath(); route != "" {
+		meta["route"] = route
+	}
+	if ua := c.Request.UserAgent(); ua != "" {
+		meta["userAgent"] = ua
+	}
+	if errs := c.Errors.ByType(gin.ErrorTypeAny); len(errs) > 0 {
+		meta["errors"] = errs.String()
+	}
+
+	message := fmt.Sprintf("%s %s %d", c.Request.Method, c.Request.URL.Path, status)
+	switch {
+	case status >= 500:
+		logger.Error(message, meta)
+	case status >= 400:
+		logger.Warn(message, meta)
+	default:
+		logger.Info(message, meta)
+	}
+}
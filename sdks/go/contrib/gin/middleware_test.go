@@ -0,0 +1,188 @@
+package logwellgin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+const testAPIKey = "lw_00000000000000000000000000000000"
+
+// captureServer records log entries sent by the Logwell client.
+type captureServer struct {
+	*httptest.Server
+	mu   sync.Mutex
+	logs []logwell.LogEntry
+}
+
+func newCaptureServer(t *testing.T) *captureServer {
+	t.Helper()
+	cs := &captureServer{}
+	cs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var entries []logwell.LogEntry
+		if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		cs.mu.Lock()
+		cs.logs = append(cs.logs, entries...)
+		cs.mu.Unlock()
+		json.NewEncoder(w).Encode(logwell.IngestResponse{Accepted: len(entries)})
+	}))
+	t.Cleanup(cs.Close)
+	return cs
+}
+
+func (cs *captureServer) getLogs() []logwell.LogEntry {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return append([]logwell.LogEntry(nil), cs.logs...)
+}
+
+func newRouter(t *testing.T, opts ...Option) (*gin.Engine, *logwell.Client, *captureServer) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	cs := newCaptureServer(t)
+	client, err := logwell.New(cs.URL, testAPIKey)
+	if err != nil {
+		t.Fatalf("logwell.New() error = %v", err)
+	}
+	router := gin.New()
+	router.Use(Middleware(client, opts...))
+	return router, client, cs
+}
+
+func TestMiddleware_LogsRequest(t *testing.T) {
+	router, client, cs := newRouter(t)
+	router.GET("/orders/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+	client.Shutdown(context.Background())
+
+	logs := cs.getLogs()
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(logs))
+	}
+	entry := logs[0]
+	if entry.Level != logwell.LevelInfo {
+		t.Errorf("Level = %q, want %q", entry.Level, logwell.LevelInfo)
+	}
+	if entry.Metadata["route"] != "/orders/:id" || entry.Metadata["path"] != "/orders/42" {
+		t.Errorf("unexpected route/path metadata: %v", entry.Metadata)
+	}
+	if entry.Metadata["status"] != float64(http.StatusOK) {
+		t.Errorf("status = %v, want 200", entry.Metadata["status"])
+	}
+}
+
+func TestMiddleware_LevelFromStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		level  logwell.LogLevel
+	}{
+		{http.StatusNotFound, logwell.LevelWarn},
+		{http.StatusServiceUnavailable, logwell.LevelError},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			router, client, cs := newRouter(t)
+			router.GET("/x", func(c *gin.Context) { c.Status(tt.status) })
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/x", nil))
+			client.Shutdown(context.Background())
+
+			logs := cs.getLogs()
+			if len(logs) != 1 {
+				t.Fatalf("expected 1 log, got %d", len(logs))
+			}
+			if logs[0].Level != tt.level {
+				t.Errorf("Level = %q, want %q", logs[0].Level, tt.level)
+			}
+		})
+	}
+}
+
+func TestMiddleware_InjectsRequestScopedLogger(t *testing.T) {
+	router, client, cs := newRouter(t)
+	router.GET("/x", func(c *gin.Context) {
+		Logger(c).Info("inside handler")
+		if logwell.FromContext(c.Request.Context()) != Logger(c) {
+			t.Error("request context logger does not match gin.Context logger")
+		}
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/x", nil)
+	req.Header.Set("X-Request-ID", "req-123")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	client.Shutdown(context.Background())
+
+	logs := cs.getLogs()
+	if len(logs) != 2 {
+		t.Fatalf("expected 2 logs, got %d", len(logs))
+	}
+	for _, entry := range logs {
+		if entry.Metadata["requestId"] != "req-123" {
+			t.Errorf("%q: requestId = %v, want req-123", entry.Message, entry.Metadata["requestId"])
+		}
+	}
+}
+
+func TestMiddleware_RecoversPanics(t *testing.T) {
+	router, client, cs := newRouter(t)
+	router.GET("/panic", func(*gin.Context) { panic("kaboom") })
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	client.Shutdown(context.Background())
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", w.Code)
+	}
+
+	logs := cs.getLogs()
+	if len(logs) != 2 {
+		t.Fatalf("expected panic log + request log, got %d", len(logs))
+	}
+	panicLog := logs[0]
+	if panicLog.Level != logwell.LevelError {
+		t.Errorf("Level = %q, want %q", panicLog.Level, logwell.LevelError)
+	}
+	if panicLog.Metadata["panic"] != "kaboom" {
+		t.Errorf("panic = %v, want kaboom", panicLog.Metadata["panic"])
+	}
+	if stack, _ := panicLog.Metadata["stack"].(string); !strings.Contains(stack, "goroutine") {
+		t.Errorf("expected stack trace, got %q", stack)
+	}
+}
+
+func TestMiddleware_SkipPaths(t *testing.T) {
+	router, client, cs := newRouter(t, WithSkipPaths("/healthz"))
+	router.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	client.Shutdown(context.Background())
+
+	if logs := cs.getLogs(); len(logs) != 0 {
+		t.Errorf("expected 0 logs for skipped path, got %d", len(logs))
+	}
+}
+
+func TestLogger_WithoutMiddleware(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	if Logger(c) != nil {
+		t.Error("expected nil logger without middleware")
+	}
+}
@@ -0,0 +1,24 @@
+// Package logwellgrpc provides gRPC server interceptors that log every RPC
+// through a Logwell client.
+//
+// Each completed RPC produces one log entry carrying the full method name,
+// status code, latency, and peer address. Successful calls are logged at Info,
+// client-side failures (InvalidArgument, NotFound, ...) at Warn, and
+// server-side failures (Internal, Unavailable, ...) at Error.
+//
+// # Usage
+//
+//	server := grpc.NewServer(
+//		grpc.UnaryInterceptor(logwellgrpc.UnaryServerInterceptor(client)),
+//		grpc.StreamInterceptor(logwellgrpc.StreamServerInterceptor(client)),
+//	)
+//
+// Noisy methods such as health checks can be sampled down per method, and
+// request metadata (request IDs, tenants, ...) can be attached with
+// extractors:
+//
+//	logwellgrpc.UnaryServerInterceptor(client,
+//		logwellgrpc.WithMethodSampleRate("/grpc.health.v1.Health/Check", 0.01),
+//		logwellgrpc.WithMetadataExtractor(logwellgrpc.IncomingMetadata("x-request-id")),
+//	)
+package logwellgrpc
@@ -0,0 +1,198 @@
+package logwellgrpc
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// MetadataExtractor returns extra metadata to attach to the log entry for an RPC.
+// It receives the RPC's context and full method name ("/package.Service/Method").
+// Returning nil or an empty map adds nothing.
+type MetadataExtractor func(ctx context.Context, fullMethod string) logwell.M
+
+// Option configures the interceptors.
+type Option func(*config)
+
+type config struct {
+	defaultRate float64
+	methodRates map[string]float64
+	extractors  []MetadataExtractor
+
+	// random returns a value in [0, 1); overridable in tests.
+	random func() float64
+}
+
+// WithSampleRate sets the fraction (0-1) of successful RPCs that are logged
+// for methods without a per-method rate. Failed RPCs are always logged.
+// Default: 1 (log everything).
+func WithSampleRate(rate float64) Option {
+	return func(c *config) {
+		c.defaultRate = rate
+	}
+}
+
+// WithMethodSampleRate sets the fraction (0-1) of successful calls to
+// fullMethod that are logged, overriding the default rate. A rate of 0
+// silences successful calls entirely; failed calls are always logged.
+func WithMethodSampleRate(fullMethod string, rate float64) Option {
+	return func(c *config) {
+		if c.methodRates == nil {
+			c.methodRates = make(map[string]float64)
+		}
+		c.methodRates[fullMethod] = rate
+	}
+}
+
+// WithMetadataExtractor adds an extractor whose result is merged into every
+// RPC log entry. Extractors run in registration order; later keys override earlier ones.
+func WithMetadataExtractor(fn MetadataExtractor) Option {
+	return func(c *config) {
+		c.extractors = append(c.extractors, fn)
+	}
+}
+
+// IncomingMetadata returns an extractor that copies the given incoming gRPC
+// metadata keys (e.g. "x-request-id") into the log entry. Keys are matched
+// case-insensitively; multiple values are joined with ", ".
+func IncomingMetadata(keys ...string) MetadataExtractor {
+	return func(ctx context.Context, _ string) logwell.M {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil
+		}
+		out := logwell.M{}
+		for _, key := range keys {
+			if values := md.Get(key); len(values) > 0 {
+				out[key] = strings.Join(values, ", ")
+			}
+		}
+		return out
+	}
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{
+		defaultRate: 1,
+		random:      rand.Float64,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// UnaryServerInterceptor returns a unary server interceptor that logs each RPC
+// through client.
+func UnaryServerInterceptor(client *logwell.Client, opts ...Option) grpc.UnaryServerInterceptor {
+	cfg := newConfig(opts)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		cfg.logRPC(ctx, client, info.FullMethod, "unary", start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor that logs each
+// RPC through client once the stream completes.
+func StreamServerInterceptor(client *logwell.Client, opts ...Option) grpc.StreamServerInterceptor {
+	cfg := newConfig(opts)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		kind := "bidi_stream"
+		switch {
+		case info.IsClientStream && !info.IsServerStream:
+			kind = "client_stream"
+		case info.IsServerStream && !info.IsClientStream:
+			kind = "server_stream"
+		}
+		cfg.logRPC(ss.Context(), client, info.FullMethod, kind, start, err)
+		return err
+	}
+}
+
+// logRPC builds and sends the log entry for a completed RPC.
+func (c *config) logRPC(ctx context.Context, client *logwell.Client, fullMethod, kind string, start time.Time, err error) {
+	code := status.Code(err)
+	if code == codes.OK && !c.sampled(fullMethod) {
+		return
+	}
+
+	service, method := splitMethod(fullMethod)
+	meta := logwell.M{
+		"grpc.service": service,
+		"grpc.method":  method,
+		"grpc.type":    kind,
+		"grpc.code":    code.String(),
+		"durationMs":   float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		meta["peer.address"] = p.Addr.String()
+	}
+	if err != nil {
+		meta["error"] = status.Convert(err).Message()
+	}
+	for _, extract := range c.extractors {
+		for k, v := range extract(ctx, fullMethod) {
+			meta[k] = v
+		}
+	}
+
+	message := "gRPC " + fullMethod + " " + code.String()
+	switch levelForCode(code) {
+	case logwell.LevelError:
+		client.Error(message, meta)
+	case logwell.LevelWarn:
+		client.Warn(message, meta)
+	default:
+		client.Info(message, meta)
+	}
+}
+
+// sampled reports whether a successful call to fullMethod should be logged.
+func (c *config) sampled(fullMethod string) bool {
+	rate, ok := c.methodRates[fullMethod]
+	if !ok {
+		rate = c.defaultRate
+	}
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	return c.random() < rate
+}
+
+// levelForCode maps a gRPC status code to a log level.
+func levelForCode(code codes.Code) logwell.LogLevel {
+	switch code {
+	case codes.OK:
+		return logwell.LevelInfo
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented,
+		codes.Internal, codes.Unavailable, codes.DataLoss:
+		return logwell.LevelError
+	default:
+		return logwell.LevelWarn
+	}
+}
+
+// splitMethod splits "/package.Service/Method" into service and method names.
+func splitMethod(fullMethod string) (service, method string) {
+	trimmed := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(trimmed, "/"); i >= 0 {
+		return trimmed[:i], trimmed[i+1:]
+	}
+	return "unknown", trimmed
+}
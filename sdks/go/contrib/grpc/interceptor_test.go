@@ -0,0 +1,244 @@
+package logwellgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+const testAPIKey = "lw_00000000000000000000000000000000"
+
+// captureServer records log entries sent by the Logwell client.
+type captureServer struct {
+	*httptest.Server
+	mu   sync.Mutex
+	logs []logwell.LogEntry
+}
+
+func newCaptureServer(t *testing.T) *captureServer {
+	t.Helper()
+	cs := &captureServer{}
+	cs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var entries []logwell.LogEntry
+		if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		cs.mu.Lock()
+		cs.logs = append(cs.logs, entries...)
+		cs.mu.Unlock()
+		json.NewEncoder(w).Encode(logwell.IngestResponse{Accepted: len(entries)})
+	}))
+	t.Cleanup(cs.Close)
+	return cs
+}
+
+func (cs *captureServer) getLogs() []logwell.LogEntry {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return append([]logwell.LogEntry(nil), cs.logs...)
+}
+
+// failingHealth returns a configurable error from Check.
+type failingHealth struct {
+	healthpb.UnimplementedHealthServer
+	err error
+}
+
+func (f *failingHealth) Check(context.Context, *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	return nil, f.err
+}
+
+// startServer runs a gRPC server over an in-memory listener and returns a health client.
+func startServer(t *testing.T, hs healthpb.HealthServer, opts ...grpc.ServerOption) healthpb.HealthClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(srv, hs)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+func newClient(t *testing.T, cs *captureServer) *logwell.Client {
+	t.Helper()
+	client, err := logwell.New(cs.URL, testAPIKey)
+	if err != nil {
+		t.Fatalf("logwell.New() error = %v", err)
+	}
+	return client
+}
+
+func TestUnaryServerInterceptor_LogsSuccessfulRPC(t *testing.T) {
+	cs := newCaptureServer(t)
+	client := newClient(t, cs)
+
+	hc := startServer(t, health.NewServer(), grpc.UnaryInterceptor(UnaryServerInterceptor(client,
+		WithMetadataExtractor(IncomingMetadata("x-request-id")),
+	)))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-1")
+	if _, err := hc.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	client.Shutdown(context.Background())
+
+	logs := cs.getLogs()
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(logs))
+	}
+	entry := logs[0]
+	if entry.Level != logwell.LevelInfo {
+		t.Errorf("Level = %q, want %q", entry.Level, logwell.LevelInfo)
+	}
+	if entry.Metadata["grpc.service"] != "grpc.health.v1.Health" || entry.Metadata["grpc.method"] != "Check" {
+		t.Errorf("unexpected service/method metadata: %v", entry.Metadata)
+	}
+	if entry.Metadata["grpc.code"] != "OK" {
+		t.Errorf("grpc.code = %v, want OK", entry.Metadata["grpc.code"])
+	}
+	if entry.Metadata["x-request-id"] != "req-1" {
+		t.Errorf("x-request-id = %v, want req-1", entry.Metadata["x-request-id"])
+	}
+	if _, ok := entry.Metadata["durationMs"]; !ok {
+		t.Error("missing durationMs metadata")
+	}
+	if _, ok := entry.Metadata["peer.address"]; !ok {
+		t.Error("missing peer.address metadata")
+	}
+}
+
+func TestUnaryServerInterceptor_ErrorLevels(t *testing.T) {
+	tests := []struct {
+		code  codes.Code
+		level logwell.LogLevel
+	}{
+		{codes.NotFound, logwell.LevelWarn},
+		{codes.InvalidArgument, logwell.LevelWarn},
+		{codes.Internal, logwell.LevelError},
+		{codes.Unavailable, logwell.LevelError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			cs := newCaptureServer(t)
+			client := newClient(t, cs)
+			hc := startServer(t, &failingHealth{err: status.Error(tt.code, "boom")},
+				grpc.UnaryInterceptor(UnaryServerInterceptor(client, WithSampleRate(0))))
+
+			hc.Check(context.Background(), &healthpb.HealthCheckRequest{})
+			client.Shutdown(context.Background())
+
+			logs := cs.getLogs()
+			if len(logs) != 1 {
+				t.Fatalf("expected 1 log (errors bypass sampling), got %d", len(logs))
+			}
+			if logs[0].Level != tt.level {
+				t.Errorf("Level = %q, want %q", logs[0].Level, tt.level)
+			}
+			if logs[0].Metadata["error"] != "boom" {
+				t.Errorf("error = %v, want boom", logs[0].Metadata["error"])
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptor_MethodSampling(t *testing.T) {
+	cs := newCaptureServer(t)
+	client := newClient(t, cs)
+
+	hc := startServer(t, health.NewServer(), grpc.UnaryInterceptor(UnaryServerInterceptor(client,
+		WithMethodSampleRate(healthpb.Health_Check_FullMethodName, 0),
+	)))
+
+	for i := 0; i < 5; i++ {
+		hc.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	}
+	client.Shutdown(context.Background())
+
+	if logs := cs.getLogs(); len(logs) != 0 {
+		t.Errorf("expected sampled-out method to produce 0 logs, got %d", len(logs))
+	}
+}
+
+func TestStreamServerInterceptor_LogsStreamRPC(t *testing.T) {
+	cs := newCaptureServer(t)
+	client := newClient(t, cs)
+
+	hc := startServer(t, health.NewServer(), grpc.StreamInterceptor(StreamServerInterceptor(client)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := hc.Watch(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	cancel()
+
+	// The interceptor logs once the server-side handler returns.
+	for i := 0; i < 100; i++ {
+		client.Flush(context.Background())
+		if len(cs.getLogs()) > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	client.Shutdown(context.Background())
+
+	logs := cs.getLogs()
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(logs))
+	}
+	if logs[0].Metadata["grpc.type"] != "server_stream" {
+		t.Errorf("grpc.type = %v, want server_stream", logs[0].Metadata["grpc.type"])
+	}
+	if logs[0].Metadata["grpc.code"] != "Canceled" {
+		t.Errorf("grpc.code = %v, want Canceled", logs[0].Metadata["grpc.code"])
+	}
+}
+
+func TestSampled(t *testing.T) {
+	cfg := newConfig([]Option{WithSampleRate(0.5)})
+	cfg.random = func() float64 { return 0.4 }
+	if !cfg.sampled("/svc/M") {
+		t.Error("expected 0.4 < 0.5 to be sampled")
+	}
+	cfg.random = func() float64 { return 0.6 }
+	if cfg.sampled("/svc/M") {
+		t.Error("expected 0.6 >= 0.5 to be dropped")
+	}
+}
+
+func TestSplitMethod(t *testing.T) {
+	service, method := splitMethod("/pkg.v1.Service/DoThing")
+	if service != "pkg.v1.Service" || method != "DoThing" {
+		t.Errorf("splitMethod() = (%q, %q)", service, method)
+	}
+}
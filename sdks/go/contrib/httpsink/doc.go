@@ -0,0 +1,25 @@
+// Package logwellhttpsink receives log events over HTTP and delivers them
+// through a logwell.Sink, so pipelines such as Vector (http sink) and
+// Benthos/Redpanda Connect (http_client output) can use the SDK's
+// batching, compression, and retries to reach Logwell.
+//
+// # Usage
+//
+//	client, err := logwell.New(endpoint, apiKey)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer client.Shutdown(context.Background())
+//
+//	http.Handle("/logs", logwellhttpsink.Handler(client,
+//		logwellhttpsink.WithBearerToken(os.Getenv("SINK_TOKEN")),
+//	))
+//	log.Fatal(http.ListenAndServe(":8080", nil))
+//
+// Request bodies may be a JSON array of events, newline-delimited JSON, or
+// plain text lines, optionally gzip-compressed. Each event is parsed with
+// logwellparse.JSON by default, so the usual level, message, timestamp,
+// and service fields fill the entry and the rest become metadata. A
+// response is sent once the events are delivered, so the pipeline retries
+// requests that fail.
+package logwellhttpsink
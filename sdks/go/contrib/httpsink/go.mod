@@ -0,0 +1,5 @@
+module github.com/Divkix/Logwell/sdks/go/contrib/httpsink
+
+go 1.25.0
+
+require github.com/Divkix/Logwell/sdks/go v1.2.0
@@ -0,0 +1,201 @@
+package logwellhttpsink
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwellparse"
+)
+
+// DefaultMaxBodySize is the largest request body accepted by default,
+// after decompression.
+const DefaultMaxBodySize = 10 << 20
+
+// Option configures Handler.
+type Option func(*config)
+
+type config struct {
+	parser      logwellparse.Parser
+	maxBodySize int64
+	token       string
+}
+
+// WithParser sets the parser for each event: a JSON value from an array
+// or line, or a plain text line. Events the parser rejects are forwarded
+// as info entries with the raw text as the message.
+// Default: logwellparse.JSON().
+func WithParser(p logwellparse.Parser) Option {
+	return func(c *config) {
+		c.parser = p
+	}
+}
+
+// WithMaxBodySize sets the largest request body accepted, in bytes after
+// decompression; larger requests get 413 Request Entity Too Large.
+// Default: DefaultMaxBodySize.
+func WithMaxBodySize(n int64) Option {
+	return func(c *config) {
+		c.maxBodySize = n
+	}
+}
+
+// WithBearerToken requires requests to carry "Authorization: Bearer
+// token"; others get 401 Unauthorized. Default: no authentication.
+func WithBearerToken(token string) Option {
+	return func(c *config) {
+		c.token = token
+	}
+}
+
+// Handler returns an http.Handler that accepts POSTed log events and
+// delivers them through sink, usually a *logwell.Client.
+//
+// The body may be a JSON array, newline-delimited JSON, or plain text
+// lines, and may be gzip-compressed (Content-Encoding: gzip). Strings in
+// a JSON array are treated as text lines. Each event becomes one entry,
+// passed to sink.Log.
+//
+// Once the events are queued the handler calls sink.Flush with the
+// request's context, and responds 200 OK with {"accepted": n} only after
+// the flush succeeds. If it fails the response is 503 Service
+// Unavailable, so the pipeline retries the request. The failed entries
+// stay queued in the sink, so a retry may deliver them twice. Malformed
+// bodies get 400 Bad Request and are not retried by most pipelines.
+func Handler(sink logwell.Sink, opts ...Option) http.Handler {
+	cfg := config{parser: logwellparse.JSON(), maxBodySize: DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &handler{sink: sink, cfg: cfg}
+}
+
+type handler struct {
+	sink logwell.Sink
+	cfg  config
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	if h.cfg.token != "" && !h.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+		return
+	}
+
+	body, status, err := h.readBody(r)
+	if err != nil {
+		writeError(w, status, err.Error())
+		return
+	}
+	entries, err := h.parse(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, entry := range entries {
+		h.sink.Log(entry)
+	}
+	if err := h.sink.Flush(r.Context()); err != nil {
+		writeError(w, http.StatusServiceUnavailable, "delivery failed: "+err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"accepted":%d}`+"\n", len(entries))
+}
+
+// authorized reports whether r carries the configured bearer token.
+func (h *handler) authorized(r *http.Request) bool {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(h.cfg.token)) == 1
+}
+
+// readBody reads and decompresses the request body, enforcing the size
+// limit. On failure it returns the response status to send.
+func (h *handler) readBody(r *http.Request) ([]byte, int, error) {
+	var body io.Reader = r.Body
+	switch enc := strings.ToLower(r.Header.Get("Content-Encoding")); enc {
+	case "", "identity":
+	case "gzip":
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid gzip body: %w", err)
+		}
+		defer zr.Close()
+		body = zr
+	default:
+		return nil, http.StatusUnsupportedMediaType, fmt.Errorf("unsupported Content-Encoding %q", enc)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, h.cfg.maxBodySize+1))
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("reading body: %w", err)
+	}
+	if int64(len(data)) > h.cfg.maxBodySize {
+		return nil, http.StatusRequestEntityTooLarge, fmt.Errorf("body exceeds %d bytes", h.cfg.maxBodySize)
+	}
+	return data, 0, nil
+}
+
+// parse splits body into events and parses each into an entry.
+func (h *handler) parse(body []byte) ([]logwell.LogEntry, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var events []json.RawMessage
+		if err := json.Unmarshal(trimmed, &events); err != nil {
+			return nil, fmt.Errorf("invalid JSON array: %w", err)
+		}
+		entries := make([]logwell.LogEntry, 0, len(events))
+		for _, raw := range events {
+			text := string(raw)
+			var s string
+			if json.Unmarshal(raw, &s) == nil {
+				text = s
+			}
+			entries = append(entries, h.entry(text))
+		}
+		return entries, nil
+	}
+
+	var entries []logwell.LogEntry
+	sc := bufio.NewScanner(bytes.NewReader(trimmed))
+	sc.Buffer(nil, len(trimmed)+1)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		entries = append(entries, h.entry(line))
+	}
+	return entries, sc.Err()
+}
+
+// entry parses one event, forwarding text the parser rejects as is.
+func (h *handler) entry(text string) logwell.LogEntry {
+	entry, err := h.cfg.parser.Parse(text)
+	if err != nil {
+		return logwell.LogEntry{Level: logwell.LevelInfo, Message: text}
+	}
+	return entry
+}
+
+// writeError sends a JSON error response in the server's error format.
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":   strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_")),
+		"message": message,
+	})
+}
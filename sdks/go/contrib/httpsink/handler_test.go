@@ -0,0 +1,150 @@
+package logwellhttpsink
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwelltest"
+)
+
+func post(t *testing.T, h http.Handler, body string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/logs", strings.NewReader(body))
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandlerVectorJSONArray(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	h := Handler(rec)
+
+	body := `[
+		{"message":"disk full","level":"error","timestamp":"2024-05-01T12:00:00Z","host":"web-1","source_type":"file"},
+		{"message":"started","service":"api"},
+		"a raw line"
+	]`
+	w := post(t, h, body, nil)
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != `{"accepted":3}` {
+		t.Fatalf("response = %d %s", w.Code, w.Body)
+	}
+
+	entries := rec.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	first := entries[0]
+	if first.Level != logwell.LevelError || first.Message != "disk full" || first.Timestamp != "2024-05-01T12:00:00.000Z" || first.Metadata["host"] != "web-1" {
+		t.Errorf("first entry = %+v", first)
+	}
+	if entries[1].Service != "api" || entries[1].Level != logwell.LevelInfo {
+		t.Errorf("second entry = %+v", entries[1])
+	}
+	if entries[2].Message != "a raw line" {
+		t.Errorf("third entry = %+v", entries[2])
+	}
+}
+
+func TestHandlerNDJSONAndText(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	h := Handler(rec)
+
+	w := post(t, h, "{\"msg\":\"one\",\"level\":\"warn\"}\r\n\nplain text\n", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("response = %d %s", w.Code, w.Body)
+	}
+	entries := rec.Entries()
+	if len(entries) != 2 || entries[0].Message != "one" || entries[0].Level != logwell.LevelWarn || entries[1].Message != "plain text" {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestHandlerGzip(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	h := Handler(rec)
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(`{"message":"compressed"}` + "\n"))
+	zw.Close()
+
+	w := post(t, h, buf.String(), http.Header{"Content-Encoding": {"gzip"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("response = %d %s", w.Code, w.Body)
+	}
+	rec.AssertLogged(t, logwell.LevelInfo, "compressed")
+
+	if w := post(t, h, "not gzip", http.Header{"Content-Encoding": {"gzip"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid gzip status = %d, want 400", w.Code)
+	}
+	if w := post(t, h, "x", http.Header{"Content-Encoding": {"br"}}); w.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("brotli status = %d, want 415", w.Code)
+	}
+}
+
+func TestHandlerRejects(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	h := Handler(rec, WithBearerToken("secret"), WithMaxBodySize(64))
+	auth := http.Header{"Authorization": {"Bearer secret"}}
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		header http.Header
+		want   int
+	}{
+		{"wrong method", http.MethodGet, "", auth, http.StatusMethodNotAllowed},
+		{"missing token", http.MethodPost, `{"message":"x"}`, nil, http.StatusUnauthorized},
+		{"wrong token", http.MethodPost, `{"message":"x"}`, http.Header{"Authorization": {"Bearer nope"}}, http.StatusUnauthorized},
+		{"too large", http.MethodPost, strings.Repeat("a", 65), auth, http.StatusRequestEntityTooLarge},
+		{"bad array", http.MethodPost, `[{"message":`, auth, http.StatusBadRequest},
+		{"ok", http.MethodPost, `{"message":"x"}`, auth, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/logs", strings.NewReader(tt.body))
+			for k, v := range tt.header {
+				req.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.want, w.Body)
+			}
+		})
+	}
+	if n := len(rec.Entries()); n != 1 {
+		t.Errorf("got %d entries, want 1", n)
+	}
+}
+
+// failingSink queues entries but fails every flush.
+type failingSink struct {
+	logged int
+}
+
+func (s *failingSink) Log(logwell.LogEntry)           { s.logged++ }
+func (s *failingSink) Flush(context.Context) error    { return errors.New("server down") }
+func (s *failingSink) Shutdown(context.Context) error { return nil }
+
+func TestHandlerFlushFailure(t *testing.T) {
+	sink := &failingSink{}
+	w := post(t, Handler(sink), `{"message":"x"}`, nil)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", w.Code)
+	}
+	if sink.logged != 1 {
+		t.Errorf("logged = %d, want 1", sink.logged)
+	}
+}
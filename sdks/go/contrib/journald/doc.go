@@ -0,0 +1,15 @@
+// Package logwelljournald forwards the systemd journal to Logwell, so a
+// host can ship its system logs with the same SDK as its applications.
+//
+// # Usage
+//
+//	err := logwelljournald.Follow(ctx, client,
+//		logwelljournald.WithUnits("nginx.service", "postgresql.service"),
+//		logwelljournald.WithCursorFile("/var/lib/logwell/journal.cursor"))
+//
+// Follow runs `journalctl -f -o json`, so it needs no cgo and no libsystemd
+// headers, only journalctl in PATH and permission to read the journal
+// (root, or membership of the systemd-journal group). Each journal entry
+// becomes an entry on the client's batching pipeline. Priority maps onto
+// the Logwell level, and the unit and process fields become metadata.
+package logwelljournald
@@ -0,0 +1,361 @@
+package logwelljournald
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// DefaultJournalctl is the journalctl command run by Follow.
+const DefaultJournalctl = "journalctl"
+
+// DefaultCursorInterval is how often the cursor is saved while Follow
+// runs.
+const DefaultCursorInterval = 5 * time.Second
+
+// maxRecordSize bounds one JSON record read from journalctl. Larger
+// records are reported and skipped.
+const maxRecordSize = 1 << 20
+
+// Option configures Follow.
+type Option func(*config)
+
+type config struct {
+	journalctl     string
+	units          []string
+	cursorFile     string
+	cursorInterval time.Duration
+	onError        func(error)
+}
+
+// WithUnits forwards only entries from the given systemd units, such as
+// "nginx.service". Default: all units.
+func WithUnits(units ...string) Option {
+	return func(c *config) {
+		c.units = append(c.units, units...)
+	}
+}
+
+// WithCursorFile persists the journal cursor of the last delivered entry
+// to path, and resumes after it on the next start. The cursor is saved
+// every cursor interval and when Follow returns, each time after a
+// successful flush of the client; a failed flush leaves the previous
+// cursor in place. A restart therefore never skips entries, but repeats
+// those forwarded since the last save. Without it, Follow forwards only
+// entries written after it starts.
+func WithCursorFile(path string) Option {
+	return func(c *config) {
+		c.cursorFile = path
+	}
+}
+
+// WithCursorInterval sets how often WithCursorFile saves the cursor while
+// Follow runs. Default: DefaultCursorInterval.
+func WithCursorInterval(d time.Duration) Option {
+	return func(c *config) {
+		c.cursorInterval = d
+	}
+}
+
+// WithJournalctl sets the journalctl command to run. Default:
+// DefaultJournalctl, looked up in PATH.
+func WithJournalctl(path string) Option {
+	return func(c *config) {
+		c.journalctl = path
+	}
+}
+
+// WithOnError sets a callback for records that cannot be decoded and for
+// periodic cursor saves that fail. Both are otherwise skipped silently.
+func WithOnError(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// Follow tails the systemd journal with `journalctl -f -o json` and
+// forwards each entry through client until ctx is done, then flushes the
+// client and returns ctx's error, or the flush error if the final flush
+// fails. It returns earlier with an error if journalctl cannot be started
+// or exits.
+//
+// Journal priority maps onto the Logwell level (emerg, alert, and crit
+// become Fatal, without exiting), the entry's realtime timestamp is kept,
+// SYSLOG_IDENTIFIER (or the command name) becomes the service, and the
+// unit, host, PID, command, and boot ID become metadata.
+//
+// Example:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+//	defer stop()
+//	err := logwelljournald.Follow(ctx, client,
+//		logwelljournald.WithCursorFile("/var/lib/logwell/journal.cursor"))
+func Follow(ctx context.Context, client *logwell.Client, opts ...Option) error {
+	cfg := &config{journalctl: DefaultJournalctl, cursorInterval: DefaultCursorInterval}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.cursorInterval <= 0 {
+		return errors.New("logwelljournald: cursor interval must be positive")
+	}
+
+	args, err := cfg.args()
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, cfg.journalctl, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("logwelljournald: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("logwelljournald: starting journalctl: %w", err)
+	}
+
+	saver := &cursorSaver{client: client, path: cfg.cursorFile}
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go saver.run(ctx, cfg.cursorInterval, cfg.onError, stop, stopped)
+	readErr := forward(stdout, client, cfg.onError, saver.forwarded)
+	waitErr := cmd.Wait()
+	close(stop)
+	<-stopped
+	if err := saver.save(context.WithoutCancel(ctx)); err != nil {
+		return err
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case readErr != nil:
+		return fmt.Errorf("logwelljournald: reading journal: %w", readErr)
+	case waitErr != nil:
+		return fmt.Errorf("logwelljournald: journalctl: %w", waitErr)
+	default:
+		return errors.New("logwelljournald: journalctl exited")
+	}
+}
+
+// args returns the journalctl arguments for cfg.
+func (cfg *config) args() ([]string, error) {
+	args := []string{"--follow", "--output=json"}
+	cursor, err := cfg.savedCursor()
+	if err != nil {
+		return nil, err
+	}
+	if cursor != "" {
+		args = append(args, "--after-cursor="+cursor)
+	} else {
+		args = append(args, "--lines=0")
+	}
+	for _, unit := range cfg.units {
+		args = append(args, "--unit="+unit)
+	}
+	return args, nil
+}
+
+// savedCursor reads the cursor file, if one is configured and exists.
+func (cfg *config) savedCursor() (string, error) {
+	if cfg.cursorFile == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(cfg.cursorFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("logwelljournald: reading cursor: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// cursorSaver saves the journal cursor of the last forwarded entry once
+// the client has delivered it.
+type cursorSaver struct {
+	client *logwell.Client
+	path   string // "" to only flush
+
+	mu     sync.Mutex
+	cursor string // cursor of the last forwarded entry
+
+	saved string // cursor last saved; only the saving goroutine uses it
+}
+
+// forwarded records the cursor of an entry just logged.
+func (s *cursorSaver) forwarded(cursor string) {
+	s.mu.Lock()
+	s.cursor = cursor
+	s.mu.Unlock()
+}
+
+// run saves the cursor every interval until stop is closed, then closes
+// stopped. It does nothing without a cursor file.
+func (s *cursorSaver) run(ctx context.Context, interval time.Duration, onError func(error), stop <-chan struct{}, stopped chan<- struct{}) {
+	defer close(stopped)
+	if s.path == "" {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := s.save(ctx); err != nil && ctx.Err() == nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// save flushes the client and, if every entry forwarded so far was
+// delivered, writes the cursor of the last one, replacing the cursor file
+// atomically.
+func (s *cursorSaver) save(ctx context.Context) error {
+	s.mu.Lock()
+	cursor := s.cursor
+	s.mu.Unlock()
+	if s.path != "" && cursor == s.saved {
+		return nil
+	}
+	if err := s.client.Flush(ctx); err != nil {
+		return fmt.Errorf("logwelljournald: flushing: %w", err)
+	}
+	if s.path == "" {
+		return nil
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(cursor+"\n"), 0o600); err != nil {
+		return fmt.Errorf("logwelljournald: saving cursor: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("logwelljournald: saving cursor: %w", err)
+	}
+	s.saved = cursor
+	return nil
+}
+
+// forward logs each JSON record read from r through client and passes
+// the cursor of each to onCursor after logging it.
+func forward(r io.Reader, client *logwell.Client, onError func(error), onCursor func(string)) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := readLine(reader)
+		if len(line) > 0 {
+			var record map[string]any
+			if jsonErr := json.Unmarshal(line, &record); jsonErr != nil {
+				if onError != nil {
+					onError(fmt.Errorf("logwelljournald: decoding record: %w", jsonErr))
+				}
+			} else {
+				client.Log(entry(record))
+				if c, ok := record["__CURSOR"].(string); ok {
+					onCursor(c)
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readLine reads one line, discarding lines longer than maxRecordSize.
+func readLine(r *bufio.Reader) ([]byte, error) {
+	var line []byte
+	overflow := false
+	for {
+		chunk, err := r.ReadSlice('\n')
+		if overflow = overflow || len(line)+len(chunk) > maxRecordSize; !overflow {
+			line = append(line, chunk...)
+		}
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			if overflow {
+				return nil, err
+			}
+			return line, err
+		}
+	}
+}
+
+// levels maps journal priorities (syslog severities) to Logwell levels.
+var levels = [...]logwell.LogLevel{
+	logwell.LevelFatal, logwell.LevelFatal, logwell.LevelFatal, logwell.LevelError,
+	logwell.LevelWarn, logwell.LevelInfo, logwell.LevelInfo, logwell.LevelDebug,
+}
+
+// metadataFields maps journal fields to metadata keys.
+var metadataFields = [...]struct{ field, key string }{
+	{"_SYSTEMD_UNIT", "unit"},
+	{"_SYSTEMD_USER_UNIT", "userUnit"},
+	{"_HOSTNAME", "host"},
+	{"_PID", "pid"},
+	{"_COMM", "comm"},
+	{"_BOOT_ID", "bootId"},
+	{"SYSLOG_IDENTIFIER", "identifier"},
+}
+
+// entry converts a journal JSON record to a Logwell entry.
+func entry(record map[string]any) logwell.LogEntry {
+	e := logwell.LogEntry{
+		Level:    logwell.LevelInfo,
+		Message:  field(record, "MESSAGE"),
+		Metadata: logwell.M{},
+	}
+	if p, err := strconv.Atoi(field(record, "PRIORITY")); err == nil && p >= 0 && p < len(levels) {
+		e.Level = levels[p]
+		e.Metadata["priority"] = p
+	}
+	if us, err := strconv.ParseInt(field(record, "__REALTIME_TIMESTAMP"), 10, 64); err == nil {
+		e.Timestamp = time.UnixMicro(us).UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	}
+	for _, f := range metadataFields {
+		if v := field(record, f.field); v != "" {
+			e.Metadata[f.key] = v
+		}
+	}
+	e.Service = field(record, "SYSLOG_IDENTIFIER")
+	if e.Service == "" {
+		e.Service = field(record, "_COMM")
+	}
+	return e
+}
+
+// field returns a journal field as a string. journalctl encodes values
+// that are not valid UTF-8 as byte arrays and repeated fields as arrays of
+// values; the former are decoded and the latter use the first value.
+func field(record map[string]any, name string) string {
+	switch v := record[name].(type) {
+	case string:
+		return v
+	case []any:
+		if len(v) == 0 {
+			return ""
+		}
+		if _, ok := v[0].(float64); !ok {
+			s, _ := v[0].(string)
+			return s
+		}
+		b := make([]byte, 0, len(v))
+		for _, n := range v {
+			f, _ := n.(float64)
+			b = append(b, byte(f))
+		}
+		return string(b)
+	}
+	return ""
+}
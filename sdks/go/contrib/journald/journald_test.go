@@ -0,0 +1,239 @@
+package logwelljournald
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwelltest"
+)
+
+const record = `{"__CURSOR":"s=abc;i=1","__REALTIME_TIMESTAMP":"1767608430123456","PRIORITY":"3","_SYSTEMD_UNIT":"nginx.service","_HOSTNAME":"web-1","_PID":"812","_COMM":"nginx","_BOOT_ID":"b00t","SYSLOG_IDENTIFIER":"nginx","MESSAGE":"upstream timed out"}`
+
+func TestEntry(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	var cursor string
+	err := forward(strings.NewReader(record+"\n"), rec.Client, nil, func(c string) { cursor = c })
+	if err != nil {
+		t.Fatalf("forward: %v", err)
+	}
+	if cursor != "s=abc;i=1" {
+		t.Errorf("cursor = %q", cursor)
+	}
+
+	e := rec.AssertLogged(t, logwell.LevelError, "upstream timed out")
+	if e.Timestamp != "2026-01-05T10:20:30.123Z" {
+		t.Errorf("timestamp = %q", e.Timestamp)
+	}
+	if e.Service != "nginx" {
+		t.Errorf("service = %q, want nginx", e.Service)
+	}
+	want := map[string]any{"unit": "nginx.service", "host": "web-1", "pid": "812", "comm": "nginx", "bootId": "b00t", "identifier": "nginx", "priority": float64(3)}
+	for key, v := range want {
+		if e.Metadata[key] != v {
+			t.Errorf("metadata[%q] = %v, want %v", key, e.Metadata[key], v)
+		}
+	}
+}
+
+func TestEntryLevels(t *testing.T) {
+	levels := map[string]logwell.LogLevel{
+		"0": logwell.LevelFatal, "2": logwell.LevelFatal, "3": logwell.LevelError, "4": logwell.LevelWarn,
+		"5": logwell.LevelInfo, "6": logwell.LevelInfo, "7": logwell.LevelDebug, "": logwell.LevelInfo, "9": logwell.LevelInfo,
+	}
+	for priority, want := range levels {
+		if got := entry(map[string]any{"PRIORITY": priority}).Level; got != want {
+			t.Errorf("priority %q: level = %s, want %s", priority, got, want)
+		}
+	}
+}
+
+func TestEntryBinaryFields(t *testing.T) {
+	e := entry(map[string]any{
+		"MESSAGE": []any{float64('h'), float64('i'), float64(0xff)},
+		"_COMM":   []any{"first", "second"},
+	})
+	if e.Message != "hi\xff" {
+		t.Errorf("message = %q, want bytes decoded", e.Message)
+	}
+	if e.Service != "first" {
+		t.Errorf("service = %q, want the first value", e.Service)
+	}
+}
+
+func TestForwardSkipsBadRecords(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	var errs []error
+	input := "not json\n" + `{"MESSAGE":"` + strings.Repeat("x", maxRecordSize) + `"}` + "\n" + `{"MESSAGE":"kept"}`
+	if err := forward(strings.NewReader(input), rec.Client, func(err error) { errs = append(errs, err) }, func(string) {}); err != nil {
+		t.Fatalf("forward: %v", err)
+	}
+
+	entries := rec.Entries()
+	if len(entries) != 1 || entries[0].Message != "kept" {
+		t.Errorf("entries = %+v, want only the valid record", entries)
+	}
+	if len(errs) != 1 {
+		t.Errorf("reported %d errors, want 1: %v", len(errs), errs)
+	}
+}
+
+func TestArgs(t *testing.T) {
+	dir := t.TempDir()
+	cursorFile := filepath.Join(dir, "cursor")
+
+	cfg := &config{units: []string{"a.service", "b.service"}, cursorFile: cursorFile}
+	args, err := cfg.args()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(args, " "), "--follow --output=json --lines=0 --unit=a.service --unit=b.service"; got != want {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+
+	if err := os.WriteFile(cursorFile, []byte("s=abc;i=1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	args, err = cfg.args()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(args, " "), "--follow --output=json --after-cursor=s=abc;i=1 --unit=a.service --unit=b.service"; got != want {
+		t.Errorf("args = %q, want %q", got, want)
+	}
+}
+
+// fakeJournalctl writes a script that prints its arguments to argsFile,
+// then output, and exits.
+func fakeJournalctl(t *testing.T, output string) (path, argsFile string) {
+	t.Helper()
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("needs /bin/sh")
+	}
+	dir := t.TempDir()
+	path = filepath.Join(dir, "journalctl")
+	argsFile = filepath.Join(dir, "args")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\ncat <<'EOF'\n" + output + "\nEOF\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path, argsFile
+}
+
+func TestFollow(t *testing.T) {
+	journalctl, argsFile := fakeJournalctl(t, record)
+	cursorFile := filepath.Join(t.TempDir(), "cursor")
+	rec := logwelltest.NewRecorder()
+
+	err := Follow(context.Background(), rec.Client, WithJournalctl(journalctl), WithUnits("nginx.service"), WithCursorFile(cursorFile))
+	if err == nil || !strings.Contains(err.Error(), "exited") {
+		t.Errorf("Follow = %v, want journalctl exited", err)
+	}
+	rec.AssertLogged(t, logwell.LevelError, "upstream timed out")
+
+	args, _ := os.ReadFile(argsFile)
+	if got := strings.TrimSpace(string(args)); got != "--follow --output=json --lines=0 --unit=nginx.service" {
+		t.Errorf("journalctl args = %q", got)
+	}
+	cursor, _ := os.ReadFile(cursorFile)
+	if got := strings.TrimSpace(string(cursor)); got != "s=abc;i=1" {
+		t.Errorf("saved cursor = %q", got)
+	}
+}
+
+// TestFollowFlushFailureKeepsCursor tests that a cursor is not saved past
+// entries the endpoint did not accept.
+func TestFollowFlushFailureKeepsCursor(t *testing.T) {
+	journalctl, _ := fakeJournalctl(t, record)
+	cursorFile := filepath.Join(t.TempDir(), "cursor")
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	client, err := logwell.New(down.URL, "lw_00000000000000000000000000000000", logwell.WithMaxRetries(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Shutdown(context.Background())
+
+	err = Follow(context.Background(), client, WithJournalctl(journalctl), WithCursorFile(cursorFile))
+	if err == nil || !strings.Contains(err.Error(), "flushing") {
+		t.Errorf("Follow = %v, want the flush error", err)
+	}
+	if _, err := os.Stat(cursorFile); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("cursor file stat = %v, want none saved", err)
+	}
+}
+
+// TestFollowSavesCursorPeriodically tests that the cursor is saved while
+// Follow runs, not only when it returns.
+func TestFollowSavesCursorPeriodically(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("needs /bin/sh")
+	}
+	dir := t.TempDir()
+	journalctl := filepath.Join(dir, "journalctl")
+	script := "#!/bin/sh\ncat <<'EOF'\n" + record + "\nEOF\nexec sleep 60\n"
+	if err := os.WriteFile(journalctl, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cursorFile := filepath.Join(dir, "cursor")
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Follow(ctx, logwelltest.NewRecorder().Client, WithJournalctl(journalctl),
+			WithCursorFile(cursorFile), WithCursorInterval(10*time.Millisecond))
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		cursor, _ := os.ReadFile(cursorFile)
+		if strings.TrimSpace(string(cursor)) == "s=abc;i=1" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("cursor file = %q while running, want the forwarded cursor", cursor)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Follow = %v, want context.Canceled", err)
+	}
+}
+
+func TestFollowCanceled(t *testing.T) {
+	if _, err := os.Stat("/bin/sh"); err != nil {
+		t.Skip("needs /bin/sh")
+	}
+	path := filepath.Join(t.TempDir(), "journalctl")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexec sleep 60\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := Follow(ctx, logwelltest.NewRecorder().Client, WithJournalctl(path)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Follow = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestFollowInvalidCursorInterval(t *testing.T) {
+	if err := Follow(context.Background(), logwelltest.NewRecorder().Client, WithCursorInterval(0)); err == nil {
+		t.Error("Follow with zero cursor interval: want error")
+	}
+}
+
+func TestFollowMissingJournalctl(t *testing.T) {
+	err := Follow(context.Background(), logwelltest.NewRecorder().Client, WithJournalctl(filepath.Join(t.TempDir(), "missing")))
+	if err == nil || !strings.Contains(err.Error(), "starting journalctl") {
+		t.Errorf("Follow = %v, want start error", err)
+	}
+}
@@ -0,0 +1,17 @@
+// Package logwellkgo routes the internal logs of the franz-go Kafka client
+// (package kgo) through a Logwell client.
+//
+// # Usage
+//
+//	cl, err := kgo.NewClient(
+//		kgo.SeedBrokers("localhost:9092"),
+//		kgo.WithLogger(logwellkgo.Logger(client)),
+//	)
+//
+// kgo levels map onto Logwell levels, and the key-value pairs kgo attaches
+// (broker, topic, partition, err, ...) become metadata. Level reports the
+// client's current minimum level, so kgo skips building messages that
+// would be discarded, and follows Client.SetLevel at runtime. Each entry
+// carries logger: "kgo". To cap a noisy broker loop, give the client
+// WithRateLimit or WithDedupe.
+package logwellkgo
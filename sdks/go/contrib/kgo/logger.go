@@ -0,0 +1,112 @@
+package logwellkgo
+
+import (
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// DefaultName is the logger name entries carry by default.
+const DefaultName = "kgo"
+
+// badKey is the metadata key of a trailing value without a key, as in
+// log/slog.
+const badKey = "!BADKEY"
+
+// Option configures Logger.
+type Option func(*config)
+
+type config struct {
+	name string
+}
+
+// WithName sets the logger name entries carry in "logger" metadata (see
+// logwell.Client.Named). Default: "kgo".
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// kgoLogger is the kgo.Logger returned by Logger.
+type kgoLogger struct {
+	client *logwell.Client
+}
+
+var _ kgo.Logger = (*kgoLogger)(nil)
+
+// Logger returns a kgo.Logger that logs through client. Pass it to
+// kgo.WithLogger.
+func Logger(client *logwell.Client, opts ...Option) kgo.Logger {
+	cfg := &config{name: DefaultName}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return &kgoLogger{client: client.Named(cfg.name)}
+}
+
+// Level returns the kgo level matching the client's minimum level.
+func (l *kgoLogger) Level() kgo.LogLevel {
+	switch l.client.Level() {
+	case logwell.LevelDebug:
+		return kgo.LogLevelDebug
+	case logwell.LevelInfo:
+		return kgo.LogLevelInfo
+	case logwell.LevelWarn:
+		return kgo.LogLevelWarn
+	default:
+		return kgo.LogLevelError
+	}
+}
+
+// Log logs msg at the Logwell level matching level, with keyvals as
+// metadata. Errors are logged as their message.
+func (l *kgoLogger) Log(level kgo.LogLevel, msg string, keyvals ...any) {
+	var lwLevel logwell.LogLevel
+	switch level {
+	case kgo.LogLevelError:
+		lwLevel = logwell.LevelError
+	case kgo.LogLevelWarn:
+		lwLevel = logwell.LevelWarn
+	case kgo.LogLevelInfo:
+		lwLevel = logwell.LevelInfo
+	case kgo.LogLevelDebug:
+		lwLevel = logwell.LevelDebug
+	default:
+		return
+	}
+	if !l.client.Enabled(lwLevel) {
+		return
+	}
+	l.client.Log(logwell.LogEntry{Level: lwLevel, Message: msg, Metadata: metadata(keyvals)})
+}
+
+// metadata converts alternating keys and values into a map.
+func metadata(keyvals []any) logwell.M {
+	if len(keyvals) == 0 {
+		return nil
+	}
+	m := make(logwell.M, (len(keyvals)+1)/2)
+	for i := 0; i < len(keyvals); i += 2 {
+		if i+1 == len(keyvals) {
+			m[badKey] = value(keyvals[i])
+			break
+		}
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		m[key] = value(keyvals[i+1])
+	}
+	return m
+}
+
+// value returns v ready for JSON encoding: errors become their message.
+func value(v any) any {
+	if err, ok := v.(error); ok {
+		return err.Error()
+	}
+	return v
+}
@@ -0,0 +1,77 @@
+package logwellkgo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwelltest"
+)
+
+func TestLogger(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	logger := Logger(rec.Client)
+
+	logger.Log(kgo.LogLevelInfo, "assigned partitions", "group", "orders", "partition", 3)
+	logger.Log(kgo.LogLevelError, "unable to dial", "broker", "b-1:9092", "err", errors.New("connection refused"))
+	logger.Log(kgo.LogLevelWarn, "odd keyvals", "topic", "orders", "dangling")
+	logger.Log(kgo.LogLevelDebug, "wrote produce request")
+	logger.Log(kgo.LogLevelNone, "never logged")
+
+	entries := rec.Entries()
+	if len(entries) != 4 {
+		t.Fatalf("recorded %d entries, want 4", len(entries))
+	}
+	levels := []logwell.LogLevel{logwell.LevelInfo, logwell.LevelError, logwell.LevelWarn, logwell.LevelDebug}
+	for i, e := range entries {
+		if e.Level != levels[i] {
+			t.Errorf("entry %d level = %s, want %s", i, e.Level, levels[i])
+		}
+		if e.Metadata["logger"] != DefaultName {
+			t.Errorf("entry %d logger = %v, want %q", i, e.Metadata["logger"], DefaultName)
+		}
+	}
+	if m := entries[0].Metadata; m["group"] != "orders" || m["partition"] != float64(3) {
+		t.Errorf("metadata = %v", m)
+	}
+	if m := entries[1].Metadata; m["broker"] != "b-1:9092" || m["err"] != "connection refused" {
+		t.Errorf("metadata = %v, want err as its message", m)
+	}
+	if m := entries[2].Metadata; m["topic"] != "orders" || m[badKey] != "dangling" {
+		t.Errorf("metadata = %v, want the dangling value under %s", m, badKey)
+	}
+}
+
+func TestLoggerLevel(t *testing.T) {
+	rec := logwelltest.NewRecorder(logwell.WithMinLevel(logwell.LevelWarn))
+	logger := Logger(rec.Client, WithName("kafka"))
+
+	tests := []struct {
+		min  logwell.LogLevel
+		want kgo.LogLevel
+	}{
+		{logwell.LevelDebug, kgo.LogLevelDebug},
+		{logwell.LevelInfo, kgo.LogLevelInfo},
+		{logwell.LevelWarn, kgo.LogLevelWarn},
+		{logwell.LevelError, kgo.LogLevelError},
+		{logwell.LevelFatal, kgo.LogLevelError},
+	}
+	for _, tt := range tests {
+		if err := rec.SetLevel(tt.min); err != nil {
+			t.Fatalf("SetLevel(%s) error = %v", tt.min, err)
+		}
+		if got := logger.Level(); got != tt.want {
+			t.Errorf("Level() with min %s = %v, want %v", tt.min, got, tt.want)
+		}
+	}
+
+	rec.SetLevel(logwell.LevelWarn)
+	logger.Log(kgo.LogLevelInfo, "heartbeat")
+	logger.Log(kgo.LogLevelWarn, "rebalance in progress")
+	rec.AssertNotLogged(t, logwell.LevelInfo, "heartbeat")
+	if e := rec.AssertLogged(t, logwell.LevelWarn, "rebalance"); e.Metadata["logger"] != "kafka" {
+		t.Errorf("logger = %v, want kafka", e.Metadata["logger"])
+	}
+}
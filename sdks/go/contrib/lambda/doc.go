@@ -0,0 +1,29 @@
+// Package logwelllambda adapts a Logwell client to AWS Lambda handlers.
+//
+// Lambda freezes the execution environment as soon as a handler returns,
+// so entries still queued by the client may never be sent. Wrap flushes
+// the client before each invocation completes and gives the handler a
+// request-scoped logger.
+//
+// # Usage
+//
+//	func handle(ctx context.Context, event events.SQSEvent) (string, error) {
+//		log := logwell.FromContext(ctx)
+//		log.Info("Processing batch", logwell.M{"records": len(event.Records)})
+//		return "ok", nil
+//	}
+//
+//	func main() {
+//		client, _ := logwell.New(endpoint, apiKey)
+//		lambda.Start(logwelllambda.Wrap(handle, client))
+//	}
+//
+// The logger carries the invocation's requestId, the functionName and
+// functionVersion, and coldStart: true on the first invocation of the
+// execution environment. Panics are logged at Fatal level and flushed
+// before they propagate to the Lambda runtime.
+//
+// When an external Lambda extension is registered, the runtime receives
+// SIGTERM before the environment shuts down; WithShutdownOnSIGTERM uses
+// that window to shut the client down.
+package logwelllambda
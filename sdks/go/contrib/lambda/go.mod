@@ -0,0 +1,8 @@
+module github.com/Divkix/Logwell/sdks/go/contrib/lambda
+
+go 1.25.0
+
+require (
+	github.com/Divkix/Logwell/sdks/go v1.2.0
+	github.com/aws/aws-lambda-go v1.54.0
+)
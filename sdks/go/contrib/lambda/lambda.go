@@ -0,0 +1,125 @@
+package logwelllambda
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// DefaultFlushTimeout bounds the flush after each invocation.
+const DefaultFlushTimeout = 2 * time.Second
+
+// DefaultShutdownTimeout bounds Shutdown after SIGTERM. Lambda allows the
+// runtime 500ms before it is killed.
+const DefaultShutdownTimeout = 400 * time.Millisecond
+
+// Option configures Wrap.
+type Option func(*config)
+
+type config struct {
+	flushTimeout      time.Duration
+	shutdownOnSIGTERM bool
+}
+
+// WithFlushTimeout sets how long the flush after each invocation may take.
+// The flush also ends at the invocation deadline. Default: 2s.
+func WithFlushTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.flushTimeout = d
+	}
+}
+
+// WithShutdownOnSIGTERM shuts the client down, within
+// DefaultShutdownTimeout, when the process receives SIGTERM. Lambda sends
+// it before shutting down an execution environment that has an external
+// extension registered, so entries logged outside an invocation (for
+// example by background goroutines) are not lost. The handler is
+// installed once per process.
+func WithShutdownOnSIGTERM() Option {
+	return func(c *config) {
+		c.shutdownOnSIGTERM = true
+	}
+}
+
+// Wrap returns handler wrapped so that each invocation logs through a
+// request-scoped child of client, available from the handler's context
+// via logwell.FromContext, and flushes client before returning.
+//
+// Flush failures are reported to the client's OnError callback; they do
+// not change the handler's result.
+func Wrap[TIn, TOut any](handler func(context.Context, TIn) (TOut, error), client *logwell.Client, opts ...Option) func(context.Context, TIn) (TOut, error) {
+	cfg := &config{flushTimeout: DefaultFlushTimeout}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.shutdownOnSIGTERM {
+		shutdownOnSIGTERM(client)
+	}
+
+	var invoked atomic.Bool
+	return func(ctx context.Context, event TIn) (TOut, error) {
+		logger := client.With(invocationMetadata(ctx, !invoked.Swap(true)))
+		ctx = logwell.NewContext(ctx, logger)
+
+		defer logwell.RecoverAndLog(logger, logwell.WithPanicFlushTimeout(cfg.flushTimeout))
+		out, err := handler(ctx, event)
+		flush(ctx, client, cfg.flushTimeout)
+		return out, err
+	}
+}
+
+// invocationMetadata describes the invocation in ctx.
+func invocationMetadata(ctx context.Context, coldStart bool) logwell.M {
+	metadata := logwell.M{}
+	if lc, ok := lambdacontext.FromContext(ctx); ok && lc.AwsRequestID != "" {
+		metadata["requestId"] = lc.AwsRequestID
+	}
+	if lambdacontext.FunctionName != "" {
+		metadata["functionName"] = lambdacontext.FunctionName
+	}
+	if lambdacontext.FunctionVersion != "" {
+		metadata["functionVersion"] = lambdacontext.FunctionVersion
+	}
+	if coldStart {
+		metadata["coldStart"] = true
+	}
+	return metadata
+}
+
+// flush flushes client within timeout, or by the invocation deadline if
+// that comes first. The handler's context may already be canceled, so the
+// flush does not inherit its cancellation.
+func flush(ctx context.Context, client *logwell.Client, timeout time.Duration) {
+	flushCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancelDeadline context.CancelFunc
+		flushCtx, cancelDeadline = context.WithDeadline(flushCtx, deadline)
+		defer cancelDeadline()
+	}
+	_ = client.Flush(flushCtx) // send failures already reach OnError
+}
+
+var sigtermOnce sync.Once
+
+// shutdownOnSIGTERM shuts client down when the process receives SIGTERM.
+func shutdownOnSIGTERM(client *logwell.Client) {
+	sigtermOnce.Do(func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM)
+		go func() {
+			<-signals
+			ctx, cancel := context.WithTimeout(context.Background(), DefaultShutdownTimeout)
+			defer cancel()
+			_ = client.Shutdown(ctx)
+		}()
+	})
+}
@@ -0,0 +1,121 @@
+package logwelllambda
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+const testAPIKey = "lw_00000000000000000000000000000000"
+
+// captureTransport records the entries the client sends.
+type captureTransport struct {
+	mu      sync.Mutex
+	entries []logwell.LogEntry
+}
+
+func (c *captureTransport) Send(_ context.Context, entries []logwell.LogEntry) (*logwell.IngestResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, entries...)
+	return &logwell.IngestResponse{Accepted: len(entries)}, nil
+}
+
+func (c *captureTransport) sent() []logwell.LogEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]logwell.LogEntry(nil), c.entries...)
+}
+
+func newTestClient(t *testing.T) (*logwell.Client, *captureTransport) {
+	t.Helper()
+	transport := &captureTransport{}
+	client, err := logwell.New("http://logwell.test", testAPIKey,
+		logwell.WithTransport(transport), logwell.WithFatalBehavior(logwell.LogOnly))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { client.Shutdown(context.Background()) })
+	return client, transport
+}
+
+func invocationContext(requestID string) context.Context {
+	return lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{AwsRequestID: requestID})
+}
+
+func TestWrap(t *testing.T) {
+	client, transport := newTestClient(t)
+	handler := Wrap(func(ctx context.Context, name string) (string, error) {
+		logwell.FromContext(ctx).Info("hello " + name)
+		return "ok", nil
+	}, client)
+
+	for _, requestID := range []string{"req-1", "req-2"} {
+		out, err := handler(invocationContext(requestID), "world")
+		if out != "ok" || err != nil {
+			t.Fatalf("handler() = %q, %v, want ok, nil", out, err)
+		}
+	}
+
+	// Wrap flushed; nothing else has sent the entries.
+	entries := transport.sent()
+	if len(entries) != 2 {
+		t.Fatalf("sent %d entries, want 2", len(entries))
+	}
+	if got := entries[0].Metadata["requestId"]; got != "req-1" {
+		t.Errorf("requestId = %v, want req-1", got)
+	}
+	if got := entries[0].Metadata["coldStart"]; got != true {
+		t.Errorf("first invocation coldStart = %v, want true", got)
+	}
+	if got := entries[1].Metadata["requestId"]; got != "req-2" {
+		t.Errorf("requestId = %v, want req-2", got)
+	}
+	if _, ok := entries[1].Metadata["coldStart"]; ok {
+		t.Error("second invocation has coldStart metadata, want none")
+	}
+}
+
+func TestWrapPanic(t *testing.T) {
+	client, transport := newTestClient(t)
+	handler := Wrap(func(ctx context.Context, _ struct{}) (struct{}, error) {
+		panic("boom")
+	}, client)
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("recovered %v, want the handler's panic", r)
+			}
+		}()
+		handler(invocationContext("req-1"), struct{}{})
+	}()
+
+	entries := transport.sent()
+	if len(entries) != 1 {
+		t.Fatalf("sent %d entries, want the panic entry", len(entries))
+	}
+	if entries[0].Level != logwell.LevelFatal || entries[0].Metadata["requestId"] != "req-1" {
+		t.Errorf("entry = %+v, want a Fatal entry with requestId", entries[0])
+	}
+}
+
+func TestWrapCanceledContext(t *testing.T) {
+	client, transport := newTestClient(t)
+	handler := Wrap(func(ctx context.Context, _ int) (int, error) {
+		logwell.FromContext(ctx).Info("late")
+		return 0, ctx.Err()
+	}, client)
+
+	ctx, cancel := context.WithCancel(invocationContext("req-1"))
+	cancel()
+	handler(ctx, 0)
+
+	if len(transport.sent()) != 1 {
+		t.Error("entry not flushed after the invocation context was canceled")
+	}
+}
@@ -0,0 +1,179 @@
+package logwellotel
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// Attribute keys (OpenTelemetry semantic conventions) that map onto
+// LogEntry source location fields instead of metadata.
+const (
+	attrCodeFilePath   = "code.file.path"
+	attrCodeFilePathV1 = "code.filepath"
+	attrCodeLineNumber = "code.line.number"
+	attrCodeLineNoV1   = "code.lineno"
+)
+
+// LoggerProvider is a log.LoggerProvider that sends every record through a
+// Logwell client.
+type LoggerProvider struct {
+	embedded.LoggerProvider
+
+	client *logwell.Client
+}
+
+// Compile-time interface checks.
+var (
+	_ log.LoggerProvider = (*LoggerProvider)(nil)
+	_ log.Logger         = (*Logger)(nil)
+)
+
+// NewLoggerProvider returns a LoggerProvider backed by client. The client
+// keeps ownership of batching, retries, and shutdown; call client.Shutdown
+// when the application exits.
+func NewLoggerProvider(client *logwell.Client) *LoggerProvider {
+	return &LoggerProvider{client: client}
+}
+
+// Logger returns a Logger for the named instrumentation scope. The scope
+// name and version are attached to every entry as "otel.scope.name" and
+// "otel.scope.version".
+func (p *LoggerProvider) Logger(name string, options ...log.LoggerOption) log.Logger {
+	cfg := log.NewLoggerConfig(options...)
+	return &Logger{
+		client:  p.client,
+		scope:   name,
+		version: cfg.InstrumentationVersion(),
+	}
+}
+
+// Logger is a log.Logger that converts OpenTelemetry log records into
+// Logwell entries.
+type Logger struct {
+	embedded.Logger
+
+	client  *logwell.Client
+	scope   string
+	version string
+}
+
+// Emit converts record and queues it on the Logwell client. The trace and
+// span IDs of the span in ctx, if any, are attached as "traceId" and "spanId";
+// a record error is expanded as by logwell.Client.WithError.
+func (l *Logger) Emit(ctx context.Context, record log.Record) {
+	entry := logwell.LogEntry{
+		Level:    levelForSeverity(record.Severity()),
+		Message:  messageFromBody(record.Body()),
+		Metadata: logwell.M{},
+	}
+
+	ts := record.Timestamp()
+	if ts.IsZero() {
+		ts = record.ObservedTimestamp()
+	}
+	if !ts.IsZero() {
+		entry.Timestamp = ts.UTC().Format(time.RFC3339Nano)
+	}
+
+	record.WalkAttributes(func(kv attribute.KeyValue) bool {
+		switch kv.Key {
+		case attrCodeFilePath, attrCodeFilePathV1:
+			if kv.Value.Type() == attribute.STRING {
+				entry.SourceFile = kv.Value.AsString()
+				return true
+			}
+		case attrCodeLineNumber, attrCodeLineNoV1:
+			if kv.Value.Type() == attribute.INT64 {
+				entry.LineNumber = int(kv.Value.AsInt64())
+				return true
+			}
+		}
+		entry.Metadata[string(kv.Key)] = convertValue(kv.Value)
+		return true
+	})
+
+	if text := record.SeverityText(); text != "" {
+		entry.Metadata["otel.severityText"] = text
+	}
+	if name := record.EventName(); name != "" {
+		entry.Metadata["otel.eventName"] = name
+	}
+	if l.scope != "" {
+		entry.Metadata["otel.scope.name"] = l.scope
+	}
+	if l.version != "" {
+		entry.Metadata["otel.scope.version"] = l.version
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		entry.Metadata["traceId"] = sc.TraceID().String()
+		entry.Metadata["spanId"] = sc.SpanID().String()
+	}
+
+	if err := record.Err(); err != nil {
+		l.client.WithError(err).Log(entry)
+		return
+	}
+	l.client.Log(entry)
+}
+
+// Enabled reports whether the logger emits records. All records are
+// accepted; filtering happens on the Logwell side.
+func (l *Logger) Enabled(context.Context, log.EnabledParameters) bool {
+	return true
+}
+
+// levelForSeverity maps an OpenTelemetry severity number to a Logwell level.
+// Trace severities map to debug; unspecified severity maps to info.
+func levelForSeverity(s log.Severity) logwell.LogLevel {
+	switch {
+	case s == log.SeverityUndefined:
+		return logwell.LevelInfo
+	case s <= log.SeverityDebug4:
+		return logwell.LevelDebug
+	case s <= log.SeverityInfo4:
+		return logwell.LevelInfo
+	case s <= log.SeverityWarn4:
+		return logwell.LevelWarn
+	case s <= log.SeverityError4:
+		return logwell.LevelError
+	default:
+		return logwell.LevelFatal
+	}
+}
+
+// messageFromBody renders the record body as the entry message.
+func messageFromBody(body attribute.Value) string {
+	if body.Type() == attribute.STRING {
+		return body.AsString()
+	}
+	return body.String()
+}
+
+// convertValue converts an OpenTelemetry value into a JSON-friendly Go value.
+func convertValue(v attribute.Value) any {
+	switch v.Type() {
+	case attribute.SLICE:
+		items := v.AsSlice()
+		out := make([]any, len(items))
+		for i, item := range items {
+			out[i] = convertValue(item)
+		}
+		return out
+	case attribute.MAP:
+		kvs := v.AsMap()
+		out := make(map[string]any, len(kvs))
+		for _, kv := range kvs {
+			out[string(kv.Key)] = convertValue(kv.Value)
+		}
+		return out
+	default:
+		return v.AsInterface()
+	}
+}
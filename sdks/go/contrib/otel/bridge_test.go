@@ -0,0 +1,215 @@
+package logwellotel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+const testAPIKey = "lw_00000000000000000000000000000000"
+
+// captureServer records log entries sent by the Logwell client.
+type captureServer struct {
+	*httptest.Server
+	mu   sync.Mutex
+	logs []logwell.LogEntry
+}
+
+func newCaptureServer(t *testing.T) *captureServer {
+	t.Helper()
+	cs := &captureServer{}
+	cs.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var entries []logwell.LogEntry
+		if err := json.NewDecoder(r.Body).Decode(&entries); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		cs.mu.Lock()
+		cs.logs = append(cs.logs, entries...)
+		cs.mu.Unlock()
+		json.NewEncoder(w).Encode(logwell.IngestResponse{Accepted: len(entries)})
+	}))
+	t.Cleanup(cs.Close)
+	return cs
+}
+
+func (cs *captureServer) getLogs() []logwell.LogEntry {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return append([]logwell.LogEntry(nil), cs.logs...)
+}
+
+func newClient(t *testing.T, cs *captureServer) *logwell.Client {
+	t.Helper()
+	client, err := logwell.New(cs.URL, testAPIKey, logwell.WithService("otel-test"))
+	if err != nil {
+		t.Fatalf("logwell.New() error = %v", err)
+	}
+	t.Cleanup(func() { client.Shutdown(context.Background()) })
+	return client
+}
+
+// emitAndFlush emits one record through a fresh provider and returns the entry.
+func emitAndFlush(t *testing.T, ctx context.Context, record log.Record, opts ...log.LoggerOption) logwell.LogEntry {
+	t.Helper()
+	cs := newCaptureServer(t)
+	client := newClient(t, cs)
+
+	logger := NewLoggerProvider(client).Logger("github.com/example/app", opts...)
+	logger.Emit(ctx, record)
+
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	logs := cs.getLogs()
+	if len(logs) != 1 {
+		t.Fatalf("got %d logs, want 1", len(logs))
+	}
+	return logs[0]
+}
+
+func TestEmitConvertsRecord(t *testing.T) {
+	ts := time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	var record log.Record
+	record.SetTimestamp(ts)
+	record.SetSeverity(log.SeverityWarn)
+	record.SetSeverityText("WARN")
+	record.SetBody(attribute.StringValue("disk almost full"))
+	record.AddAttributes(
+		attribute.String("mount", "/data"),
+		attribute.Int64("freeMB", 512),
+		attribute.Float64("ratio", 0.95),
+		attribute.Bool("critical", false),
+		attribute.Slice("tags", attribute.StringValue("a"), attribute.StringValue("b")),
+		attribute.Map("host", attribute.String("name", "web-1")),
+		attribute.String("code.file.path", "/app/disk.go"),
+		attribute.Int64("code.line.number", 42),
+	)
+
+	entry := emitAndFlush(t, context.Background(), record, log.WithInstrumentationVersion("1.2.3"))
+
+	if entry.Level != logwell.LevelWarn {
+		t.Errorf("Level = %q, want warn", entry.Level)
+	}
+	if entry.Message != "disk almost full" {
+		t.Errorf("Message = %q", entry.Message)
+	}
+	if entry.Service != "otel-test" {
+		t.Errorf("Service = %q, want client service", entry.Service)
+	}
+	if got, _ := time.Parse(time.RFC3339Nano, entry.Timestamp); !got.Equal(ts) {
+		t.Errorf("Timestamp = %q, want %v", entry.Timestamp, ts)
+	}
+	if entry.SourceFile != "/app/disk.go" || entry.LineNumber != 42 {
+		t.Errorf("source = %s:%d, want /app/disk.go:42", entry.SourceFile, entry.LineNumber)
+	}
+
+	want := map[string]any{
+		"mount":              "/data",
+		"freeMB":             float64(512),
+		"ratio":              0.95,
+		"critical":           false,
+		"otel.severityText":  "WARN",
+		"otel.scope.name":    "github.com/example/app",
+		"otel.scope.version": "1.2.3",
+	}
+	for k, v := range want {
+		if entry.Metadata[k] != v {
+			t.Errorf("Metadata[%q] = %v, want %v", k, entry.Metadata[k], v)
+		}
+	}
+	if tags, ok := entry.Metadata["tags"].([]any); !ok || len(tags) != 2 || tags[0] != "a" {
+		t.Errorf("Metadata[tags] = %v, want [a b]", entry.Metadata["tags"])
+	}
+	if host, ok := entry.Metadata["host"].(map[string]any); !ok || host["name"] != "web-1" {
+		t.Errorf("Metadata[host] = %v, want map with name", entry.Metadata["host"])
+	}
+	if _, ok := entry.Metadata["code.file.path"]; ok {
+		t.Error("code.file.path should map to SourceFile, not metadata")
+	}
+}
+
+func TestEmitAddsTraceContext(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	var record log.Record
+	record.SetBody(attribute.StringValue("traced"))
+
+	entry := emitAndFlush(t, ctx, record)
+
+	if entry.Metadata["traceId"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("traceId = %v", entry.Metadata["traceId"])
+	}
+	if entry.Metadata["spanId"] != "00f067aa0ba902b7" {
+		t.Errorf("spanId = %v", entry.Metadata["spanId"])
+	}
+	if entry.Level != logwell.LevelInfo {
+		t.Errorf("Level = %q, want info for unspecified severity", entry.Level)
+	}
+}
+
+func TestLevelForSeverity(t *testing.T) {
+	tests := []struct {
+		severity log.Severity
+		want     logwell.LogLevel
+	}{
+		{log.SeverityUndefined, logwell.LevelInfo},
+		{log.SeverityTrace, logwell.LevelDebug},
+		{log.SeverityDebug4, logwell.LevelDebug},
+		{log.SeverityInfo, logwell.LevelInfo},
+		{log.SeverityInfo4, logwell.LevelInfo},
+		{log.SeverityWarn2, logwell.LevelWarn},
+		{log.SeverityError, logwell.LevelError},
+		{log.SeverityError4, logwell.LevelError},
+		{log.SeverityFatal, logwell.LevelFatal},
+		{log.SeverityFatal4, logwell.LevelFatal},
+	}
+	for _, tt := range tests {
+		if got := levelForSeverity(tt.severity); got != tt.want {
+			t.Errorf("levelForSeverity(%v) = %q, want %q", tt.severity, got, tt.want)
+		}
+	}
+}
+
+func TestMessageFromNonStringBody(t *testing.T) {
+	var record log.Record
+	record.SetBody(attribute.MapValue(attribute.String("event", "login")))
+
+	entry := emitAndFlush(t, context.Background(), record)
+	if entry.Message != `{"event":"login"}` {
+		t.Errorf("Message = %q, want the JSON form of the map body", entry.Message)
+	}
+}
+
+func TestEmitExpandsRecordError(t *testing.T) {
+	var record log.Record
+	record.SetSeverity(log.SeverityError)
+	record.SetBody(attribute.StringValue("query failed"))
+	record.SetErr(errors.New("connection reset"))
+
+	entry := emitAndFlush(t, context.Background(), record)
+	if entry.Metadata["error"] != "connection reset" {
+		t.Errorf("Metadata[error] = %v, want connection reset", entry.Metadata["error"])
+	}
+	if entry.Metadata["errorType"] != "*errors.errorString" {
+		t.Errorf("Metadata[errorType] = %v", entry.Metadata["errorType"])
+	}
+}
@@ -0,0 +1,28 @@
+// Package logwellotel bridges the OpenTelemetry logs API
+// (go.opentelemetry.io/otel/log) to Logwell.
+//
+// NewLoggerProvider returns a log.LoggerProvider backed by a Logwell client,
+// so code instrumented with the OTel logging API, including the otelslog,
+// otelzap, and otellogrus bridges, can use Logwell as its exporter without
+// an OpenTelemetry Collector.
+//
+// Records are converted as follows:
+//   - Severity maps to the Logwell level (trace and debug to debug, and so on;
+//     unspecified to info).
+//   - The body becomes the message.
+//   - Attributes become metadata, with code.file.path / code.line.number
+//     mapped to the entry's source location.
+//   - The instrumentation scope and the active span's trace and span IDs
+//     are added as metadata.
+//
+// # Usage
+//
+//	client, _ := logwell.New(endpoint, apiKey, logwell.WithService("checkout"))
+//	defer client.Shutdown(context.Background())
+//
+//	global.SetLoggerProvider(logwellotel.NewLoggerProvider(client))
+//
+//	// Anywhere in the app, e.g. through otelslog:
+//	logger := otelslog.NewLogger("checkout")
+//	logger.InfoContext(ctx, "order placed", "orderId", id)
+package logwellotel
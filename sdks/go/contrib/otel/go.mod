@@ -0,0 +1,12 @@
+module github.com/Divkix/Logwell/sdks/go/contrib/otel
+
+go 1.25.0
+
+require (
+	github.com/Divkix/Logwell/sdks/go v1.2.0
+	go.opentelemetry.io/otel v1.46.0
+	go.opentelemetry.io/otel/log v0.22.0
+	go.opentelemetry.io/otel/trace v1.46.0
+)
+
+require github.com/cespare/xxhash/v2 v2.3.0 // indirect
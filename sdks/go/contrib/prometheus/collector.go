@@ -0,0 +1,95 @@
+package logwellprom
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// Option configures a collector.
+type Option func(*collector)
+
+// WithNamespace sets the metric name prefix. Default: "logwell".
+func WithNamespace(namespace string) Option {
+	return func(c *collector) {
+		c.namespace = namespace
+	}
+}
+
+// WithConstLabels attaches fixed labels to every metric, for telling
+// several clients apart in one registry.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(c *collector) {
+		c.constLabels = labels
+	}
+}
+
+// collector is a pull collector over logwell.Client.Stats.
+type collector struct {
+	client      *logwell.Client
+	namespace   string
+	constLabels prometheus.Labels
+
+	queueDepth    *prometheus.Desc
+	dropped       *prometheus.Desc
+	throttled     *prometheus.Desc
+	batchesSent   *prometheus.Desc
+	batchesFailed *prometheus.Desc
+	retries       *prometheus.Desc
+	sendLatency   *prometheus.Desc
+}
+
+// Collector returns a prometheus.Collector reporting the pipeline stats of
+// client. Child loggers share their root client's stats, so pass the root.
+func Collector(client *logwell.Client, opts ...Option) prometheus.Collector {
+	c := &collector{client: client, namespace: "logwell"}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(c.namespace, "", name), help, nil, c.constLabels)
+	}
+	c.queueDepth = desc("queue_depth", "Number of log entries waiting to be sent.")
+	c.dropped = desc("dropped_entries_total", "Log entries discarded because the queue was full.")
+	c.throttled = desc("throttled_entries_total", "Log entries suppressed by the rate limit.")
+	c.batchesSent = desc("batches_sent_total", "Batches accepted by the server.")
+	c.batchesFailed = desc("batches_failed_total", "Batches that failed after all retries.")
+	c.retries = desc("retries_total", "Retry attempts made by the transport.")
+	c.sendLatency = desc("send_latency_seconds", "Time taken to send a batch successfully, including retries.")
+	return c
+}
+
+// Describe implements prometheus.Collector.
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.queueDepth
+	ch <- c.dropped
+	ch <- c.throttled
+	ch <- c.batchesSent
+	ch <- c.batchesFailed
+	ch <- c.retries
+	ch <- c.sendLatency
+}
+
+// Collect implements prometheus.Collector.
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.client.Stats()
+
+	ch <- prometheus.MustNewConstMetric(c.queueDepth, prometheus.GaugeValue, float64(stats.Queued))
+	ch <- prometheus.MustNewConstMetric(c.dropped, prometheus.CounterValue, float64(stats.Dropped))
+	ch <- prometheus.MustNewConstMetric(c.throttled, prometheus.CounterValue, float64(stats.Throttled))
+	ch <- prometheus.MustNewConstMetric(c.batchesSent, prometheus.CounterValue, float64(stats.BatchesSent))
+	ch <- prometheus.MustNewConstMetric(c.batchesFailed, prometheus.CounterValue, float64(stats.BatchesFailed))
+	ch <- prometheus.MustNewConstMetric(c.retries, prometheus.CounterValue, float64(stats.Retries))
+
+	// Prometheus buckets are cumulative; the snapshot's are not. The
+	// overflow count is implied by the total.
+	h := stats.SendLatency
+	buckets := make(map[float64]uint64, len(h.Bounds))
+	var cumulative uint64
+	for i, bound := range h.Bounds {
+		cumulative += h.Counts[i]
+		buckets[bound.Seconds()] = cumulative
+	}
+	ch <- prometheus.MustNewConstHistogram(c.sendLatency, h.Count, h.Sum.Seconds(), buckets)
+}
@@ -0,0 +1,127 @@
+package logwellprom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+const testAPIKey = "lw_00000000000000000000000000000000"
+
+// newServer accepts batches, failing the first request to force a retry.
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var entries []logwell.LogEntry
+		json.NewDecoder(r.Body).Decode(&entries)
+		json.NewEncoder(w).Encode(logwell.IngestResponse{Accepted: len(entries)})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newClient(t *testing.T, url string, opts ...logwell.Option) *logwell.Client {
+	t.Helper()
+	client, err := logwell.New(url, testAPIKey, opts...)
+	if err != nil {
+		t.Fatalf("logwell.New() error = %v", err)
+	}
+	t.Cleanup(func() { client.Shutdown(context.Background()) })
+	return client
+}
+
+func TestCollectorReportsStats(t *testing.T) {
+	client := newClient(t, newServer(t).URL, logwell.WithMaxRetries(2), logwell.WithMaxQueueSize(3))
+	for range 5 {
+		client.Info("entry")
+	}
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	client.Info("pending")
+
+	expected := `
+# HELP logwell_batches_failed_total Batches that failed after all retries.
+# TYPE logwell_batches_failed_total counter
+logwell_batches_failed_total 0
+# HELP logwell_batches_sent_total Batches accepted by the server.
+# TYPE logwell_batches_sent_total counter
+logwell_batches_sent_total 1
+# HELP logwell_dropped_entries_total Log entries discarded because the queue was full.
+# TYPE logwell_dropped_entries_total counter
+logwell_dropped_entries_total 2
+# HELP logwell_queue_depth Number of log entries waiting to be sent.
+# TYPE logwell_queue_depth gauge
+logwell_queue_depth 1
+# HELP logwell_retries_total Retry attempts made by the transport.
+# TYPE logwell_retries_total counter
+logwell_retries_total 1
+`
+	c := Collector(client)
+	err := testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"logwell_batches_failed_total", "logwell_batches_sent_total",
+		"logwell_dropped_entries_total", "logwell_queue_depth", "logwell_retries_total")
+	if err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(c, "logwell_send_latency_seconds"); n != 1 {
+		t.Errorf("send latency series = %d, want 1", n)
+	}
+}
+
+func TestCollectorHistogram(t *testing.T) {
+	client := newClient(t, newServer(t).URL, logwell.WithMaxRetries(1))
+	client.Info("entry")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(Collector(client))
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, mf := range families {
+		if mf.GetName() != "logwell_send_latency_seconds" {
+			continue
+		}
+		h := mf.GetMetric()[0].GetHistogram()
+		if h.GetSampleCount() != 1 || h.GetSampleSum() <= 0 {
+			t.Errorf("count/sum = %d/%v, want one positive sample", h.GetSampleCount(), h.GetSampleSum())
+		}
+		if got, want := len(h.GetBucket()), len(logwell.LatencyBuckets()); got != want {
+			t.Errorf("buckets = %d, want %d", got, want)
+		}
+		return
+	}
+	t.Error("logwell_send_latency_seconds not gathered")
+}
+
+func TestCollectorOptions(t *testing.T) {
+	client := newClient(t, newServer(t).URL)
+	c := Collector(client, WithNamespace("app_logs"), WithConstLabels(prometheus.Labels{"client": "primary"}))
+
+	expected := `
+# HELP app_logs_queue_depth Number of log entries waiting to be sent.
+# TYPE app_logs_queue_depth gauge
+app_logs_queue_depth{client="primary"} 0
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected), "app_logs_queue_depth"); err != nil {
+		t.Error(err)
+	}
+}
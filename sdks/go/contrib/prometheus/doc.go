@@ -0,0 +1,20 @@
+// Package logwellprom exposes a Logwell client's delivery pipeline as
+// Prometheus metrics.
+//
+// # Usage
+//
+//	prometheus.MustRegister(logwellprom.Collector(client))
+//
+// The collector reads Client.Stats on every scrape and reports:
+//
+//	logwell_queue_depth                  entries waiting to be sent
+//	logwell_dropped_entries_total        entries discarded on queue overflow
+//	logwell_throttled_entries_total      entries suppressed by the rate limit
+//	logwell_batches_sent_total           batches accepted by the server
+//	logwell_batches_failed_total         batches that failed after all retries
+//	logwell_retries_total                transport retry attempts
+//	logwell_send_latency_seconds         histogram of successful batch send times
+//
+// Register one collector per client. When several clients share a registry,
+// distinguish them with WithConstLabels.
+package logwellprom
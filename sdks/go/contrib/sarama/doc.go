@@ -0,0 +1,18 @@
+// Package logwellsarama routes the internal logs of the IBM/sarama Kafka
+// client through a Logwell client.
+//
+// Sarama reports broker connections, metadata refreshes, rebalances, and
+// retries through a package-level sarama.StdLogger that writes nowhere by
+// default, or to raw stdout once enabled. Logger implements that interface
+// so the same messages become leveled, structured Logwell entries.
+//
+// # Usage
+//
+//	sarama.Logger = logwellsarama.Logger(client)
+//
+// Sarama's messages carry no level. By default those mentioning an error
+// or failure log at Warn and the rest at Debug, so a client at the Info
+// minimum level keeps only the problems; WithLevel sets one level for all
+// of them. Each entry carries logger: "sarama". To cap a noisy broker
+// loop, give the client WithRateLimit or WithDedupe.
+package logwellsarama
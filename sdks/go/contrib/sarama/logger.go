@@ -0,0 +1,94 @@
+package logwellsarama
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IBM/sarama"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// DefaultName is the logger name entries carry by default.
+const DefaultName = "sarama"
+
+// Option configures Logger.
+type Option func(*config)
+
+type config struct {
+	name  string
+	level logwell.LogLevel
+}
+
+// WithName sets the logger name entries carry in "logger" metadata (see
+// logwell.Client.Named). Default: "sarama".
+func WithName(name string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// WithLevel logs every message at level instead of classifying it.
+func WithLevel(level logwell.LogLevel) Option {
+	return func(c *config) {
+		c.level = level
+	}
+}
+
+// stdLogger is the sarama.StdLogger returned by Logger.
+type stdLogger struct {
+	client *logwell.Client
+	level  logwell.LogLevel
+}
+
+var _ sarama.StdLogger = (*stdLogger)(nil)
+
+// Logger returns a sarama.StdLogger that logs each message through client.
+// Assign it to sarama.Logger (or sarama.DebugLogger).
+func Logger(client *logwell.Client, opts ...Option) sarama.StdLogger {
+	cfg := &config{name: DefaultName}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return &stdLogger{client: client.Named(cfg.name), level: cfg.level}
+}
+
+// Print logs its arguments in the manner of fmt.Sprint.
+func (l *stdLogger) Print(v ...any) {
+	l.log(fmt.Sprint(v...))
+}
+
+// Printf logs its arguments in the manner of fmt.Sprintf.
+func (l *stdLogger) Printf(format string, v ...any) {
+	l.log(fmt.Sprintf(format, v...))
+}
+
+// Println logs its arguments in the manner of fmt.Sprintln.
+func (l *stdLogger) Println(v ...any) {
+	l.log(fmt.Sprintln(v...))
+}
+
+// log logs message without its trailing newline.
+func (l *stdLogger) log(message string) {
+	message = strings.TrimRight(message, "\n")
+	level := l.level
+	if level == "" {
+		level = classify(message)
+	}
+	l.client.Log(logwell.LogEntry{Level: level, Message: message})
+}
+
+// problemWords mark a sarama message as a problem worth a warning.
+var problemWords = []string{"error", "fail", "unable", "could not", "cannot", "timeout", "timed out"}
+
+// classify returns Warn for messages reporting a problem and Debug for
+// the rest.
+func classify(message string) logwell.LogLevel {
+	lower := strings.ToLower(message)
+	for _, word := range problemWords {
+		if strings.Contains(lower, word) {
+			return logwell.LevelWarn
+		}
+	}
+	return logwell.LevelDebug
+}
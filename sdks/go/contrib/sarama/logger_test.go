@@ -0,0 +1,53 @@
+package logwellsarama
+
+import (
+	"testing"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwelltest"
+)
+
+func TestLogger(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	logger := Logger(rec.Client)
+
+	logger.Printf("client/metadata fetching metadata for all topics from broker %s\n", "localhost:9092")
+	logger.Println("Failed to connect to broker", "localhost:9092")
+	logger.Print("consumer/broker/", 1, " Timed out waiting")
+
+	entries := rec.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("recorded %d entries, want 3", len(entries))
+	}
+	want := []struct {
+		level   logwell.LogLevel
+		message string
+	}{
+		{logwell.LevelDebug, "client/metadata fetching metadata for all topics from broker localhost:9092"},
+		{logwell.LevelWarn, "Failed to connect to broker localhost:9092"},
+		{logwell.LevelWarn, "consumer/broker/1 Timed out waiting"},
+	}
+	for i, e := range entries {
+		if e.Level != want[i].level || e.Message != want[i].message {
+			t.Errorf("entry %d = %s %q, want %s %q", i, e.Level, e.Message, want[i].level, want[i].message)
+		}
+		if e.Metadata["logger"] != DefaultName {
+			t.Errorf("entry %d logger = %v, want %q", i, e.Metadata["logger"], DefaultName)
+		}
+	}
+}
+
+func TestLoggerOptions(t *testing.T) {
+	rec := logwelltest.NewRecorder(logwell.WithMinLevel(logwell.LevelInfo))
+	logger := Logger(rec.Client, WithName("kafka"), WithLevel(logwell.LevelInfo))
+
+	logger.Print("Connected to broker")
+	rec.AssertLogged(t, logwell.LevelInfo, "Connected to broker")
+	if e, _ := rec.LastEntry(); e.Metadata["logger"] != "kafka" {
+		t.Errorf("logger = %v, want kafka", e.Metadata["logger"])
+	}
+
+	// Debug-classified messages respect the client's minimum level.
+	Logger(rec.Client).Print("client/metadata fetching metadata")
+	rec.AssertNotLogged(t, logwell.LevelDebug, "fetching metadata")
+}
@@ -0,0 +1,19 @@
+// Package logwellsyslog receives syslog messages and forwards them to
+// Logwell, for devices, appliances, and daemons that can only log to a
+// syslog server.
+//
+// # Usage
+//
+//	srv, err := logwellsyslog.Listen(":5514", client)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer srv.Close()
+//
+// Listen accepts RFC 5424 and RFC 3164 (BSD) messages over UDP and TCP,
+// with octet-counted or newline-delimited TCP framing (RFC 6587). Each
+// message becomes an entry on the client's batching pipeline, so
+// processors, sampling, rate limits, and retries apply as usual. Severity
+// maps onto the Logwell level, APP-NAME or the TAG becomes the service,
+// and the header fields become metadata.
+package logwellsyslog
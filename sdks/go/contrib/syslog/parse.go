@@ -0,0 +1,220 @@
+package logwellsyslog
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// defaultPriority is assumed for messages without a PRI part: user.notice,
+// as RFC 3164 section 4.3.3 prescribes for relays.
+const defaultPriority = 13
+
+// facilities names the syslog facilities by code.
+var facilities = [...]string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+// severities names the syslog severities by code.
+var severities = [...]string{"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug"}
+
+// levels maps syslog severities to Logwell levels.
+var levels = [...]logwell.LogLevel{
+	logwell.LevelFatal, logwell.LevelFatal, logwell.LevelFatal, logwell.LevelError,
+	logwell.LevelWarn, logwell.LevelInfo, logwell.LevelInfo, logwell.LevelDebug,
+}
+
+// message is a parsed syslog message.
+type message struct {
+	priority       int
+	timestamp      time.Time
+	hostname       string
+	appName        string
+	procID         string
+	msgID          string
+	structuredData map[string]map[string]string
+	text           string
+}
+
+// parse parses an RFC 5424 or RFC 3164 message. It never fails: text it
+// cannot parse becomes the message text. now supplies the year RFC 3164
+// timestamps lack and the time of messages without a timestamp.
+func parse(raw string, now time.Time) message {
+	raw = strings.TrimRight(raw, "\r\n\x00")
+	m := message{priority: defaultPriority}
+	rest, ok := parsePriority(raw, &m)
+	if !ok {
+		m.timestamp = now
+		m.text = raw
+		return m
+	}
+	if strings.HasPrefix(rest, "1 ") {
+		parse5424(rest[2:], &m, now)
+	} else {
+		parse3164(rest, &m, now)
+	}
+	return m
+}
+
+// parsePriority reads a leading "<PRI>" into m.priority.
+func parsePriority(raw string, m *message) (string, bool) {
+	if !strings.HasPrefix(raw, "<") {
+		return raw, false
+	}
+	end := strings.IndexByte(raw, '>')
+	if end < 2 || end > 4 {
+		return raw, false
+	}
+	pri, err := strconv.Atoi(raw[1:end])
+	if err != nil || pri < 0 || pri > 191 {
+		return raw, false
+	}
+	m.priority = pri
+	return raw[end+1:], true
+}
+
+// parse5424 parses the part of an RFC 5424 message after "<PRI>1 ".
+func parse5424(s string, m *message, now time.Time) {
+	fields := make([]string, 0, 5)
+	for range 5 {
+		field, rest, _ := strings.Cut(s, " ")
+		fields = append(fields, field)
+		s = rest
+	}
+	m.timestamp = now
+	if t, err := time.Parse(time.RFC3339Nano, fields[0]); err == nil {
+		m.timestamp = t
+	}
+	m.hostname = nilValue(fields[1])
+	m.appName = nilValue(fields[2])
+	m.procID = nilValue(fields[3])
+	m.msgID = nilValue(fields[4])
+
+	if strings.HasPrefix(s, "-") {
+		s = strings.TrimPrefix(s[1:], " ")
+	} else if strings.HasPrefix(s, "[") {
+		m.structuredData, s = parseStructuredData(s)
+	}
+	m.text = strings.TrimPrefix(s, "\ufeff") // BOM marking UTF-8
+}
+
+// nilValue maps the RFC 5424 NILVALUE "-" to "".
+func nilValue(s string) string {
+	if s == "-" {
+		return ""
+	}
+	return s
+}
+
+// parseStructuredData parses leading SD-ELEMENTs such as
+// [id@32473 key="value"] and returns them with the remaining text.
+func parseStructuredData(s string) (map[string]map[string]string, string) {
+	sd := make(map[string]map[string]string)
+	for strings.HasPrefix(s, "[") {
+		s = s[1:]
+		id, rest, _ := strings.Cut(s, " ")
+		if i := strings.IndexByte(id, ']'); i >= 0 {
+			id, rest = id[:i], s[i:]
+		}
+		params := make(map[string]string)
+		s = rest
+		for {
+			s = strings.TrimLeft(s, " ")
+			if s == "" || s[0] == ']' {
+				break
+			}
+			name, value, ok := strings.Cut(s, `="`)
+			if !ok {
+				s = ""
+				break
+			}
+			var b strings.Builder
+			i := 0
+			for ; i < len(value) && value[i] != '"'; i++ {
+				if value[i] == '\\' && i+1 < len(value) {
+					i++
+				}
+				b.WriteByte(value[i])
+			}
+			params[name] = b.String()
+			s = value[min(i+1, len(value)):]
+		}
+		sd[id] = params
+		s = strings.TrimPrefix(s, "]")
+	}
+	return sd, strings.TrimPrefix(s, " ")
+}
+
+// rfc3164Time is the RFC 3164 TIMESTAMP layout; days below 10 are padded
+// with a space.
+const rfc3164Time = "Jan _2 15:04:05"
+
+// parse3164 parses the part of an RFC 3164 message after "<PRI>".
+func parse3164(s string, m *message, now time.Time) {
+	m.timestamp = now
+	if len(s) >= len(rfc3164Time) {
+		if t, err := time.ParseInLocation(rfc3164Time, s[:len(rfc3164Time)], now.Location()); err == nil {
+			t = t.AddDate(now.Year(), 0, 0)
+			if t.After(now.Add(24 * time.Hour)) {
+				t = t.AddDate(-1, 0, 0) // December message received in January
+			}
+			m.timestamp = t
+			s = strings.TrimPrefix(s[len(rfc3164Time):], " ")
+			if host, rest, ok := strings.Cut(s, " "); ok && !strings.HasSuffix(host, ":") {
+				m.hostname = host
+				s = rest
+			}
+		}
+	}
+
+	// TAG is up to 32 alphanumeric characters, optionally with [PID],
+	// followed by a colon.
+	if i := strings.IndexAny(s, ":[ "); i > 0 && i <= 32 {
+		tag, rest := s[:i], s[i:]
+		if strings.HasPrefix(rest, "[") {
+			if end := strings.Index(rest, "]:"); end > 0 {
+				m.appName, m.procID = tag, rest[1:end]
+				s = strings.TrimPrefix(rest[end+2:], " ")
+			}
+		} else if strings.HasPrefix(rest, ":") {
+			m.appName = tag
+			s = strings.TrimPrefix(rest[1:], " ")
+		}
+	}
+	m.text = s
+}
+
+// entry converts m to a Logwell entry.
+func (m message) entry() logwell.LogEntry {
+	facility, severity := m.priority/8, m.priority%8
+	meta := logwell.M{
+		"syslogFacility": facilities[facility],
+		"syslogSeverity": severities[severity],
+	}
+	if m.hostname != "" {
+		meta["host"] = m.hostname
+	}
+	if m.appName != "" {
+		meta["appName"] = m.appName
+	}
+	if m.procID != "" {
+		meta["procId"] = m.procID
+	}
+	if m.msgID != "" {
+		meta["msgId"] = m.msgID
+	}
+	if len(m.structuredData) > 0 {
+		meta["structuredData"] = m.structuredData
+	}
+	return logwell.LogEntry{
+		Timestamp: m.timestamp.UTC().Format("2006-01-02T15:04:05.000Z07:00"),
+		Level:     levels[severity],
+		Message:   m.text,
+		Service:   m.appName,
+		Metadata:  meta,
+	}
+}
@@ -0,0 +1,132 @@
+package logwellsyslog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+var testNow = time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+
+func TestParse5424(t *testing.T) {
+	raw := `<165>1 2026-01-05T10:20:30.123Z web-1 billing 4021 ID47 [exampleSDID@32473 iut="3" eventSource="App\"lication"][meta x="y"]` + " \ufeffcharge failed\n"
+	m := parse(raw, testNow)
+
+	if m.priority != 165 {
+		t.Errorf("priority = %d, want 165", m.priority)
+	}
+	if want := time.Date(2026, 1, 5, 10, 20, 30, 123e6, time.UTC); !m.timestamp.Equal(want) {
+		t.Errorf("timestamp = %v, want %v", m.timestamp, want)
+	}
+	if m.hostname != "web-1" || m.appName != "billing" || m.procID != "4021" || m.msgID != "ID47" {
+		t.Errorf("header = %+v", m)
+	}
+	if got := m.structuredData["exampleSDID@32473"]; got["iut"] != "3" || got["eventSource"] != `App"lication` {
+		t.Errorf("structured data = %v", m.structuredData)
+	}
+	if got := m.structuredData["meta"]; got["x"] != "y" {
+		t.Errorf("structured data = %v", m.structuredData)
+	}
+	if m.text != "charge failed" {
+		t.Errorf("text = %q, want %q", m.text, "charge failed")
+	}
+}
+
+func TestParse5424NilValues(t *testing.T) {
+	m := parse("<14>1 - - - - - - started", testNow)
+
+	if !m.timestamp.Equal(testNow) {
+		t.Errorf("timestamp = %v, want now", m.timestamp)
+	}
+	if m.hostname != "" || m.appName != "" || m.procID != "" || m.msgID != "" || m.structuredData != nil {
+		t.Errorf("header = %+v, want empty", m)
+	}
+	if m.text != "started" {
+		t.Errorf("text = %q, want %q", m.text, "started")
+	}
+}
+
+func TestParse3164(t *testing.T) {
+	m := parse("<34>Jan  5 11:59:00 mymachine su[230]: 'su root' failed for lonvick on /dev/pts/8", testNow)
+
+	if want := time.Date(2026, 1, 5, 11, 59, 0, 0, time.UTC); !m.timestamp.Equal(want) {
+		t.Errorf("timestamp = %v, want %v", m.timestamp, want)
+	}
+	if m.hostname != "mymachine" || m.appName != "su" || m.procID != "230" {
+		t.Errorf("header = %+v", m)
+	}
+	if m.text != "'su root' failed for lonvick on /dev/pts/8" {
+		t.Errorf("text = %q", m.text)
+	}
+}
+
+func TestParse3164PreviousYear(t *testing.T) {
+	m := parse("<13>Dec 31 23:59:59 host cron: tick", testNow)
+
+	if want := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC); !m.timestamp.Equal(want) {
+		t.Errorf("timestamp = %v, want %v", m.timestamp, want)
+	}
+	if m.appName != "cron" || m.text != "tick" {
+		t.Errorf("message = %+v", m)
+	}
+}
+
+func TestParse3164WithoutHeader(t *testing.T) {
+	m := parse("<13>just some text", testNow)
+
+	if !m.timestamp.Equal(testNow) || m.hostname != "" || m.appName != "" {
+		t.Errorf("message = %+v, want no header", m)
+	}
+	if m.text != "just some text" {
+		t.Errorf("text = %q", m.text)
+	}
+}
+
+func TestParseWithoutPriority(t *testing.T) {
+	for _, raw := range []string{"plain text", "<999>too high", "<x>bad"} {
+		m := parse(raw, testNow)
+		if m.priority != defaultPriority || m.text != raw {
+			t.Errorf("parse(%q) = %+v, want user.notice with the whole text", raw, m)
+		}
+	}
+}
+
+func TestEntry(t *testing.T) {
+	tests := []struct {
+		priority int
+		level    logwell.LogLevel
+		facility string
+		severity string
+	}{
+		{0, logwell.LevelFatal, "kern", "emerg"},
+		{10, logwell.LevelFatal, "user", "crit"},
+		{27, logwell.LevelError, "daemon", "err"},
+		{36, logwell.LevelWarn, "auth", "warning"},
+		{133, logwell.LevelInfo, "local0", "notice"},
+		{190, logwell.LevelInfo, "local7", "info"},
+		{191, logwell.LevelDebug, "local7", "debug"},
+	}
+	for _, tt := range tests {
+		e := message{priority: tt.priority, timestamp: testNow}.entry()
+		if e.Level != tt.level {
+			t.Errorf("priority %d: level = %s, want %s", tt.priority, e.Level, tt.level)
+		}
+		if e.Metadata["syslogFacility"] != tt.facility || e.Metadata["syslogSeverity"] != tt.severity {
+			t.Errorf("priority %d: metadata = %v", tt.priority, e.Metadata)
+		}
+	}
+
+	e := parse("<165>1 2026-01-05T10:20:30.123+02:00 web-1 billing 4021 ID47 - charge failed", testNow).entry()
+	if e.Timestamp != "2026-01-05T08:20:30.123Z" {
+		t.Errorf("timestamp = %q", e.Timestamp)
+	}
+	if e.Service != "billing" || e.Message != "charge failed" {
+		t.Errorf("entry = %+v", e)
+	}
+	for key, want := range map[string]string{"host": "web-1", "appName": "billing", "procId": "4021", "msgId": "ID47"} {
+		if e.Metadata[key] != want {
+			t.Errorf("metadata[%q] = %v, want %q", key, e.Metadata[key], want)
+		}
+	}
+}
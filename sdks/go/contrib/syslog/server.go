@@ -0,0 +1,342 @@
+package logwellsyslog
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwellparse"
+)
+
+// DefaultMaxMessageSize is the largest message accepted by default; longer
+// UDP datagrams are truncated and longer TCP frames are dropped.
+const DefaultMaxMessageSize = 64 << 10
+
+// Option configures Listen.
+type Option func(*config)
+
+type config struct {
+	networks       []string
+	maxMessageSize int
+	onError        func(error)
+	parser         logwellparse.Parser
+}
+
+// WithNetworks selects the transports to listen on: "udp", "tcp", or both.
+// Default: both.
+func WithNetworks(networks ...string) Option {
+	return func(c *config) {
+		c.networks = networks
+	}
+}
+
+// WithMaxMessageSize sets the largest message accepted, in bytes.
+// Default: DefaultMaxMessageSize.
+func WithMaxMessageSize(n int) Option {
+	return func(c *config) {
+		c.maxMessageSize = n
+	}
+}
+
+// WithOnError sets a callback for errors reading from connections, such
+// as an oversized TCP frame. Errors are otherwise ignored.
+func WithOnError(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// WithParser parses the text of each message, for senders that log JSON,
+// logfmt, or access log lines over syslog. The parsed message, timestamp,
+// service, and metadata replace or extend the syslog ones, except that
+// the syslog header metadata is kept and the entry takes the more severe
+// of the syslog and parsed levels. Text the parser rejects is forwarded
+// as is. Default: none.
+func WithParser(p logwellparse.Parser) Option {
+	return func(c *config) {
+		c.parser = p
+	}
+}
+
+// Server receives syslog messages and forwards them through a Logwell
+// client. Close it to stop.
+type Server struct {
+	client *logwell.Client
+	cfg    config
+	now    func() time.Time
+
+	udp net.PacketConn
+	tcp net.Listener
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// Listen starts receiving syslog messages on addr (such as ":514" or
+// "127.0.0.1:5514") over UDP and TCP, and forwards each through client,
+// which batches and sends it like any entry. Both RFC 5424 and RFC 3164
+// (BSD) messages are accepted; TCP streams may use octet counting or
+// newline framing (RFC 6587).
+//
+// The syslog severity sets the level (emerg, alert, and crit map to
+// Fatal, without exiting), the message timestamp is kept, APP-NAME or the
+// TAG becomes the service, and facility, severity, host, process ID,
+// message ID, and structured data become metadata. Messages without a
+// valid PRI part are forwarded whole as user.notice.
+//
+// Example:
+//
+//	srv, err := logwellsyslog.Listen(":5514", client)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer srv.Close()
+func Listen(addr string, client *logwell.Client, opts ...Option) (*Server, error) {
+	cfg := config{networks: []string{"udp", "tcp"}, maxMessageSize: DefaultMaxMessageSize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.maxMessageSize <= 0 {
+		return nil, errors.New("logwellsyslog: max message size must be positive")
+	}
+
+	s := &Server{client: client, cfg: cfg, now: time.Now, conns: make(map[net.Conn]struct{})}
+	for _, network := range cfg.networks {
+		var err error
+		switch network {
+		case "udp":
+			s.udp, err = net.ListenPacket("udp", addr)
+		case "tcp":
+			s.tcp, err = net.Listen("tcp", addr)
+		default:
+			err = fmt.Errorf("unsupported network %q", network)
+		}
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("logwellsyslog: %w", err)
+		}
+	}
+	if s.udp == nil && s.tcp == nil {
+		return nil, errors.New("logwellsyslog: no network to listen on")
+	}
+
+	if s.udp != nil {
+		s.wg.Add(1)
+		go s.serveUDP()
+	}
+	if s.tcp != nil {
+		s.wg.Add(1)
+		go s.serveTCP()
+	}
+	return s, nil
+}
+
+// UDPAddr returns the UDP address the server listens on, or nil.
+func (s *Server) UDPAddr() net.Addr {
+	if s.udp == nil {
+		return nil
+	}
+	return s.udp.LocalAddr()
+}
+
+// TCPAddr returns the TCP address the server listens on, or nil.
+func (s *Server) TCPAddr() net.Addr {
+	if s.tcp == nil {
+		return nil
+	}
+	return s.tcp.Addr()
+}
+
+// Close stops listening, closes open TCP connections, and waits until
+// every received message has been handed to the client. It does not
+// flush or shut down the client.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	var errs []error
+	if s.udp != nil {
+		errs = append(errs, s.udp.Close())
+	}
+	if s.tcp != nil {
+		errs = append(errs, s.tcp.Close())
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return errors.Join(errs...)
+}
+
+// forward sends one raw message through the client.
+func (s *Server) forward(raw string) {
+	if raw == "" {
+		return
+	}
+	entry := parse(raw, s.now()).entry()
+	if s.cfg.parser != nil {
+		if parsed, err := s.cfg.parser.Parse(entry.Message); err == nil {
+			entry = merge(entry, parsed)
+		}
+	}
+	s.client.Log(entry)
+}
+
+// merge applies a parsed message text to the entry built from its syslog
+// message.
+func merge(entry, parsed logwell.LogEntry) logwell.LogEntry {
+	entry.Message = parsed.Message
+	if parsed.Level.Enabled(entry.Level) {
+		entry.Level = parsed.Level
+	}
+	if parsed.Timestamp != "" {
+		entry.Timestamp = parsed.Timestamp
+	}
+	if parsed.Service != "" {
+		entry.Service = parsed.Service
+	}
+	for k, v := range parsed.Metadata {
+		if _, ok := entry.Metadata[k]; !ok {
+			entry.Metadata[k] = v
+		}
+	}
+	return entry
+}
+
+// reportError passes err to the OnError callback, if any.
+func (s *Server) reportError(err error) {
+	if s.cfg.onError != nil {
+		s.cfg.onError(err)
+	}
+}
+
+// serveUDP forwards each datagram as one message.
+func (s *Server) serveUDP() {
+	defer s.wg.Done()
+	buf := make([]byte, s.cfg.maxMessageSize)
+	for {
+		n, _, err := s.udp.ReadFrom(buf)
+		if n > 0 {
+			s.forward(string(buf[:n]))
+		}
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			s.reportError(err)
+		}
+	}
+}
+
+// serveTCP accepts connections until the listener is closed.
+func (s *Server) serveTCP() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.tcp.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			s.reportError(err)
+			continue
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+		go s.serveConn(conn)
+	}
+}
+
+// serveConn forwards the messages framed on one TCP connection.
+func (s *Server) serveConn(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	// The buffer holds a whole newline-framed message and its newline.
+	r := bufio.NewReaderSize(conn, s.cfg.maxMessageSize+1)
+	for {
+		raw, err := s.readFrame(r)
+		if raw != "" {
+			s.forward(raw)
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				s.reportError(err)
+			}
+			return
+		}
+	}
+}
+
+// maxFrameDigits bounds the octet-counting length prefix, so a peer that
+// never sends the space after it cannot make the server buffer without
+// limit. Ten digits cover any length an int can hold.
+const maxFrameDigits = 10
+
+// readFrame reads one message, framed by octet counting ("LEN MSG") when
+// it starts with a digit and by a trailing newline otherwise.
+func (s *Server) readFrame(r *bufio.Reader) (string, error) {
+	first, err := r.Peek(1)
+	if err != nil {
+		return "", err
+	}
+	if first[0] < '0' || first[0] > '9' {
+		line, err := r.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) || len(bytes.TrimSuffix(line, []byte{'\n'})) > s.cfg.maxMessageSize {
+			return "", fmt.Errorf("logwellsyslog: message exceeds %d bytes", s.cfg.maxMessageSize)
+		}
+		return string(line), err
+	}
+
+	var prefix []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		if b == ' ' {
+			break
+		}
+		prefix = append(prefix, b)
+		if b < '0' || b > '9' || len(prefix) > maxFrameDigits {
+			return "", fmt.Errorf("logwellsyslog: invalid frame length %q", prefix)
+		}
+	}
+	n, err := strconv.Atoi(string(prefix))
+	if err != nil {
+		return "", fmt.Errorf("logwellsyslog: invalid frame length %q", prefix)
+	}
+	if n > s.cfg.maxMessageSize {
+		return "", fmt.Errorf("logwellsyslog: message of %d bytes exceeds %d", n, s.cfg.maxMessageSize)
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
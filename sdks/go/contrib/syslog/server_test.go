@@ -0,0 +1,220 @@
+package logwellsyslog
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwellparse"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwelltest"
+)
+
+func listen(t *testing.T, rec *logwelltest.Recorder, opts ...Option) *Server {
+	t.Helper()
+	srv, err := Listen("127.0.0.1:0", rec.Client, opts...)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	t.Cleanup(func() { srv.Close() })
+	return srv
+}
+
+// waitEntries polls rec until it holds n entries.
+func waitEntries(t *testing.T, rec *logwelltest.Recorder, n int) []logwell.LogEntry {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entries := rec.Entries()
+		if len(entries) >= n || time.Now().After(deadline) {
+			if len(entries) != n {
+				t.Fatalf("recorded %d entries, want %d: %+v", len(entries), n, entries)
+			}
+			return entries
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestListenUDP(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	srv := listen(t, rec, WithNetworks("udp"))
+	if srv.TCPAddr() != nil {
+		t.Errorf("TCPAddr = %v, want nil", srv.TCPAddr())
+	}
+
+	conn, err := net.Dial("udp", srv.UDPAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	fmt.Fprint(conn, "<11>1 2026-01-05T10:20:30Z web-1 api - - - disk failed")
+
+	e := waitEntries(t, rec, 1)[0]
+	if e.Level != logwell.LevelError || e.Message != "disk failed" || e.Service != "api" {
+		t.Errorf("entry = %+v", e)
+	}
+}
+
+func TestListenTCPFraming(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	srv := listen(t, rec)
+
+	conn, err := net.Dial("tcp", srv.TCPAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	counted := "<14>1 - host app - - - line one\nstill one"
+	fmt.Fprintf(conn, "%d %s", len(counted), counted)
+	fmt.Fprint(conn, "<12>Jan  5 11:59:00 host app: line two\n")
+	fmt.Fprint(conn, "<15>Jan  5 11:59:01 host app: line three\r\n")
+
+	entries := waitEntries(t, rec, 3)
+	want := []string{"line one\nstill one", "line two", "line three"}
+	for i, e := range entries {
+		if e.Message != want[i] {
+			t.Errorf("entry %d message = %q, want %q", i, e.Message, want[i])
+		}
+	}
+}
+
+func TestListenTCPOversizedFrame(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	errs := make(chan error, 1)
+	srv := listen(t, rec, WithNetworks("tcp"), WithMaxMessageSize(16), WithOnError(func(err error) { errs <- err }))
+
+	conn, err := net.Dial("tcp", srv.TCPAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	fmt.Fprintf(conn, "100 %s", strings.Repeat("x", 100))
+
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "exceeds") {
+			t.Errorf("error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error reported")
+	}
+	if n := len(rec.Entries()); n != 0 {
+		t.Errorf("recorded %d entries, want 0", n)
+	}
+}
+
+// TestListenTCPLargeLine tests that newline-framed messages larger than the
+// default bufio buffer are accepted up to the size limit.
+func TestListenTCPLargeLine(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	srv := listen(t, rec, WithNetworks("tcp"))
+
+	conn, err := net.Dial("tcp", srv.TCPAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	body := strings.Repeat("x", 8<<10)
+	fmt.Fprintf(conn, "<14>1 - host app - - - %s\n", body)
+	fmt.Fprint(conn, "<14>1 - host app - - - after\n")
+
+	entries := waitEntries(t, rec, 2)
+	if entries[0].Message != body || entries[1].Message != "after" {
+		t.Errorf("messages = %d bytes, %q; want %d bytes, \"after\"", len(entries[0].Message), entries[1].Message, len(body))
+	}
+}
+
+// TestListenTCPUnboundedPrefix tests that a length prefix that never ends
+// is rejected instead of buffered.
+func TestListenTCPUnboundedPrefix(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	errs := make(chan error, 1)
+	srv := listen(t, rec, WithNetworks("tcp"), WithOnError(func(err error) { errs <- err }))
+
+	conn, err := net.Dial("tcp", srv.TCPAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	fmt.Fprint(conn, strings.Repeat("1", 64))
+
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "invalid frame length") {
+			t.Errorf("error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error reported")
+	}
+}
+
+func TestListenInvalidOptions(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	if _, err := Listen("127.0.0.1:0", rec.Client, WithNetworks("unix")); err == nil {
+		t.Error("Listen with unsupported network: want error")
+	}
+	if _, err := Listen("127.0.0.1:0", rec.Client, WithNetworks()); err == nil {
+		t.Error("Listen with no networks: want error")
+	}
+	if _, err := Listen("127.0.0.1:0", rec.Client, WithMaxMessageSize(0)); err == nil {
+		t.Error("Listen with zero max message size: want error")
+	}
+}
+
+func TestCloseClosesConnections(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	srv := listen(t, rec, WithNetworks("tcp"))
+
+	conn, err := net.Dial("tcp", srv.TCPAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	fmt.Fprint(conn, "<14>open connection\n")
+	waitEntries(t, rec, 1)
+
+	done := make(chan error, 1)
+	go func() { done <- srv.Close() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Close: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close did not return with a connection open")
+	}
+	if err := srv.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+}
+
+func TestListenWithParser(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	srv := listen(t, rec, WithNetworks("udp"), WithParser(logwellparse.JSON()))
+
+	conn, err := net.Dial("udp", srv.UDPAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	fmt.Fprint(conn, `<14>1 2026-01-05T10:20:30Z web-1 api - - - {"level":"error","msg":"charge failed","host":"container-7","orderId":"o-1"}`)
+	fmt.Fprint(conn, `<11>1 2026-01-05T10:20:31Z web-1 api - - - {"level":"debug","msg":"retrying"}`)
+	fmt.Fprint(conn, `<14>1 2026-01-05T10:20:32Z web-1 api - - - not json`)
+
+	entries := waitEntries(t, rec, 3)
+	if e := entries[0]; e.Level != logwell.LevelError || e.Message != "charge failed" || e.Metadata["orderId"] != "o-1" {
+		t.Errorf("parsed entry = %+v", e)
+	}
+	if host := entries[0].Metadata["host"]; host != "web-1" {
+		t.Errorf("host = %v, want the syslog header to win", host)
+	}
+	if e := entries[1]; e.Level != logwell.LevelError || e.Message != "retrying" {
+		t.Errorf("entry = %+v, want the more severe syslog level", e)
+	}
+	if e := entries[2]; e.Level != logwell.LevelInfo || e.Message != "not json" {
+		t.Errorf("unparsed entry = %+v", e)
+	}
+}
@@ -0,0 +1,19 @@
+// Package logwelltail follows log files and forwards their lines to
+// Logwell, for programs that only write to files.
+//
+// # Usage
+//
+//	t, err := logwelltail.Tail("/var/log/app.log", logwellparse.JSON(), client,
+//		logwelltail.WithCheckpointFile("/var/lib/logwell/app.log.pos"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer t.Close()
+//
+// A Tailer behaves like `tail -F`: it follows the file across rotation
+// and truncation by polling, so it needs no platform file-watching API.
+// Lines are parsed by a logwellparse.Parser (JSON, logfmt, a regular
+// expression, or your own) and forwarded through the client's batching
+// pipeline. With a checkpoint file, a restarted tailer resumes after the
+// last line the previous one delivered.
+package logwelltail
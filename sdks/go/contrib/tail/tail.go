@@ -0,0 +1,404 @@
+package logwelltail
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwellparse"
+)
+
+// DefaultPollInterval is how often the file is checked for new lines,
+// rotation, and truncation.
+const DefaultPollInterval = 250 * time.Millisecond
+
+// maxLineSize bounds one line; longer lines are split.
+const maxLineSize = 64 << 10
+
+// fingerprintSize is how many leading bytes identify a file in
+// checkpoints, so a resumed tailer can tell whether the file was rotated
+// while it was stopped.
+const fingerprintSize = 256
+
+// Option configures Tail.
+type Option func(*config)
+
+type config struct {
+	pollInterval   time.Duration
+	checkpointFile string
+	fromStart      bool
+	onError        func(error)
+}
+
+// WithPollInterval sets how often the file is checked for changes.
+// Default: DefaultPollInterval.
+func WithPollInterval(d time.Duration) Option {
+	return func(c *config) {
+		c.pollInterval = d
+	}
+}
+
+// WithCheckpointFile persists the offset of the last delivered line to
+// path as it advances and on Close, and resumes from it on the next start.
+// Before each save the client is flushed, and the offset is saved only if
+// the flush succeeds, so lines still queued when the process crashes or
+// the endpoint fails are read again on restart: delivery is at least once,
+// and lines sent after the last save may repeat. If the file was rotated
+// in between, tailing restarts at the beginning of the new file.
+func WithCheckpointFile(path string) Option {
+	return func(c *config) {
+		c.checkpointFile = path
+	}
+}
+
+// WithFromStart forwards the file's existing contents when there is no
+// checkpoint. Default: only lines written after Tail starts.
+func WithFromStart() Option {
+	return func(c *config) {
+		c.fromStart = true
+	}
+}
+
+// WithOnError sets a callback for errors reading the file or saving the
+// checkpoint; tailing continues. They are otherwise ignored.
+func WithOnError(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// Tailer follows a file. Close it to stop.
+type Tailer struct {
+	path   string
+	parser logwellparse.Parser
+	client *logwell.Client
+	cfg    config
+
+	file    *os.File
+	info    os.FileInfo
+	offset  int64 // end of the last forwarded line
+	pending []byte
+	saved   int64 // offset last written to the checkpoint, -1 if none
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// Tail follows the file at path like `tail -F`, parsing each line with
+// parser and forwarding it through client. Lines the parser rejects are
+// forwarded whole at INFO. Each entry carries the path as "file"
+// metadata. parser may be nil for logwellparse.Plain(logwell.LevelInfo).
+//
+// Tail keeps following when the file is rotated (renamed or removed and
+// recreated): it finishes the old file and continues from the start of
+// the new one. When the file is truncated in place it starts over from
+// the beginning. If path does not exist yet, Tail waits for it.
+//
+// Example:
+//
+//	t, err := logwelltail.Tail("/var/log/app.log", logwellparse.JSON(), client,
+//		logwelltail.WithCheckpointFile("/var/lib/logwell/app.log.pos"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer t.Close()
+func Tail(path string, parser logwellparse.Parser, client *logwell.Client, opts ...Option) (*Tailer, error) {
+	cfg := config{pollInterval: DefaultPollInterval}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.pollInterval <= 0 {
+		return nil, errors.New("logwelltail: poll interval must be positive")
+	}
+	if parser == nil {
+		parser = logwellparse.Plain(logwell.LevelInfo)
+	}
+
+	t := &Tailer{
+		path:   path,
+		parser: parser,
+		client: client,
+		cfg:    cfg,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	if err := t.open(true); err != nil {
+		return nil, err
+	}
+	go t.run()
+	return t, nil
+}
+
+// Close stops tailing, saves the checkpoint, and closes the file. A
+// partial last line without a newline is left for the next run. With
+// WithCheckpointFile, Close flushes the client to save the checkpoint and
+// returns the flush error if that fails; it never shuts the client down.
+func (t *Tailer) Close() error {
+	t.closeOnce.Do(func() {
+		close(t.stop)
+		<-t.done
+		t.closeErr = t.saveCheckpoint()
+		if t.file != nil {
+			t.file.Close()
+		}
+	})
+	return t.closeErr
+}
+
+// run polls the file until Close.
+func (t *Tailer) run() {
+	defer close(t.done)
+	ticker := time.NewTicker(t.cfg.pollInterval)
+	defer ticker.Stop()
+	for {
+		t.poll()
+		select {
+		case <-t.stop:
+			t.read()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll forwards new lines, follows rotation and truncation, and saves the
+// checkpoint.
+func (t *Tailer) poll() {
+	if t.file == nil {
+		if err := t.open(false); err != nil {
+			t.reportError(err)
+			return
+		}
+		if t.file == nil {
+			return
+		}
+	}
+	t.read()
+
+	info, err := os.Stat(t.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// Rotated away and not recreated yet; keep the old file.
+	case err != nil:
+		t.reportError(fmt.Errorf("logwelltail: %w", err))
+	case !os.SameFile(info, t.info):
+		t.read() // lines written just before the rotation
+		if len(t.pending) > 0 {
+			t.forward(t.pending)
+			t.pending = nil
+		}
+		if err := t.saveCheckpoint(); err != nil {
+			t.reportError(err)
+		}
+		t.file.Close()
+		t.file, t.offset, t.saved = nil, 0, -1
+		if err := t.open(false); err != nil {
+			t.reportError(err)
+		} else if t.file != nil {
+			t.read()
+		}
+	case info.Size() < t.offset+int64(len(t.pending)):
+		if _, err := t.file.Seek(0, io.SeekStart); err != nil {
+			t.reportError(fmt.Errorf("logwelltail: %w", err))
+			return
+		}
+		t.offset, t.pending, t.saved = 0, nil, -1
+		t.read()
+	}
+
+	if err := t.saveCheckpoint(); err != nil {
+		t.reportError(err)
+	}
+}
+
+// open opens the file, positioned at the checkpoint, at the start, or (on
+// the first open without WithFromStart) at the end. A missing file leaves
+// t.file nil.
+func (t *Tailer) open(first bool) error {
+	f, err := os.Open(t.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("logwelltail: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("logwelltail: %w", err)
+	}
+
+	offset := int64(0)
+	if first {
+		if cp, ok := t.loadCheckpoint(); ok {
+			if cp.Offset <= info.Size() && cp.Fingerprint == fingerprint(f, cp.FingerprintSize) {
+				offset = cp.Offset
+			}
+		} else if !t.cfg.fromStart {
+			offset = info.Size()
+		}
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return fmt.Errorf("logwelltail: %w", err)
+	}
+	t.file, t.info, t.offset, t.saved = f, info, offset, -1
+	// Record the starting position before forwarding anything, so a
+	// restart reads again the lines this run forwards but never delivers.
+	if err := t.writeCheckpoint(); err != nil {
+		t.reportError(err)
+	}
+	return nil
+}
+
+// read forwards the complete lines written since the last read.
+func (t *Tailer) read() {
+	if t.file == nil {
+		return
+	}
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := t.file.Read(buf)
+		if n > 0 {
+			t.pending = append(t.pending, buf[:n]...)
+			t.forwardLines()
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.reportError(fmt.Errorf("logwelltail: %w", err))
+			}
+			return
+		}
+	}
+}
+
+// forwardLines forwards each complete line in t.pending, splitting lines
+// longer than maxLineSize.
+func (t *Tailer) forwardLines() {
+	for {
+		i := bytes.IndexByte(t.pending, '\n')
+		if i < 0 && len(t.pending) < maxLineSize {
+			break
+		}
+		n := maxLineSize
+		if i >= 0 && i < maxLineSize {
+			n = i + 1
+		}
+		t.forward(t.pending[:n])
+		t.pending = t.pending[n:]
+		t.offset += int64(n)
+	}
+	if len(t.pending) == 0 {
+		t.pending = nil
+	}
+}
+
+// forward parses one line and logs it.
+func (t *Tailer) forward(line []byte) {
+	text := string(bytes.TrimRight(line, "\r\n"))
+	if text == "" {
+		return
+	}
+	entry, err := t.parser.Parse(text)
+	if err != nil {
+		entry = logwell.LogEntry{Level: logwell.LevelInfo, Message: text}
+	}
+	if entry.Metadata == nil {
+		entry.Metadata = logwell.M{}
+	}
+	entry.Metadata["file"] = t.path
+	t.client.Log(entry)
+}
+
+// reportError passes err to the OnError callback, if any.
+func (t *Tailer) reportError(err error) {
+	if t.cfg.onError != nil {
+		t.cfg.onError(err)
+	}
+}
+
+// checkpoint is the persisted tailing position.
+type checkpoint struct {
+	Path            string `json:"path"`
+	Offset          int64  `json:"offset"`
+	Fingerprint     string `json:"fingerprint"`
+	FingerprintSize int    `json:"fingerprintSize"`
+}
+
+// loadCheckpoint reads the checkpoint for t.path, if any.
+func (t *Tailer) loadCheckpoint() (checkpoint, bool) {
+	if t.cfg.checkpointFile == "" {
+		return checkpoint{}, false
+	}
+	data, err := os.ReadFile(t.cfg.checkpointFile)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			t.reportError(fmt.Errorf("logwelltail: reading checkpoint: %w", err))
+		}
+		return checkpoint{}, false
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		t.reportError(fmt.Errorf("logwelltail: reading checkpoint: %w", err))
+		return checkpoint{}, false
+	}
+	return cp, cp.Path == t.path
+}
+
+// saveCheckpoint flushes the client and, once the lines forwarded so far
+// are delivered, writes the current offset if it changed.
+func (t *Tailer) saveCheckpoint() error {
+	if t.cfg.checkpointFile == "" || t.file == nil || t.offset == t.saved {
+		return nil
+	}
+	if err := t.client.Flush(context.Background()); err != nil {
+		return fmt.Errorf("logwelltail: saving checkpoint: %w", err)
+	}
+	return t.writeCheckpoint()
+}
+
+// writeCheckpoint writes the current offset if it changed, replacing the
+// checkpoint file atomically.
+func (t *Tailer) writeCheckpoint() error {
+	if t.cfg.checkpointFile == "" || t.file == nil || t.offset == t.saved {
+		return nil
+	}
+	size := int(min(t.offset, fingerprintSize))
+	data, _ := json.Marshal(checkpoint{
+		Path:            t.path,
+		Offset:          t.offset,
+		Fingerprint:     fingerprint(t.file, size),
+		FingerprintSize: size,
+	})
+	tmp := t.cfg.checkpointFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("logwelltail: saving checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, t.cfg.checkpointFile); err != nil {
+		return fmt.Errorf("logwelltail: saving checkpoint: %w", err)
+	}
+	t.saved = t.offset
+	return nil
+}
+
+// fingerprint hashes the first size bytes of f, or returns "" if f is
+// shorter.
+func fingerprint(f *os.File, size int) string {
+	buf := make([]byte, size)
+	if _, err := f.ReadAt(buf, 0); err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(buf)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,267 @@
+package logwelltail
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwellparse"
+	"github.com/Divkix/Logwell/sdks/go/logwell/logwelltest"
+)
+
+const testPoll = 10 * time.Millisecond
+
+func appendFile(t *testing.T, path, text string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(text); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitMessages polls rec until it holds n entries and returns their
+// messages.
+func waitMessages(t *testing.T, rec *logwelltest.Recorder, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entries := rec.Entries()
+		if len(entries) >= n || time.Now().After(deadline) {
+			msgs := make([]string, len(entries))
+			for i, e := range entries {
+				msgs[i] = e.Message
+			}
+			if len(msgs) != n {
+				t.Fatalf("recorded %q, want %d entries", msgs, n)
+			}
+			return msgs
+		}
+		time.Sleep(testPoll)
+	}
+}
+
+func startTail(t *testing.T, path string, parser logwellparse.Parser, rec *logwelltest.Recorder, opts ...Option) *Tailer {
+	t.Helper()
+	tailer, err := Tail(path, parser, rec.Client, append([]Option{WithPollInterval(testPoll)}, opts...)...)
+	if err != nil {
+		t.Fatalf("Tail: %v", err)
+	}
+	t.Cleanup(func() { tailer.Close() })
+	return tailer
+}
+
+func TestTailNewLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "old line\n")
+	rec := logwelltest.NewRecorder()
+	startTail(t, path, logwellparse.JSON(), rec)
+
+	appendFile(t, path, `{"level":"error","msg":"disk full","disk":"/dev/sda"}`+"\nnot json\r\npartial")
+	msgs := waitMessages(t, rec, 2)
+	if msgs[0] != "disk full" || msgs[1] != "not json" {
+		t.Errorf("messages = %q", msgs)
+	}
+
+	entries := rec.Entries()
+	if e := entries[0]; e.Level != logwell.LevelError || e.Metadata["disk"] != "/dev/sda" || e.Metadata["file"] != path {
+		t.Errorf("parsed entry = %+v", e)
+	}
+	if e := entries[1]; e.Level != logwell.LevelInfo || e.Metadata["file"] != path {
+		t.Errorf("unparsed entry = %+v", e)
+	}
+
+	appendFile(t, path, " line\n")
+	if msgs := waitMessages(t, rec, 3); msgs[2] != "partial line" {
+		t.Errorf("messages = %q, want the partial line completed", msgs)
+	}
+}
+
+func TestTailFromStart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "one\ntwo\n")
+	rec := logwelltest.NewRecorder()
+	startTail(t, path, nil, rec, WithFromStart())
+
+	if msgs := waitMessages(t, rec, 2); msgs[0] != "one" || msgs[1] != "two" {
+		t.Errorf("messages = %q", msgs)
+	}
+}
+
+func TestTailWaitsForFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	rec := logwelltest.NewRecorder()
+	startTail(t, path, nil, rec)
+
+	time.Sleep(3 * testPoll)
+	appendFile(t, path, "created\n")
+	if msgs := waitMessages(t, rec, 1); msgs[0] != "created" {
+		t.Errorf("messages = %q", msgs)
+	}
+}
+
+func TestTailRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "")
+	rec := logwelltest.NewRecorder()
+	startTail(t, path, nil, rec)
+
+	appendFile(t, path, "before\n")
+	waitMessages(t, rec, 1)
+
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path+".1", "late write to old file\n")
+	appendFile(t, path, "after\n")
+
+	msgs := waitMessages(t, rec, 3)
+	if msgs[1] != "late write to old file" || msgs[2] != "after" {
+		t.Errorf("messages = %q", msgs)
+	}
+}
+
+func TestTailTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "")
+	rec := logwelltest.NewRecorder()
+	startTail(t, path, nil, rec)
+
+	appendFile(t, path, "a long first line\n")
+	waitMessages(t, rec, 1)
+
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(3 * testPoll)
+	appendFile(t, path, "short\n")
+
+	if msgs := waitMessages(t, rec, 2); msgs[1] != "short" {
+		t.Errorf("messages = %q", msgs)
+	}
+}
+
+func TestTailLongLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "")
+	rec := logwelltest.NewRecorder()
+	startTail(t, path, nil, rec)
+
+	appendFile(t, path, strings.Repeat("x", maxLineSize+5)+"\n")
+	msgs := waitMessages(t, rec, 2)
+	if len(msgs[0]) != maxLineSize || msgs[1] != "xxxxx" {
+		t.Errorf("got lines of %d and %d bytes, want the line split", len(msgs[0]), len(msgs[1]))
+	}
+}
+
+func TestTailCheckpoint(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	cpFile := filepath.Join(dir, "app.pos")
+	appendFile(t, path, "")
+
+	rec := logwelltest.NewRecorder()
+	tailer := startTail(t, path, nil, rec, WithCheckpointFile(cpFile))
+	appendFile(t, path, "one\ntwo\npart")
+	waitMessages(t, rec, 2)
+	if err := tailer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	var cp checkpoint
+	data, err := os.ReadFile(cpFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &cp); err != nil {
+		t.Fatal(err)
+	}
+	if cp.Path != path || cp.Offset != int64(len("one\ntwo\n")) {
+		t.Errorf("checkpoint = %+v", cp)
+	}
+
+	// Written while stopped: resumed from the checkpoint, including the
+	// partial line.
+	appendFile(t, path, "ial\nthree\n")
+	rec2 := logwelltest.NewRecorder()
+	startTail(t, path, nil, rec2, WithCheckpointFile(cpFile))
+	if msgs := waitMessages(t, rec2, 2); msgs[0] != "partial" || msgs[1] != "three" {
+		t.Errorf("messages = %q", msgs)
+	}
+}
+
+// TestTailCheckpointUndelivered tests that lines the endpoint never
+// accepted are not covered by the checkpoint and are sent after a restart.
+func TestTailCheckpointUndelivered(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	cpFile := filepath.Join(dir, "app.pos")
+	appendFile(t, path, "")
+
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	client, err := logwell.New(down.URL, "lw_00000000000000000000000000000000", logwell.WithMaxRetries(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Shutdown(context.Background())
+
+	tailer, err := Tail(path, nil, client, WithPollInterval(testPoll), WithCheckpointFile(cpFile))
+	if err != nil {
+		t.Fatalf("Tail: %v", err)
+	}
+	appendFile(t, path, "one\ntwo\n")
+	time.Sleep(5 * testPoll)
+	if err := tailer.Close(); err == nil {
+		t.Error("Close: want the flush error")
+	}
+
+	rec := logwelltest.NewRecorder()
+	startTail(t, path, nil, rec, WithCheckpointFile(cpFile))
+	if msgs := waitMessages(t, rec, 2); msgs[0] != "one" || msgs[1] != "two" {
+		t.Errorf("messages = %q, want the undelivered lines again", msgs)
+	}
+}
+
+func TestTailCheckpointAfterRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	cpFile := filepath.Join(dir, "app.pos")
+	appendFile(t, path, "")
+
+	rec := logwelltest.NewRecorder()
+	tailer := startTail(t, path, nil, rec, WithCheckpointFile(cpFile))
+	appendFile(t, path, "old file line\n")
+	waitMessages(t, rec, 1)
+	tailer.Close()
+
+	// Rotated while stopped: the new file is read from its start.
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "new file line one\nnew file line two\n")
+	rec2 := logwelltest.NewRecorder()
+	startTail(t, path, nil, rec2, WithCheckpointFile(cpFile))
+	if msgs := waitMessages(t, rec2, 2); msgs[0] != "new file line one" {
+		t.Errorf("messages = %q", msgs)
+	}
+}
+
+func TestTailInvalidOptions(t *testing.T) {
+	rec := logwelltest.NewRecorder()
+	if _, err := Tail("app.log", nil, rec.Client, WithPollInterval(0)); err == nil {
+		t.Error("Tail with zero poll interval: want error")
+	}
+}
@@ -0,0 +1,26 @@
+// Package logwellyaml lets logwell.NewFromConfigFile read YAML config
+// files.
+//
+// # Usage
+//
+// Import the package for its side effect, then load a file ending in
+// .yaml or .yml:
+//
+//	import _ "github.com/Divkix/Logwell/sdks/go/contrib/yaml"
+//
+//	client, err := logwell.NewFromConfigFile("/etc/logwell/agent.yaml")
+//
+// Keys are the same as in JSON files (see logwell.FileConfig):
+//
+//	endpoint: https://logs.example.com
+//	apiKeyEnv: LOGWELL_API_KEY
+//	service: billing-agent
+//	flushInterval: 2s
+//	redaction:
+//	  presets: [commonKeys, emails]
+//	sampling:
+//	  fraction: 0.1
+//	  keepLevel: warn
+//
+// Unknown keys are rejected, so typos surface as errors.
+package logwellyaml
@@ -0,0 +1,8 @@
+module github.com/Divkix/Logwell/sdks/go/contrib/yaml
+
+go 1.25.0
+
+require (
+	github.com/Divkix/Logwell/sdks/go v1.2.0
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,27 @@
+package logwellyaml
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+func init() {
+	logwell.RegisterConfigFormat(".yaml", Unmarshal)
+	logwell.RegisterConfigFormat(".yml", Unmarshal)
+}
+
+// Unmarshal decodes a YAML document into v, rejecting unknown keys. An
+// empty document leaves v unchanged.
+func Unmarshal(data []byte, v any) error {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
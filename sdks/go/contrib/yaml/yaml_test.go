@@ -0,0 +1,87 @@
+package logwellyaml
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+const testAPIKey = "lw_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	for _, name := range []string{"logwell.yaml", "logwell.yml"} {
+		t.Run(name, func(t *testing.T) {
+			fc, err := logwell.LoadConfigFile(writeFile(t, name, `
+endpoint: http://localhost:3000
+apiKey: `+testAPIKey+`
+service: agent
+maxRetries: 0
+flushInterval: 2s
+metadata:
+  region: eu
+  limits:
+    cpu: 2
+redaction:
+  presets: [commonKeys]
+  keys: [ssn]
+sampling:
+  fraction: 0.25
+  keepLevel: warn
+`))
+			if err != nil {
+				t.Fatalf("LoadConfigFile() error = %v", err)
+			}
+			if fc.Endpoint != "http://localhost:3000" || fc.Service != "agent" || fc.FlushInterval != "2s" {
+				t.Errorf("FileConfig = %+v", fc)
+			}
+			if fc.MaxRetries == nil || *fc.MaxRetries != 0 {
+				t.Errorf("MaxRetries = %v, want 0", fc.MaxRetries)
+			}
+			if limits, ok := fc.Metadata["limits"].(map[string]any); !ok || limits["cpu"] != 2 {
+				t.Errorf("Metadata = %v, want nested limits.cpu = 2", fc.Metadata)
+			}
+			if fc.Sampling == nil || fc.Sampling.Fraction == nil || *fc.Sampling.Fraction != 0.25 {
+				t.Errorf("Sampling = %+v", fc.Sampling)
+			}
+			if fc.Redaction == nil || len(fc.Redaction.Keys) != 1 {
+				t.Errorf("Redaction = %+v", fc.Redaction)
+			}
+		})
+	}
+}
+
+func TestNewFromConfigFile(t *testing.T) {
+	path := writeFile(t, "logwell.yaml", "endpoint: http://localhost:3000\napiKey: "+testAPIKey+"\n")
+	client, err := logwell.NewFromConfigFile(path, logwell.WithService("override"))
+	if err != nil {
+		t.Fatalf("NewFromConfigFile() error = %v", err)
+	}
+	client.Shutdown(context.Background())
+}
+
+func TestUnmarshalRejectsUnknownKeys(t *testing.T) {
+	_, err := logwell.LoadConfigFile(writeFile(t, "logwell.yaml", "endpiont: http://localhost:3000\n"))
+	if err == nil || !strings.Contains(err.Error(), "failed to parse") {
+		t.Errorf("LoadConfigFile() error = %v, want a parse error", err)
+	}
+}
+
+func TestUnmarshalEmpty(t *testing.T) {
+	var fc logwell.FileConfig
+	if err := Unmarshal(nil, &fc); err != nil {
+		t.Errorf("Unmarshal(empty) error = %v, want nil", err)
+	}
+}
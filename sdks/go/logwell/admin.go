@@ -0,0 +1,158 @@
+package logwell
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// AdminClient manages Logwell projects and their API keys, for provisioning
+// Logwell from Go programs. Like QueryClient it authenticates with a
+// dashboard session (see WithSessionToken) and acts on projects owned by
+// that session's user.
+//
+// Each project has exactly one API key. The key is returned in plain text
+// only when the project is created or the key is rotated; the server keeps
+// only a hash, so keys cannot be listed or read back later. Rotating a key
+// revokes the previous one immediately.
+//
+// An AdminClient is safe for concurrent use.
+type AdminClient struct {
+	session *sessionClient
+}
+
+// NewAdminClient creates a client for the project management API.
+// Returns an error if the endpoint is invalid or the session token is missing.
+//
+// Example:
+//
+//	admin, err := logwell.NewAdminClient(
+//	    "https://logs.example.com",
+//	    logwell.WithSessionToken(os.Getenv("LOGWELL_SESSION")),
+//	)
+func NewAdminClient(endpoint string, opts ...SessionOption) (*AdminClient, error) {
+	session, err := newSessionClient(endpoint, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &AdminClient{session: session}, nil
+}
+
+// Project is a Logwell project.
+type Project struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// APIKey is the project's ingestion key. It is only set on the result
+	// of CreateProject; use RotateAPIKey to obtain a new one.
+	APIKey string `json:"apiKey,omitempty"`
+
+	// RetentionDays is the log retention period: nil means the server
+	// default and 0 means logs are never deleted. Not set by ListProjects.
+	RetentionDays *int `json:"retentionDays,omitempty"`
+
+	// LogCount is the number of stored logs. Only set by ListProjects.
+	LogCount int `json:"logCount,omitempty"`
+
+	// Stats holds log totals. Only set by GetProject.
+	Stats *ProjectStats `json:"stats,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ProjectStats summarizes the logs stored in a project.
+type ProjectStats struct {
+	TotalLogs   int              `json:"totalLogs"`
+	LevelCounts map[LogLevel]int `json:"levelCounts"`
+}
+
+// ProjectUpdate describes changes to a project. Zero fields are left
+// unchanged.
+type ProjectUpdate struct {
+	// Name renames the project.
+	Name string
+
+	// RetentionDays sets the retention period in days (0 = never delete,
+	// 1-3650 = days).
+	RetentionDays *int
+
+	// DefaultRetention resets retention to the server default. It takes
+	// precedence over RetentionDays.
+	DefaultRetention bool
+}
+
+// ListProjects returns the projects owned by the session user, newest first.
+func (a *AdminClient) ListProjects(ctx context.Context) ([]Project, error) {
+	var resp struct {
+		Projects []Project `json:"projects"`
+	}
+	if err := a.session.doJSON(ctx, http.MethodGet, "/projects", nil, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Projects, nil
+}
+
+// GetProject returns the project with the given ID, including its stats.
+func (a *AdminClient) GetProject(ctx context.Context, id string) (*Project, error) {
+	var p Project
+	if err := a.session.doJSON(ctx, http.MethodGet, projectsPath(id, ""), nil, nil, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CreateProject creates a project and returns it with its API key set.
+// Names are 1-50 characters (letters, digits, hyphens, underscores) and
+// must be unique per user.
+func (a *AdminClient) CreateProject(ctx context.Context, name string) (*Project, error) {
+	var p Project
+	body := map[string]string{"name": name}
+	if err := a.session.doJSON(ctx, http.MethodPost, "/projects", nil, body, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// UpdateProject applies update to the project and returns the result.
+func (a *AdminClient) UpdateProject(ctx context.Context, id string, update ProjectUpdate) (*Project, error) {
+	body := map[string]any{}
+	if update.Name != "" {
+		body["name"] = update.Name
+	}
+	if update.DefaultRetention {
+		body["retentionDays"] = nil
+	} else if update.RetentionDays != nil {
+		body["retentionDays"] = *update.RetentionDays
+	}
+
+	var p Project
+	if err := a.session.doJSON(ctx, http.MethodPatch, projectsPath(id, ""), nil, body, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// DeleteProject deletes the project and all of its logs, and revokes its
+// API key.
+func (a *AdminClient) DeleteProject(ctx context.Context, id string) error {
+	return a.session.doJSON(ctx, http.MethodDelete, projectsPath(id, ""), nil, nil, nil)
+}
+
+// RotateAPIKey replaces the project's API key and returns the new key.
+// The previous key stops working immediately.
+func (a *AdminClient) RotateAPIKey(ctx context.Context, id string) (string, error) {
+	var resp struct {
+		APIKey string `json:"apiKey"`
+	}
+	if err := a.session.doJSON(ctx, http.MethodPost, projectsPath(id, "/regenerate"), nil, nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.APIKey, nil
+}
+
+// projectsPath returns path under the project with the given ID.
+func projectsPath(id, path string) string {
+	return "/projects/" + url.PathEscape(id) + path
+}
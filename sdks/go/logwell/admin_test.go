@@ -0,0 +1,196 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newAdminTestClient creates an AdminClient pointed at server.
+func newAdminTestClient(t *testing.T, server *httptest.Server) *AdminClient {
+	t.Helper()
+	admin, err := NewAdminClient(server.URL, WithSessionToken("session-abc"))
+	if err != nil {
+		t.Fatalf("NewAdminClient() error = %v", err)
+	}
+	return admin
+}
+
+func TestNewAdminClientRequiresSession(t *testing.T) {
+	_, err := NewAdminClient(validEndpoint())
+	assertConfigError(t, err, ErrInvalidConfig)
+}
+
+func TestAdminClientListProjects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/projects" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if _, err := r.Cookie("better-auth.session_token"); err != nil {
+			t.Errorf("missing session cookie: %v", err)
+		}
+		w.Write([]byte(`{"projects":[
+			{"id":"p1","name":"api","logCount":12,"createdAt":"2025-01-02T00:00:00.000Z","updatedAt":"2025-01-02T00:00:00.000Z"},
+			{"id":"p2","name":"worker","logCount":0,"createdAt":"2025-01-01T00:00:00.000Z","updatedAt":"2025-01-01T00:00:00.000Z"}
+		]}`))
+	}))
+	defer server.Close()
+
+	projects, err := newAdminTestClient(t, server).ListProjects(context.Background())
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if len(projects) != 2 || projects[0].ID != "p1" || projects[0].LogCount != 12 || projects[1].Name != "worker" {
+		t.Errorf("projects = %+v", projects)
+	}
+}
+
+func TestAdminClientGetProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/projects/p1" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		w.Write([]byte(`{"id":"p1","name":"api","retentionDays":30,
+			"createdAt":"2025-01-01T00:00:00.000Z","updatedAt":"2025-01-01T00:00:00.000Z",
+			"stats":{"totalLogs":7,"levelCounts":{"info":5,"error":2}}}`))
+	}))
+	defer server.Close()
+
+	p, err := newAdminTestClient(t, server).GetProject(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("GetProject() error = %v", err)
+	}
+	if p.RetentionDays == nil || *p.RetentionDays != 30 {
+		t.Errorf("RetentionDays = %v, want 30", p.RetentionDays)
+	}
+	if p.Stats == nil || p.Stats.TotalLogs != 7 || p.Stats.LevelCounts[LevelError] != 2 {
+		t.Errorf("Stats = %+v", p.Stats)
+	}
+}
+
+func TestAdminClientCreateProject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/projects" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		if r.Header.Get("Origin") == "" {
+			t.Error("missing Origin header for CSRF check")
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "checkout" {
+			t.Errorf("body = %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"p9","name":"checkout","apiKey":"lw_new","createdAt":"2025-01-01T00:00:00.000Z","updatedAt":"2025-01-01T00:00:00.000Z"}`))
+	}))
+	defer server.Close()
+
+	p, err := newAdminTestClient(t, server).CreateProject(context.Background(), "checkout")
+	if err != nil {
+		t.Fatalf("CreateProject() error = %v", err)
+	}
+	if p.ID != "p9" || p.APIKey != "lw_new" {
+		t.Errorf("project = %+v", p)
+	}
+}
+
+func TestAdminClientCreateProjectDuplicate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"duplicate_name","message":"A project with this name already exists"}`))
+	}))
+	defer server.Close()
+
+	_, err := newAdminTestClient(t, server).CreateProject(context.Background(), "api")
+	lwErr, ok := err.(*Error)
+	if !ok || lwErr.Code != ErrValidationError {
+		t.Fatalf("error = %v, want ErrValidationError", err)
+	}
+}
+
+func TestAdminClientUpdateProject(t *testing.T) {
+	days := 14
+	tests := []struct {
+		name   string
+		update ProjectUpdate
+		want   string
+	}{
+		{"rename", ProjectUpdate{Name: "api-v2"}, `{"name":"api-v2"}`},
+		{"retention", ProjectUpdate{RetentionDays: &days}, `{"retentionDays":14}`},
+		{"default retention", ProjectUpdate{RetentionDays: &days, DefaultRetention: true}, `{"retentionDays":null}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch || r.URL.Path != "/api/projects/p1" {
+					t.Errorf("request = %s %s", r.Method, r.URL.Path)
+				}
+				var body json.RawMessage
+				json.NewDecoder(r.Body).Decode(&body)
+				if string(body) != tt.want {
+					t.Errorf("body = %s, want %s", body, tt.want)
+				}
+				w.Write([]byte(`{"id":"p1","name":"api-v2","retentionDays":null,"createdAt":"2025-01-01T00:00:00Z","updatedAt":"2025-01-01T00:00:00Z"}`))
+			}))
+			defer server.Close()
+
+			if _, err := newAdminTestClient(t, server).UpdateProject(context.Background(), "p1", tt.update); err != nil {
+				t.Fatalf("UpdateProject() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestAdminClientDeleteProject(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = r.Method == http.MethodDelete && r.URL.Path == "/api/projects/p1"
+		w.Write([]byte(`{"success":true,"id":"p1"}`))
+	}))
+	defer server.Close()
+
+	if err := newAdminTestClient(t, server).DeleteProject(context.Background(), "p1"); err != nil {
+		t.Fatalf("DeleteProject() error = %v", err)
+	}
+	if !called {
+		t.Error("DELETE /api/projects/p1 was not called")
+	}
+}
+
+func TestAdminClientRotateAPIKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/projects/p1/regenerate" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"apiKey":"lw_rotated"}`))
+	}))
+	defer server.Close()
+
+	key, err := newAdminTestClient(t, server).RotateAPIKey(context.Background(), "p1")
+	if err != nil {
+		t.Fatalf("RotateAPIKey() error = %v", err)
+	}
+	if key != "lw_rotated" {
+		t.Errorf("key = %q, want lw_rotated", key)
+	}
+}
+
+func TestAdminClientNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"not_found","message":"Project not found"}`))
+	}))
+	defer server.Close()
+
+	_, err := newAdminTestClient(t, server).GetProject(context.Background(), "missing")
+	lwErr, ok := err.(*Error)
+	if !ok || lwErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("error = %v, want status 404", err)
+	}
+}
@@ -0,0 +1,37 @@
+//go:build !race
+
+// The race detector randomly drops sync.Pool entries and adds allocations,
+// so the allocation budget is only checked in normal builds.
+
+package logwell
+
+import (
+	"context"
+	"testing"
+)
+
+// TestLogAllocations pins the allocation budget of the log path: with
+// millisecond timestamps, an Info call with five metadata fields allocates
+// only its metadata map.
+func TestLogAllocations(t *testing.T) {
+	client, err := New("http://unused.invalid", validAPIKey(),
+		WithTransport(discardTransport{}), WithBatchSize(500),
+		WithTimestampFormat(TimestampRFC3339Millis))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	allocs := testing.AllocsPerRun(1000, func() {
+		client.Info("request handled", M{
+			"method": "GET",
+			"path":   "/api/users",
+			"status": 200,
+			"bytes":  1024,
+			"cached": true,
+		})
+	})
+	if allocs > 2 {
+		t.Errorf("Info with 5 fields allocates %v times, want at most 2", allocs)
+	}
+}
@@ -0,0 +1,69 @@
+package logwell
+
+import (
+	"math/rand"
+	"time"
+)
+
+// BackoffPolicy computes the delay before a retry; see WithBackoff.
+// attempt is the retry number, starting at 1. A Retry-After header from
+// the server can lengthen the delay (see WithMaxRetryAfter), and a negative
+// delay is treated as 0. Delay must be safe for concurrent use.
+type BackoffPolicy interface {
+	Delay(attempt int) time.Duration
+}
+
+// BackoffFunc adapts an ordinary function to BackoffPolicy.
+type BackoffFunc func(attempt int) time.Duration
+
+// Delay returns f(attempt).
+func (f BackoffFunc) Delay(attempt int) time.Duration {
+	return f(attempt)
+}
+
+// defaultBackoff is the policy used without WithBackoff: 200ms, 400ms,
+// 800ms, ... up to 10s, plus 0-30% jitter (aligned with the TS/Python SDKs).
+var defaultBackoff = ExponentialBackoff(200*time.Millisecond, 10*time.Second, 0.3)
+
+// ExponentialBackoff doubles the delay on each retry: base, 2·base, 4·base,
+// ... capped at max. jitter adds a random 0 to jitter fraction of the delay
+// (0.3 adds up to 30%), so clients that failed together do not retry in
+// lockstep.
+func ExponentialBackoff(base, max time.Duration, jitter float64) BackoffPolicy {
+	return exponentialBackoff{base: base, max: max, jitter: jitter}
+}
+
+type exponentialBackoff struct {
+	base, max time.Duration
+	jitter    float64
+}
+
+func (b exponentialBackoff) Delay(attempt int) time.Duration {
+	delay := b.base
+	for i := 1; i < attempt && delay < b.max; i++ {
+		delay *= 2
+	}
+	delay = min(delay, b.max)
+	if b.jitter > 0 {
+		delay += time.Duration(float64(delay) * b.jitter * rand.Float64())
+	}
+	return delay
+}
+
+// ConstantBackoff waits d before every retry.
+func ConstantBackoff(d time.Duration) BackoffPolicy {
+	return BackoffFunc(func(int) time.Duration { return d })
+}
+
+// FibonacciBackoff grows the delay along the Fibonacci sequence: base,
+// 2·base, 3·base, 5·base, 8·base, ... capped at max. It backs off more
+// gently than ExponentialBackoff.
+func FibonacciBackoff(base, max time.Duration) BackoffPolicy {
+	return BackoffFunc(func(attempt int) time.Duration {
+		prev, cur := base, base
+		for i := 1; i < attempt && cur < max; i++ {
+			prev, cur = cur, prev+cur
+		}
+		return min(cur, max)
+	})
+}
@@ -0,0 +1,99 @@
+package logwell
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	policy := ExponentialBackoff(50*time.Millisecond, time.Second, 0)
+	want := []time.Duration{
+		50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond,
+		400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second,
+	}
+	for i, w := range want {
+		if got := policy.Delay(i + 1); got != w {
+			t.Errorf("Delay(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+	if got := policy.Delay(1000); got != time.Second {
+		t.Errorf("Delay(1000) = %v, want the 1s cap", got)
+	}
+}
+
+func TestExponentialBackoffJitter(t *testing.T) {
+	policy := ExponentialBackoff(100*time.Millisecond, time.Second, 0.5)
+	for range 100 {
+		if got := policy.Delay(2); got < 200*time.Millisecond || got > 300*time.Millisecond {
+			t.Fatalf("Delay(2) = %v, want within [200ms, 300ms]", got)
+		}
+	}
+}
+
+func TestConstantBackoff(t *testing.T) {
+	policy := ConstantBackoff(250 * time.Millisecond)
+	for attempt := 1; attempt <= 5; attempt++ {
+		if got := policy.Delay(attempt); got != 250*time.Millisecond {
+			t.Errorf("Delay(%d) = %v, want 250ms", attempt, got)
+		}
+	}
+}
+
+func TestFibonacciBackoff(t *testing.T) {
+	policy := FibonacciBackoff(10*time.Millisecond, 100*time.Millisecond)
+	want := []time.Duration{10, 20, 30, 50, 80, 100, 100}
+	for i, w := range want {
+		if got := policy.Delay(i + 1); got != w*time.Millisecond {
+			t.Errorf("Delay(%d) = %v, want %v", i+1, got, w*time.Millisecond)
+		}
+	}
+}
+
+// TestTransport_CustomBackoff tests that sendWithRetry waits the delays of
+// the configured policy, and that negative delays are treated as 0.
+func TestTransport_CustomBackoff(t *testing.T) {
+	var mu sync.Mutex
+	var times []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var attempts []int
+	cfg := newDefaultConfig(server.URL, validAPIKey())
+	WithMaxRetries(2)(cfg)
+	WithBackoff(BackoffFunc(func(attempt int) time.Duration {
+		attempts = append(attempts, attempt)
+		if attempt == 1 {
+			return -time.Second
+		}
+		return 150 * time.Millisecond
+	}))(cfg)
+	transport := newHTTPTransportFromConfig(cfg)
+
+	if _, err := transport.sendWithRetry(context.Background(), []LogEntry{{Level: LevelInfo, Message: "test"}}); err == nil {
+		t.Fatal("sendWithRetry() error = nil, want 503")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Errorf("Delay attempts = %v, want [1 2]", attempts)
+	}
+	if len(times) != 3 {
+		t.Fatalf("requests = %d, want 3", len(times))
+	}
+	if gap := times[1].Sub(times[0]); gap > 100*time.Millisecond {
+		t.Errorf("first retry waited %v, want ~0 for a negative delay", gap)
+	}
+	if gap := times[2].Sub(times[1]); gap < 150*time.Millisecond {
+		t.Errorf("second retry waited %v, want at least 150ms", gap)
+	}
+}
@@ -0,0 +1,84 @@
+package logwell
+
+import (
+	"context"
+	"testing"
+)
+
+// discardTransport accepts every batch without sending it, so benchmarks
+// measure the log path rather than the network.
+type discardTransport struct{}
+
+func (discardTransport) Send(context.Context, []LogEntry) (*IngestResponse, error) {
+	return nil, nil
+}
+
+func newBenchClient(b *testing.B, opts ...Option) *Client {
+	b.Helper()
+	client, err := New("http://unused.invalid", validAPIKey(),
+		append([]Option{WithTransport(discardTransport{}), WithBatchSize(500), WithTimestampFormat(TimestampRFC3339Millis)}, opts...)...)
+	if err != nil {
+		b.Fatalf("New() error = %v", err)
+	}
+	b.Cleanup(func() { client.Shutdown(context.Background()) })
+	return client
+}
+
+func BenchmarkLog(b *testing.B) {
+	client := newBenchClient(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		client.Info("request handled")
+	}
+}
+
+func BenchmarkLogWithFields(b *testing.B) {
+	client := newBenchClient(b)
+	b.Run("Map", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			client.Info("request handled", M{
+				"method": "GET",
+				"path":   "/api/users",
+				"status": 200,
+				"bytes":  1024,
+				"cached": true,
+			})
+		}
+	})
+	b.Run("Fields", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			client.InfoFields("request handled",
+				String("method", "GET"),
+				String("path", "/api/users"),
+				Int("status", 200),
+				Int("bytes", 1024),
+				Bool("cached", true),
+			)
+		}
+	})
+}
+
+func BenchmarkFlush(b *testing.B) {
+	client := newBenchClient(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		for range 100 {
+			client.Info("request handled")
+		}
+		if err := client.Flush(context.Background()); err != nil {
+			b.Fatalf("Flush() error = %v", err)
+		}
+	}
+}
+
+func BenchmarkLogParallel(b *testing.B) {
+	client := newBenchClient(b)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			client.Info("request handled")
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package logwell
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of the client's circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every send through. This is the normal state.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen short-circuits sends without touching the network;
+	// entries stay queued, subject to the overflow strategy.
+	CircuitOpen
+
+	// CircuitHalfOpen lets a single probe send through after the cooldown.
+	// Success closes the circuit; failure opens it again.
+	CircuitHalfOpen
+)
+
+// String returns "closed", "open", or "half-open".
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// circuitBreaker stops the client from hammering an endpoint that keeps
+// failing. It counts consecutive failed sends (after retries); at threshold
+// it opens for cooldown, then admits one probe send.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	onChange  func(from, to CircuitState, failures int)
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// newCircuitBreaker creates a closed breaker. onChange, if non-nil, is
+// called outside the breaker's lock on every state transition.
+func newCircuitBreaker(threshold int, cooldown time.Duration, onChange func(from, to CircuitState, failures int)) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		onChange:  onChange,
+		now:       time.Now,
+	}
+}
+
+// allow reports whether a send may proceed. After the cooldown, the first
+// caller moves the breaker to half-open and becomes the probe.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	switch b.state {
+	case CircuitClosed:
+		b.mu.Unlock()
+		return true
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			b.mu.Unlock()
+			return false
+		}
+		b.probing = true
+		b.transitionLocked(CircuitHalfOpen)
+		return true
+	default: // half-open
+		if b.probing {
+			b.mu.Unlock()
+			return false
+		}
+		b.probing = true
+		b.mu.Unlock()
+		return true
+	}
+}
+
+// blocked reports whether the breaker is open and still cooling down.
+// Unlike allow, it never claims the probe.
+func (b *circuitBreaker) blocked() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == CircuitOpen && b.now().Sub(b.openedAt) < b.cooldown
+}
+
+// success records a send that reached the server.
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	b.failures = 0
+	b.probing = false
+	if b.state == CircuitClosed {
+		b.mu.Unlock()
+		return
+	}
+	b.transitionLocked(CircuitClosed)
+}
+
+// failure records a send that failed with a transient error.
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	b.failures++
+	b.probing = false
+	if b.state == CircuitOpen || (b.state == CircuitClosed && b.failures < b.threshold) {
+		b.mu.Unlock()
+		return
+	}
+	b.openedAt = b.now()
+	b.transitionLocked(CircuitOpen)
+}
+
+// current returns the breaker's state.
+func (b *circuitBreaker) current() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// transitionLocked moves to state to, releases b.mu, and notifies onChange.
+func (b *circuitBreaker) transitionLocked(to CircuitState) {
+	from, failures := b.state, b.failures
+	b.state = to
+	b.mu.Unlock()
+
+	if b.onChange != nil {
+		b.onChange(from, to, failures)
+	}
+}
+
+// circuitTransitionError describes a breaker state change for OnError.
+func circuitTransitionError(from, to CircuitState, failures int) *Error {
+	msg := fmt.Sprintf("circuit breaker %s -> %s", from, to)
+	if to == CircuitOpen {
+		msg += fmt.Sprintf(" after %d consecutive failures", failures)
+	}
+	return NewError(ErrCircuitStateChange, msg)
+}
@@ -0,0 +1,180 @@
+package logwell
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCircuitBreaker_Transitions tests closed -> open -> half-open -> closed.
+func TestCircuitBreaker_Transitions(t *testing.T) {
+	var mu sync.Mutex
+	var changes []string
+	b := newCircuitBreaker(2, 50*time.Millisecond, func(from, to CircuitState, _ int) {
+		mu.Lock()
+		changes = append(changes, from.String()+"->"+to.String())
+		mu.Unlock()
+	})
+
+	if !b.allow() {
+		t.Fatal("allow() = false on closed breaker")
+	}
+	b.failure()
+	if b.current() != CircuitClosed {
+		t.Fatalf("state after 1 failure = %v, want closed", b.current())
+	}
+	b.failure()
+	if b.current() != CircuitOpen {
+		t.Fatalf("state after 2 failures = %v, want open", b.current())
+	}
+	if b.allow() {
+		t.Error("allow() = true while open and cooling down")
+	}
+	if !b.blocked() {
+		t.Error("blocked() = false while open and cooling down")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if b.blocked() {
+		t.Error("blocked() = true after cooldown")
+	}
+	if !b.allow() {
+		t.Fatal("allow() = false after cooldown, want probe")
+	}
+	if b.current() != CircuitHalfOpen {
+		t.Fatalf("state after probe = %v, want half-open", b.current())
+	}
+	if b.allow() {
+		t.Error("allow() = true for a second caller while probing")
+	}
+
+	b.success()
+	if b.current() != CircuitClosed {
+		t.Fatalf("state after probe success = %v, want closed", b.current())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %v, want %v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("changes[%d] = %q, want %q", i, changes[i], want[i])
+		}
+	}
+}
+
+// TestCircuitBreaker_ProbeFailureReopens tests that a failed probe reopens
+// the circuit and restarts the cooldown.
+func TestCircuitBreaker_ProbeFailureReopens(t *testing.T) {
+	b := newCircuitBreaker(1, 30*time.Millisecond, nil)
+
+	b.failure()
+	time.Sleep(40 * time.Millisecond)
+	if !b.allow() {
+		t.Fatal("allow() = false after cooldown")
+	}
+	b.failure()
+
+	if b.current() != CircuitOpen {
+		t.Fatalf("state after probe failure = %v, want open", b.current())
+	}
+	if b.allow() {
+		t.Error("allow() = true immediately after reopening")
+	}
+}
+
+// TestCircuitBreaker_SuccessResetsFailures tests that failures must be consecutive.
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	b := newCircuitBreaker(2, time.Second, nil)
+
+	b.failure()
+	b.success()
+	b.failure()
+
+	if b.current() != CircuitClosed {
+		t.Errorf("state = %v, want closed (failures not consecutive)", b.current())
+	}
+}
+
+// TestClientCircuitBreaker tests that the client stops sending while the
+// circuit is open and resumes after a successful probe.
+func TestClientCircuitBreaker(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var failing atomic.Bool
+	var attempts atomic.Int32
+	failing.Store(true)
+	ts.setHandler(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"accepted":1}`))
+	})
+
+	var mu sync.Mutex
+	var transitions []string
+	client := createTestClient(t, ts,
+		WithMaxRetries(0),
+		WithCircuitBreaker(2, 200*time.Millisecond),
+		WithOnError(func(err *Error) {
+			if err.Code == ErrCircuitStateChange {
+				mu.Lock()
+				transitions = append(transitions, err.Message)
+				mu.Unlock()
+			}
+		}),
+	)
+	defer client.Shutdown(context.Background())
+
+	ctx := context.Background()
+	client.Info("one")
+	_ = client.Flush(ctx)
+	client.Info("two")
+	_ = client.Flush(ctx)
+
+	if client.CircuitState() != CircuitOpen {
+		t.Fatalf("CircuitState() = %v, want open after 2 failures", client.CircuitState())
+	}
+	before := attempts.Load()
+
+	err := client.Flush(ctx)
+	if lwErr, ok := err.(*Error); !ok || lwErr.Code != ErrCircuitOpen {
+		t.Fatalf("Flush() while open error = %v, want %s", err, ErrCircuitOpen)
+	}
+	if got := attempts.Load(); got != before {
+		t.Errorf("requests while open = %d, want 0", got-before)
+	}
+
+	// Recover; the scheduled post-cooldown flush probes and closes the circuit.
+	failing.Store(false)
+	deadline := time.Now().Add(2 * time.Second)
+	for client.CircuitState() != CircuitClosed && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if client.CircuitState() != CircuitClosed {
+		t.Fatalf("CircuitState() = %v, want closed after recovery", client.CircuitState())
+	}
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush() after recovery error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(transitions) != 3 {
+		t.Fatalf("transitions = %v, want open, half-open, closed", transitions)
+	}
+	if transitions[0] != "circuit breaker closed -> open after 2 consecutive failures" {
+		t.Errorf("transitions[0] = %q", transitions[0])
+	}
+}
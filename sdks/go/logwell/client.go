@@ -3,12 +3,21 @@ package logwell
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
-// ErrClientShutdown is returned when attempting to log after shutdown.
-var ErrClientShutdown = NewError(ErrValidationError, "client has been shut down")
+// ErrClientClosed is returned by Flush once the client (or its root) has been shut down.
+var ErrClientClosed = NewError(ErrValidationError, "client has been shut down")
+
+// ErrClientShutdown is returned when attempting to use the client after shutdown.
+//
+// Deprecated: Use ErrClientClosed. Both names refer to the same error value.
+var ErrClientShutdown = ErrClientClosed
 
 // Client is the main entry point for sending logs to Logwell.
 type Client struct {
@@ -21,19 +30,105 @@ type Client struct {
 	// Child loggers share the parent's queue and transport.
 	parent *Client
 
+	// name is the dot-separated logger name built by Named; empty for
+	// unnamed loggers. Children inherit it.
+	name string
+
 	mu       sync.Mutex
 	shutdown bool
 
-	// flushWG tracks in-flight async flush goroutines so Shutdown can wait for them.
-	flushWG sync.WaitGroup
+	// closed mirrors shutdown for the log methods, which check it without
+	// taking mu.
+	closed atomic.Bool
+
+	// sender is the background worker pool that ships batches, so log calls
+	// never block on network I/O. Only set on root clients.
+	sender *sender
+
+	// flushPending records that a timer flush could not dispatch every entry
+	// because the sender pool was saturated; the next completed batch then
+	// dispatches the partial remainder too. Guarded by mu. Root clients only.
+	flushPending bool
+
+	// breaker is the optional circuit breaker guarding sends.
+	// Only set on root clients.
+	breaker *circuitBreaker
+
+	// redactor scrubs entries when redaction rules are configured.
+	// Only set on root clients.
+	redactor *redactor
+
+	// limiter is the optional per-key rate limiter.
+	// Only set on root clients.
+	limiter *rateLimiter
+
+	// deduper is the optional duplicate collapser.
+	// Only set on root clients.
+	deduper *deduper
+
+	// runtimeStats is the optional runtime stats heartbeat.
+	// Only set on root clients.
+	runtimeStats *runtimeReporter
+
+	// persist is the optional write-ahead log mirroring the queue on disk.
+	// Only set on root clients.
+	persist *persistentQueue
+
+	// sink is the optional local writer mirroring admitted entries.
+	// Only set on root clients.
+	sink *localSink
+
+	// fallback is the optional file failed batches are spilled to.
+	// Only set on root clients.
+	fallback *fallbackFile
+
+	// ring buffers admitted entries until the drain goroutine moves them
+	// into queue, so concurrent log calls do not contend on mu. Nil when
+	// admission must be synchronous (persistent queue, Block overflow,
+	// sync mode).
+	// Only set on root clients.
+	ring *entryRing
+
+	// ringDone stops the drain goroutine. Only set with ring.
+	ringDone chan struct{}
+
+	// routes holds the clients that entries at routed levels are admitted
+	// to instead of queue (see WithLevelRouting). Only set on root clients.
+	routes map[LogLevel]*Client
+
+	// minLevel is the severity below which entries are discarded.
+	// Only used on root clients; children read their root's.
+	minLevel atomic.Int32
+
+	// clock is the source of time (WithClock). Only set on root clients.
+	clock Clock
+
+	// timestamps generates the timestamps of entries logged without one.
+	// Only used on root clients.
+	timestamps timestamper
+
+	// seq is the last sequence number assigned (WithSequenceNumbers).
+	// Only used on root clients.
+	seq atomic.Uint64
+
+	// stats holds the pipeline counters reported by Stats.
+	// Only used on root clients.
+	stats clientStats
+
+	// inflightCtx parents the contexts of background sends. Shutdown
+	// cancels it when its own context expires, aborting in-flight requests.
+	// Only set on root clients.
+	inflightCtx    context.Context
+	cancelInflight context.CancelFunc
 }
 
 // ChildOption configures a child logger created via Client.Child().
 type ChildOption func(*childConfig)
 
 type childConfig struct {
-	service  string
-	metadata map[string]any
+	service    string
+	metadata   map[string]any
+	callerSkip int
 }
 
 // ChildWithService sets the service name for the child logger.
@@ -52,6 +147,16 @@ func ChildWithMetadata(metadata map[string]any) ChildOption {
 	}
 }
 
+// ChildWithCallerSkip makes the child skip n more stack frames than its
+// parent when capturing the source location and stack trace (see
+// WithCallerSkip), for a wrapper that logs through the child. Negative
+// values are ignored.
+func ChildWithCallerSkip(n int) ChildOption {
+	return func(c *childConfig) {
+		c.callerSkip = max(n, 0)
+	}
+}
+
 // New creates a new Logwell client with the given endpoint and API key.
 // Returns an error if the configuration is invalid.
 //
@@ -77,16 +182,113 @@ func New(endpoint, apiKey string, opts ...Option) (*Client, error) {
 		return nil, err
 	}
 
+	if cfg.RuntimeMetadata {
+		cfg.Metadata = mergeMetadata(runtimeMetadata(), cfg.Metadata)
+	}
+
+	c, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.LevelRouting) > 0 {
+		c.routes = newRoutes(cfg)
+	}
+	if cfg.ExpvarPrefix != "" {
+		if err := publishExpvar(cfg.ExpvarPrefix, c); err != nil {
+			c.Shutdown(context.Background())
+			return nil, err
+		}
+	}
+	if cfg.StartupEvent {
+		c.logStartup()
+	}
+	if cfg.RuntimeStatsInterval > 0 {
+		c.runtimeStats = startRuntimeReporter(c, cfg.RuntimeStatsInterval)
+	}
+	return c, nil
+}
+
+// newClient creates a root client from a validated config.
+func newClient(cfg *Config) (*Client, error) {
 	transport := newHTTPTransportFromConfig(cfg)
 
 	// Create client first so we can pass flush callback to queue
+	inflightCtx, cancelInflight := context.WithCancel(context.Background())
 	c := &Client{
-		config:    cfg,
-		transport: transport,
+		config:         cfg,
+		transport:      transport,
+		inflightCtx:    inflightCtx,
+		cancelInflight: cancelInflight,
 	}
+	c.minLevel.Store(max(cfg.MinLevel.severity(), 0))
+	c.clock = clockOrSystem(cfg.Clock)
+	c.timestamps.format = cfg.TimestampFormat
+	c.timestamps.now = c.clock.Now
 
 	// Create queue with timer-based auto-flush and overflow protection
 	c.queue = newBatchQueue(cfg.FlushInterval, c.flush, cfg.MaxQueueSize, cfg.OnError)
+	c.queue.dropNewest = cfg.OverflowStrategy.kind != overflowDropOldest
+	c.queue.onDrop = cfg.OnDrop
+	c.queue.clock = c.clock
+	c.queue.flushJitter = cfg.FlushJitter
+	c.queue.deferNotify = true // delivered by unlock, outside c.mu
+	c.sender = newSender(cfg.SenderConcurrency, c.sendAsync)
+	if cfg.PersistentQueueDir == "" && cfg.OverflowStrategy.kind != overflowBlock && !cfg.SyncMode {
+		c.ring = newEntryRing()
+		c.ringDone = make(chan struct{})
+		go c.runRing()
+	}
+
+	if cfg.CircuitBreakerThreshold > 0 {
+		c.breaker = newCircuitBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown, c.circuitChanged)
+		c.breaker.now = c.clock.Now
+	}
+
+	if len(cfg.Redaction) > 0 {
+		c.redactor = newRedactor(cfg.Redaction)
+	}
+
+	if cfg.FallbackFile != "" {
+		c.fallback = newFallbackFile(cfg.FallbackFile, cfg.FallbackFileMaxSize, cfg.FallbackFileMaxFiles)
+	}
+
+	if cfg.LocalSink != nil {
+		c.sink = &localSink{w: cfg.LocalSink, format: cfg.LocalSinkFormat}
+	}
+
+	if cfg.RateLimit > 0 {
+		c.limiter = newRateLimiter(cfg.RateLimit, cfg.RateLimitBurst, cfg.RateLimitKey,
+			c.admitSummary, c.clock)
+	}
+
+	if cfg.DedupeWindow > 0 {
+		c.deduper = newDeduper(cfg.DedupeWindow, cfg.DedupeKey, c.admitDeduped, c.clock)
+	}
+
+	if cfg.PersistentQueueDir != "" {
+		persist, replay, err := openPersistentQueue(cfg.PersistentQueueDir, cfg.PersistentQueueMaxBytes)
+		if err != nil {
+			c.queue.stopTimer()
+			c.sender.close()
+			if c.limiter != nil {
+				c.limiter.stop()
+			}
+			if c.deduper != nil {
+				c.deduper.take(true)
+			}
+			cancelInflight()
+			return nil, NewErrorWithCause(ErrInvalidConfig, "failed to open persistent queue", err)
+		}
+		c.persist = persist
+
+		// Replay entries left undelivered by a previous process.
+		c.mu.Lock()
+		for _, entry := range replay {
+			c.queue.add(entry)
+		}
+		c.dispatchLocked(false)
+		c.unlock()
+	}
 
 	return c, nil
 }
@@ -111,10 +313,7 @@ func (c *Client) Child(opts ...ChildOption) *Client {
 	}
 
 	// Determine the root client (for accessing queue/transport)
-	root := c
-	if c.parent != nil {
-		root = c.parent
-	}
+	root := c.root()
 
 	// Build child config
 	childCfg := &Config{
@@ -125,8 +324,13 @@ func (c *Client) Child(opts ...ChildOption) *Client {
 		FlushInterval:         c.config.FlushInterval,
 		MaxQueueSize:          c.config.MaxQueueSize,
 		CaptureSourceLocation: c.config.CaptureSourceLocation,
+		CallerSkip:            c.config.CallerSkip + cfg.callerSkip,
+		StackTraceLevel:       c.config.StackTraceLevel,
 		OnError:               c.config.OnError,
 		OnFlush:               c.config.OnFlush,
+		OnBatchSent:           c.config.OnBatchSent,
+		OnBatchSentInfo:       c.config.OnBatchSentInfo,
+		OnDeadLetter:          c.config.OnDeadLetter,
 		// Merge parent metadata with child metadata (child overrides parent)
 		Metadata: mergeMetadata(c.config.Metadata, cfg.metadata),
 	}
@@ -141,7 +345,77 @@ func (c *Client) Child(opts ...ChildOption) *Client {
 		queue:     root.queue,
 		transport: root.transport,
 		parent:    root,
+		name:      c.name,
+	}
+}
+
+// Named returns a child logger whose name is c's name extended by name,
+// joined with a dot, so subsystems form a hierarchy as in zap. Entries
+// carry the full name in "logger" metadata and, with WithNameInMessage,
+// as a message prefix. An empty name returns c.
+//
+// Example:
+//
+//	payments := client.Named("payments")
+//	payments.Named("worker").Info("Charge settled") // logger: "payments.worker"
+func (c *Client) Named(name string) *Client {
+	if name == "" {
+		return c
+	}
+	if c.name != "" {
+		name = c.name + "." + name
 	}
+	child := c.Child(ChildWithMetadata(M{"logger": name}))
+	child.name = name
+	return child
+}
+
+// With returns a child logger whose entries carry metadata in addition to
+// everything inherited from c. It is shorthand for
+// c.Child(ChildWithMetadata(metadata)) and can be nested freely; the child
+// shares c's queue and transport.
+//
+// Example:
+//
+//	reqLogger := client.With(logwell.M{"requestId": "abc123"})
+//	reqLogger.With(logwell.M{"userId": "u-1"}).Info("Order placed")
+func (c *Client) With(metadata M) *Client {
+	return c.Child(ChildWithMetadata(metadata))
+}
+
+// WithService returns a child logger whose entries carry service instead
+// of c's service, so one process hosting several logical services can tag
+// each distinctly. Children of the returned logger inherit the service
+// until they set their own. It is shorthand for
+// c.Child(ChildWithService(service)). An empty service returns c.
+//
+// Example:
+//
+//	checkout := client.WithService("checkout")
+//	checkout.With(logwell.M{"orderId": "o-1"}).Info("Order placed") // service: "checkout"
+func (c *Client) WithService(service string) *Client {
+	if service == "" {
+		return c
+	}
+	return c.Child(ChildWithService(service))
+}
+
+// WithError returns a child logger whose entries describe err: its message,
+// dynamic type, wrapped error chain, and stack trace (if err implements
+// StackTracer). See Err for the exact metadata keys. A nil err returns c.
+//
+// Example:
+//
+//	if err := db.Ping(); err != nil {
+//	    client.WithError(err).Error("Database unreachable")
+//	}
+func (c *Client) WithError(err error) *Client {
+	if err == nil {
+		return c
+	}
+	metadata := make(M, 4)
+	errorMetadata(metadata, err)
+	return c.Child(ChildWithMetadata(metadata))
 }
 
 // Debug logs a message at DEBUG level.
@@ -170,197 +444,813 @@ func (c *Client) Error(message string, metadata ...map[string]any) {
 
 // Fatal logs a message at FATAL level.
 // Accepts optional metadata maps that will be merged (later maps override earlier).
+// Then, by default, it shuts the client down and exits the process with
+// status 1; see WithFatalBehavior.
 func (c *Client) Fatal(message string, metadata ...map[string]any) {
 	c.log(LevelFatal, message, metadata...)
+	c.afterFatal(message)
+}
+
+// Debugf logs a formatted message at DEBUG level.
+// Arguments are handled in the manner of fmt.Sprintf.
+func (c *Client) Debugf(format string, args ...any) {
+	if !c.Enabled(LevelDebug) {
+		return
+	}
+	c.log(LevelDebug, fmt.Sprintf(format, args...))
+}
+
+// Infof logs a formatted message at INFO level.
+// Arguments are handled in the manner of fmt.Sprintf.
+func (c *Client) Infof(format string, args ...any) {
+	if !c.Enabled(LevelInfo) {
+		return
+	}
+	c.log(LevelInfo, fmt.Sprintf(format, args...))
+}
+
+// Warnf logs a formatted message at WARN level.
+// Arguments are handled in the manner of fmt.Sprintf.
+func (c *Client) Warnf(format string, args ...any) {
+	if !c.Enabled(LevelWarn) {
+		return
+	}
+	c.log(LevelWarn, fmt.Sprintf(format, args...))
+}
+
+// Errorf logs a formatted message at ERROR level.
+// Arguments are handled in the manner of fmt.Sprintf.
+func (c *Client) Errorf(format string, args ...any) {
+	if !c.Enabled(LevelError) {
+		return
+	}
+	c.log(LevelError, fmt.Sprintf(format, args...))
+}
+
+// Fatalf logs a formatted message at FATAL level, then applies the
+// FatalBehavior as Fatal does.
+// Arguments are handled in the manner of fmt.Sprintf.
+func (c *Client) Fatalf(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	c.log(LevelFatal, message)
+	c.afterFatal(message)
+}
+
+// DebugFields logs a message at DEBUG level with typed fields.
+func (c *Client) DebugFields(message string, fields ...Field) {
+	c.logFields(LevelDebug, message, fields)
+}
+
+// InfoFields logs a message at INFO level with typed fields.
+//
+// Example:
+//
+//	client.InfoFields("Request served",
+//	    logwell.String("path", r.URL.Path),
+//	    logwell.Int("status", 200),
+//	    logwell.Dur("latency", elapsed),
+//	)
+func (c *Client) InfoFields(message string, fields ...Field) {
+	c.logFields(LevelInfo, message, fields)
+}
+
+// WarnFields logs a message at WARN level with typed fields.
+func (c *Client) WarnFields(message string, fields ...Field) {
+	c.logFields(LevelWarn, message, fields)
+}
+
+// ErrorFields logs a message at ERROR level with typed fields.
+func (c *Client) ErrorFields(message string, fields ...Field) {
+	c.logFields(LevelError, message, fields)
+}
+
+// FatalFields logs a message at FATAL level with typed fields, then applies
+// the FatalBehavior as Fatal does.
+func (c *Client) FatalFields(message string, fields ...Field) {
+	c.logFields(LevelFatal, message, fields)
+	c.afterFatal(message)
 }
 
 // Log sends a custom log entry directly.
 // Use this when you need full control over the log entry.
 // The entry's timestamp will be set to now if empty, and service will be set from config if empty.
-// Returns without logging if the client has been shut down.
+// Returns without logging if the client has been shut down or entry.Level is
+// below the minimum level.
 func (c *Client) Log(entry LogEntry) {
-	c.mu.Lock()
-	if c.shutdown {
-		c.mu.Unlock()
+	if !c.Enabled(entry.Level) {
+		return
+	}
+
+	if c.closed.Load() {
 		return
 	}
-	c.mu.Unlock()
 
 	// Capture source location if enabled and not already set
 	if c.config.CaptureSourceLocation && entry.SourceFile == "" {
-		if file, line := captureSource(2); file != "" {
+		if file, line := captureSource(2 + c.config.CallerSkip); file != "" {
 			entry.SourceFile = file
 			entry.LineNumber = line
 		}
 	}
 
 	// Set defaults if not provided
-	if entry.Timestamp == "" {
-		entry.Timestamp = now()
-	}
 	if entry.Service == "" {
 		entry.Service = c.config.Service
 	}
 	// Merge config metadata with entry metadata
 	entry.Metadata = mergeMetadata(c.config.Metadata, entry.Metadata)
 
-	c.enqueue(entry)
+	if c.wantsStack(entry.Level) {
+		entry.Metadata = withStack(entry.Metadata, captureStack(2+c.config.CallerSkip))
+	}
+
+	c.enqueue(&entry)
 }
 
 // log is the internal logging method used by all level methods.
-// Returns without logging if the client has been shut down.
+// Returns without logging if the client has been shut down or level is
+// below the minimum level.
 func (c *Client) log(level LogLevel, message string, metadata ...map[string]any) {
-	c.mu.Lock()
-	if c.shutdown {
-		c.mu.Unlock()
+	c.logWith(2, callOptions{}, level, message, metadata)
+}
+
+// logWith logs an entry adjusted by opts. frames is the number of SDK
+// frames between logWith and the caller to attribute the entry to: 2 for
+// log and a level method, 1 for LogOpts.
+func (c *Client) logWith(frames int, opts callOptions, level LogLevel, message string, metadata []map[string]any) {
+	if !c.Enabled(level) {
 		return
 	}
-	c.mu.Unlock()
 
-	entry := LogEntry{
-		Level:     level,
-		Message:   message,
-		Timestamp: now(),
-		Service:   c.config.Service,
-		Metadata:  mergeMetadata(c.config.Metadata, mergeMetadata(metadata...)),
+	if c.closed.Load() {
+		return
+	}
+
+	entry := getEntry()
+	defer putEntry(entry)
+	entry.Level = level
+	entry.Message = message
+	entry.Service = c.config.Service
+	entry.Metadata = buildMetadata(c.config.Metadata, metadata)
+	if !opts.at.IsZero() {
+		entry.Timestamp = c.root().timestamps.at(opts.at)
 	}
 
-	// Capture source location if enabled
-	// Skip 3 frames: captureSource -> log -> Debug/Info/Warn/Error/Fatal
+	// Skip captureSource, logWith, and the frames above it, plus any
+	// requested by WithCallerSkip and CallerSkip
+	skip := 2 + frames + c.config.CallerSkip + opts.callerSkip
 	if c.config.CaptureSourceLocation {
-		entry.SourceFile, entry.LineNumber = captureSource(3)
+		entry.SourceFile, entry.LineNumber = captureSource(skip)
+	}
+	if c.wantsStack(level) {
+		entry.Metadata = withStack(entry.Metadata, captureStack(skip))
 	}
 
 	c.enqueue(entry)
 }
 
-// enqueue admits an entry into the shared root queue and, if the batch size is
-// reached, spawns an async flush. Admission and flush-goroutine spawning are
-// coordinated under the root's mutex and re-check the root's shutdown flag, so
-// once Shutdown begins no new entries are admitted and no new flush goroutines
-// are started (preventing races with flushWG.Wait()).
-func (c *Client) enqueue(entry LogEntry) {
-	root := c
-	if c.parent != nil {
-		root = c.parent
+// logFields is the internal logging method used by the *Fields level methods.
+// It builds metadata directly from the typed fields, skipping the
+// intermediate maps that log's variadic metadata requires.
+func (c *Client) logFields(level LogLevel, message string, fields []Field) {
+	if !c.Enabled(level) {
+		return
 	}
 
-	root.mu.Lock()
-	// Re-check the root's shutdown flag under the same lock that guards the
-	// enqueue and async-flush spawn. A child may still be active while the
-	// shared root is shutting down; reject admission in that case.
-	if root.shutdown {
-		root.mu.Unlock()
+	if c.closed.Load() {
 		return
 	}
-	c.queue.add(entry)
-	shouldFlush := c.queue.size() >= c.config.BatchSize
-	if shouldFlush {
-		// Register the in-flight flush while still holding root.mu so it is
-		// guaranteed to be observed by Shutdown's flushWG.Wait().
-		root.flushWG.Add(1)
+
+	entry := getEntry()
+	defer putEntry(entry)
+	entry.Level = level
+	entry.Message = message
+	entry.Service = c.config.Service
+	entry.Metadata = fieldsToMetadata(c.config.Metadata, fields)
+
+	// Skip 3 frames: captureSource -> logFields -> DebugFields/InfoFields/...,
+	// plus any requested by WithCallerSkip
+	if c.config.CaptureSourceLocation {
+		entry.SourceFile, entry.LineNumber = captureSource(3 + c.config.CallerSkip)
+	}
+	if c.wantsStack(level) {
+		entry.Metadata = withStack(entry.Metadata, captureStack(3+c.config.CallerSkip))
 	}
-	root.mu.Unlock()
 
-	if shouldFlush {
-		go func() {
-			defer root.flushWG.Done()
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
-			// Flush handles OnError callback internally; ignore the returned error here.
-			_ = c.Flush(ctx)
-		}()
+	c.enqueue(entry)
+}
+
+// wantsStack reports whether entries at level get a stack trace.
+func (c *Client) wantsStack(level LogLevel) bool {
+	threshold := c.config.StackTraceLevel
+	return threshold != "" && level.severity() >= threshold.severity()
+}
+
+// enqueue prepares the entry, runs the sampler, the dedupe window, and the
+// rate limit, if any, numbers the entry if enabled, and admits it into the
+// shared root queue.
+func (c *Client) enqueue(entry *LogEntry) {
+	root := c.root()
+	if !c.prepare(entry) {
+		return
+	}
+	if sampler := root.config.Sampler; sampler != nil && !sampler.Sample(*entry) {
+		return
+	}
+	if root.deduper != nil && !root.deduper.pass(entry) {
+		return
+	}
+	if root.limiter != nil && !root.limiter.allow(*entry) {
+		return
+	}
+	if root.config.SequenceNumbers {
+		entry.Seq = root.seq.Add(1)
 	}
+	root.admit(entry)
 }
 
-// flush sends all queued log entries to the server.
-// Internal method - does not respect context cancellation.
-// Calls OnFlush callback on success and OnError callback on failure.
-func (c *Client) flush() {
-	entries := c.queue.flush()
-	if len(entries) == 0 {
+// prepare stamps the entry if it has no timestamp, adds the logger name
+// prefix, runs the processors, replaces unserializable metadata values, and
+// applies redaction and the size limits. It reports false if a processor
+// dropped the entry.
+func (c *Client) prepare(entry *LogEntry) bool {
+	root := c.root()
+	if entry.Timestamp == "" {
+		entry.Timestamp = root.timestamps.timestamp()
+	}
+	if c.name != "" && root.config.NameInMessage {
+		entry.Message = c.name + ": " + entry.Message
+	}
+	for _, process := range root.config.Processors {
+		if !process(entry) {
+			return false
+		}
+	}
+	if entry.Metadata != nil {
+		var replaced []string
+		if entry.Metadata, replaced = sanitizeMetadata(entry.Metadata); replaced != nil {
+			root.reportError(unserializableError(entry.Message, replaced))
+		}
+	}
+	if root.redactor != nil {
+		root.redactor.redact(entry)
+	}
+	if cfg := root.config; cfg.MaxMessageBytes > 0 || cfg.MaxMetadataBytes > 0 {
+		limitEntry(entry, cfg.MaxMessageBytes, cfg.MaxMetadataBytes)
+	}
+	return true
+}
+
+// admitSummary stamps and admits a rate limit summary entry, which skips
+// the rest of the pipeline. Must be called on the root client.
+func (c *Client) admitSummary(entry LogEntry) {
+	entry.Timestamp = c.timestamps.timestamp()
+	if c.config.SequenceNumbers {
+		entry.Seq = c.seq.Add(1)
+	}
+	c.admit(&entry)
+}
+
+// admitDeduped admits an entry released by the dedupe window, which has
+// already passed the rest of the pipeline apart from the rate limit.
+// Must be called on the root client.
+func (c *Client) admitDeduped(entry LogEntry) {
+	if c.limiter != nil && !c.limiter.allow(entry) {
 		return
 	}
+	if c.config.SequenceNumbers {
+		entry.Seq = c.seq.Add(1)
+	}
+	c.admit(&entry)
+}
 
-	count := len(entries)
+// admit admits an entry into the queue and hands any full batches to the
+// sender pool. Admission and dispatch are coordinated under the mutex and
+// re-check the shutdown flag, so once Shutdown begins no new entries are
+// admitted and no new batches are submitted. Never blocks on network I/O.
+// The local sink, if any, sees every entry, even ones the queue drops.
+// Entries at a routed level are admitted to that route's client instead.
+// Must be called on the root client.
+func (c *Client) admit(entry *LogEntry) {
+	c.writeSink(entry)
 
-	// Send logs with retry
-	ctx := context.Background()
-	_, err := c.transport.sendWithRetry(ctx, entries)
+	if route := c.routes[entry.Level]; route != nil {
+		route.admit(entry)
+		return
+	}
 
-	// Call callbacks (non-blocking)
-	if err != nil {
-		// Re-queue failed entries at the front for retry
-		c.queue.prepend(entries)
-		if c.config.OnError != nil {
-			var logwellErr *Error
-			if errors.As(err, &logwellErr) {
-				c.config.OnError(logwellErr)
-			} else {
-				c.config.OnError(NewErrorWithCause(ErrNetworkError, "flush failed", err))
+	if c.config.SyncMode {
+		c.admitSync(entry)
+		return
+	}
+
+	if c.ring != nil {
+		c.admitRing(entry)
+		return
+	}
+
+	if c.config.OverflowStrategy.kind == overflowBlock {
+		c.enqueueBlocking(entry)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.unlock()
+	if c.shutdown {
+		return
+	}
+	c.admitLocked(entry)
+}
+
+// writeSink mirrors entry to the local sink, if any, reporting only the
+// first write failure. Must be called on the root client.
+func (c *Client) writeSink(entry *LogEntry) {
+	if c.sink != nil {
+		if err := c.sink.write(entry); err != nil && c.sink.failed.CompareAndSwap(false, true) {
+			c.reportError(NewErrorWithCause(ErrQueueOverflow, "failed to write local sink", err))
+		}
+	}
+}
+
+// admitRing pushes entry onto the ring for the drain goroutine. When the
+// ring is full the caller drains it itself, so entries keep their order.
+// Must be called on the root client.
+func (c *Client) admitRing(entry *LogEntry) {
+	if c.closed.Load() {
+		return
+	}
+	for !c.ring.push(entry) {
+		if c.closed.Load() {
+			return
+		}
+		c.drainRing(true)
+	}
+}
+
+// runRing moves entries from the ring into the queue whenever producers
+// signal, until Shutdown.
+func (c *Client) runRing() {
+	for {
+		select {
+		case <-c.ring.wake:
+			c.drainRing(true)
+		case <-c.ringDone:
+			return
+		}
+	}
+}
+
+// drainRing moves the entries buffered in the ring into the queue and, if
+// dispatch is true, hands full batches to the sender pool.
+func (c *Client) drainRing(dispatch bool) {
+	c.mu.Lock()
+	defer c.unlock()
+	if c.shutdown {
+		return
+	}
+	c.drainRingLocked()
+	if dispatch {
+		c.dispatchLocked(false)
+	}
+}
+
+// drainRingLocked moves the entries buffered in the ring into the queue.
+// Must be called on the root client with c.mu held.
+func (c *Client) drainRingLocked() {
+	c.ring.drain(func(entry LogEntry) { c.queue.add(entry) })
+}
+
+// enqueueBlocking implements the Block overflow strategy: while the queue is
+// full it pushes out whatever the sender can take and waits, without holding
+// c.mu, for space to free up. On timeout the entry goes through the queue's
+// drop-newest path. Must be called on the root client.
+func (c *Client) enqueueBlocking(entry *LogEntry) {
+	var timeout chan struct{}
+
+	for {
+		c.mu.Lock()
+		if c.shutdown {
+			c.unlock()
+			return
+		}
+		if !c.queue.full() {
+			c.admitLocked(entry)
+			c.unlock()
+			return
+		}
+		c.dispatchLocked(true)
+		space := c.queue.spaceFreed()
+		if !c.queue.full() {
+			c.admitLocked(entry)
+			c.unlock()
+			return
+		}
+		c.unlock()
+
+		if timeout == nil {
+			timeout = make(chan struct{})
+			timer := c.clock.AfterFunc(c.config.OverflowStrategy.timeout, func() { close(timeout) })
+			defer timer.Stop()
+		}
+
+		select {
+		case <-space:
+		case <-timeout:
+			c.mu.Lock()
+			if !c.shutdown {
+				c.admitLocked(entry)
 			}
+			c.unlock()
+			return
+		}
+	}
+}
+
+// admitLocked writes entry to the persistent queue (if any) and the
+// in-memory queue, then dispatches full batches. Must be called on the root
+// client with c.mu held.
+func (c *Client) admitLocked(entry *LogEntry) {
+	if c.persist != nil {
+		evicted, err := c.persist.append(entry)
+		if err != nil {
+			c.queue.notice(NewErrorWithCause(ErrQueueOverflow, "failed to write persistent queue", err))
+		}
+		if evicted > 0 {
+			c.queue.notice(NewError(ErrQueueOverflow,
+				fmt.Sprintf("persistent queue full: dropping %d undelivered entries from disk", evicted)))
 		}
+	}
+	if !c.queue.add(*entry) && c.persist != nil {
+		// Rejected by a drop-newest overflow; it will never be sent.
+		c.persist.ack([]LogEntry{*entry})
+	}
+	c.dispatchLocked(false)
+}
+
+// dispatchLocked hands batches from the queue to the sender pool without
+// blocking. Full batches are always dispatched; a trailing partial batch only
+// when partial is true (timer flush) or a previous timer flush is still owed.
+// If the pool is saturated, entries stay queued until a worker frees up.
+// Must be called on the root client with c.mu held.
+func (c *Client) dispatchLocked(partial bool) {
+	partial = partial || c.flushPending
+	c.flushPending = false
+	if c.breaker != nil && c.breaker.blocked() {
+		// circuitChanged schedules a flush for when the cooldown ends.
 		return
 	}
+	for {
+		size := c.queue.size()
+		if size == 0 || (size < c.config.BatchSize && !partial) {
+			return
+		}
+		if !c.sender.hasCapacity() {
+			c.flushPending = partial
+			return
+		}
+		c.sender.submit(c.queue.take(c.config.BatchSize))
+	}
+}
 
-	if c.config.OnFlush != nil {
-		c.config.OnFlush(count)
+// flush dispatches all queued entries, including a partial batch, to the
+// sender pool. Used by the queue's auto-flush timer; never blocks on I/O.
+func (c *Client) flush() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.shutdown {
+		return
 	}
+	c.debug("logwell: flush triggered", "queued", c.queue.size())
+	c.dispatchLocked(true)
 }
 
-// Flush sends all queued log entries immediately.
-// Respects context cancellation and timeout.
-// Calls OnFlush callback on success and OnError callback on failure.
-// Returns any error from the transport layer.
-func (c *Client) Flush(ctx context.Context) error {
-	entries := c.queue.flush()
-	if len(entries) == 0 {
-		return nil
+// sendAsync is the sender pool's send function. On success it dispatches any
+// backlog that built up while the pool was busy. The send is bounded by the
+// retry settings (MaxRetries, RetryDeadline, per-attempt Timeout) rather
+// than a fixed timeout, and is canceled by Shutdown.
+func (c *Client) sendAsync(batch []LogEntry) {
+	// sendBatch handles the OnError callback internally.
+	err := c.sendBatch(c.inflightCtx, batch)
+	putBatch(batch)
+	if err != nil {
+		return
 	}
 
-	count := len(entries)
-	_, err := c.transport.sendWithRetry(ctx, entries)
+	c.mu.Lock()
+	if !c.shutdown {
+		c.dispatchLocked(false)
+	}
+	c.mu.Unlock()
+}
+
+// sendBatch sends one batch with retry. On failure the batch is re-queued at
+// the front and OnError is called; on success OnFlush is called.
+// If the circuit breaker is open, the batch is re-queued without a network
+// call and ErrCircuitOpen is returned (not reported to OnError).
+// With WithFallbackFile, batches that the circuit skips or that fail with a
+// retryable error after all retries are spilled to the file instead.
+// A batch rejected as too large (413) is split and resent (see sendSplit);
+// only the entries not yet delivered are re-queued if a part then fails.
+func (c *Client) sendBatch(ctx context.Context, batch []LogEntry) error {
+	breaker := c.root().breaker
+	if breaker != nil && !breaker.allow() {
+		c.debug("logwell: send skipped, circuit open", "entries", len(batch))
+		if !c.spill(batch) {
+			c.requeue(batch)
+		}
+		return NewError(ErrCircuitOpen, "circuit breaker open: send skipped")
+	}
 
-	// Call callbacks (non-blocking)
+	unsent, err := c.sendPart(ctx, batch)
+	if breaker != nil {
+		// Only transient failures indicate an unhealthy endpoint; a
+		// rejected batch (e.g. 400) still proves the server is reachable.
+		if err != nil && c.transport.isRetryableError(err) {
+			breaker.failure()
+		} else {
+			breaker.success()
+		}
+	}
 	if err != nil {
-		// Re-queue failed entries at the front for retry
-		c.queue.prepend(entries)
-		if c.config.OnError != nil {
-			var logwellErr *Error
-			if errors.As(err, &logwellErr) {
-				c.config.OnError(logwellErr)
-			} else {
-				c.config.OnError(NewErrorWithCause(ErrNetworkError, "flush failed", err))
-			}
+		c.root().stats.batchesFailed.Add(1)
+		c.warn("logwell: batch failed", "entries", len(unsent), "error", err)
+		if !c.transport.isRetryableError(err) || !c.spill(unsent) {
+			c.requeue(unsent)
 		}
+		c.reportError(err)
 		return err
 	}
+	return nil
+}
+
+// requeue puts entries back at the front of the queue and reports any
+// overflow. Must be called without c.mu held.
+func (c *Client) requeue(entries []LogEntry) {
+	c.queue.prepend(entries)
+	c.queue.notify()
+}
+
+// sendPart sends batch, or a piece of a split batch, with retry. It returns
+// the entries left undelivered along with the error that stopped them.
+func (c *Client) sendPart(ctx context.Context, batch []LogEntry) ([]LogEntry, error) {
+	key := newIdempotencyKey()
+	clock := c.root().clock
+	start := clock.Now()
+	resp, err := c.transport.sendWithRetry(withIdempotencyKey(ctx, key), batch)
+	if err != nil {
+		var logwellErr *Error
+		if errors.As(err, &logwellErr) && logwellErr.Code == ErrPayloadTooLarge {
+			return c.sendSplit(ctx, batch, logwellErr)
+		}
+		return batch, err
+	}
+	latency := clock.Now().Sub(start)
+	if persist := c.root().persist; persist != nil {
+		persist.ack(batch)
+	}
+	if receipt := receiptFromContext(ctx); receipt != nil {
+		receipt.add(len(batch), resp)
+	}
+	c.batchSent(batch, key, resp, latency)
+	return nil, nil
+}
+
+// batchSent records an accepted batch in the stats and debug log and
+// calls the sent and flush callbacks.
+func (c *Client) batchSent(batch []LogEntry, key string, resp *IngestResponse, latency time.Duration) {
+	c.debug("logwell: batch sent", "entries", len(batch), "accepted", resp.Accepted, "latency", latency, "idempotencyKey", key)
+	stats := &c.root().stats
+	stats.batchesSent.Add(1)
+	stats.lastFlushLatency.Store(int64(latency))
+	stats.sendLatency.observe(latency)
 
+	if c.config.OnBatchSent != nil {
+		c.config.OnBatchSent(resp.Accepted, latency)
+	}
+	if c.config.OnBatchSentInfo != nil {
+		c.config.OnBatchSentInfo(BatchInfo{
+			IdempotencyKey: key,
+			Entries:        len(batch),
+			Accepted:       resp.Accepted,
+			Latency:        latency,
+			IDs:            resp.IDs,
+		})
+	}
 	if c.config.OnFlush != nil {
-		c.config.OnFlush(count)
+		c.config.OnFlush(len(batch))
+	}
+}
+
+// sendSplit handles a batch the server rejected as too large by sending each
+// half separately, recursing down to single entries. An entry too large on
+// its own is passed to OnDeadLetter and dropped. If a half fails for another
+// reason, the entries from it onward are returned undelivered.
+func (c *Client) sendSplit(ctx context.Context, batch []LogEntry, tooLarge *Error) ([]LogEntry, error) {
+	if len(batch) == 1 {
+		if persist := c.root().persist; persist != nil {
+			persist.ack(batch)
+		}
+		if c.config.OnDeadLetter != nil {
+			c.config.OnDeadLetter(batch[0], tooLarge)
+		}
+		if receipt := receiptFromContext(ctx); receipt != nil {
+			receipt.DeadLettered++
+		}
+		c.reportError(tooLarge)
+		return nil, nil
+	}
+
+	mid := len(batch) / 2
+	if unsent, err := c.sendPart(ctx, batch[:mid]); err != nil {
+		// Copy so appending cannot overwrite batch[mid:] in place.
+		return append(slices.Clone(unsent), batch[mid:]...), err
+	}
+	return c.sendPart(ctx, batch[mid:])
+}
+
+// Flush synchronously drains the queue and sends every pending entry,
+// split into batches of at most BatchSize entries.
+//
+// Flush first waits for batches already handed to the background sender,
+// so when it returns nil every entry logged before the call has been
+// accepted by the server. This makes it suitable for serverless handlers
+// that must guarantee delivery before returning.
+//
+// Sending stops at the first batch that fails after retries; that batch and
+// all remaining entries stay queued and the transport error is returned.
+// (With WithFallbackFile, the failed batch is spilled to the file instead.)
+// With WithLevelRouting, each route's queue is flushed the same way and the
+// first error is returned.
+// Entries held by WithDedupe are released first, without waiting for their
+// window.
+// Respects context cancellation and timeout.
+// Calls OnFlush after each successful batch and OnError on failure.
+// Returns ErrClientClosed if c or its root client has been shut down.
+func (c *Client) Flush(ctx context.Context) error {
+	if c.isClosed() {
+		return ErrClientClosed
+	}
+	c.debug("logwell: flush requested")
+	if root := c.root(); root.deduper != nil {
+		for _, entry := range root.deduper.take(false) {
+			root.admitDeduped(entry)
+		}
+	}
+	err := c.flushQueue(ctx)
+	for _, route := range c.root().routeClients() {
+		if routeErr := route.flushQueue(ctx); routeErr != nil && err == nil {
+			err = routeErr
+		}
+	}
+	return err
+}
+
+// isClosed reports whether c or the root client it shares a queue with has
+// been shut down.
+func (c *Client) isClosed() bool {
+	c.mu.Lock()
+	closed := c.shutdown
+	c.mu.Unlock()
+	if closed || c.parent == nil {
+		return closed
+	}
+	return c.parent.isClosed()
+}
+
+// flushQueue moves buffered entries into the queue, waits for in-flight
+// background sends, then sends the entries queued at that point on the
+// caller's goroutine in BatchSize chunks.
+// Entries logged while the drain runs are left for the background sender.
+func (c *Client) flushQueue(ctx context.Context) error {
+	if root := c.root(); root.ring != nil {
+		root.drainRing(false)
+	}
+	if err := c.root().sender.waitIdle(ctx); err != nil {
+		return NewErrorWithCause(ErrNetworkError, "context canceled", err)
+	}
+
+	remaining := c.queue.size()
+	for remaining > 0 {
+		batch := c.queue.take(min(remaining, c.config.BatchSize))
+		if len(batch) == 0 {
+			return nil
+		}
+		err := c.sendBatch(ctx, batch)
+		putBatch(batch)
+		if err != nil {
+			return err
+		}
+		remaining -= len(batch)
 	}
 
 	return nil
 }
 
+// CircuitState returns the state of the circuit breaker shared by c and its
+// root client. Without WithCircuitBreaker it is always CircuitClosed.
+func (c *Client) CircuitState() CircuitState {
+	if breaker := c.root().breaker; breaker != nil {
+		return breaker.current()
+	}
+	return CircuitClosed
+}
+
+// circuitChanged reports breaker transitions to OnError and, when the
+// circuit opens, schedules a flush for the end of the cooldown so queued
+// entries are probed even if no further logs arrive.
+func (c *Client) circuitChanged(from, to CircuitState, failures int) {
+	if to == CircuitOpen {
+		c.warn("logwell: circuit opened", "from", from, "failures", failures)
+	} else {
+		c.debug("logwell: circuit "+to.String(), "from", from)
+	}
+	if to == CircuitOpen {
+		c.clock.AfterFunc(c.config.CircuitBreakerCooldown, c.flush)
+	}
+	if c.config.OnError != nil {
+		c.config.OnError(circuitTransitionError(from, to, failures))
+	}
+}
+
+// reportError passes err to the OnError callback, if configured,
+// converting non-SDK errors into a network Error.
+func (c *Client) reportError(err error) {
+	if c.config.OnError == nil {
+		return
+	}
+	var logwellErr *Error
+	if errors.As(err, &logwellErr) {
+		c.config.OnError(logwellErr)
+	} else {
+		c.config.OnError(NewErrorWithCause(ErrNetworkError, "flush failed", err))
+	}
+}
+
+// unlock releases c.mu, then delivers the queue overflow notices recorded
+// while it was held, so OnError and OnDrop callbacks may log through the
+// client without deadlocking.
+func (c *Client) unlock() {
+	c.mu.Unlock()
+	if c.queue != nil {
+		c.queue.notify()
+	}
+}
+
+// root returns the client that owns the shared queue and transport.
+func (c *Client) root() *Client {
+	if c.parent != nil {
+		return c.parent
+	}
+	return c
+}
+
 // Shutdown gracefully shuts down the client.
-// It stops accepting new logs, flushes any remaining queued logs,
-// and cleans up resources.
-// Respects context cancellation and timeout.
-// Returns any error from flushing remaining logs. A non-nil error
-// means that some logs may not have been delivered to the server.
+// It stops accepting new logs, stops the auto-flush timer, waits for in-flight
+// flushes, and drains any remaining queued logs. Each batch is retried at most
+// MaxRetries times, so Shutdown completes in bounded time even if the server
+// is down.
+//
+// If ctx expires before the drain completes, in-flight requests are canceled
+// and ctx.Err() (or the transport error wrapping it) is returned. A non-nil
+// error means that some logs may not have been delivered to the server.
+//
+// After Shutdown, log calls are silent no-ops and Flush returns ErrClientClosed.
+// Calling Shutdown again returns nil.
 //
 // For child loggers, Shutdown only marks the child as shut down;
 // it does NOT affect the parent or other children. The parent must
 // be shut down separately to flush remaining logs and stop the timer.
 func (c *Client) Shutdown(ctx context.Context) error {
+	if c.parent == nil && c.runtimeStats != nil {
+		c.runtimeStats.stop()
+	}
+
+	// Release held duplicates and report suppressed entries while the
+	// queue still admits them.
+	if c.parent == nil && c.deduper != nil {
+		for _, entry := range c.deduper.take(true) {
+			c.admitDeduped(entry)
+		}
+	}
+	if c.parent == nil && c.limiter != nil {
+		for _, entry := range c.limiter.stop() {
+			c.admitSummary(entry)
+		}
+	}
+
 	c.mu.Lock()
 	if c.shutdown {
 		c.mu.Unlock()
 		return nil // Already shut down
 	}
 	c.shutdown = true
-	c.mu.Unlock()
+	c.closed.Store(true)
+	if c.ring != nil {
+		// Move what is buffered into the queue for the final drain.
+		c.drainRingLocked()
+		close(c.ringDone)
+	}
+	c.unlock()
 
 	// Child loggers don't own the queue/transport, so they shouldn't
 	// stop the timer or flush. Only mark themselves as shut down.
@@ -370,23 +1260,44 @@ func (c *Client) Shutdown(ctx context.Context) error {
 
 	// Stop the queue timer to prevent further auto-flushes
 	c.queue.stopTimer()
+	c.debug("logwell: shutting down", "queued", c.queue.size())
+
+	// Abort in-flight requests if ctx expires before the drain finishes.
+	stop := context.AfterFunc(ctx, c.cancelInflight)
 
-	// Wait for any in-flight async flush goroutines to complete, but respect
-	// context cancellation/timeout so Shutdown does not block uninterruptibly.
-	done := make(chan struct{})
-	go func() {
-		c.flushWG.Wait()
-		close(done)
-	}()
-	select {
-	case <-done:
-		// All in-flight flushes completed.
-	case <-ctx.Done():
-		return ctx.Err()
+	// Wait for in-flight background sends, then drain what remains.
+	err := c.flushQueue(ctx)
+
+	stop()
+	c.cancelInflight()
+	c.sender.close()
+	c.transport.closeIdleConnections()
+
+	if closer, ok := c.config.Transport.(io.Closer); ok {
+		if closeErr := closer.Close(); closeErr != nil && err == nil {
+			err = NewErrorWithCause(ErrNetworkError, "failed to close transport", closeErr)
+		}
+	}
+
+	if c.fallback != nil {
+		if closeErr := c.fallback.close(); closeErr != nil && err == nil {
+			err = NewErrorWithCause(ErrQueueOverflow, "failed to close fallback file", closeErr)
+		}
 	}
 
-	// Flush remaining logs with context
-	return c.Flush(ctx)
+	// Entries still undelivered stay on disk for the next startup.
+	if c.persist != nil {
+		if closeErr := c.persist.close(); closeErr != nil && err == nil {
+			err = NewErrorWithCause(ErrQueueOverflow, "failed to close persistent queue", closeErr)
+		}
+	}
+
+	for _, route := range c.routeClients() {
+		if routeErr := route.Shutdown(ctx); routeErr != nil && err == nil {
+			err = routeErr
+		}
+	}
+	return err
 }
 
 // mergeMetadata combines multiple metadata maps into one.
@@ -428,6 +1339,29 @@ func mergeMetadata(maps ...map[string]any) map[string]any {
 	return result
 }
 
+// buildMetadata merges base and the call's metadata maps into one new map,
+// sized up front so a call allocates a single map. Later maps override
+// earlier ones for duplicate keys.
+func buildMetadata(base map[string]any, extra []map[string]any) map[string]any {
+	n := len(base)
+	for _, m := range extra {
+		n += len(m)
+	}
+	if n == 0 {
+		return nil
+	}
+	result := make(map[string]any, n)
+	for k, v := range base {
+		result[k] = v
+	}
+	for _, m := range extra {
+		for k, v := range m {
+			result[k] = v
+		}
+	}
+	return result
+}
+
 // cloneMetadata returns a shallow copy of m. m must be non-empty.
 func cloneMetadata(m map[string]any) map[string]any {
 	clone := make(map[string]any, len(m))
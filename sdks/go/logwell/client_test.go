@@ -3,9 +3,12 @@ package logwell
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -118,6 +121,9 @@ func mapToLogEntry(m map[string]any) (LogEntry, error) {
 	if ln, ok := m["lineNumber"].(float64); ok {
 		entry.LineNumber = int(ln)
 	}
+	if seq, ok := m["seq"].(float64); ok {
+		entry.Seq = uint64(seq)
+	}
 
 	return entry, nil
 }
@@ -215,7 +221,7 @@ func TestClientLogLevels(t *testing.T) {
 	ts := newTestServer()
 	defer ts.Close()
 
-	client, err := New(ts.URL, validAPIKey(), WithBatchSize(1))
+	client, err := New(ts.URL, validAPIKey(), WithBatchSize(1), WithFatalBehavior(LogOnly))
 	if err != nil {
 		t.Fatalf("New() error = %v", err)
 	}
@@ -406,6 +412,337 @@ func TestClientManualFlush(t *testing.T) {
 	}
 }
 
+// TestClientLogDoesNotBlockOnNetwork tests that log calls return immediately
+// even when the server is slow, because batches are sent by background workers.
+func TestClientLogDoesNotBlockOnNetwork(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	release := make(chan struct{})
+	ts.setHandler(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: 1})
+	})
+	defer close(release)
+
+	client := createTestClient(t, ts, WithBatchSize(1), WithSenderConcurrency(1), WithMaxRetries(0))
+
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		client.Info("message")
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("20 log calls took %v with a stalled server, want non-blocking", elapsed)
+	}
+}
+
+// TestClientOverflowBlockWaitsForSpace tests that with Block, log calls on a
+// full queue wait for the sender instead of dropping entries.
+func TestClientOverflowBlockWaitsForSpace(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	release := make(chan struct{})
+	var received atomic.Int32
+	ts.setHandler(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		var entries []LogEntry
+		json.NewDecoder(r.Body).Decode(&entries)
+		received.Add(int32(len(entries)))
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: len(entries)})
+	})
+
+	var overflows atomic.Int32
+	client := createTestClient(t, ts,
+		WithBatchSize(1),
+		WithSenderConcurrency(1),
+		WithMaxQueueSize(2),
+		WithOverflowStrategy(Block(5*time.Second)),
+		WithOnError(func(err *Error) {
+			if err.Code == ErrQueueOverflow {
+				overflows.Add(1)
+			}
+		}),
+	)
+
+	time.AfterFunc(100*time.Millisecond, func() { close(release) })
+
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		client.Info("message")
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("log calls returned after %v, want them to block until the server responds", elapsed)
+	}
+
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if got := received.Load(); got != 10 {
+		t.Errorf("received %d entries, want 10", got)
+	}
+	if got := overflows.Load(); got != 0 {
+		t.Errorf("overflow errors = %d, want 0", got)
+	}
+}
+
+// TestClientOverflowBlockTimeout tests that Block discards the new entry once
+// its timeout expires.
+func TestClientOverflowBlockTimeout(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	release := make(chan struct{})
+	ts.setHandler(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: 1})
+	})
+
+	var mu sync.Mutex
+	var messages []string
+	client := createTestClient(t, ts,
+		WithBatchSize(1),
+		WithSenderConcurrency(1),
+		WithMaxQueueSize(1),
+		WithOverflowStrategy(Block(50*time.Millisecond)),
+		WithOnError(func(err *Error) {
+			mu.Lock()
+			messages = append(messages, err.Message)
+			mu.Unlock()
+		}),
+	)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		client.Info("message")
+	}
+	elapsed := time.Since(start)
+	close(release)
+
+	if elapsed < 50*time.Millisecond {
+		t.Errorf("log calls took %v, want at least the 50ms block timeout", elapsed)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(messages) == 0 || messages[0] != "queue overflow: dropping newest entry" {
+		t.Errorf("OnError messages = %v, want a drop-newest overflow", messages)
+	}
+}
+
+// TestClientOverflowCallbackLogs tests that OnError may log
+// through the client that reported the overflow without deadlocking.
+func TestClientOverflowCallbackLogs(t *testing.T) {
+	tests := []struct {
+		name string
+		opts func(t *testing.T) []Option
+	}{
+		{"block", func(*testing.T) []Option {
+			return []Option{WithOverflowStrategy(Block(10 * time.Millisecond))}
+		}},
+		{"persistent queue", func(t *testing.T) []Option {
+			return []Option{WithPersistentQueue(t.TempDir(), 1<<20)}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			var client *Client
+			var reported atomic.Bool
+			opts := append(tt.opts(t),
+				WithBatchSize(1),
+				WithSenderConcurrency(1),
+				WithMaxQueueSize(1),
+				WithTransport(transportFunc(func(ctx context.Context, logs []LogEntry) (*IngestResponse, error) {
+					select {
+					case <-release:
+					case <-ctx.Done():
+					}
+					return &IngestResponse{Accepted: len(logs)}, nil
+				})),
+				WithOnError(func(err *Error) {
+					if reported.CompareAndSwap(false, true) {
+						client.Warn("overflow: " + err.Message)
+					}
+				}),
+			)
+			var err error
+			client, err = New(validEndpoint(), validAPIKey(), opts...)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			defer client.Shutdown(context.Background())
+			defer close(release)
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for range 5 {
+					client.Info("message")
+				}
+			}()
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatal("log calls deadlocked in an overflow callback")
+			}
+			if !reported.Load() {
+				t.Error("OnError was not called for the overflow")
+			}
+		})
+	}
+}
+
+// TestClientBackgroundSendLongRetryAfter tests that background sends wait
+// out a Retry-After delay longer than any fixed send timeout.
+func TestClientBackgroundSendLongRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.Header().Set("Retry-After", "45")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: 1})
+	}))
+	defer server.Close()
+
+	flushed := make(chan int, 1)
+	var failed atomic.Bool
+	client, err := New(server.URL, validAPIKey(),
+		WithBatchSize(1),
+		WithClock(&skipClock{now: time.Unix(0, 0)}),
+		WithMaxRetryAfter(time.Minute),
+		WithOnFlush(func(n int) { flushed <- n }),
+		WithOnError(func(*Error) { failed.Store(true) }),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("delayed")
+	select {
+	case <-flushed:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("batch not delivered after the Retry-After wait; requests = %d", requests.Load())
+	}
+	if failed.Load() {
+		t.Error("send gave up before the Retry-After wait")
+	}
+}
+
+// TestClientFlushSplitsBatches tests that Flush sends the queue in BatchSize chunks.
+func TestClientFlushSplitsBatches(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var flushed []int
+	var flushMu sync.Mutex
+	client := createTestClient(t, ts,
+		WithBatchSize(2),
+		WithFlushInterval(1*time.Minute),
+		WithOnFlush(func(n int) {
+			flushMu.Lock()
+			flushed = append(flushed, n)
+			flushMu.Unlock()
+		}),
+	)
+	defer client.Shutdown(context.Background())
+
+	// Fill the queue directly so no size-triggered flush runs.
+	for i := 0; i < 5; i++ {
+		client.queue.add(LogEntry{Level: LevelInfo, Message: fmt.Sprintf("message %d", i)})
+	}
+
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	requests := ts.getRequests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(requests))
+	}
+	for i, want := range []int{2, 2, 1} {
+		if len(requests[i]) != want {
+			t.Errorf("request[%d] has %d entries, want %d", i, len(requests[i]), want)
+		}
+	}
+
+	flushMu.Lock()
+	defer flushMu.Unlock()
+	if len(flushed) != 3 {
+		t.Errorf("OnFlush called %d times, want 3", len(flushed))
+	}
+}
+
+// TestClientFlushStopsAtFirstError tests that a failed batch stops the drain
+// and re-queues the failed and unsent entries.
+func TestClientFlushStopsAtFirstError(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var requestCount int32
+	ts.setHandler(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requestCount, 1) == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "bad batch"})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: 2})
+	})
+
+	client := createTestClient(t, ts,
+		WithBatchSize(2),
+		WithFlushInterval(1*time.Minute),
+		WithMaxRetries(0),
+	)
+
+	for i := 0; i < 6; i++ {
+		client.queue.add(LogEntry{Level: LevelInfo, Message: fmt.Sprintf("message %d", i)})
+	}
+
+	err := client.Flush(context.Background())
+	assertConfigError(t, err, ErrValidationError)
+
+	if got := atomic.LoadInt32(&requestCount); got != 2 {
+		t.Errorf("expected 2 requests before stopping, got %d", got)
+	}
+	if client.queue.size() != 4 {
+		t.Errorf("expected 4 entries re-queued, got %d", client.queue.size())
+	}
+}
+
+// TestClientFlushWaitsForInFlight tests that Flush does not return until an
+// in-flight automatic flush has been delivered.
+func TestClientFlushWaitsForInFlight(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var delivered int32
+	ts.setHandler(func(w http.ResponseWriter, r *http.Request) {
+		var raw []map[string]any
+		json.NewDecoder(r.Body).Decode(&raw)
+		time.Sleep(100 * time.Millisecond)
+		atomic.AddInt32(&delivered, int32(len(raw)))
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: len(raw)})
+	})
+
+	client := createTestClient(t, ts, WithBatchSize(1), WithFlushInterval(1*time.Minute))
+	defer client.Shutdown(context.Background())
+
+	client.Info("triggers async flush")
+	time.Sleep(10 * time.Millisecond) // let the async flush take the entry
+
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got := atomic.LoadInt32(&delivered); got != 1 {
+		t.Errorf("delivered = %d after Flush returned, want 1", got)
+	}
+}
+
 // TestClientShutdown tests graceful shutdown behavior.
 func TestClientShutdown(t *testing.T) {
 	ts := newTestServer()
@@ -477,6 +814,84 @@ func TestClientShutdownIdempotent(t *testing.T) {
 	}
 }
 
+// TestClientShutdownClosesClient tests behavior after Shutdown.
+func TestClientShutdownClosesClient(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithBatchSize(100))
+	child := client.Child()
+
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	t.Run("Flush returns ErrClientClosed", func(t *testing.T) {
+		if err := client.Flush(context.Background()); !errors.Is(err, ErrClientClosed) {
+			t.Errorf("Flush() error = %v, want ErrClientClosed", err)
+		}
+	})
+
+	t.Run("child Flush returns ErrClientClosed", func(t *testing.T) {
+		if err := child.Flush(context.Background()); !errors.Is(err, ErrClientClosed) {
+			t.Errorf("child Flush() error = %v, want ErrClientClosed", err)
+		}
+	})
+
+	t.Run("log calls are no-ops", func(t *testing.T) {
+		client.Info("dropped")
+		child.Info("dropped")
+		if client.queue.size() != 0 {
+			t.Errorf("queue size = %d, want 0", client.queue.size())
+		}
+	})
+
+	t.Run("ErrClientShutdown aliases ErrClientClosed", func(t *testing.T) {
+		if !errors.Is(ErrClientShutdown, ErrClientClosed) {
+			t.Error("ErrClientShutdown should be ErrClientClosed")
+		}
+	})
+}
+
+// TestClientShutdownCancelsInFlight tests that an expiring Shutdown context
+// aborts in-flight requests instead of waiting for them.
+func TestClientShutdownCancelsInFlight(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	requestCanceled := make(chan struct{})
+	ts.setHandler(func(w http.ResponseWriter, r *http.Request) {
+		// Drain the body so the server notices the client disconnecting.
+		io.Copy(io.Discard, r.Body)
+		select {
+		case <-r.Context().Done():
+			close(requestCanceled)
+		case <-time.After(5 * time.Second):
+		}
+	})
+
+	client := createTestClient(t, ts, WithBatchSize(1), WithMaxRetries(0))
+	client.Info("hangs")
+	time.Sleep(20 * time.Millisecond) // let the async flush start its request
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := client.Shutdown(ctx); err == nil {
+		t.Fatal("Shutdown() expected error when context expires")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Shutdown took %v, want it bounded by the context", elapsed)
+	}
+
+	select {
+	case <-requestCanceled:
+	case <-time.After(time.Second):
+		t.Error("in-flight request was not canceled")
+	}
+}
+
 // TestClientChild tests child logger creation and inheritance.
 func TestClientChild(t *testing.T) {
 	ts := newTestServer()
@@ -558,6 +973,183 @@ func TestClientChild(t *testing.T) {
 	})
 }
 
+// TestClientWith tests metadata-scoped child loggers created via With.
+func TestClientWith(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	parent := createTestClient(t, ts,
+		WithBatchSize(1),
+		WithMetadata(M{"env": "test"}),
+	)
+	defer parent.Shutdown(context.Background())
+
+	t.Run("merges inherited metadata", func(t *testing.T) {
+		log := logAndWait(parent, ts, parent.With(M{"requestId": "req-1"}).Info, "with message")
+		assertLogMetadata(t, log, map[string]string{
+			"env":       "test",
+			"requestId": "req-1",
+		})
+	})
+
+	t.Run("supports nesting", func(t *testing.T) {
+		nested := parent.With(M{"requestId": "req-1"}).With(M{"userId": "u-1", "env": "staging"})
+		log := logAndWait(parent, ts, nested.Info, "nested message")
+		assertLogMetadata(t, log, map[string]string{
+			"env":       "staging",
+			"requestId": "req-1",
+			"userId":    "u-1",
+		})
+	})
+
+	t.Run("shares root queue and transport", func(t *testing.T) {
+		nested := parent.With(M{"a": 1}).With(M{"b": 2})
+		if nested.queue != parent.queue || nested.transport != parent.transport {
+			t.Error("nested With logger does not share the root queue/transport")
+		}
+	})
+}
+
+// TestClientWithService tests service-scoped child loggers.
+func TestClientWithService(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	parent := createTestClient(t, ts, WithBatchSize(1), WithService("monolith"))
+	defer parent.Shutdown(context.Background())
+
+	t.Run("overrides the client service", func(t *testing.T) {
+		log := logAndWait(parent, ts, parent.WithService("checkout").Info, "order placed")
+		if log.Service != "checkout" {
+			t.Errorf("Service = %q, want checkout", log.Service)
+		}
+	})
+
+	t.Run("propagates through nested children", func(t *testing.T) {
+		nested := parent.WithService("checkout").With(M{"orderId": "o-1"}).Named("cart")
+		log := logAndWait(parent, ts, nested.Info, "item added")
+		if log.Service != "checkout" {
+			t.Errorf("Service = %q, want checkout", log.Service)
+		}
+		assertLogMetadata(t, log, map[string]string{"orderId": "o-1", "logger": "cart"})
+	})
+
+	t.Run("nested service wins", func(t *testing.T) {
+		log := logAndWait(parent, ts, parent.WithService("checkout").WithService("billing").Info, "invoice sent")
+		if log.Service != "billing" {
+			t.Errorf("Service = %q, want billing", log.Service)
+		}
+	})
+
+	t.Run("leaves the parent unchanged", func(t *testing.T) {
+		if parent.WithService("") != parent {
+			t.Error("WithService(\"\") did not return the receiver")
+		}
+		log := logAndWait(parent, ts, parent.Info, "parent message")
+		if log.Service != "monolith" {
+			t.Errorf("Service = %q, want monolith", log.Service)
+		}
+	})
+}
+
+// TestClientNamed tests hierarchical logger names.
+func TestClientNamed(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithBatchSize(1))
+	defer client.Shutdown(context.Background())
+
+	t.Run("sets logger metadata", func(t *testing.T) {
+		worker := client.Named("payments").Named("worker")
+		log := logAndWait(client, ts, worker.Info, "charge settled")
+		assertLogMetadata(t, log, map[string]string{"logger": "payments.worker"})
+		if log.Message != "charge settled" {
+			t.Errorf("Message = %q, want it unprefixed by default", log.Message)
+		}
+	})
+
+	t.Run("children inherit the name", func(t *testing.T) {
+		child := client.Named("payments").With(M{"orderId": "o-1"}).Named("refunds")
+		log := logAndWait(client, ts, child.Info, "refund issued")
+		assertLogMetadata(t, log, map[string]string{"logger": "payments.refunds", "orderId": "o-1"})
+	})
+
+	t.Run("empty name returns the receiver", func(t *testing.T) {
+		if got := client.Named(""); got != client {
+			t.Error("Named(\"\") returned a new logger, want the receiver")
+		}
+	})
+
+	t.Run("prefixes the message with WithNameInMessage", func(t *testing.T) {
+		prefixed := createTestClient(t, ts, WithBatchSize(1), WithNameInMessage(true))
+		defer prefixed.Shutdown(context.Background())
+
+		log := logAndWait(prefixed, ts, prefixed.Named("payments").Named("worker").Warn, "retrying")
+		if log.Message != "payments.worker: retrying" {
+			t.Errorf("Message = %q, want %q", log.Message, "payments.worker: retrying")
+		}
+		log = logAndWait(prefixed, ts, prefixed.Info, "root")
+		if log.Message != "root" {
+			t.Errorf("unnamed Message = %q, want %q", log.Message, "root")
+		}
+	})
+}
+
+// TestClientSequenceNumbers tests that queued entries are numbered 1..n
+// across goroutines and child loggers, in each goroutine's logging order.
+func TestClientSequenceNumbers(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts,
+		WithBatchSize(500),
+		WithSequenceNumbers(true),
+		WithSampler(SamplerFunc(func(e LogEntry) bool { return e.Message != "sampled out" })),
+	)
+	defer client.Shutdown(context.Background())
+
+	const goroutines, perGoroutine = 4, 50
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		logger := client.With(M{"goroutine": g})
+		wg.Go(func() {
+			for i := range perGoroutine {
+				logger.Info("sampled out")
+				logger.Info("numbered", M{"i": i})
+			}
+		})
+	}
+	wg.Wait()
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	logs := ts.getLogs()
+	if len(logs) != goroutines*perGoroutine {
+		t.Fatalf("received %d logs, want %d", len(logs), goroutines*perGoroutine)
+	}
+	seen := make(map[uint64]bool)
+	last := make(map[float64]uint64)
+	for _, log := range logs {
+		if log.Seq == 0 || log.Seq > uint64(len(logs)) || seen[log.Seq] {
+			t.Fatalf("Seq = %d, want unique numbers 1..%d", log.Seq, len(logs))
+		}
+		seen[log.Seq] = true
+		g := log.Metadata["goroutine"].(float64)
+		if log.Seq <= last[g] {
+			t.Errorf("goroutine %v: Seq %d after %d, want increasing", g, log.Seq, last[g])
+		}
+		last[g] = log.Seq
+	}
+
+	unnumbered := createTestClient(t, ts, WithBatchSize(1))
+	defer unnumbered.Shutdown(context.Background())
+	if log := logAndWait(unnumbered, ts, unnumbered.Info, "plain"); log.Seq != 0 {
+		t.Errorf("Seq = %d without WithSequenceNumbers, want 0", log.Seq)
+	}
+}
+
 // TestClientOnErrorCallback tests the OnError callback.
 func TestClientOnErrorCallback(t *testing.T) {
 	var errorReceived *Error
@@ -635,6 +1227,232 @@ func TestClientOnFlushCallback(t *testing.T) {
 	}
 }
 
+// TestClientLifecycleCallbacks tests the OnRetry and OnBatchSent callbacks.
+func TestClientLifecycleCallbacks(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var requests atomic.Int32
+	ts.handler = func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"accepted":2}`))
+	}
+
+	var retries []int
+	var retryErr error
+	var accepted int
+	var latency time.Duration
+	client := createTestClient(t, ts,
+		WithMaxRetries(2),
+		WithOnRetry(func(attempt int, err error) {
+			retries = append(retries, attempt)
+			retryErr = err
+		}),
+		WithOnBatchSent(func(n int, d time.Duration) {
+			accepted, latency = n, d
+		}),
+	)
+	defer client.Shutdown(context.Background())
+
+	client.Info("one")
+	client.Info("two")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if len(retries) != 1 || retries[0] != 1 {
+		t.Errorf("OnRetry attempts = %v, want [1]", retries)
+	}
+	if lwErr, ok := retryErr.(*Error); !ok || lwErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("OnRetry err = %v, want 503", retryErr)
+	}
+	if accepted != 2 || latency <= 0 {
+		t.Errorf("OnBatchSent = (%d, %v), want (2, > 0)", accepted, latency)
+	}
+}
+
+// TestClientSplitsPayloadTooLarge tests that a batch rejected with 413 is
+// bisected and resent, and that an entry too large on its own is passed to
+// OnDeadLetter instead of being re-queued.
+func TestClientSplitsPayloadTooLarge(t *testing.T) {
+	var mu sync.Mutex
+	var sizes []int
+	var delivered []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entries := decodeRequestBody(t, r)
+		mu.Lock()
+		defer mu.Unlock()
+		sizes = append(sizes, len(entries))
+		for _, e := range entries {
+			if len(entries) > 2 || e.Message == "huge" {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+		}
+		for _, e := range entries {
+			delivered = append(delivered, e.Message)
+		}
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: len(entries)})
+	}))
+	defer server.Close()
+
+	var dead []LogEntry
+	var deadErr *Error
+	var onError []*Error
+	client, err := New(server.URL, validAPIKey(),
+		WithOnDeadLetter(func(e LogEntry, err *Error) {
+			dead = append(dead, e)
+			deadErr = err
+		}),
+		WithOnError(func(err *Error) { onError = append(onError, err) }),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	for _, msg := range []string{"a", "b", "huge", "c", "d"} {
+		client.Info(msg)
+	}
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// [a b huge c d] -> [a b] + [huge c d] -> [huge] + [c d]
+	if want := []int{5, 2, 3, 1, 2}; !slices.Equal(sizes, want) {
+		t.Errorf("request sizes = %v, want %v", sizes, want)
+	}
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(delivered, want) {
+		t.Errorf("delivered = %v, want %v", delivered, want)
+	}
+	if len(dead) != 1 || dead[0].Message != "huge" {
+		t.Fatalf("OnDeadLetter entries = %v, want [huge]", dead)
+	}
+	if deadErr.Code != ErrPayloadTooLarge || deadErr.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("OnDeadLetter err = %v, want PAYLOAD_TOO_LARGE 413", deadErr)
+	}
+	if len(onError) != 1 || onError[0].Code != ErrPayloadTooLarge {
+		t.Errorf("OnError = %v, want one PAYLOAD_TOO_LARGE", onError)
+	}
+	if stats := client.Stats(); stats.Queued != 0 || stats.BatchesSent != 2 || stats.BatchesFailed != 0 {
+		t.Errorf("Queued/BatchesSent/BatchesFailed = %d/%d/%d, want 0/2/0", stats.Queued, stats.BatchesSent, stats.BatchesFailed)
+	}
+}
+
+// TestClientSplitRequeuesUndelivered tests that when a split part fails
+// for another reason, only the entries not yet delivered are re-queued.
+func TestClientSplitRequeuesUndelivered(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entries := decodeRequestBody(t, r)
+		switch requests.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		case 2:
+			json.NewEncoder(w).Encode(IngestResponse{Accepted: len(entries)})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(server.URL, validAPIKey())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		client.Info(msg)
+	}
+	if err := client.Flush(context.Background()); err == nil {
+		t.Fatal("Flush() error = nil, want 400 from the second half")
+	}
+	if got := client.Stats().Queued; got != 2 {
+		t.Errorf("Queued = %d, want the 2 undelivered entries", got)
+	}
+}
+
+// TestClientOnDropCallback tests that OnDrop receives each discarded entry.
+func TestClientOnDropCallback(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		strategy OverflowStrategy
+		want     string
+	}{
+		{"DropOldest", DropOldest, "m1,m2"},
+		{"DropNewest", DropNewest, "m3,m4"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer()
+			defer ts.Close()
+
+			var dropped []string
+			client := createTestClient(t, ts,
+				WithBatchSize(10),
+				WithMaxQueueSize(2),
+				WithOverflowStrategy(tt.strategy),
+				WithOnDrop(func(e LogEntry) { dropped = append(dropped, e.Message) }),
+			)
+			defer client.Shutdown(context.Background())
+
+			for i := 1; i <= 4; i++ {
+				client.Infof("m%d", i)
+			}
+			client.drainRing(false) // overflow is applied as the ring drains
+			if got := strings.Join(dropped, ","); got != tt.want {
+				t.Errorf("dropped = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestClientFormattedLogging tests the printf-style level methods.
+func TestClientFormattedLogging(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithBatchSize(5), WithCaptureSourceLocation(true), WithFatalBehavior(LogOnly))
+	defer client.Shutdown(context.Background())
+
+	client.Debugf("debug %d", 1)
+	client.Infof("info %s", "two")
+	client.Warnf("warn %v", 3.5)
+	client.Errorf("error %q", "four")
+	client.Fatalf("fatal %t", true)
+	time.Sleep(100 * time.Millisecond)
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 5)
+
+	want := []struct {
+		level   LogLevel
+		message string
+	}{
+		{LevelDebug, "debug 1"},
+		{LevelInfo, "info two"},
+		{LevelWarn, "warn 3.5"},
+		{LevelError, `error "four"`},
+		{LevelFatal, "fatal true"},
+	}
+	for i, w := range want {
+		if i >= len(logs) {
+			break
+		}
+		if logs[i].Level != w.level || logs[i].Message != w.message {
+			t.Errorf("log[%d] = (%q, %q), want (%q, %q)", i, logs[i].Level, logs[i].Message, w.level, w.message)
+		}
+		if !strings.HasSuffix(logs[i].SourceFile, "client_test.go") {
+			t.Errorf("log[%d] SourceFile = %q, want caller file", i, logs[i].SourceFile)
+		}
+	}
+}
+
 // TestClientSourceLocation tests source location capture.
 func TestClientSourceLocation(t *testing.T) {
 	ts := newTestServer()
@@ -867,6 +1685,7 @@ func TestClientFullFlow(t *testing.T) {
 		WithBatchSize(5),
 		WithFlushInterval(500*time.Millisecond),
 		WithMetadata(M{"test": "integration"}),
+		WithFatalBehavior(LogOnly),
 	)
 	if err != nil {
 		t.Fatalf("New() error = %v", err)
@@ -986,8 +1805,8 @@ func TestClientRequeueOnFailure(t *testing.T) {
 	client.Info("message 3")
 
 	// Verify entries are queued
-	if client.queue.size() != 3 {
-		t.Fatalf("expected 3 entries in queue, got %d", client.queue.size())
+	if got := client.Stats().Queued; got != 3 {
+		t.Fatalf("expected 3 entries in queue, got %d", got)
 	}
 
 	// Flush should fail
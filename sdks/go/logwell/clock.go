@@ -0,0 +1,57 @@
+package logwell
+
+import (
+	"context"
+	"time"
+)
+
+// Clock is the client's source of time: entry timestamps, flush timers,
+// Block overflow timeouts, retry waits and deadlines, Retry-After dates,
+// send latencies, the circuit breaker cooldown, fallback endpoint probes,
+// and the rate limit, retry budget, and upload rate refills read it.
+// Replace it with WithClock to test batching and retry behavior without
+// real sleeps; logwelltest.Clock is a manually advanced implementation.
+// Request signatures always use the system clock, since the server checks
+// them against its own.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// AfterFunc calls f in its own goroutine once d has elapsed, like
+	// time.AfterFunc.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer created by Clock.AfterFunc. *time.Timer implements it.
+type Timer interface {
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// systemClock is the Clock backed by package time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer { return time.AfterFunc(d, f) }
+
+// clockOrSystem returns clock, or the system clock if it is nil.
+func clockOrSystem(clock Clock) Clock {
+	if clock == nil {
+		return systemClock{}
+	}
+	return clock
+}
+
+// sleep waits d on clock, returning ctx.Err() if ctx is done first.
+func sleep(ctx context.Context, clock Clock, d time.Duration) error {
+	done := make(chan struct{})
+	timer := clock.AfterFunc(d, func() { close(done) })
+	select {
+	case <-ctx.Done():
+		timer.Stop()
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
@@ -0,0 +1,135 @@
+package logwell
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClockOrSystem(t *testing.T) {
+	if got := clockOrSystem(nil); got != (systemClock{}) {
+		t.Errorf("clockOrSystem(nil) = %v, want systemClock{}", got)
+	}
+	clock := stubClock{}
+	if got := clockOrSystem(clock); got != clock {
+		t.Errorf("clockOrSystem(clock) = %v, want clock", got)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	if err := sleep(context.Background(), systemClock{}, time.Millisecond); err != nil {
+		t.Errorf("sleep() error = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleep(ctx, systemClock{}, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("sleep() with a canceled context error = %v, want context.Canceled", err)
+	}
+}
+
+// stubClock is a Clock frozen at the zero time whose timers never fire.
+type stubClock struct{}
+
+func (stubClock) Now() time.Time { return time.Time{} }
+
+func (stubClock) AfterFunc(time.Duration, func()) Timer { return stubTimer{} }
+
+type stubTimer struct{}
+
+func (stubTimer) Stop() bool               { return true }
+func (stubTimer) Reset(time.Duration) bool { return true }
+
+// TestClientClockTimestamps tests that generated timestamps read the
+// client's Clock.
+func TestClientClockTimestamps(t *testing.T) {
+	var got []LogEntry
+	client, err := New(validEndpoint(), validAPIKey(), WithClock(stubClock{}),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			got = append(got, logs...)
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("hello")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Timestamp != "0001-01-01T00:00:00Z" {
+		t.Errorf("sent %+v, want one entry stamped at the zero time", got)
+	}
+}
+
+// TestClientClockLatency tests that send latencies are measured on the
+// client's Clock.
+func TestClientClockLatency(t *testing.T) {
+	clock := &skipClock{now: time.Unix(0, 0)}
+	latencies := make(chan time.Duration, 1)
+	client, err := New(validEndpoint(), validAPIKey(), WithClock(clock),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			clock.mu.Lock()
+			clock.now = clock.now.Add(250 * time.Millisecond)
+			clock.mu.Unlock()
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})),
+		WithOnBatchSent(func(_ int, latency time.Duration) { latencies <- latency }))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("hello")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got := <-latencies; got != 250*time.Millisecond {
+		t.Errorf("latency = %v, want 250ms on the client clock", got)
+	}
+}
+
+// TestClientClockBlockTimeout tests that the Block overflow timeout runs
+// on the client's Clock.
+func TestClientClockBlockTimeout(t *testing.T) {
+	release := make(chan struct{})
+	var dropped atomic.Int32
+	client, err := New(validEndpoint(), validAPIKey(),
+		WithClock(&skipClock{now: time.Unix(0, 0)}),
+		WithBatchSize(1),
+		WithSenderConcurrency(1),
+		WithMaxQueueSize(1),
+		WithOverflowStrategy(Block(time.Hour)),
+		WithTransport(transportFunc(func(ctx context.Context, logs []LogEntry) (*IngestResponse, error) {
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			return nil, nil
+		})),
+		WithOnDrop(func(LogEntry) { dropped.Add(1) }))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+	defer close(release)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 5 {
+			client.Info("message")
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("log calls waited on the system clock")
+	}
+	if dropped.Load() == 0 {
+		t.Error("no entry was dropped after the Block timeout")
+	}
+}
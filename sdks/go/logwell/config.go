@@ -1,9 +1,17 @@
 package logwell
 
 import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
 	"net/http"
 	"net/url"
+	"os"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -13,6 +21,11 @@ const (
 	DefaultFlushInterval = 5 * time.Second
 	DefaultMaxQueueSize  = 1000
 	DefaultMaxRetries    = 3
+
+	DefaultSenderConcurrency = 2
+	DefaultMaxRetryAfter     = 30 * time.Second
+	DefaultRequestTimeout    = 10 * time.Second
+	DefaultSyncTimeout       = 2 * time.Second
 )
 
 // Validation bounds.
@@ -21,12 +34,95 @@ const (
 	MaxBatchSize     = 500
 	MinFlushInterval = 100 * time.Millisecond
 	MaxFlushInterval = 60 * time.Second
+	MaxFlushJitter   = 0.5
 	MinMaxQueueSize  = 1
 	MaxMaxQueueSize  = 10000
 	MinMaxRetries    = 0
 	MaxMaxRetries    = 10
+
+	MinSenderConcurrency = 1
+	MaxSenderConcurrency = 32
+
+	MinPersistentQueueBytes = 1024
+
+	MinFallbackFileSize  = 1024
+	MinFallbackFileFiles = 1
+	MaxFallbackFileFiles = 100
+
+	MinMaxRetryAfter = 0
+	MaxMaxRetryAfter = 10 * time.Minute
+
+	MinRequestTimeout = 100 * time.Millisecond
+	MaxRequestTimeout = 5 * time.Minute
+
+	MinRetryDeadline = 100 * time.Millisecond
+	MaxRetryDeadline = 10 * time.Minute
+
+	MinUploadRate = 1024
+
+	MinDedupeWindow = 100 * time.Millisecond
+	MaxDedupeWindow = 10 * time.Minute
+
+	MinRuntimeStatsInterval = time.Second
+	MaxRuntimeStatsInterval = 24 * time.Hour
+
+	MinSyncTimeout = 100 * time.Millisecond
+	MaxSyncTimeout = time.Minute
+
+	MinMaxMessageBytes  = 16
+	MinMaxMetadataBytes = 64
+
+	MinCircuitBreakerThreshold = 1
+	MaxCircuitBreakerThreshold = 100
+	MinCircuitBreakerCooldown  = 100 * time.Millisecond
+	MaxCircuitBreakerCooldown  = 10 * time.Minute
+)
+
+// OverflowStrategy controls what happens when a log call finds the queue
+// at MaxQueueSize. Use DropOldest, DropNewest, or Block.
+type OverflowStrategy struct {
+	kind    overflowKind
+	timeout time.Duration
+}
+
+type overflowKind uint8
+
+const (
+	overflowDropOldest overflowKind = iota
+	overflowDropNewest
+	overflowBlock
+)
+
+var (
+	// DropOldest evicts the oldest queued entry to make room for the new one.
+	// This is the default, favoring recent logs.
+	DropOldest = OverflowStrategy{kind: overflowDropOldest}
+
+	// DropNewest discards the new entry and keeps the queue unchanged,
+	// shedding load without touching history.
+	DropNewest = OverflowStrategy{kind: overflowDropNewest}
 )
 
+// Block makes log calls wait up to timeout for the background sender to
+// free queue space, applying backpressure to the caller. If no space frees
+// up in time, the new entry is discarded as with DropNewest.
+// timeout must be positive.
+func Block(timeout time.Duration) OverflowStrategy {
+	return OverflowStrategy{kind: overflowBlock, timeout: timeout}
+}
+
+// String returns a readable name such as "drop-oldest" or "block(2s)".
+func (s OverflowStrategy) String() string {
+	switch s.kind {
+	case overflowDropNewest:
+		return "drop-newest"
+	case overflowBlock:
+		return "block(" + s.timeout.String() + ")"
+	default:
+		return "drop-oldest"
+	}
+}
+
 // apiKeyRegex matches valid Logwell API keys: lw_ followed by exactly 32 alphanumeric chars including - and _.
 var apiKeyRegex = regexp.MustCompile(`^lw_[a-zA-Z0-9_-]{32}$`)
 
@@ -38,12 +134,37 @@ type Config struct {
 	// APIKey is the Logwell API key (required).
 	APIKey string
 
+	// SkipAPIKeyValidation accepts API keys, including those of
+	// LevelRouting, that do not match the lw_ format, for self-hosted
+	// servers that mint their own. Keys must still be non-empty and free
+	// of whitespace and control characters. Default: false.
+	SkipAPIKeyValidation bool
+
 	// Service is the service name to attach to all logs.
 	Service string
 
 	// Metadata is default metadata to attach to all logs.
 	Metadata map[string]any
 
+	// RuntimeMetadata adds host and process details (see WithRuntimeMetadata)
+	// to Metadata. Default: false.
+	RuntimeMetadata bool
+
+	// StartupEvent logs one StartupMessage entry when the client is
+	// created, describing the SDK, host, and configuration; see
+	// WithStartupEvent. Default: false.
+	StartupEvent bool
+
+	// RuntimeStatsInterval, if positive, logs a RuntimeStatsMessage entry
+	// with Go runtime metrics at this interval; see WithRuntimeStats.
+	// Default: 0 (disabled), Range: 1s-24h.
+	RuntimeStatsInterval time.Duration
+
+	// MinLevel drops entries below this level before they are queued.
+	// It can be changed at runtime with Client.SetLevel.
+	// Default: LevelDebug (everything is sent).
+	MinLevel LogLevel
+
 	// BatchSize is the number of logs to batch before sending.
 	// Default: 50, Range: 1-500.
 	BatchSize int
@@ -52,6 +173,11 @@ type Config struct {
 	// Default: 5s, Range: 100ms-60s.
 	FlushInterval time.Duration
 
+	// FlushJitter randomizes each flush timer interval by up to this
+	// fraction of FlushInterval either way, so instances started together
+	// do not flush in lockstep. Default: 0, Range: 0-0.5.
+	FlushJitter float64
+
 	// MaxQueueSize is the maximum number of logs to hold in queue.
 	// Default: 1000, Range: 1-10000.
 	MaxQueueSize int
@@ -60,19 +186,304 @@ type Config struct {
 	// Default: 3, Range: 0-10.
 	MaxRetries int
 
+	// RequestTimeout bounds each send attempt, separately from the overall
+	// retry budget, so a hung server fails the attempt and leaves time to
+	// retry instead of stalling the whole send.
+	// Default: 10s, Range: 100ms-5m.
+	RequestTimeout time.Duration
+
+	// Backoff computes the delay before each retry.
+	// Default: ExponentialBackoff(200ms, 10s, 0.3).
+	Backoff BackoffPolicy
+
+	// RetryDeadline bounds the time spent retrying one batch: no retry
+	// starts, or waits out a backoff, past this long after the first attempt.
+	// Default: 0 (bounded only by MaxRetries and, for Flush, its context),
+	// Range: 100ms-10m.
+	RetryDeadline time.Duration
+
+	// RetryBudget is the number of retries per second shared by all
+	// batches, with bursts of RetryBudgetBurst. When it is spent, failed
+	// sends are not retried, so an outage cannot multiply the load on the
+	// server or stall Flush and Shutdown behind retries of every batch.
+	// Default: 0 (unlimited).
+	RetryBudget float64
+
+	// RetryBudgetBurst is the number of retries allowed at once before
+	// RetryBudget applies. Must be at least 1 when RetryBudget is set.
+	RetryBudgetBurst int
+
+	// MaxRetryAfter caps how long a retry waits when a 429 or 503 response
+	// carries a Retry-After header. The header value (seconds or HTTP date)
+	// becomes the floor for the next backoff delay. 0 ignores the header.
+	// Default: 30s, Range: 0-10m.
+	MaxRetryAfter time.Duration
+
+	// CircuitBreakerThreshold is the number of consecutive failed sends
+	// (after retries) that opens the circuit breaker. While open, sends are
+	// skipped and entries stay queued, subject to OverflowStrategy.
+	// Default: 0 (disabled), Range: 1-100 when enabled.
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is how long the circuit stays open before a
+	// single probe send is allowed through (half-open).
+	// Range: 100ms-10m when the breaker is enabled.
+	CircuitBreakerCooldown time.Duration
+
+	// OverflowStrategy decides what a log call does when the queue is full.
+	// Default: DropOldest.
+	OverflowStrategy OverflowStrategy
+
+	// Processors run in order on every entry before it is queued; see
+	// Processor.
+	// Default: nil.
+	Processors []Processor
+
+	// ContextExtractors pull metadata from the context passed to the *Ctx
+	// logging methods, in order; see ContextExtractor.
+	// Default: nil.
+	ContextExtractors []ContextExtractor
+
+	// Redaction lists rules scrubbing sensitive data from the message and
+	// metadata of every entry before it is queued.
+	// Default: nil (no redaction).
+	Redaction []RedactRule
+
+	// Sampler decides which entries at or above MinLevel are sent.
+	// Default: nil (every entry is sent).
+	Sampler Sampler
+
+	// RateLimit is the number of entries per second allowed for each
+	// RateLimitKey. Entries over the limit are suppressed and summarized
+	// every RateLimitSummaryInterval.
+	// Default: 0 (disabled).
+	RateLimit float64
+
+	// RateLimitBurst is the number of entries per key allowed at once before
+	// RateLimit applies. Must be at least 1 when RateLimit is set.
+	RateLimitBurst int
+
+	// RateLimitKey groups entries for rate limiting.
+	// Default: RateLimitKey (same level and message).
+	RateLimitKey func(LogEntry) string
+
+	// DedupeWindow collapses entries with the same DedupeKey logged within
+	// this window into one, carrying a "count" metadata field.
+	// Default: 0 (disabled), Range: 100ms-10m.
+	DedupeWindow time.Duration
+
+	// DedupeKey groups entries for DedupeWindow.
+	// Default: RateLimitKey (same level and message).
+	DedupeKey func(LogEntry) string
+
+	// SyncMode sends each entry on the logging goroutine, before the log
+	// call returns, instead of batching it for the background sender; see
+	// WithSyncMode. Default: false.
+	SyncMode bool
+
+	// SyncTimeout bounds each synchronous send in SyncMode, including
+	// retries. Default: 2s, Range: 100ms-1m.
+	SyncTimeout time.Duration
+
+	// SenderConcurrency is the number of background workers sending batches.
+	// Log calls never block on the network; they hand full batches to these workers.
+	// Default: 2, Range: 1-32.
+	SenderConcurrency int
+
+	// PersistentQueueDir enables a write-ahead log in this directory. Every
+	// admitted entry is also written to disk and removed once delivered, so
+	// entries survive process crashes and endpoint outages and are replayed
+	// by the next client opened on the same directory.
+	// Default: "" (in-memory queue only).
+	PersistentQueueDir string
+
+	// PersistentQueueMaxBytes caps the disk space used by the persistent queue.
+	// When exceeded, the oldest undelivered entries are dropped from disk.
+	// Required when PersistentQueueDir is set; must be at least 1KB.
+	PersistentQueueMaxBytes int64
+
+	// FallbackFile is an NDJSON file that batches are appended to when the
+	// circuit breaker is open or a send fails after all retries, for a later
+	// Client.ReplayFallback. Default: "" (failed batches are re-queued).
+	FallbackFile string
+
+	// FallbackFileMaxSize is the size at which FallbackFile is rotated.
+	// Required when FallbackFile is set; must be at least 1KB.
+	FallbackFileMaxSize int64
+
+	// FallbackFileMaxFiles is the number of fallback files kept, including
+	// the active one; the oldest is deleted on rotation beyond it.
+	// Range: 1-100.
+	FallbackFileMaxFiles int
+
 	// CaptureSourceLocation enables capturing source file and line number.
 	// Default: false.
 	CaptureSourceLocation bool
 
+	// CallerSkip is the number of extra stack frames skipped when capturing
+	// the source location and stack trace, for wrapper libraries.
+	// Default: 0.
+	CallerSkip int
+
+	// FatalBehavior controls what Fatal, Fatalf, and FatalFields do after
+	// logging. Default: ExitProcess.
+	FatalBehavior FatalBehavior
+
+	// ExitFunc is called by Fatal under ExitProcess. Default: os.Exit.
+	ExitFunc func(code int)
+
+	// StackTraceLevel attaches the caller's goroutine stack, in "stack"
+	// metadata, to entries at or above this level.
+	// Default: "" (disabled).
+	StackTraceLevel LogLevel
+
+	// MaxMessageBytes truncates longer messages to this many bytes (on a
+	// UTF-8 boundary) and marks the entry with "truncated": true metadata.
+	// Default: 0 (unlimited). Minimum: 16.
+	MaxMessageBytes int
+
+	// MaxMetadataBytes bounds the JSON size of an entry's metadata. The
+	// largest values are replaced with a size summary, and dropped if that
+	// is not enough, until it fits; the entry is marked "truncated": true.
+	// Default: 0 (unlimited). Minimum: 64.
+	MaxMetadataBytes int
+
+	// NameInMessage prefixes the message of entries from Named loggers with
+	// the logger name, as in "payments.worker: charge failed".
+	// Default: false (the name is only in "logger" metadata).
+	NameInMessage bool
+
+	// TimestampFormat is the format of timestamps generated for entries
+	// logged without one. The Unix formats require a custom Transport.
+	// Default: TimestampRFC3339Nano.
+	TimestampFormat TimestampFormat
+
+	// Clock is the source of time for timestamps, flush timers, and retry
+	// waits; see Clock. Default: nil (the system clock).
+	Clock Clock
+
+	// SequenceNumbers sets LogEntry.Seq on every queued entry: 1, 2, 3, ...
+	// per client, shared with its child loggers. Default: false.
+	SequenceNumbers bool
+
+	// SigningSecret, if set, signs every ingest request: an HMAC-SHA256 of
+	// the timestamp and body is sent in the X-Logwell-Signature header, for
+	// gateways that verify payload integrity beyond the API key.
+	// Default: "" (unsigned). Minimum length: 16.
+	SigningSecret string
+
+	// Transport replaces the HTTP sender; batches are handed to it instead
+	// of being posted to Endpoint. HTTP-specific options (HTTPClient,
+	// Headers, Proxy, TLSConfig, FallbackEndpoints) then have no effect.
+	// Default: nil (HTTP).
+	Transport Transport
+
+	// MaxUploadRate caps the request body bytes sent per second, averaged
+	// over retries and all sender workers, so log shipping leaves room for
+	// the workload on a thin uplink; see WithMaxUploadRate.
+	// Default: 0 (unlimited), Min: MinUploadRate.
+	MaxUploadRate int
+
 	// HTTPClient is a custom HTTP client for making requests.
 	// Default: http.DefaultClient.
 	HTTPClient *http.Client
 
+	// FallbackEndpoints are alternate Logwell instances, tried in order when
+	// Endpoint is unreachable or failing. While on a fallback, the primary is
+	// probed periodically and sends fail back once it succeeds.
+	// Default: none.
+	FallbackEndpoints []string
+
+	// LevelRouting sends entries at the given levels to another project,
+	// through a separate queue and transport. Unlisted levels go to Endpoint.
+	// Default: none.
+	LevelRouting map[LogLevel]RouteConfig
+
+	// LocalSink receives a copy of every entry, in LocalSinkFormat, in
+	// addition to shipping it, so logs stay visible in container output and
+	// during outages. Default: nil.
+	LocalSink io.Writer
+
+	// LocalSinkFormat is the format of LocalSink. Default: SinkText.
+	LocalSinkFormat SinkFormat
+
+	// Proxy is the URL (http, https, or socks5) of the proxy used for
+	// ingest requests. Default: "" (the environment's HTTP_PROXY settings).
+	Proxy string
+
+	// TLSConfig configures TLS for ingest requests, e.g. a custom CA pool
+	// for internal PKI or a client certificate for mTLS.
+	// Default: nil (system roots).
+	TLSConfig *tls.Config
+
+	// TransportTuning configures connection pooling and dial/TLS timeouts
+	// of the default transport, so high-throughput clients keep connections
+	// alive instead of paying a handshake per request.
+	// Default: nil (DefaultTransportTuning).
+	TransportTuning *TransportTuning
+
+	// Headers are extra HTTP headers sent with every ingest request, such as
+	// tenant or routing headers required by a gateway. A User-Agent entry
+	// replaces the SDK's default; Authorization, Content-Type, and
+	// Idempotency-Key are reserved.
+	Headers map[string]string
+
+	// ExpvarPrefix, if set, publishes the client's stats as expvar
+	// variables named "<prefix>.sent" and so on; see WithExpvar.
+	// Default: "" (not published).
+	ExpvarPrefix string
+
+	// DebugLogger receives the SDK's own activity (flushes, sent and failed
+	// batches, retries, circuit breaker transitions), for troubleshooting
+	// delivery. Default: nil (silent).
+	DebugLogger *slog.Logger
+
 	// OnError is called when an error occurs during logging.
 	OnError func(*Error)
 
 	// OnFlush is called after a successful flush with the count of logs sent.
 	OnFlush func(int)
+
+	// OnDrop is called with each entry discarded because the queue was full.
+	// It runs as entries are moved into the queue, usually on a background
+	// goroutine, and must not block or log through the client.
+	OnDrop func(LogEntry)
+
+	// OnDeadLetter is called with each entry dropped because the server
+	// rejected it as too large (413) even when sent on its own.
+	OnDeadLetter func(entry LogEntry, err *Error)
+
+	// OnRetry is called before each retry of a failed send with the retry
+	// number (starting at 1) and the error that caused it.
+	OnRetry func(attempt int, err error)
+
+	// OnBatchSent is called after each batch the server accepts with the
+	// accepted count and the send latency, including retries.
+	OnBatchSent func(accepted int, latency time.Duration)
+
+	// OnBatchSentInfo is called alongside OnBatchSent with details of the
+	// accepted batch, including its idempotency key for correlating with
+	// server-side records.
+	OnBatchSentInfo func(BatchInfo)
+}
+
+// BatchInfo describes a batch the server accepted.
+type BatchInfo struct {
+	// IdempotencyKey is the key sent with every attempt of the batch.
+	IdempotencyKey string
+
+	// Entries is the number of entries in the batch.
+	Entries int
+
+	// Accepted is the number of entries the server accepted.
+	Accepted int
+
+	// Latency is the send latency, including retries.
+	Latency time.Duration
+
+	// IDs are the IDs the server assigned to the accepted entries, if it
+	// reports them (IngestResponse.IDs).
+	IDs []string
 }
 
 // Option is a functional option for configuring the client.
@@ -94,6 +505,18 @@ func WithFlushInterval(d time.Duration) Option {
 	}
 }
 
+// WithFlushJitter spreads flushes out in time: each flush timer runs for
+// FlushInterval scaled by a random factor between 1-fraction and
+// 1+fraction, so a fleet of instances started by the same deploy does not
+// flush in lockstep and hit the server with synchronized bursts. The
+// average interval is unchanged. Must be between 0 and 0.5; 0.1 to 0.2 is
+// usually enough.
+func WithFlushJitter(fraction float64) Option {
+	return func(c *Config) {
+		c.FlushJitter = fraction
+	}
+}
+
 // WithMaxQueueSize sets the maximum queue size.
 // Must be between 1 and 10000.
 func WithMaxQueueSize(n int) Option {
@@ -110,6 +533,193 @@ func WithMaxRetries(n int) Option {
 	}
 }
 
+// WithRequestTimeout sets the timeout of each send attempt.
+// Must be between 100ms and 5m.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(c *Config) {
+		c.RequestTimeout = d
+	}
+}
+
+// WithMaxRetryAfter caps the delay honored from a Retry-After header.
+// Must be between 0 (ignore the header) and 10m.
+func WithMaxRetryAfter(d time.Duration) Option {
+	return func(c *Config) {
+		c.MaxRetryAfter = d
+	}
+}
+
+// WithCircuitBreaker enables a circuit breaker that opens after threshold
+// consecutive failed sends and probes the endpoint again after cooldown.
+// State transitions are reported to OnError with code ErrCircuitStateChange.
+// threshold must be between 1 and 100; cooldown between 100ms and 10m.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) Option {
+	return func(c *Config) {
+		c.CircuitBreakerThreshold = threshold
+		c.CircuitBreakerCooldown = cooldown
+	}
+}
+
+// WithOverflowStrategy sets what happens when the queue is full:
+// DropOldest (default), DropNewest, or Block(timeout).
+func WithOverflowStrategy(s OverflowStrategy) Option {
+	return func(c *Config) {
+		c.OverflowStrategy = s
+	}
+}
+
+// WithProcessor appends p to the processor chain. Processors run in the
+// order they were added.
+func WithProcessor(p Processor) Option {
+	return func(c *Config) {
+		c.Processors = append(c.Processors, p)
+	}
+}
+
+// WithContextExtractor appends fn to the extractors that the *Ctx logging
+// methods (InfoCtx and friends) run on their context, so request-scoped
+// values reach every entry without being passed by hand. Extracted
+// metadata overrides the logger's metadata and is overridden by metadata
+// passed to the call; later extractors override earlier ones.
+func WithContextExtractor(fn ContextExtractor) Option {
+	return func(c *Config) {
+		c.ContextExtractors = append(c.ContextExtractors, fn)
+	}
+}
+
+// WithRedaction scrubs sensitive data from every entry before it is queued,
+// so it never leaves the process. Rules accumulate across calls.
+//
+// Example:
+//
+//	logwell.WithRedaction(logwell.RedactCommonKeys, logwell.RedactEmails, logwell.RedactJWTs)
+func WithRedaction(rules ...RedactRule) Option {
+	return func(c *Config) {
+		c.Redaction = append(c.Redaction, rules...)
+	}
+}
+
+// WithSampler downsamples entries client-side. See FractionSampler,
+// CountSampler, and LevelSampler.
+func WithSampler(s Sampler) Option {
+	return func(c *Config) {
+		c.Sampler = s
+	}
+}
+
+// WithRateLimit throttles noisy log lines: each key may log burst entries
+// at once and perSecond entries per second after that. Suppressed entries
+// are counted and reported every RateLimitSummaryInterval in a summary
+// entry such as "suppressed 42 duplicates in 10s: <message>".
+// A nil key groups entries by level and message (RateLimitKey).
+func WithRateLimit(perSecond float64, burst int, key func(LogEntry) string) Option {
+	return func(c *Config) {
+		c.RateLimit = perSecond
+		c.RateLimitBurst = burst
+		c.RateLimitKey = key
+	}
+}
+
+// WithDedupe collapses identical entries logged within window into one,
+// so a tight error loop sends a single entry instead of thousands. The
+// first entry for a key is held for window; later entries with the same
+// key are counted and discarded, and when the window ends the first is
+// sent with "count": n metadata (omitted when n is 1). A nil key groups
+// entries by level and message (RateLimitKey). Flush and Shutdown send
+// held entries without waiting for their window. Must be between 100ms and
+// 10m.
+func WithDedupe(window time.Duration, key func(LogEntry) string) Option {
+	return func(c *Config) {
+		c.DedupeWindow = window
+		c.DedupeKey = key
+	}
+}
+
+// WithBackoff sets the policy for delays between retries: one of
+// ExponentialBackoff, ConstantBackoff, FibonacciBackoff, or a custom
+// BackoffPolicy. A nil policy restores the default,
+// ExponentialBackoff(200ms, 10s, 0.3).
+func WithBackoff(policy BackoffPolicy) Option {
+	return func(c *Config) {
+		c.Backoff = policy
+	}
+}
+
+// WithRetryDeadline bounds the total time spent retrying a batch, measured
+// from its first attempt. Must be between 100ms and 10m.
+func WithRetryDeadline(d time.Duration) Option {
+	return func(c *Config) {
+		c.RetryDeadline = d
+	}
+}
+
+// WithRetryBudget limits retries across all batches to perSecond per second,
+// with bursts of up to burst, like a token bucket. A send that finds the
+// budget spent fails with its last error instead of retrying.
+func WithRetryBudget(perSecond float64, burst int) Option {
+	return func(c *Config) {
+		c.RetryBudget = perSecond
+		c.RetryBudgetBurst = burst
+	}
+}
+
+// WithSenderConcurrency sets the number of background workers sending batches.
+// Must be between 1 and 32.
+func WithSenderConcurrency(n int) Option {
+	return func(c *Config) {
+		c.SenderConcurrency = n
+	}
+}
+
+// WithSyncMode sends every entry before the log call returns, for
+// serverless runtimes (AWS Lambda, Cloud Functions) that may freeze the
+// process as soon as the handler returns, stranding queued entries. Each
+// send, retries included, is bounded by SyncTimeout; an entry that fails
+// stays queued for the next Flush or Shutdown. Cannot be combined with
+// WithPersistentQueue.
+func WithSyncMode() Option {
+	return func(c *Config) {
+		c.SyncMode = true
+	}
+}
+
+// WithSyncTimeout sets the time a log call may spend sending in sync mode.
+// Must be between 100ms and 1m.
+func WithSyncTimeout(d time.Duration) Option {
+	return func(c *Config) {
+		c.SyncTimeout = d
+	}
+}
+
+// WithPersistentQueue spools entries to a write-ahead log in dir, using at
+// most maxBytes of disk. Entries not yet delivered when the process crashes
+// or the endpoint is unreachable are replayed on the next startup.
+//
+// Delivery is at-least-once: after a crash, some entries may be sent twice.
+// Segments are synced to disk only when they fill up or the client shuts
+// down, so a power loss or OS crash may lose the most recent entries.
+// Use a dedicated directory per client; sharing one between live clients is
+// not supported.
+func WithPersistentQueue(dir string, maxBytes int64) Option {
+	return func(c *Config) {
+		c.PersistentQueueDir = dir
+		c.PersistentQueueMaxBytes = maxBytes
+	}
+}
+
+// WithFallbackFile spills batches that cannot be delivered (circuit open,
+// or retries exhausted on a transient error) to NDJSON files at path instead
+// of re-queueing them. The file rotates at maxSize bytes to path.1, path.2,
+// and so on, keeping at most maxFiles files. Resend them with
+// Client.ReplayFallback once the endpoint recovers.
+func WithFallbackFile(path string, maxSize int64, maxFiles int) Option {
+	return func(c *Config) {
+		c.FallbackFile = path
+		c.FallbackFileMaxSize = maxSize
+		c.FallbackFileMaxFiles = maxFiles
+	}
+}
+
 // WithService sets the service name attached to all logs.
 func WithService(s string) Option {
 	return func(c *Config) {
@@ -124,6 +734,77 @@ func WithMetadata(m map[string]any) Option {
 	}
 }
 
+// WithRuntimeMetadata annotates every entry with the hostname, PID, Go
+// version, GOOS/GOARCH, container ID (from /proc/self/cgroup), and the
+// Kubernetes pod, namespace, and node read from the POD_NAME,
+// POD_NAMESPACE, and NODE_NAME environment variables. Values are collected
+// once by New; those that cannot be determined are omitted. Metadata set
+// with WithMetadata takes precedence on conflicting keys.
+func WithRuntimeMetadata() Option {
+	return func(c *Config) {
+		c.RuntimeMetadata = true
+	}
+}
+
+// WithStartupEvent logs an INFO "sdk started" entry when New creates the
+// client, with the SDK version, host metadata (as WithRuntimeMetadata
+// collects it), and a summary of the configuration under "sdkConfig", so
+// it is easy to audit which services ship logs and with what settings.
+// The API key, signing secret, and header values are never included. The
+// entry is sent even when the minimum level is above INFO.
+func WithStartupEvent() Option {
+	return func(c *Config) {
+		c.StartupEvent = true
+	}
+}
+
+// WithRuntimeStats logs an INFO "runtime stats" entry every interval with
+// the goroutine count, heap in use, heap object count, GC count and total
+// pause time, and, on Linux, the number of open file descriptors, giving
+// lightweight process monitoring without another agent. Like
+// WithStartupEvent, the entries are sent even when the minimum level is
+// above INFO. Reporting stops at Shutdown. Must be between 1s and 24h.
+func WithRuntimeStats(interval time.Duration) Option {
+	return func(c *Config) {
+		c.RuntimeStatsInterval = interval
+	}
+}
+
+// WithMinLevel sets the minimum level; entries below it are discarded.
+func WithMinLevel(level LogLevel) Option {
+	return func(c *Config) {
+		c.MinLevel = level
+	}
+}
+
+// WithExpvar publishes the client's stats through the expvar package, so
+// services serving /debug/vars expose them without further setup:
+// <prefix>.sent, .failed, .dropped, .throttled, .retries, and .queued
+// mirror ClientStats, and <prefix>.flush_latency holds the send latency
+// histogram in milliseconds. An empty prefix uses DefaultExpvarPrefix.
+// Give each live client its own prefix; a client created with the prefix
+// of another takes over its variables. New fails with ErrInvalidConfig if
+// another package already published one of the names.
+func WithExpvar(prefix string) Option {
+	return func(c *Config) {
+		if prefix == "" {
+			prefix = DefaultExpvarPrefix
+		}
+		c.ExpvarPrefix = prefix
+	}
+}
+
+// WithDebugLogger reports the SDK's internal activity to logger: flushes
+// triggered, batches sent or failed, retries scheduled, and circuit
+// breaker transitions. Routine events are logged at slog.LevelDebug and
+// failures at slog.LevelWarn. Never pass a logger that writes back to this
+// client.
+func WithDebugLogger(logger *slog.Logger) Option {
+	return func(c *Config) {
+		c.DebugLogger = logger
+	}
+}
+
 // WithOnError sets the error callback.
 func WithOnError(fn func(*Error)) Option {
 	return func(c *Config) {
@@ -138,6 +819,43 @@ func WithOnFlush(fn func(int)) Option {
 	}
 }
 
+// WithOnDrop sets the callback for entries discarded on queue overflow.
+func WithOnDrop(fn func(LogEntry)) Option {
+	return func(c *Config) {
+		c.OnDrop = fn
+	}
+}
+
+// WithOnDeadLetter sets the callback for entries the server rejects as too
+// large to ingest.
+func WithOnDeadLetter(fn func(entry LogEntry, err *Error)) Option {
+	return func(c *Config) {
+		c.OnDeadLetter = fn
+	}
+}
+
+// WithOnRetry sets the callback invoked before each send retry.
+func WithOnRetry(fn func(attempt int, err error)) Option {
+	return func(c *Config) {
+		c.OnRetry = fn
+	}
+}
+
+// WithOnBatchSent sets the callback invoked after each accepted batch.
+func WithOnBatchSent(fn func(accepted int, latency time.Duration)) Option {
+	return func(c *Config) {
+		c.OnBatchSent = fn
+	}
+}
+
+// WithOnBatchSentInfo sets the callback invoked with details of each
+// accepted batch.
+func WithOnBatchSentInfo(fn func(BatchInfo)) Option {
+	return func(c *Config) {
+		c.OnBatchSentInfo = fn
+	}
+}
+
 // WithCaptureSourceLocation enables or disables source location capture.
 func WithCaptureSourceLocation(enabled bool) Option {
 	return func(c *Config) {
@@ -145,6 +863,129 @@ func WithCaptureSourceLocation(enabled bool) Option {
 	}
 }
 
+// WithCallerSkip skips n extra stack frames when capturing the source
+// location and stack trace of every entry, so a team's own logging facade
+// wrapping the client reports the facade's callers rather than itself.
+// Use 1 when each facade function calls the client directly. Child
+// loggers can add more with ChildWithCallerSkip, and a single call with
+// CallerSkip. Must not be negative.
+func WithCallerSkip(n int) Option {
+	return func(c *Config) {
+		c.CallerSkip = n
+	}
+}
+
+// WithFatalBehavior sets what Fatal does after logging: ExitProcess
+// (default), PanicAfterLog, or LogOnly.
+func WithFatalBehavior(b FatalBehavior) Option {
+	return func(c *Config) {
+		c.FatalBehavior = b
+	}
+}
+
+// WithExitFunc replaces os.Exit as the function Fatal calls under
+// ExitProcess, e.g. to observe exits in tests.
+func WithExitFunc(fn func(code int)) Option {
+	return func(c *Config) {
+		c.ExitFunc = fn
+	}
+}
+
+// WithStackTrace attaches a stack trace of the logging goroutine to every
+// entry at or above minLevel, in the "stack" metadata key. Each capture
+// walks the stack, so keep minLevel high (typically LevelError).
+func WithStackTrace(minLevel LogLevel) Option {
+	return func(c *Config) {
+		c.StackTraceLevel = minLevel
+	}
+}
+
+// WithMaxMessageBytes truncates messages longer than n bytes, marking the
+// entry with "truncated": true metadata. 0 disables the limit.
+func WithMaxMessageBytes(n int) Option {
+	return func(c *Config) {
+		c.MaxMessageBytes = n
+	}
+}
+
+// WithMaxMetadataBytes limits the JSON-encoded size of an entry's metadata
+// to n bytes by summarizing, then dropping, the largest values, so a stray
+// multi-megabyte value does not get the whole batch rejected.
+// 0 disables the limit.
+func WithMaxMetadataBytes(n int) Option {
+	return func(c *Config) {
+		c.MaxMetadataBytes = n
+	}
+}
+
+// WithNameInMessage prefixes messages from Named loggers with the logger
+// name, so it shows in views that display only the message.
+func WithNameInMessage(enabled bool) Option {
+	return func(c *Config) {
+		c.NameInMessage = enabled
+	}
+}
+
+// WithTimestampFormat sets the format of generated timestamps. The Logwell
+// ingest API parses RFC 3339 only, so TimestampUnixMillis and
+// TimestampUnixNanos are accepted only together with WithTransport.
+func WithTimestampFormat(format TimestampFormat) Option {
+	return func(c *Config) {
+		c.TimestampFormat = format
+	}
+}
+
+// WithClock replaces the system clock, so tests of batching and retry
+// behavior can advance time instead of sleeping.
+func WithClock(clock Clock) Option {
+	return func(c *Config) {
+		c.Clock = clock
+	}
+}
+
+// WithSequenceNumbers numbers queued entries in LogEntry.Seq, so consumers
+// can restore the exact order of entries whose timestamps collide at
+// millisecond resolution or are skewed. Numbers restart at 1 with each
+// client.
+func WithSequenceNumbers(enabled bool) Option {
+	return func(c *Config) {
+		c.SequenceNumbers = enabled
+	}
+}
+
+// WithRequestSigning signs every ingest request with secret. The
+// X-Logwell-Signature header carries "t=<unix seconds>,v1=<hex>", where the
+// hex value is HMAC-SHA256(secret, "<unix seconds>.<body>"). Must be at
+// least 16 characters.
+func WithRequestSigning(secret string) Option {
+	return func(c *Config) {
+		c.SigningSecret = secret
+	}
+}
+
+// WithTransport replaces the HTTP sender with t, for tests or for
+// delivering batches elsewhere (a message queue, another protocol). The
+// client's batching, retries, circuit breaker, and lifecycle still apply.
+func WithTransport(t Transport) Option {
+	return func(c *Config) {
+		c.Transport = t
+	}
+}
+
+// WithMaxUploadRate limits outgoing batches to bytesPerSec bytes of
+// request body per second, for edge and IoT devices that share a thin
+// uplink with their real work. Sends wait their turn in a token bucket
+// holding one second of bytes; a batch larger than that is sent whole and
+// delays the ones after it, so the average stays at the limit. Time spent
+// waiting counts against the Flush or Shutdown context but not the
+// request timeout. Headers and TLS overhead are not counted, and it has
+// no effect with WithTransport. Must be at least MinUploadRate.
+func WithMaxUploadRate(bytesPerSec int) Option {
+	return func(c *Config) {
+		c.MaxUploadRate = bytesPerSec
+	}
+}
+
 // WithHTTPClient sets a custom HTTP client.
 func WithHTTPClient(client *http.Client) Option {
 	return func(c *Config) {
@@ -152,6 +993,100 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithAPIKey sets the API key, replacing the one passed to New or read by
+// NewFromConfigFile.
+func WithAPIKey(key string) Option {
+	return func(c *Config) {
+		c.APIKey = key
+	}
+}
+
+// WithSkipAPIKeyValidation accepts API keys that do not match the
+// lw_ + 32 characters format of keys minted by Logwell, for self-hosted
+// deployments that issue their own. The server remains the judge of
+// whether a key is valid.
+func WithSkipAPIKeyValidation() Option {
+	return func(c *Config) {
+		c.SkipAPIKeyValidation = true
+	}
+}
+
+// WithEndpoints sets the primary endpoint, replacing the one passed to
+// New, and fallbacks to fail over to, in order, when it is unreachable or
+// returns 5xx responses. All endpoints share the API key.
+func WithEndpoints(primary string, fallbacks ...string) Option {
+	return func(c *Config) {
+		c.Endpoint = primary
+		c.FallbackEndpoints = fallbacks
+	}
+}
+
+// WithLevelRouting sends entries at the given levels to other projects, each
+// with its own API key (and optionally endpoint), queue, and transport.
+// Levels not in routes go to the client's own project.
+//
+// Example:
+//
+//	alerts := logwell.RouteConfig{APIKey: alertsKey}
+//	logwell.WithLevelRouting(map[logwell.LogLevel]logwell.RouteConfig{
+//	    logwell.LevelError: alerts,
+//	    logwell.LevelFatal: alerts,
+//	})
+func WithLevelRouting(routes map[LogLevel]RouteConfig) Option {
+	return func(c *Config) {
+		c.LevelRouting = routes
+	}
+}
+
+// WithLocalSink mirrors entries to w (os.Stdout, os.Stderr, a file) in the
+// given format. It sees what would be shipped, after processors, redaction,
+// sampling, and rate limiting, including entries later dropped on queue
+// overflow. Only the first write error is reported to OnError.
+func WithLocalSink(w io.Writer, format SinkFormat) Option {
+	return func(c *Config) {
+		c.LocalSink = w
+		c.LocalSinkFormat = format
+	}
+}
+
+// WithProxy routes ingest requests through the proxy at proxyURL
+// (http, https, or socks5). It applies to the SDK's default transport and
+// cannot be combined with a custom HTTP client that sets its own Transport.
+func WithProxy(proxyURL string) Option {
+	return func(c *Config) {
+		c.Proxy = proxyURL
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of ingest requests; see
+// LoadTLSConfig for building one from PEM files. Like WithProxy, it applies
+// to the default transport only.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Config) {
+		c.TLSConfig = cfg
+	}
+}
+
+// WithTransportTuning sets the connection pool and timeouts of the SDK's
+// default transport; zero fields keep their defaults. Like WithProxy, it
+// applies to the default transport only.
+func WithTransportTuning(tuning TransportTuning) Option {
+	return func(c *Config) {
+		c.TransportTuning = &tuning
+	}
+}
+
+// WithHeaders adds HTTP headers to every ingest request. Repeated calls
+// merge, with later values winning.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *Config) {
+		if c.Headers == nil {
+			c.Headers = make(map[string]string, len(headers))
+		}
+		maps.Copy(c.Headers, headers)
+	}
+}
+
 // newDefaultConfig creates a Config with default values.
 func newDefaultConfig(endpoint, apiKey string) *Config {
 	return &Config{
@@ -161,7 +1096,12 @@ func newDefaultConfig(endpoint, apiKey string) *Config {
 		FlushInterval:         DefaultFlushInterval,
 		MaxQueueSize:          DefaultMaxQueueSize,
 		MaxRetries:            DefaultMaxRetries,
+		SenderConcurrency:     DefaultSenderConcurrency,
+		MaxRetryAfter:         DefaultMaxRetryAfter,
+		RequestTimeout:        DefaultRequestTimeout,
+		SyncTimeout:           DefaultSyncTimeout,
 		CaptureSourceLocation: false,
+		ExitFunc:              os.Exit,
 		HTTPClient:            http.DefaultClient,
 	}
 }
@@ -188,12 +1128,20 @@ func validateEndpoint(endpoint string) error {
 	return nil
 }
 
-// validateAPIKey validates the API key format.
-func validateAPIKey(apiKey string) error {
+// validateAPIKey validates the API key format. With skipFormat, any key
+// that can be sent in an Authorization header is accepted.
+func validateAPIKey(apiKey string, skipFormat bool) error {
 	if apiKey == "" {
 		return NewError(ErrInvalidConfig, "apiKey is required")
 	}
 
+	if skipFormat {
+		if strings.ContainsFunc(apiKey, invalidAPIKeyRune) {
+			return NewError(ErrInvalidConfig, "apiKey must not contain whitespace or control characters")
+		}
+		return nil
+	}
+
 	if !apiKeyRegex.MatchString(apiKey) {
 		return NewError(ErrInvalidConfig, "apiKey format invalid: must match lw_[a-zA-Z0-9_-]{32}")
 	}
@@ -217,6 +1165,14 @@ func validateFlushInterval(flushInterval time.Duration) error {
 	return nil
 }
 
+// validateFlushJitter validates the flush jitter configuration.
+func validateFlushJitter(fraction float64) error {
+	if !(fraction >= 0 && fraction <= MaxFlushJitter) {
+		return NewError(ErrInvalidConfig, "flushJitter must be between 0 and 0.5")
+	}
+	return nil
+}
+
 // validateMaxQueueSize validates the max queue size configuration.
 func validateMaxQueueSize(maxQueueSize int) error {
 	if maxQueueSize < MinMaxQueueSize || maxQueueSize > MaxMaxQueueSize {
@@ -233,31 +1189,416 @@ func validateMaxRetries(maxRetries int) error {
 	return nil
 }
 
-// validateConfig validates the configuration and returns an error if invalid.
-func validateConfig(c *Config) error {
-	if err := validateEndpoint(c.Endpoint); err != nil {
-		return err
+// validateSenderConcurrency validates the sender concurrency configuration.
+func validateSenderConcurrency(n int) error {
+	if n < MinSenderConcurrency || n > MaxSenderConcurrency {
+		return NewError(ErrInvalidConfig, "senderConcurrency must be between 1 and 32")
 	}
+	return nil
+}
 
-	if err := validateAPIKey(c.APIKey); err != nil {
-		return err
+// validateMaxRetryAfter validates the max Retry-After configuration.
+func validateMaxRetryAfter(d time.Duration) error {
+	if d < MinMaxRetryAfter || d > MaxMaxRetryAfter {
+		return NewError(ErrInvalidConfig, "maxRetryAfter must be between 0 and 10m")
 	}
+	return nil
+}
 
-	if err := validateBatchSize(c.BatchSize); err != nil {
-		return err
+// validateRequestTimeout validates the request timeout configuration.
+func validateRequestTimeout(d time.Duration) error {
+	if d < MinRequestTimeout || d > MaxRequestTimeout {
+		return NewError(ErrInvalidConfig, "requestTimeout must be between 100ms and 5m")
 	}
+	return nil
+}
 
-	if err := validateFlushInterval(c.FlushInterval); err != nil {
-		return err
+// validateSyncMode validates the sync mode configuration.
+func validateSyncMode(c *Config) error {
+	if !c.SyncMode {
+		return nil
+	}
+	if c.SyncTimeout < MinSyncTimeout || c.SyncTimeout > MaxSyncTimeout {
+		return NewError(ErrInvalidConfig, "syncTimeout must be between 100ms and 1m")
 	}
+	if c.PersistentQueueDir != "" {
+		return NewError(ErrInvalidConfig, "syncMode cannot be combined with persistentQueue")
+	}
+	return nil
+}
 
-	if err := validateMaxQueueSize(c.MaxQueueSize); err != nil {
-		return err
+// validateCircuitBreaker validates the circuit breaker configuration.
+func validateCircuitBreaker(threshold int, cooldown time.Duration) error {
+	if threshold == 0 {
+		return nil
+	}
+	if threshold < MinCircuitBreakerThreshold || threshold > MaxCircuitBreakerThreshold {
+		return NewError(ErrInvalidConfig, "circuitBreakerThreshold must be between 1 and 100")
+	}
+	if cooldown < MinCircuitBreakerCooldown || cooldown > MaxCircuitBreakerCooldown {
+		return NewError(ErrInvalidConfig, "circuitBreakerCooldown must be between 100ms and 10m")
 	}
+	return nil
+}
 
-	if err := validateMaxRetries(c.MaxRetries); err != nil {
-		return err
+// validateOverflowStrategy validates the overflow strategy configuration.
+func validateOverflowStrategy(s OverflowStrategy) error {
+	if s.kind == overflowBlock && s.timeout <= 0 {
+		return NewError(ErrInvalidConfig, "overflowStrategy: block timeout must be positive")
 	}
+	return nil
+}
 
+// validateMinLevel validates the minimum level configuration.
+// The empty level means LevelDebug.
+func validateMinLevel(level LogLevel) error {
+	if level != "" && level.severity() < 0 {
+		return NewError(ErrInvalidConfig, "minLevel must be one of debug, info, warn, error, fatal")
+	}
+	return nil
+}
+
+// validateRuntimeStatsInterval validates the runtime stats interval.
+func validateRuntimeStatsInterval(interval time.Duration) error {
+	if interval != 0 && (interval < MinRuntimeStatsInterval || interval > MaxRuntimeStatsInterval) {
+		return NewError(ErrInvalidConfig, "runtimeStatsInterval must be between 1s and 24h")
+	}
+	return nil
+}
+
+// validateCallerSkip validates the caller skip.
+func validateCallerSkip(n int) error {
+	if n < 0 {
+		return NewError(ErrInvalidConfig, "callerSkip must not be negative")
+	}
 	return nil
 }
+
+// validateFatalBehavior validates the fatal behavior configuration.
+func validateFatalBehavior(b FatalBehavior, exit func(int)) error {
+	if b > LogOnly {
+		return NewError(ErrInvalidConfig, "fatalBehavior must be ExitProcess, PanicAfterLog, or LogOnly")
+	}
+	if b == ExitProcess && exit == nil {
+		return NewError(ErrInvalidConfig, "exitFunc is required with ExitProcess")
+	}
+	return nil
+}
+
+// validateStackTraceLevel validates the stack trace level configuration.
+func validateStackTraceLevel(level LogLevel) error {
+	if level != "" && level.severity() < 0 {
+		return NewError(ErrInvalidConfig, "stackTraceLevel must be one of debug, info, warn, error, fatal")
+	}
+	return nil
+}
+
+// validateProcessors validates the processor chain.
+func validateProcessors(processors []Processor) error {
+	for _, p := range processors {
+		if p == nil {
+			return NewError(ErrInvalidConfig, "processors: processor must not be nil")
+		}
+	}
+	return nil
+}
+
+// validateContextExtractors validates the context extractors.
+func validateContextExtractors(extractors []ContextExtractor) error {
+	for _, extract := range extractors {
+		if extract == nil {
+			return NewError(ErrInvalidConfig, "contextExtractors: extractor must not be nil")
+		}
+	}
+	return nil
+}
+
+// validateRedaction validates the redaction rules.
+func validateRedaction(rules []RedactRule) error {
+	for _, rule := range rules {
+		if len(rule.Keys) == 0 && rule.Pattern == nil {
+			return NewError(ErrInvalidConfig, "redaction: rule must set Keys or Pattern")
+		}
+	}
+	return nil
+}
+
+// validateRateLimit validates the rate limit configuration.
+func validateRateLimit(perSecond float64, burst int) error {
+	if perSecond == 0 {
+		return nil
+	}
+	if perSecond < 0 {
+		return NewError(ErrInvalidConfig, "rateLimit must be positive")
+	}
+	if burst < 1 {
+		return NewError(ErrInvalidConfig, "rateLimitBurst must be at least 1")
+	}
+	return nil
+}
+
+// validateDedupeWindow validates the dedupe window.
+func validateDedupeWindow(window time.Duration) error {
+	if window != 0 && (window < MinDedupeWindow || window > MaxDedupeWindow) {
+		return NewError(ErrInvalidConfig, "dedupeWindow must be between 100ms and 10m")
+	}
+	return nil
+}
+
+// validateMaxUploadRate validates the upload rate limit.
+func validateMaxUploadRate(bytesPerSec int) error {
+	if bytesPerSec != 0 && bytesPerSec < MinUploadRate {
+		return NewError(ErrInvalidConfig, fmt.Sprintf("maxUploadRate must be at least %d bytes per second", MinUploadRate))
+	}
+	return nil
+}
+
+// validateRetryLimits validates the retry deadline and retry budget.
+func validateRetryLimits(deadline time.Duration, perSecond float64, burst int) error {
+	if deadline != 0 && (deadline < MinRetryDeadline || deadline > MaxRetryDeadline) {
+		return NewError(ErrInvalidConfig, "retryDeadline must be between 100ms and 10m")
+	}
+	if perSecond == 0 {
+		return nil
+	}
+	if perSecond < 0 {
+		return NewError(ErrInvalidConfig, "retryBudget must be positive")
+	}
+	if burst < 1 {
+		return NewError(ErrInvalidConfig, "retryBudgetBurst must be at least 1")
+	}
+	return nil
+}
+
+// validatePersistentQueue validates the persistent queue configuration.
+func validatePersistentQueue(dir string, maxBytes int64) error {
+	if dir == "" {
+		return nil
+	}
+	if maxBytes < MinPersistentQueueBytes {
+		return NewError(ErrInvalidConfig, "persistentQueueMaxBytes must be at least 1024")
+	}
+	return nil
+}
+
+// validateSizeLimits validates the message and metadata size limits.
+func validateSizeLimits(maxMessage, maxMetadata int) error {
+	if maxMessage != 0 && maxMessage < MinMaxMessageBytes {
+		return NewError(ErrInvalidConfig, "maxMessageBytes must be 0 (unlimited) or at least 16")
+	}
+	if maxMetadata != 0 && maxMetadata < MinMaxMetadataBytes {
+		return NewError(ErrInvalidConfig, "maxMetadataBytes must be 0 (unlimited) or at least 64")
+	}
+	return nil
+}
+
+// validateTimestampFormat validates the timestamp format, rejecting Unix
+// formats the ingest API would not parse.
+func validateTimestampFormat(c *Config) error {
+	if !c.TimestampFormat.valid() {
+		return NewError(ErrInvalidConfig, "timestampFormat: unknown format "+c.TimestampFormat.String())
+	}
+	if (c.TimestampFormat == TimestampUnixMillis || c.TimestampFormat == TimestampUnixNanos) && c.Transport == nil {
+		return NewError(ErrInvalidConfig, "timestampFormat "+c.TimestampFormat.String()+" requires a custom transport; the ingest API parses RFC 3339 only")
+	}
+	return nil
+}
+
+// validateFallbackEndpoints validates the fallback endpoints.
+func validateFallbackEndpoints(endpoints []string) error {
+	for _, endpoint := range endpoints {
+		if err := validateEndpoint(endpoint); err != nil {
+			return NewErrorWithCause(ErrInvalidConfig, "fallbackEndpoints: invalid endpoint "+endpoint, err)
+		}
+	}
+	return nil
+}
+
+// validateLevelRouting validates the level routes.
+func validateLevelRouting(routes map[LogLevel]RouteConfig, skipKeyFormat bool) error {
+	for level, route := range routes {
+		if level.severity() < 0 {
+			return NewError(ErrInvalidConfig, fmt.Sprintf("levelRouting: invalid level %q", level))
+		}
+		if route.Endpoint != "" {
+			if err := validateEndpoint(route.Endpoint); err != nil {
+				return NewErrorWithCause(ErrInvalidConfig, fmt.Sprintf("levelRouting: invalid endpoint for %s", level), err)
+			}
+		}
+		if err := validateAPIKey(route.APIKey, skipKeyFormat); err != nil {
+			return NewErrorWithCause(ErrInvalidConfig, fmt.Sprintf("levelRouting: invalid API key for %s", level), err)
+		}
+	}
+	return nil
+}
+
+// validateFallbackFile validates the fallback file configuration.
+func validateFallbackFile(path string, maxSize int64, maxFiles int) error {
+	if path == "" {
+		return nil
+	}
+	if maxSize < MinFallbackFileSize {
+		return NewError(ErrInvalidConfig, "fallbackFileMaxSize must be at least 1024")
+	}
+	if maxFiles < MinFallbackFileFiles || maxFiles > MaxFallbackFileFiles {
+		return NewError(ErrInvalidConfig, "fallbackFileMaxFiles must be between 1 and 100")
+	}
+	return nil
+}
+
+// validateLocalSink validates the local sink configuration.
+func validateLocalSink(format SinkFormat) error {
+	if format != SinkText && format != SinkJSON {
+		return NewError(ErrInvalidConfig, "localSinkFormat must be SinkText or SinkJSON")
+	}
+	return nil
+}
+
+// validateProxy validates the proxy configuration.
+func validateProxy(proxy string) error {
+	if proxy == "" {
+		return nil
+	}
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return NewError(ErrInvalidConfig, "proxy is not a valid URL: "+err.Error())
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return NewError(ErrInvalidConfig, "proxy must use http, https, or socks5 scheme")
+	}
+	if u.Host == "" {
+		return NewError(ErrInvalidConfig, "proxy must have a host")
+	}
+	return nil
+}
+
+// validateTransportOptions rejects negative tuning values, and proxy, TLS,
+// or tuning settings that would be silently ignored because the custom HTTP
+// client brings its own Transport.
+func validateTransportOptions(c *Config) error {
+	if t := c.TransportTuning; t != nil {
+		if t.MaxIdleConns < 0 || t.MaxIdleConnsPerHost < 0 {
+			return NewError(ErrInvalidConfig, "transportTuning: idle connection limits cannot be negative")
+		}
+		if t.IdleConnTimeout < 0 || t.DialTimeout < 0 || t.KeepAlive < 0 || t.TLSHandshakeTimeout < 0 {
+			return NewError(ErrInvalidConfig, "transportTuning: timeouts cannot be negative")
+		}
+	}
+	if c.Proxy == "" && c.TLSConfig == nil && c.TransportTuning == nil {
+		return nil
+	}
+	if c.HTTPClient != nil && c.HTTPClient != http.DefaultClient && c.HTTPClient.Transport != nil {
+		return NewError(ErrInvalidConfig, "proxy, TLS, and transport tuning options cannot be combined with a custom HTTP client transport")
+	}
+	return nil
+}
+
+// validateSigningSecret validates the request signing secret.
+func validateSigningSecret(secret string) error {
+	if secret != "" && len(secret) < MinSigningSecretLength {
+		return NewError(ErrInvalidConfig, "signingSecret must be at least 16 characters")
+	}
+	return nil
+}
+
+// validateHeaders validates the custom request headers.
+func validateHeaders(headers map[string]string) error {
+	for name, value := range headers {
+		if name == "" || strings.ContainsFunc(name, invalidHeaderNameRune) {
+			return NewError(ErrInvalidConfig, fmt.Sprintf("headers: invalid header name %q", name))
+		}
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return NewError(ErrInvalidConfig, fmt.Sprintf("headers: %s value must not contain control characters", name))
+		}
+		switch http.CanonicalHeaderKey(name) {
+		case "Authorization", "Content-Type", IdempotencyKeyHeader:
+			return NewError(ErrInvalidConfig, fmt.Sprintf("headers: %s is set by the SDK", name))
+		}
+	}
+	return nil
+}
+
+// invalidHeaderNameRune reports whether r may not appear in an HTTP header
+// field name (RFC 9110 token characters).
+func invalidHeaderNameRune(r rune) bool {
+	if r <= ' ' || r >= 0x7f {
+		return true
+	}
+	return strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r)
+}
+
+// invalidAPIKeyRune reports whether r cannot appear in an API key sent as
+// a bearer token.
+func invalidAPIKeyRune(r rune) bool {
+	return r <= ' ' || r == 0x7f
+}
+
+// validateConfig validates the configuration, reporting every invalid
+// field at once (see joinConfigErrors).
+func validateConfig(c *Config) error {
+	var errs []error
+	for _, err := range []error{
+		validateEndpoint(c.Endpoint),
+		validateAPIKey(c.APIKey, c.SkipAPIKeyValidation),
+		validateBatchSize(c.BatchSize),
+		validateFlushInterval(c.FlushInterval),
+		validateFlushJitter(c.FlushJitter),
+		validateMaxQueueSize(c.MaxQueueSize),
+		validateMaxRetries(c.MaxRetries),
+		validateSenderConcurrency(c.SenderConcurrency),
+		validateMaxRetryAfter(c.MaxRetryAfter),
+		validateRequestTimeout(c.RequestTimeout),
+		validateRetryLimits(c.RetryDeadline, c.RetryBudget, c.RetryBudgetBurst),
+		validateMaxUploadRate(c.MaxUploadRate),
+		validateSyncMode(c),
+		validateCircuitBreaker(c.CircuitBreakerThreshold, c.CircuitBreakerCooldown),
+		validateOverflowStrategy(c.OverflowStrategy),
+		validatePersistentQueue(c.PersistentQueueDir, c.PersistentQueueMaxBytes),
+		validateMinLevel(c.MinLevel),
+		validateRateLimit(c.RateLimit, c.RateLimitBurst),
+		validateDedupeWindow(c.DedupeWindow),
+		validateCallerSkip(c.CallerSkip),
+		validateRuntimeStatsInterval(c.RuntimeStatsInterval),
+		validateFatalBehavior(c.FatalBehavior, c.ExitFunc),
+		validateStackTraceLevel(c.StackTraceLevel),
+		validateProcessors(c.Processors),
+		validateContextExtractors(c.ContextExtractors),
+		validateRedaction(c.Redaction),
+		validateSizeLimits(c.MaxMessageBytes, c.MaxMetadataBytes),
+		validateTimestampFormat(c),
+		validateHeaders(c.Headers),
+		validateSigningSecret(c.SigningSecret),
+		validateFallbackFile(c.FallbackFile, c.FallbackFileMaxSize, c.FallbackFileMaxFiles),
+		validateLocalSink(c.LocalSinkFormat),
+		validateFallbackEndpoints(c.FallbackEndpoints),
+		validateLevelRouting(c.LevelRouting, c.SkipAPIKeyValidation),
+		validateProxy(c.Proxy),
+		validateTransportOptions(c),
+	} {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return joinConfigErrors(errs)
+}
+
+// joinConfigErrors combines validation failures into one ErrInvalidConfig
+// error listing every message, whose Cause is errors.Join of the
+// individual errors. A single failure is returned as is.
+func joinConfigErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.(*Error).Message
+	}
+	return NewErrorWithCause(ErrInvalidConfig,
+		fmt.Sprintf("%d config errors: %s", len(errs), strings.Join(messages, "; ")),
+		errors.Join(errs...))
+}
@@ -0,0 +1,328 @@
+package logwell
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileConfig is a client configuration read from a file by
+// NewFromConfigFile. Fields left out keep their defaults. Durations are
+// strings such as "5s" or "250ms".
+//
+// Example (JSON):
+//
+//	{
+//	  "endpoint": "https://logs.example.com",
+//	  "apiKeyEnv": "LOGWELL_API_KEY",
+//	  "service": "billing-agent",
+//	  "batchSize": 100,
+//	  "flushInterval": "2s",
+//	  "redaction": {"presets": ["commonKeys", "emails"], "keys": ["ssn"]},
+//	  "sampling": {"fraction": 0.1, "keepLevel": "warn"}
+//	}
+type FileConfig struct {
+	// Endpoint is the Logwell server URL.
+	Endpoint string `json:"endpoint" yaml:"endpoint"`
+
+	// The API key comes from exactly one of APIKey, APIKeyEnv (the name of
+	// an environment variable holding it), or APIKeyFile (a file holding
+	// it; surrounding whitespace is trimmed). Prefer the references, so
+	// the config file itself holds no secret.
+	APIKey     string `json:"apiKey" yaml:"apiKey"`
+	APIKeyEnv  string `json:"apiKeyEnv" yaml:"apiKeyEnv"`
+	APIKeyFile string `json:"apiKeyFile" yaml:"apiKeyFile"`
+
+	// SkipAPIKeyValidation sets WithSkipAPIKeyValidation.
+	SkipAPIKeyValidation bool `json:"skipApiKeyValidation" yaml:"skipApiKeyValidation"`
+
+	Service  string         `json:"service" yaml:"service"`
+	MinLevel LogLevel       `json:"minLevel" yaml:"minLevel"`
+	Metadata map[string]any `json:"metadata" yaml:"metadata"`
+
+	BatchSize      int    `json:"batchSize" yaml:"batchSize"`
+	FlushInterval  string `json:"flushInterval" yaml:"flushInterval"`
+	MaxQueueSize   int    `json:"maxQueueSize" yaml:"maxQueueSize"`
+	MaxRetries     *int   `json:"maxRetries" yaml:"maxRetries"`
+	RequestTimeout string `json:"requestTimeout" yaml:"requestTimeout"`
+
+	Redaction *FileRedaction `json:"redaction" yaml:"redaction"`
+	Sampling  *FileSampling  `json:"sampling" yaml:"sampling"`
+}
+
+// FileRedaction configures WithRedaction from a file.
+type FileRedaction struct {
+	// Presets names built-in rules: "commonKeys" (RedactCommonKeys),
+	// "emails", "creditCards", and "jwts".
+	Presets []string `json:"presets" yaml:"presets"`
+
+	// Keys lists more metadata keys to redact, as RedactKeys.
+	Keys []string `json:"keys" yaml:"keys"`
+
+	// Patterns lists regular expressions to redact, as RedactPattern.
+	Patterns []string `json:"patterns" yaml:"patterns"`
+
+	// Replacement replaces redacted data for Keys and Patterns.
+	// Default: DefaultRedactReplacement.
+	Replacement string `json:"replacement" yaml:"replacement"`
+}
+
+// FileSampling configures WithSampler from a file: either Fraction
+// (FractionSampler) or First and Thereafter (CountSampler), optionally
+// exempting entries at or above KeepLevel (LevelSampler).
+type FileSampling struct {
+	Fraction   *float64 `json:"fraction" yaml:"fraction"`
+	First      int      `json:"first" yaml:"first"`
+	Thereafter int      `json:"thereafter" yaml:"thereafter"`
+	Tick       string   `json:"tick" yaml:"tick"`
+	KeepLevel  LogLevel `json:"keepLevel" yaml:"keepLevel"`
+}
+
+// redactPresets maps FileRedaction preset names to their rules.
+var redactPresets = map[string]RedactRule{
+	"commonKeys":  RedactCommonKeys,
+	"emails":      RedactEmails,
+	"creditCards": RedactCreditCards,
+	"jwts":        RedactJWTs,
+}
+
+var (
+	configFormatsMu sync.RWMutex
+	configFormats   = map[string]func(data []byte, v any) error{
+		".json": unmarshalConfigJSON,
+	}
+)
+
+// RegisterConfigFormat makes NewFromConfigFile and LoadConfigFile decode
+// files with the given extension (such as ".yaml") using unmarshal, which
+// should reject unknown fields. JSON is built in; importing
+// github.com/Divkix/Logwell/sdks/go/contrib/yaml registers ".yaml" and
+// ".yml".
+func RegisterConfigFormat(ext string, unmarshal func(data []byte, v any) error) {
+	configFormatsMu.Lock()
+	defer configFormatsMu.Unlock()
+	configFormats[strings.ToLower(ext)] = unmarshal
+}
+
+// unmarshalConfigJSON decodes JSON, rejecting unknown fields so typos in
+// hand-edited files surface as errors.
+func unmarshalConfigJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// LoadConfigFile reads a FileConfig from path, choosing the format by the
+// file extension. Returns ErrInvalidConfig if the file cannot be read or
+// decoded.
+func LoadConfigFile(path string) (*FileConfig, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	configFormatsMu.RLock()
+	unmarshal := configFormats[ext]
+	configFormatsMu.RUnlock()
+	if unmarshal == nil {
+		return nil, NewError(ErrInvalidConfig, fmt.Sprintf("unsupported config file format %q", ext))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, NewErrorWithCause(ErrInvalidConfig, "failed to read config file", err)
+	}
+	fc := &FileConfig{}
+	if err := unmarshal(data, fc); err != nil {
+		return nil, NewErrorWithCause(ErrInvalidConfig, "failed to parse config file "+path, err)
+	}
+	return fc, nil
+}
+
+// NewFromConfigFile creates a client from the config file at path (see
+// FileConfig and LoadConfigFile). opts are applied after the file's
+// settings, so they override them; use WithEndpoints and WithAPIKey to
+// override the endpoint and key.
+//
+// Example:
+//
+//	client, err := logwell.NewFromConfigFile("/etc/logwell/agent.json",
+//	    logwell.WithOnError(reportError))
+func NewFromConfigFile(path string, opts ...Option) (*Client, error) {
+	fc, err := LoadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fileOpts, err := fc.Options()
+	if err != nil {
+		return nil, err
+	}
+	return New("", "", append(fileOpts, opts...)...)
+}
+
+// Options converts the file's settings into options for New, resolving
+// the API key reference. Returns ErrInvalidConfig naming the first field
+// that cannot be converted.
+func (fc *FileConfig) Options() ([]Option, error) {
+	var opts []Option
+	if fc.Endpoint != "" {
+		opts = append(opts, WithEndpoints(fc.Endpoint))
+	}
+	key, err := fc.apiKey()
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		opts = append(opts, WithAPIKey(key))
+	}
+	if fc.SkipAPIKeyValidation {
+		opts = append(opts, WithSkipAPIKeyValidation())
+	}
+
+	if fc.Service != "" {
+		opts = append(opts, WithService(fc.Service))
+	}
+	if fc.MinLevel != "" {
+		opts = append(opts, WithMinLevel(fc.MinLevel))
+	}
+	if fc.Metadata != nil {
+		opts = append(opts, WithMetadata(fc.Metadata))
+	}
+	if fc.BatchSize != 0 {
+		opts = append(opts, WithBatchSize(fc.BatchSize))
+	}
+	if fc.MaxQueueSize != 0 {
+		opts = append(opts, WithMaxQueueSize(fc.MaxQueueSize))
+	}
+	if fc.MaxRetries != nil {
+		opts = append(opts, WithMaxRetries(*fc.MaxRetries))
+	}
+	if d, err := parseFileDuration("flushInterval", fc.FlushInterval); err != nil {
+		return nil, err
+	} else if d != 0 {
+		opts = append(opts, WithFlushInterval(d))
+	}
+	if d, err := parseFileDuration("requestTimeout", fc.RequestTimeout); err != nil {
+		return nil, err
+	} else if d != 0 {
+		opts = append(opts, WithRequestTimeout(d))
+	}
+
+	if fc.Redaction != nil {
+		rules, err := fc.Redaction.rules()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithRedaction(rules...))
+	}
+	if fc.Sampling != nil {
+		sampler, err := fc.Sampling.sampler()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, WithSampler(sampler))
+	}
+	return opts, nil
+}
+
+// apiKey resolves the file's API key reference.
+func (fc *FileConfig) apiKey() (string, error) {
+	set := 0
+	for _, v := range []string{fc.APIKey, fc.APIKeyEnv, fc.APIKeyFile} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return "", NewError(ErrInvalidConfig, "apiKey, apiKeyEnv, and apiKeyFile are mutually exclusive")
+	}
+
+	switch {
+	case fc.APIKeyEnv != "":
+		key, ok := os.LookupEnv(fc.APIKeyEnv)
+		if !ok {
+			return "", NewError(ErrInvalidConfig, fmt.Sprintf("apiKeyEnv: environment variable %s is not set", fc.APIKeyEnv))
+		}
+		return key, nil
+	case fc.APIKeyFile != "":
+		data, err := os.ReadFile(fc.APIKeyFile)
+		if err != nil {
+			return "", NewErrorWithCause(ErrInvalidConfig, "apiKeyFile: failed to read API key", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return fc.APIKey, nil
+}
+
+// parseFileDuration parses the duration string s of the named field; empty
+// means unset.
+func parseFileDuration(field, s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, NewErrorWithCause(ErrInvalidConfig, field+": invalid duration "+s, err)
+	}
+	return d, nil
+}
+
+func (r *FileRedaction) rules() ([]RedactRule, error) {
+	var rules []RedactRule
+	for _, name := range r.Presets {
+		rule, ok := redactPresets[name]
+		if !ok {
+			return nil, NewError(ErrInvalidConfig, fmt.Sprintf("redaction.presets: unknown preset %q", name))
+		}
+		rules = append(rules, rule)
+	}
+	if len(r.Keys) > 0 {
+		rule := RedactKeys(r.Keys...)
+		rule.Replacement = r.Replacement
+		rules = append(rules, rule)
+	}
+	for _, pattern := range r.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, NewErrorWithCause(ErrInvalidConfig, "redaction.patterns: invalid pattern "+pattern, err)
+		}
+		rule := RedactPattern(re)
+		rule.Replacement = r.Replacement
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func (s *FileSampling) sampler() (Sampler, error) {
+	var sampler Sampler
+	switch {
+	case s.Fraction != nil && (s.First != 0 || s.Thereafter != 0):
+		return nil, NewError(ErrInvalidConfig, "sampling: fraction and first/thereafter are mutually exclusive")
+	case s.Fraction != nil:
+		if *s.Fraction < 0 || *s.Fraction > 1 {
+			return nil, NewError(ErrInvalidConfig, "sampling.fraction must be between 0 and 1")
+		}
+		sampler = FractionSampler(*s.Fraction)
+	case s.First != 0 || s.Thereafter != 0:
+		if s.First < 0 || s.Thereafter < 0 {
+			return nil, NewError(ErrInvalidConfig, "sampling.first and sampling.thereafter must not be negative")
+		}
+		tick, err := parseFileDuration("sampling.tick", s.Tick)
+		if err != nil {
+			return nil, err
+		}
+		sampler = CountSampler(s.First, s.Thereafter, tick)
+	default:
+		return nil, NewError(ErrInvalidConfig, "sampling requires fraction or first/thereafter")
+	}
+
+	if s.KeepLevel != "" {
+		if err := validateMinLevel(s.KeepLevel); err != nil {
+			return nil, NewError(ErrInvalidConfig, "sampling.keepLevel: invalid level "+string(s.KeepLevel))
+		}
+		sampler = LevelSampler(s.KeepLevel, sampler)
+	}
+	return sampler, nil
+}
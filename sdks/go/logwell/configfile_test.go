@@ -0,0 +1,179 @@
+package logwell
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile writes content to a file named name in a temp dir and
+// returns its path.
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+func TestNewFromConfigFile(t *testing.T) {
+	t.Setenv("TEST_LOGWELL_KEY", validAPIKey())
+	path := writeConfigFile(t, "logwell.json", `{
+		"endpoint": "http://localhost:3000",
+		"apiKeyEnv": "TEST_LOGWELL_KEY",
+		"service": "agent",
+		"minLevel": "warn",
+		"metadata": {"region": "eu"},
+		"batchSize": 10,
+		"flushInterval": "2s",
+		"maxQueueSize": 500,
+		"maxRetries": 0,
+		"requestTimeout": "5s",
+		"redaction": {"presets": ["emails"], "keys": ["ssn"], "patterns": ["tok_[a-z]+"], "replacement": "***"},
+		"sampling": {"fraction": 0.5, "keepLevel": "error"}
+	}`)
+
+	client, err := NewFromConfigFile(path, WithBatchSize(20))
+	if err != nil {
+		t.Fatalf("NewFromConfigFile() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	cfg := client.config
+	if cfg.Endpoint != "http://localhost:3000" || cfg.APIKey != validAPIKey() {
+		t.Errorf("endpoint, key = %q, %q", cfg.Endpoint, cfg.APIKey)
+	}
+	if cfg.Service != "agent" || cfg.MinLevel != LevelWarn || cfg.Metadata["region"] != "eu" {
+		t.Errorf("service, level, metadata = %q, %q, %v", cfg.Service, cfg.MinLevel, cfg.Metadata)
+	}
+	if cfg.BatchSize != 20 {
+		t.Errorf("BatchSize = %d, want 20 (option overrides file)", cfg.BatchSize)
+	}
+	if cfg.FlushInterval != 2*time.Second || cfg.RequestTimeout != 5*time.Second {
+		t.Errorf("FlushInterval, RequestTimeout = %v, %v", cfg.FlushInterval, cfg.RequestTimeout)
+	}
+	if cfg.MaxQueueSize != 500 || cfg.MaxRetries != 0 {
+		t.Errorf("MaxQueueSize, MaxRetries = %d, %d", cfg.MaxQueueSize, cfg.MaxRetries)
+	}
+	if len(cfg.Redaction) != 3 || cfg.Redaction[1].Replacement != "***" {
+		t.Errorf("Redaction = %+v, want 3 rules", cfg.Redaction)
+	}
+	if cfg.Sampler == nil {
+		t.Error("Sampler = nil, want a sampler")
+	} else if !cfg.Sampler.Sample(LogEntry{Level: LevelError}) {
+		t.Error("sampler dropped an error entry, want keepLevel to keep it")
+	}
+}
+
+func TestNewFromConfigFileOverrides(t *testing.T) {
+	path := writeConfigFile(t, "logwell.json", `{"endpoint": "http://file.invalid", "apiKey": "file-key"}`)
+	client, err := NewFromConfigFile(path, WithEndpoints(validEndpoint()), WithAPIKey(validAPIKey()))
+	if err != nil {
+		t.Fatalf("NewFromConfigFile() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+	if client.config.Endpoint != validEndpoint() || client.config.APIKey != validAPIKey() {
+		t.Errorf("endpoint, key = %q, %q, want the option values", client.config.Endpoint, client.config.APIKey)
+	}
+}
+
+func TestNewFromConfigFileSkipAPIKeyValidation(t *testing.T) {
+	path := writeConfigFile(t, "logwell.json", `{"endpoint": "http://localhost:3000", "apiKey": "tenant-key", "skipApiKeyValidation": true}`)
+	client, err := NewFromConfigFile(path)
+	if err != nil {
+		t.Fatalf("NewFromConfigFile() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+	if !client.config.SkipAPIKeyValidation {
+		t.Error("SkipAPIKeyValidation = false, want true")
+	}
+}
+
+func TestFileConfigAPIKeyFile(t *testing.T) {
+	keyPath := writeConfigFile(t, "key", validAPIKey()+"\n")
+	fc := &FileConfig{APIKeyFile: keyPath}
+	key, err := fc.apiKey()
+	if err != nil {
+		t.Fatalf("apiKey() error = %v", err)
+	}
+	if key != validAPIKey() {
+		t.Errorf("apiKey() = %q, want %q", key, validAPIKey())
+	}
+}
+
+func TestLoadConfigFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    string
+	}{
+		{"unsupported format", "logwell.toml", `endpoint = "x"`, "unsupported config file format"},
+		{"malformed", "logwell.json", `{"endpoint":`, "failed to parse"},
+		{"unknown field", "logwell.json", `{"endpiont": "x"}`, "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfigFile(writeConfigFile(t, tt.file, tt.content))
+			assertConfigError(t, err, ErrInvalidConfig)
+			if err != nil && !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+
+	_, err := LoadConfigFile(filepath.Join(t.TempDir(), "missing.json"))
+	assertConfigError(t, err, ErrInvalidConfig)
+}
+
+func TestFileConfigOptionsErrors(t *testing.T) {
+	half := 0.5
+	tests := []struct {
+		name string
+		fc   FileConfig
+		want string
+	}{
+		{"two key sources", FileConfig{APIKey: "a", APIKeyEnv: "B"}, "mutually exclusive"},
+		{"unset key env", FileConfig{APIKeyEnv: "TEST_LOGWELL_UNSET"}, "apiKeyEnv"},
+		{"missing key file", FileConfig{APIKeyFile: "/nonexistent/key"}, "apiKeyFile"},
+		{"bad duration", FileConfig{FlushInterval: "soon"}, "flushInterval"},
+		{"unknown preset", FileConfig{Redaction: &FileRedaction{Presets: []string{"passwords"}}}, "redaction.presets"},
+		{"bad pattern", FileConfig{Redaction: &FileRedaction{Patterns: []string{"("}}}, "redaction.patterns"},
+		{"empty sampling", FileConfig{Sampling: &FileSampling{}}, "sampling requires"},
+		{"both samplers", FileConfig{Sampling: &FileSampling{Fraction: &half, First: 1}}, "mutually exclusive"},
+		{"bad keep level", FileConfig{Sampling: &FileSampling{Fraction: &half, KeepLevel: "loud"}}, "keepLevel"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.fc.Options()
+			assertConfigError(t, err, ErrInvalidConfig)
+			if err != nil && !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterConfigFormat(t *testing.T) {
+	RegisterConfigFormat(".TEST", func(data []byte, v any) error {
+		v.(*FileConfig).Service = string(data)
+		return nil
+	})
+	t.Cleanup(func() {
+		configFormatsMu.Lock()
+		delete(configFormats, ".test")
+		configFormatsMu.Unlock()
+	})
+
+	fc, err := LoadConfigFile(writeConfigFile(t, "logwell.test", "custom"))
+	if err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+	if fc.Service != "custom" {
+		t.Errorf("Service = %q, want %q", fc.Service, "custom")
+	}
+}
@@ -0,0 +1,117 @@
+package logwell
+
+import (
+	"context"
+	"sync"
+)
+
+// contextKey is the unexported key type for storing a logger in a context.
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying logger.
+// Retrieve it later with FromContext, typically in request handlers:
+//
+//	reqLogger := client.Child(logwell.ChildWithMetadata(logwell.M{"requestId": id}))
+//	ctx = logwell.NewContext(ctx, reqLogger)
+//	// ... deeper in the call stack:
+//	logwell.FromContext(ctx).Info("Charging card")
+func NewContext(ctx context.Context, logger *Client) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger stored in ctx by NewContext. If ctx
+// carries no logger it returns Default(), or, without a default client, a
+// shut-down client that discards everything, so the result is never nil.
+func FromContext(ctx context.Context) *Client {
+	if logger := contextLogger(ctx); logger != nil {
+		return logger
+	}
+	if logger := Default(); logger != nil {
+		return logger
+	}
+	return discardClient()
+}
+
+// contextLogger returns the logger stored in ctx, or nil.
+func contextLogger(ctx context.Context) *Client {
+	logger, _ := ctx.Value(contextKey{}).(*Client)
+	return logger
+}
+
+// discardClient returns the shut-down client FromContext falls back to.
+var discardClient = sync.OnceValue(func() *Client {
+	c, _ := newClient(newDefaultConfig("http://localhost", ""))
+	c.Shutdown(context.Background())
+	return c
+})
+
+// WithContext returns a copy of ctx carrying c.
+// It is shorthand for NewContext(ctx, c).
+func (c *Client) WithContext(ctx context.Context) context.Context {
+	return NewContext(ctx, c)
+}
+
+// DebugCtx logs a message at DEBUG level with metadata extracted from ctx
+// by the configured context extractors (see WithContextExtractor).
+// Metadata passed to the call overrides extracted metadata.
+func (c *Client) DebugCtx(ctx context.Context, message string, metadata ...map[string]any) {
+	if !c.Enabled(LevelDebug) {
+		return
+	}
+	c.log(LevelDebug, message, c.contextMetadata(ctx, metadata)...)
+}
+
+// InfoCtx logs a message at INFO level with metadata extracted from ctx
+// by the configured context extractors (see WithContextExtractor).
+// Metadata passed to the call overrides extracted metadata.
+func (c *Client) InfoCtx(ctx context.Context, message string, metadata ...map[string]any) {
+	if !c.Enabled(LevelInfo) {
+		return
+	}
+	c.log(LevelInfo, message, c.contextMetadata(ctx, metadata)...)
+}
+
+// WarnCtx logs a message at WARN level with metadata extracted from ctx
+// by the configured context extractors (see WithContextExtractor).
+// Metadata passed to the call overrides extracted metadata.
+func (c *Client) WarnCtx(ctx context.Context, message string, metadata ...map[string]any) {
+	if !c.Enabled(LevelWarn) {
+		return
+	}
+	c.log(LevelWarn, message, c.contextMetadata(ctx, metadata)...)
+}
+
+// ErrorCtx logs a message at ERROR level with metadata extracted from ctx
+// by the configured context extractors (see WithContextExtractor).
+// Metadata passed to the call overrides extracted metadata.
+func (c *Client) ErrorCtx(ctx context.Context, message string, metadata ...map[string]any) {
+	if !c.Enabled(LevelError) {
+		return
+	}
+	c.log(LevelError, message, c.contextMetadata(ctx, metadata)...)
+}
+
+// FatalCtx logs a message at FATAL level with metadata extracted from ctx,
+// like ErrorCtx, then behaves like Fatal.
+func (c *Client) FatalCtx(ctx context.Context, message string, metadata ...map[string]any) {
+	if c.Enabled(LevelFatal) {
+		c.log(LevelFatal, message, c.contextMetadata(ctx, metadata)...)
+	}
+	c.afterFatal(message)
+}
+
+// contextMetadata returns metadata preceded by what the root client's
+// context extractors find in ctx, so call metadata wins on conflicts.
+func (c *Client) contextMetadata(ctx context.Context, metadata []map[string]any) []map[string]any {
+	extractors := c.root().config.ContextExtractors
+	if ctx == nil || len(extractors) == 0 {
+		return metadata
+	}
+	merged := make([]map[string]any, 0, len(extractors)+len(metadata))
+	for _, extract := range extractors {
+		if extracted := extract(ctx); len(extracted) > 0 {
+			merged = append(merged, extracted)
+		}
+	}
+	return append(merged, metadata...)
+}
@@ -0,0 +1,152 @@
+package logwell
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFromContext_ReturnsStoredLogger(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts)
+	defer client.Shutdown(context.Background())
+
+	child := client.Child(ChildWithMetadata(M{"requestId": "req-1"}))
+	ctx := NewContext(context.Background(), child)
+
+	if got := FromContext(ctx); got != child {
+		t.Errorf("FromContext() = %p, want %p", got, child)
+	}
+}
+
+func TestFromContext_EmptyContext(t *testing.T) {
+	logger := FromContext(context.Background())
+	if logger == nil {
+		t.Fatal("FromContext() = nil, want a discarding logger")
+	}
+	// Logging through the fallback must not panic or send anything.
+	logger.Info("discarded")
+	logger.Child(ChildWithService("svc")).Warn("discarded")
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+	if FromContext(context.Background()) != logger {
+		t.Error("FromContext() returned a new fallback logger")
+	}
+}
+
+func TestFromContext_DefaultClient(t *testing.T) {
+	client, err := New(validEndpoint(), validAPIKey(), WithTransport(discardTransport{}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+	SetDefault(client)
+	defer SetDefault(nil)
+
+	if got := FromContext(context.Background()); got != client {
+		t.Errorf("FromContext() = %p, want the default client %p", got, client)
+	}
+}
+
+func TestClient_WithContext(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithBatchSize(1))
+	defer client.Shutdown(context.Background())
+
+	child := client.Child(ChildWithMetadata(M{"requestId": "req-2"}))
+	ctx := child.WithContext(context.Background())
+
+	entry := logAndWait(client, ts, FromContext(ctx).Info, "scoped message")
+	assertLogMetadata(t, entry, map[string]string{"requestId": "req-2"})
+}
+
+type requestIDKey struct{}
+
+// extractRequestID is a ContextExtractor reading requestIDKey.
+func extractRequestID(ctx context.Context) M {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return M{"requestId": id, "source": "context"}
+	}
+	return nil
+}
+
+func TestClientCtxMethodsExtractMetadata(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts,
+		WithBatchSize(1),
+		WithMetadata(M{"source": "client"}),
+		WithContextExtractor(extractRequestID),
+		WithContextExtractor(func(context.Context) M { return M{"tenant": "acme"} }),
+		WithCaptureSourceLocation(true),
+	)
+	defer client.Shutdown(context.Background())
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+
+	t.Run("extracted metadata overrides the logger's", func(t *testing.T) {
+		log := logAndWait(client, ts, func(msg string, md ...map[string]any) {
+			client.With(M{"userId": "u-1"}).InfoCtx(ctx, msg, md...)
+		}, "order placed")
+		assertLogMetadata(t, log, map[string]string{
+			"requestId": "req-1",
+			"source":    "context",
+			"tenant":    "acme",
+			"userId":    "u-1",
+		})
+		if log.Level != LevelInfo {
+			t.Errorf("Level = %q, want info", log.Level)
+		}
+	})
+
+	t.Run("call metadata overrides extracted", func(t *testing.T) {
+		log := logAndWait(client, ts, func(msg string, md ...map[string]any) {
+			client.ErrorCtx(ctx, msg, M{"source": "call"})
+		}, "charge failed")
+		assertLogMetadata(t, log, map[string]string{"requestId": "req-1", "source": "call"})
+	})
+
+	t.Run("context without values", func(t *testing.T) {
+		log := logAndWait(client, ts, func(msg string, md ...map[string]any) {
+			client.WarnCtx(context.Background(), msg)
+		}, "no request")
+		assertLogMetadata(t, log, map[string]string{"source": "client", "tenant": "acme"})
+		if _, ok := log.Metadata["requestId"]; ok {
+			t.Errorf("Metadata = %v, want no requestId", log.Metadata)
+		}
+	})
+
+	t.Run("source location points at the caller", func(t *testing.T) {
+		log := logAndWait(client, ts, func(msg string, md ...map[string]any) {
+			client.InfoCtx(ctx, msg)
+		}, "located")
+		if !strings.HasSuffix(log.SourceFile, "context_test.go") {
+			t.Errorf("SourceFile = %q, want context_test.go", log.SourceFile)
+		}
+	})
+}
+
+func TestClientCtxMethodsSkipDisabledLevels(t *testing.T) {
+	calls := 0
+	client, err := New(validEndpoint(), validAPIKey(), WithMinLevel(LevelInfo),
+		WithContextExtractor(func(context.Context) M { calls++; return nil }))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.DebugCtx(context.Background(), "ignored")
+	if calls != 0 {
+		t.Errorf("extractor ran %d times for a disabled level, want 0", calls)
+	}
+}
+
+func TestContextExtractorValidation(t *testing.T) {
+	_, err := New(validEndpoint(), validAPIKey(), WithContextExtractor(nil))
+	assertConfigError(t, err, ErrInvalidConfig)
+}
@@ -0,0 +1,16 @@
+package logwell
+
+// debug reports routine internal activity to the WithDebugLogger logger,
+// if any.
+func (c *Client) debug(msg string, args ...any) {
+	if logger := c.root().config.DebugLogger; logger != nil {
+		logger.Debug(msg, args...)
+	}
+}
+
+// warn reports an internal failure to the WithDebugLogger logger, if any.
+func (c *Client) warn(msg string, args ...any) {
+	if logger := c.root().config.DebugLogger; logger != nil {
+		logger.Warn(msg, args...)
+	}
+}
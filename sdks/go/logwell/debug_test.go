@@ -0,0 +1,74 @@
+package logwell
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestDebugLogger(t *testing.T) {
+	var out syncBuffer
+	logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	attempts := 0
+	client, err := New(validEndpoint(), validAPIKey(),
+		WithDebugLogger(logger),
+		WithMaxRetries(1),
+		WithBackoff(ConstantBackoff(time.Millisecond)),
+		WithCircuitBreaker(1, time.Minute),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			attempts++
+			if attempts == 2 {
+				return &IngestResponse{Accepted: len(logs)}, nil
+			}
+			return nil, NewError(ErrServerError, "unavailable")
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	client.Info("first")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	client.Info("second")
+	client.Flush(context.Background())
+	client.Shutdown(context.Background())
+
+	got := out.String()
+	for _, want := range []string{
+		"level=DEBUG msg=\"logwell: flush requested\"",
+		"level=DEBUG msg=\"logwell: retry scheduled\" attempt=1",
+		"level=DEBUG msg=\"logwell: batch sent\" entries=1 accepted=1",
+		"level=WARN msg=\"logwell: batch failed\"",
+		"level=WARN msg=\"logwell: circuit opened\"",
+		"level=DEBUG msg=\"logwell: send skipped, circuit open\"",
+		"level=DEBUG msg=\"logwell: shutting down\"",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("debug output missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package logwell
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// maxDedupeKeys bounds the number of entries held at once. Entries with a
+// new key are let through unheld while the table is full.
+const maxDedupeKeys = 10000
+
+// deduper collapses entries with the same key within a window: the first
+// is held for the window, later ones only bump its count, and the held
+// entry is emitted with the count when the window ends.
+type deduper struct {
+	window time.Duration
+	key    func(LogEntry) string
+	emit   func(LogEntry)
+	clock  Clock
+
+	mu      sync.Mutex
+	pending map[string]*dedupeGroup
+	stopped bool
+
+	// collapsed counts entries merged into a held one, for Client.Stats.
+	collapsed atomic.Uint64
+}
+
+// dedupeGroup is one held entry and the number of entries it stands for.
+type dedupeGroup struct {
+	entry LogEntry
+	count int
+	timer Timer
+}
+
+// newDeduper creates a deduper holding entries for window on clock and
+// passing them to emit when it ends.
+func newDeduper(window time.Duration, key func(LogEntry) string, emit func(LogEntry), clock Clock) *deduper {
+	if key == nil {
+		key = RateLimitKey
+	}
+	return &deduper{
+		window:  window,
+		key:     key,
+		emit:    emit,
+		clock:   clock,
+		pending: make(map[string]*dedupeGroup),
+	}
+}
+
+// pass reports whether entry should continue down the pipeline now. It
+// returns false when entry is held or merged into a held entry.
+func (d *deduper) pass(entry *LogEntry) bool {
+	key := d.key(*entry)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.stopped {
+		return true
+	}
+	if g, ok := d.pending[key]; ok {
+		g.count++
+		d.collapsed.Add(1)
+		return false
+	}
+	if len(d.pending) >= maxDedupeKeys {
+		return true
+	}
+	g := &dedupeGroup{entry: *entry, count: 1}
+	g.timer = d.clock.AfterFunc(d.window, func() { d.release(key, g) })
+	d.pending[key] = g
+	return false
+}
+
+// release emits g when its window ends, unless it was already released.
+func (d *deduper) release(key string, g *dedupeGroup) {
+	d.mu.Lock()
+	if d.pending[key] != g {
+		d.mu.Unlock()
+		return
+	}
+	delete(d.pending, key)
+	d.mu.Unlock()
+	d.emit(g.collapsed())
+}
+
+// collapsed returns the held entry, marked with its count when it stands
+// for more than one entry.
+func (g *dedupeGroup) collapsed() LogEntry {
+	entry := g.entry
+	if g.count > 1 {
+		entry.Metadata = mergeMetadata(entry.Metadata, M{"count": g.count})
+	}
+	return entry
+}
+
+// take ends every window early and returns the held entries. After stop,
+// entries are no longer held.
+func (d *deduper) take(stop bool) []LogEntry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stopped = d.stopped || stop
+	var out []LogEntry
+	for key, g := range d.pending {
+		g.timer.Stop()
+		out = append(out, g.collapsed())
+		delete(d.pending, key)
+	}
+	return out
+}
@@ -0,0 +1,139 @@
+package logwell
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeduperCollapses(t *testing.T) {
+	var emitted []LogEntry
+	d := newDeduper(time.Second, nil, func(e LogEntry) { emitted = append(emitted, e) }, stubClock{})
+
+	first := LogEntry{Level: LevelError, Message: "db timeout", Timestamp: "t1"}
+	if d.pass(&first) {
+		t.Fatal("pass() = true for the first entry, want held")
+	}
+	for range 4 {
+		if d.pass(&LogEntry{Level: LevelError, Message: "db timeout", Timestamp: "t2"}) {
+			t.Fatal("pass() = true for a duplicate")
+		}
+	}
+	d.pass(&LogEntry{Level: LevelWarn, Message: "db timeout"})
+
+	g := d.pending[RateLimitKey(first)]
+	d.release(RateLimitKey(first), g)
+	d.release(RateLimitKey(first), g) // already released
+	if len(emitted) != 1 {
+		t.Fatalf("emitted %d entries, want 1", len(emitted))
+	}
+	if e := emitted[0]; e.Timestamp != "t1" || e.Metadata["count"] != 5 {
+		t.Errorf("emitted %+v, want first timestamp and count 5", e)
+	}
+	if got := d.collapsed.Load(); got != 4 {
+		t.Errorf("collapsed = %d, want 4", got)
+	}
+
+	rest := d.take(true)
+	if len(rest) != 1 || rest[0].Level != LevelWarn || rest[0].Metadata != nil {
+		t.Errorf("take() = %+v, want the single warn entry without count", rest)
+	}
+	if !d.pass(&first) {
+		t.Error("pass() = false after stop")
+	}
+}
+
+func TestDeduperKeyCap(t *testing.T) {
+	d := newDeduper(time.Second, nil, func(LogEntry) {}, stubClock{})
+	for i := range maxDedupeKeys {
+		d.pending[strings.Repeat("k", i+1)] = &dedupeGroup{timer: stubTimer{}}
+	}
+	if !d.pass(&LogEntry{Level: LevelInfo, Message: "untracked"}) {
+		t.Error("pass() = false with the key table full")
+	}
+}
+
+func TestClientDedupe(t *testing.T) {
+	var got []LogEntry
+	client, err := New(validEndpoint(), validAPIKey(), WithClock(stubClock{}),
+		WithDedupe(time.Minute, func(e LogEntry) string { return e.Message }),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			got = append(got, logs...)
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	child := client.With(M{"k": "v"})
+	for range 10 {
+		child.Error("connection refused")
+	}
+	client.Warn("connection refused")
+	client.Info("unrelated")
+
+	if got := client.Stats().Deduplicated; got != 10 {
+		t.Errorf("Deduplicated = %d, want 10", got)
+	}
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("sent %d entries, want 2", len(got))
+	}
+	for _, e := range got {
+		switch e.Message {
+		case "connection refused":
+			if e.Level != LevelError || e.Metadata["count"] != 11 || e.Metadata["k"] != "v" {
+				t.Errorf("collapsed entry = %+v, want first error with count 11", e)
+			}
+		case "unrelated":
+			if _, ok := e.Metadata["count"]; ok {
+				t.Errorf("single entry has count: %+v", e)
+			}
+		}
+	}
+
+	client.Info("held")
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if len(got) != 3 || got[2].Message != "held" {
+		t.Errorf("Shutdown did not send the held entry: %+v", got)
+	}
+}
+
+func TestClientDedupeWindowExpiry(t *testing.T) {
+	sent := make(chan LogEntry, 10)
+	client, err := New(validEndpoint(), validAPIKey(), WithBatchSize(1),
+		WithDedupe(MinDedupeWindow, nil),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			for _, e := range logs {
+				sent <- e
+			}
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Error("disk full")
+	client.Error("disk full")
+	select {
+	case e := <-sent:
+		if e.Metadata["count"] != 2 {
+			t.Errorf("sent %+v, want count 2", e)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("held entry not sent after the window")
+	}
+}
+
+func TestDedupeWindowValidation(t *testing.T) {
+	for _, window := range []time.Duration{-time.Second, time.Millisecond, time.Hour} {
+		_, err := New(validEndpoint(), validAPIKey(), WithDedupe(window, nil))
+		assertConfigError(t, err, ErrInvalidConfig)
+	}
+}
@@ -0,0 +1,109 @@
+package logwell
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// defaultClient is the client used by the package-level log functions.
+var defaultClient atomic.Pointer[Client]
+
+// SetDefault makes client the target of the package-level log functions
+// (Info, Warnf, and so on), so small programs and libraries can log without
+// passing a client around. SetDefault(nil) clears it; until a default is set,
+// the package-level functions are no-ops. The caller still owns the client
+// and must Shutdown it.
+func SetDefault(client *Client) {
+	defaultClient.Store(client)
+}
+
+// Default returns the client set by SetDefault, or nil. Use it for the
+// methods without a package-level form, such as Default().Error(...):
+// the name Error belongs to the Error type.
+func Default() *Client {
+	return defaultClient.Load()
+}
+
+// The package-level functions call log and logFields directly, like the
+// Client methods, so source locations point at their callers.
+
+// Debug logs a message at DEBUG level on the default client.
+func Debug(message string, metadata ...map[string]any) {
+	if c := Default(); c != nil {
+		c.log(LevelDebug, message, metadata...)
+	}
+}
+
+// Info logs a message at INFO level on the default client.
+func Info(message string, metadata ...map[string]any) {
+	if c := Default(); c != nil {
+		c.log(LevelInfo, message, metadata...)
+	}
+}
+
+// Warn logs a message at WARN level on the default client.
+func Warn(message string, metadata ...map[string]any) {
+	if c := Default(); c != nil {
+		c.log(LevelWarn, message, metadata...)
+	}
+}
+
+// Debugf logs a formatted message at DEBUG level on the default client.
+func Debugf(format string, args ...any) {
+	if c := Default(); c != nil && c.Enabled(LevelDebug) {
+		c.log(LevelDebug, fmt.Sprintf(format, args...))
+	}
+}
+
+// Infof logs a formatted message at INFO level on the default client.
+func Infof(format string, args ...any) {
+	if c := Default(); c != nil && c.Enabled(LevelInfo) {
+		c.log(LevelInfo, fmt.Sprintf(format, args...))
+	}
+}
+
+// Warnf logs a formatted message at WARN level on the default client.
+func Warnf(format string, args ...any) {
+	if c := Default(); c != nil && c.Enabled(LevelWarn) {
+		c.log(LevelWarn, fmt.Sprintf(format, args...))
+	}
+}
+
+// Errorf logs a formatted message at ERROR level on the default client.
+func Errorf(format string, args ...any) {
+	if c := Default(); c != nil && c.Enabled(LevelError) {
+		c.log(LevelError, fmt.Sprintf(format, args...))
+	}
+}
+
+// DebugFields logs a message at DEBUG level with typed fields on the
+// default client.
+func DebugFields(message string, fields ...Field) {
+	if c := Default(); c != nil {
+		c.logFields(LevelDebug, message, fields)
+	}
+}
+
+// InfoFields logs a message at INFO level with typed fields on the default
+// client.
+func InfoFields(message string, fields ...Field) {
+	if c := Default(); c != nil {
+		c.logFields(LevelInfo, message, fields)
+	}
+}
+
+// WarnFields logs a message at WARN level with typed fields on the default
+// client.
+func WarnFields(message string, fields ...Field) {
+	if c := Default(); c != nil {
+		c.logFields(LevelWarn, message, fields)
+	}
+}
+
+// ErrorFields logs a message at ERROR level with typed fields on the default
+// client.
+func ErrorFields(message string, fields ...Field) {
+	if c := Default(); c != nil {
+		c.logFields(LevelError, message, fields)
+	}
+}
@@ -0,0 +1,71 @@
+package logwell
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPackageLevelFunctions(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithCaptureSourceLocation(true), WithMinLevel(LevelInfo))
+	defer client.Shutdown(context.Background())
+
+	SetDefault(client)
+	defer SetDefault(nil)
+	if Default() != client {
+		t.Fatal("Default() did not return the client passed to SetDefault")
+	}
+
+	Debug("filtered")
+	Info("info", M{"k": "v"})
+	Warnf("warn %d", 2)
+	Errorf("error %s", "three")
+	ErrorFields("fields", Int("n", 4))
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 4)
+	want := []struct {
+		level   LogLevel
+		message string
+	}{
+		{LevelInfo, "info"},
+		{LevelWarn, "warn 2"},
+		{LevelError, "error three"},
+		{LevelError, "fields"},
+	}
+	for i, w := range want {
+		if i >= len(logs) {
+			break
+		}
+		if logs[i].Level != w.level || logs[i].Message != w.message {
+			t.Errorf("logs[%d] = %s %q, want %s %q", i, logs[i].Level, logs[i].Message, w.level, w.message)
+		}
+		if !strings.HasSuffix(logs[i].SourceFile, "default_test.go") {
+			t.Errorf("logs[%d].SourceFile = %q, want the caller's file", i, logs[i].SourceFile)
+		}
+	}
+	if len(logs) > 0 {
+		assertLogMetadata(t, logs[0], map[string]string{"k": "v"})
+	}
+}
+
+func TestPackageLevelFunctionsWithoutDefault(t *testing.T) {
+	SetDefault(nil)
+	if Default() != nil {
+		t.Fatal("Default() != nil after SetDefault(nil)")
+	}
+
+	// Must not panic.
+	Debug("x")
+	Info("x")
+	Warn("x")
+	Infof("%s", "x")
+	Errorf("%s", "x")
+	InfoFields("x", String("k", "v"))
+}
@@ -1,6 +1,9 @@
 package logwell
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // ErrorCode represents the type of error that occurred.
 type ErrorCode string
@@ -34,6 +37,42 @@ const (
 	// ErrInvalidConfig indicates invalid client configuration.
 	// This error is not retryable.
 	ErrInvalidConfig ErrorCode = "INVALID_CONFIG"
+
+	// ErrPayloadTooLarge indicates the server rejected a request body as too
+	// large (413). Batches are split and resent; it is not retryable as is.
+	ErrPayloadTooLarge ErrorCode = "PAYLOAD_TOO_LARGE"
+
+	// ErrCircuitOpen indicates a send was skipped because the circuit
+	// breaker is open. This error is retryable.
+	ErrCircuitOpen ErrorCode = "CIRCUIT_OPEN"
+
+	// ErrCircuitStateChange reports a circuit breaker state transition via
+	// OnError. It is informational and not retryable.
+	ErrCircuitStateChange ErrorCode = "CIRCUIT_STATE_CHANGE"
+)
+
+// Sentinel errors, one per ErrorCode, for use with errors.Is. Any *Error
+// matches the sentinel for its Code, including when wrapped:
+//
+//	logwell.WithOnError(func(err *logwell.Error) {
+//	    if errors.Is(err, logwell.ErrUnauthorizedErr) {
+//	        alertOnCall("logwell API key rejected")
+//	    }
+//	})
+//
+// Codes named ...Error have sentinels named ...Err (ErrNetworkErr for
+// ErrNetworkError); the rest append Err (ErrUnauthorizedErr).
+var (
+	ErrNetworkErr            = newSentinel(ErrNetworkError, "network error")
+	ErrUnauthorizedErr       = newSentinel(ErrUnauthorized, "unauthorized")
+	ErrValidationErr         = newSentinel(ErrValidationError, "validation error")
+	ErrRateLimitedErr        = newSentinel(ErrRateLimited, "rate limited")
+	ErrServerErr             = newSentinel(ErrServerError, "server error")
+	ErrQueueOverflowErr      = newSentinel(ErrQueueOverflow, "queue overflow")
+	ErrInvalidConfigErr      = newSentinel(ErrInvalidConfig, "invalid config")
+	ErrPayloadTooLargeErr    = newSentinel(ErrPayloadTooLarge, "payload too large")
+	ErrCircuitOpenErr        = newSentinel(ErrCircuitOpen, "circuit breaker open")
+	ErrCircuitStateChangeErr = newSentinel(ErrCircuitStateChange, "circuit breaker state change")
 )
 
 // Error represents a Logwell SDK error.
@@ -50,8 +89,15 @@ type Error struct {
 	// Retryable indicates whether this error can be retried.
 	Retryable bool
 
+	// RetryAfter is the delay the server asked for via a Retry-After header
+	// on a 429 or 503 response (0 if absent or unparseable).
+	RetryAfter time.Duration
+
 	// Cause is the underlying error, if any.
 	Cause error
+
+	// sentinel marks the per-code values matched by Is.
+	sentinel bool
 }
 
 // Error implements the error interface.
@@ -67,6 +113,20 @@ func (e *Error) Unwrap() error {
 	return e.Cause
 }
 
+// Is reports whether target is the sentinel for e's Code, so that
+// errors.Is(err, ErrUnauthorizedErr) matches every unauthorized *Error.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.sentinel && t.Code == e.Code
+}
+
+// newSentinel creates the sentinel error for code.
+func newSentinel(code ErrorCode, message string) *Error {
+	err := NewError(code, message)
+	err.sentinel = true
+	return err
+}
+
 // NewError creates a new Error with the given code and message.
 func NewError(code ErrorCode, message string) *Error {
 	return &Error{
@@ -99,7 +159,7 @@ func NewErrorWithCause(code ErrorCode, message string, cause error) *Error {
 // isRetryable returns whether an error code indicates a retryable error.
 func isRetryable(code ErrorCode) bool {
 	switch code {
-	case ErrNetworkError, ErrRateLimited, ErrServerError:
+	case ErrNetworkError, ErrRateLimited, ErrServerError, ErrCircuitOpen:
 		return true
 	default:
 		return false
@@ -0,0 +1,76 @@
+package logwell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorIsSentinel(t *testing.T) {
+	tests := []struct {
+		err      error
+		sentinel *Error
+	}{
+		{NewErrorWithStatus(ErrUnauthorized, "unauthorized: bad key", 401), ErrUnauthorizedErr},
+		{NewError(ErrQueueOverflow, "queue full"), ErrQueueOverflowErr},
+		{NewErrorWithCause(ErrNetworkError, "request failed", io.EOF), ErrNetworkErr},
+		{ErrClientClosed, ErrValidationErr},
+		{fmt.Errorf("flush: %w", NewError(ErrRateLimited, "slow down")), ErrRateLimitedErr},
+		{errors.Join(io.EOF, NewError(ErrPayloadTooLarge, "too big")), ErrPayloadTooLargeErr},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.sentinel) {
+			t.Errorf("errors.Is(%v, %v) = false, want true", tt.err, tt.sentinel)
+		}
+		if errors.Is(tt.err, ErrCircuitOpenErr) {
+			t.Errorf("errors.Is(%v, ErrCircuitOpenErr) = true, want false", tt.err)
+		}
+	}
+}
+
+func TestErrorIsOnlyMatchesSentinels(t *testing.T) {
+	a := NewError(ErrValidationError, "a")
+	b := NewError(ErrValidationError, "b")
+	if errors.Is(a, b) {
+		t.Error("errors.Is matched two distinct non-sentinel errors with the same code")
+	}
+	if errors.Is(NewError(ErrValidationError, "other"), ErrClientClosed) {
+		t.Error("errors.Is matched ErrClientClosed by code, want identity only")
+	}
+}
+
+func TestErrorUnwrapCause(t *testing.T) {
+	err := NewErrorWithCause(ErrNetworkError, "request failed", context.DeadlineExceeded)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("errors.Is(err, context.DeadlineExceeded) = false, want the cause matched")
+	}
+}
+
+// TestOnErrorSentinel tests matching an OnError error against a sentinel.
+func TestOnErrorSentinel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	var unauthorized bool
+	client, err := New(server.URL, validAPIKey(), WithOnError(func(err *Error) {
+		unauthorized = errors.Is(err, ErrUnauthorizedErr)
+	}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("one")
+	if err := client.Flush(context.Background()); !errors.Is(err, ErrUnauthorizedErr) {
+		t.Errorf("Flush() error = %v, want ErrUnauthorizedErr", err)
+	}
+	if !unauthorized {
+		t.Error("OnError error did not match ErrUnauthorizedErr")
+	}
+}
@@ -0,0 +1,196 @@
+package logwell
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// ExportFormat is the encoding produced by QueryClient.Export.
+type ExportFormat string
+
+// Export formats.
+const (
+	// ExportNDJSON writes one JSON-encoded LogRecord per line.
+	ExportNDJSON ExportFormat = "ndjson"
+
+	// ExportCSV writes a header row followed by one row per log, using the
+	// same columns as the dashboard's CSV export.
+	ExportCSV ExportFormat = "csv"
+)
+
+// exportCSVHeader matches the columns of the server's CSV export.
+var exportCSVHeader = []string{
+	"id", "timestamp", "level", "message", "metadata",
+	"sourceFile", "lineNumber", "requestId", "userId", "ipAddress",
+}
+
+// ExportRequest describes a bulk export.
+type ExportRequest struct {
+	// Range restricts the export to a timestamp window.
+	Range TimeRange
+
+	// Format is the output encoding. Default: ExportNDJSON.
+	Format ExportFormat
+
+	// Levels restricts the export to these levels. Empty exports all levels.
+	Levels []LogLevel
+
+	// Text is a full-text search query. Empty exports everything.
+	Text string
+}
+
+// Export streams every log matching req, newest first, encoded as req.Format.
+// Pages are fetched on demand as the returned reader is consumed, so exports
+// are not subject to the dashboard's 10,000-log export limit. The first page
+// is fetched before Export returns, so authentication and request errors are
+// reported directly; later errors are returned by Read.
+//
+// The caller must close the reader; closing it early stops the export.
+//
+// Example:
+//
+//	rc, err := qc.Export(ctx, logwell.ExportRequest{
+//	    Range:  logwell.TimeRange{From: time.Now().AddDate(0, 0, -7)},
+//	    Format: logwell.ExportCSV,
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer rc.Close()
+//	_, err = io.Copy(file, rc)
+func (q *QueryClient) Export(ctx context.Context, req ExportRequest) (io.ReadCloser, error) {
+	format := req.Format
+	if format == "" {
+		format = ExportNDJSON
+	}
+	if format != ExportNDJSON && format != ExportCSV {
+		return nil, NewError(ErrInvalidConfig, "export format must be ndjson or csv")
+	}
+
+	query := Query{
+		Text:      req.Text,
+		Levels:    req.Levels,
+		TimeRange: req.Range,
+		Limit:     MaxSearchLimit,
+	}
+	first, err := q.Search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	pr, pw := io.Pipe()
+	go func() {
+		defer cancel()
+		pw.CloseWithError(q.writeExport(ctx, pw, format, query, first))
+	}()
+	return &exportReader{PipeReader: pr, cancel: cancel}, nil
+}
+
+// writeExport encodes page and every following page to w.
+func (q *QueryClient) writeExport(ctx context.Context, w io.Writer, format ExportFormat, query Query, page *SearchResult) error {
+	bw := bufio.NewWriter(w)
+	encode := newExportEncoder(bw, format)
+	if format == ExportCSV {
+		if err := encode.csv.Write(exportCSVHeader); err != nil {
+			return err
+		}
+	}
+
+	for {
+		for i := range page.Logs {
+			if err := encode.record(&page.Logs[i]); err != nil {
+				return err
+			}
+		}
+		if err := encode.flush(); err != nil {
+			return err
+		}
+		if !page.HasMore || page.NextCursor == "" {
+			return nil
+		}
+
+		query.Cursor = page.NextCursor
+		var err error
+		if page, err = q.Search(ctx, query); err != nil {
+			return err
+		}
+	}
+}
+
+// exportEncoder writes records in one export format.
+type exportEncoder struct {
+	bw   *bufio.Writer
+	json *json.Encoder
+	csv  *csv.Writer
+}
+
+func newExportEncoder(bw *bufio.Writer, format ExportFormat) *exportEncoder {
+	e := &exportEncoder{bw: bw}
+	if format == ExportCSV {
+		e.csv = csv.NewWriter(bw)
+	} else {
+		e.json = json.NewEncoder(bw)
+	}
+	return e
+}
+
+// record writes one log.
+func (e *exportEncoder) record(rec *LogRecord) error {
+	if e.json != nil {
+		return e.json.Encode(rec)
+	}
+
+	var metadata string
+	if len(rec.Metadata) > 0 {
+		data, err := json.Marshal(rec.Metadata)
+		if err != nil {
+			return err
+		}
+		metadata = string(data)
+	}
+	var line string
+	if rec.LineNumber != 0 {
+		line = strconv.Itoa(rec.LineNumber)
+	}
+	return e.csv.Write([]string{
+		rec.ID,
+		rec.Timestamp.UTC().Format(time.RFC3339Nano),
+		string(rec.Level),
+		rec.Message,
+		metadata,
+		rec.SourceFile,
+		line,
+		rec.RequestID,
+		rec.UserID,
+		rec.IPAddress,
+	})
+}
+
+// flush pushes buffered output to the underlying writer.
+func (e *exportEncoder) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return e.bw.Flush()
+}
+
+// exportReader stops the export goroutine when closed.
+type exportReader struct {
+	*io.PipeReader
+	cancel context.CancelFunc
+}
+
+// Close stops the export and releases its resources.
+func (r *exportReader) Close() error {
+	r.cancel()
+	return r.PipeReader.Close()
+}
@@ -0,0 +1,135 @@
+package logwell
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newExportServer serves two pages of search results.
+func newExportServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	pages := map[string]string{
+		"": `{"logs":[
+			{"id":"1","level":"error","message":"boom, again","metadata":{"k":"v"},"sourceFile":"a.go","lineNumber":7,"timestamp":"2025-01-01T00:00:02Z"},
+			{"id":"2","level":"info","message":"hello","timestamp":"2025-01-01T00:00:01Z"}
+		],"total":3,"has_more":true,"nextCursor":"c2"}`,
+		"c2": `{"logs":[{"id":"3","level":"warn","message":"line\nbreak","timestamp":"2025-01-01T00:00:00Z"}],"has_more":false,"nextCursor":null}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("limit") != "500" || q.Get("level") != "error,warn,info" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(pages[q.Get("cursor")]))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func exportAll(t *testing.T, qc *QueryClient, format ExportFormat) string {
+	t.Helper()
+	rc, err := qc.Export(context.Background(), ExportRequest{
+		Format: format,
+		Levels: []LogLevel{LevelError, LevelWarn, LevelInfo},
+	})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	return string(data)
+}
+
+func TestExportNDJSON(t *testing.T) {
+	qc := newQueryTestClient(t, newExportServer(t))
+	out := exportAll(t, qc, "")
+
+	var ids []string
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		var rec LogRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("line %q is not a JSON record: %v", scanner.Text(), err)
+		}
+		ids = append(ids, rec.ID)
+	}
+	if strings.Join(ids, ",") != "1,2,3" {
+		t.Errorf("ids = %v, want 1,2,3", ids)
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	qc := newQueryTestClient(t, newExportServer(t))
+	out := exportAll(t, qc, ExportCSV)
+
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, want header + 3", len(rows))
+	}
+	if strings.Join(rows[0], ",") != strings.Join(exportCSVHeader, ",") {
+		t.Errorf("header = %v", rows[0])
+	}
+	want := []string{"1", "2025-01-01T00:00:02Z", "error", "boom, again", `{"k":"v"}`, "a.go", "7", "", "", ""}
+	if strings.Join(rows[1], "|") != strings.Join(want, "|") {
+		t.Errorf("row 1 = %q, want %q", rows[1], want)
+	}
+	if rows[3][3] != "line\nbreak" {
+		t.Errorf("row 3 message = %q, want the embedded newline preserved", rows[3][3])
+	}
+}
+
+func TestExportInvalidFormat(t *testing.T) {
+	qc := newQueryTestClient(t, newExportServer(t))
+	_, err := qc.Export(context.Background(), ExportRequest{Format: "xml"})
+	assertConfigError(t, err, ErrInvalidConfig)
+}
+
+func TestExportFirstPageError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := newQueryTestClient(t, server).Export(context.Background(), ExportRequest{})
+	if lwErr, ok := err.(*Error); !ok || lwErr.Code != ErrUnauthorized {
+		t.Fatalf("error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestExportLaterPageError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"logs":[{"id":"1","level":"info","message":"a","timestamp":"2025-01-01T00:00:00Z"}],"has_more":true,"nextCursor":"c2"}`))
+	}))
+	defer server.Close()
+
+	rc, err := newQueryTestClient(t, server).Export(context.Background(), ExportRequest{})
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if lwErr, ok := err.(*Error); !ok || lwErr.Code != ErrServerError {
+		t.Fatalf("ReadAll() error = %v, want ErrServerError", err)
+	}
+	if !strings.Contains(string(data), `"id":"1"`) {
+		t.Errorf("data = %q, want the first page before the error", data)
+	}
+}
@@ -0,0 +1,81 @@
+package logwell
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultExpvarPrefix is the expvar name prefix used by WithExpvar("").
+const DefaultExpvarPrefix = "logwell"
+
+var (
+	expvarMu      sync.Mutex
+	expvarClients = map[string]*Client{}
+)
+
+// expvarValues lists the variables published for each prefix.
+var expvarValues = []struct {
+	name  string
+	value func(ClientStats) any
+}{
+	{"sent", func(s ClientStats) any { return s.BatchesSent }},
+	{"failed", func(s ClientStats) any { return s.BatchesFailed }},
+	{"dropped", func(s ClientStats) any { return s.Dropped }},
+	{"throttled", func(s ClientStats) any { return s.Throttled }},
+	{"deduplicated", func(s ClientStats) any { return s.Deduplicated }},
+	{"retries", func(s ClientStats) any { return s.Retries }},
+	{"queued", func(s ClientStats) any { return s.Queued }},
+	{"flush_latency", func(s ClientStats) any { return expvarLatency(s) }},
+}
+
+// publishExpvar publishes c's stats under prefix. expvar variables cannot
+// be removed, so each name is published once and reads whichever client
+// last claimed the prefix; a client replacing a shut-down one with the
+// same prefix takes over its variables. It fails if another package has
+// already published one of the names.
+func publishExpvar(prefix string, c *Client) error {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	if _, ok := expvarClients[prefix]; !ok {
+		for _, v := range expvarValues {
+			if expvar.Get(prefix+"."+v.name) != nil {
+				return NewError(ErrInvalidConfig, fmt.Sprintf("expvar %q is already published", prefix+"."+v.name))
+			}
+		}
+		for _, v := range expvarValues {
+			value := v.value
+			expvar.Publish(prefix+"."+v.name, expvar.Func(func() any {
+				expvarMu.Lock()
+				client := expvarClients[prefix]
+				expvarMu.Unlock()
+				return value(client.Stats())
+			}))
+		}
+	}
+	expvarClients[prefix] = c
+	return nil
+}
+
+// expvarLatency renders the send latency histogram with durations in
+// milliseconds. counts[i] counts sends up to boundsMs[i], after
+// boundsMs[i-1]; the final extra count is for slower sends.
+func expvarLatency(s ClientStats) map[string]any {
+	bounds := make([]float64, len(s.SendLatency.Bounds))
+	for i, b := range s.SendLatency.Bounds {
+		bounds[i] = milliseconds(b)
+	}
+	return map[string]any{
+		"count":    s.SendLatency.Count,
+		"sumMs":    milliseconds(s.SendLatency.Sum),
+		"lastMs":   milliseconds(s.LastFlushLatency),
+		"boundsMs": bounds,
+		"counts":   s.SendLatency.Counts,
+	}
+}
+
+// milliseconds converts d to fractional milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
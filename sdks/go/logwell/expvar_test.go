@@ -0,0 +1,71 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"testing"
+)
+
+func newExpvarClient(t *testing.T, prefix string) *Client {
+	t.Helper()
+	client, err := New(validEndpoint(), validAPIKey(), WithExpvar(prefix),
+		WithTransport(transportFunc(func(context.Context, []LogEntry) (*IngestResponse, error) { return nil, nil })))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { client.Shutdown(context.Background()) })
+	return client
+}
+
+func TestExpvar(t *testing.T) {
+	client := newExpvarClient(t, "logwell_expvar_test")
+	client.Info("hello")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if got := expvar.Get("logwell_expvar_test.sent").String(); got != "1" {
+		t.Errorf("sent = %s, want 1", got)
+	}
+	if got := expvar.Get("logwell_expvar_test.dropped").String(); got != "0" {
+		t.Errorf("dropped = %s, want 0", got)
+	}
+
+	var latency struct {
+		Count    uint64    `json:"count"`
+		BoundsMs []float64 `json:"boundsMs"`
+		Counts   []uint64  `json:"counts"`
+	}
+	if err := json.Unmarshal([]byte(expvar.Get("logwell_expvar_test.flush_latency").String()), &latency); err != nil {
+		t.Fatalf("flush_latency is not JSON: %v", err)
+	}
+	if latency.Count != 1 || len(latency.Counts) != len(latency.BoundsMs)+1 || latency.BoundsMs[0] != 5 {
+		t.Errorf("flush_latency = %+v, want one send in %d buckets starting at 5ms", latency, len(LatencyBuckets())+1)
+	}
+
+	// A new client with the same prefix takes over the variables.
+	newExpvarClient(t, "logwell_expvar_test")
+	if got := expvar.Get("logwell_expvar_test.sent").String(); got != "0" {
+		t.Errorf("sent after replacing the client = %s, want 0", got)
+	}
+}
+
+// TestExpvarNameTaken tests that New fails instead of panicking when
+// another package already published one of the variables.
+func TestExpvarNameTaken(t *testing.T) {
+	expvar.NewInt("logwell_expvar_taken.sent")
+	_, err := New(validEndpoint(), validAPIKey(), WithExpvar("logwell_expvar_taken"))
+	assertConfigError(t, err, ErrInvalidConfig)
+	if expvar.Get("logwell_expvar_taken.failed") != nil {
+		t.Error("New() published variables despite the conflict")
+	}
+}
+
+func TestWithExpvarDefaultPrefix(t *testing.T) {
+	cfg := newDefaultConfig(validEndpoint(), validAPIKey())
+	WithExpvar("")(cfg)
+	if cfg.ExpvarPrefix != DefaultExpvarPrefix {
+		t.Errorf("ExpvarPrefix = %q, want %q", cfg.ExpvarPrefix, DefaultExpvarPrefix)
+	}
+}
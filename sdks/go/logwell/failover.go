@@ -0,0 +1,92 @@
+package logwell
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// failbackProbeInterval is how long the transport stays on a fallback
+// endpoint before probing the primary again with a real send.
+const failbackProbeInterval = 30 * time.Second
+
+// endpointPool tracks which of several ingest endpoints is healthy.
+// Sends go to the active endpoint; a network or 5xx failure there moves
+// the pool to the next endpoint in order. While on a fallback, one send per
+// failbackProbeInterval is routed to the primary, and its success fails back.
+type endpointPool struct {
+	endpoints  []string // base URLs, primary first
+	ingestURLs []string
+
+	mu       sync.Mutex
+	active   int
+	probeAt  time.Time // when the primary may next be probed
+	probing  bool
+	now      func() time.Time
+	interval time.Duration
+}
+
+// newEndpointPool creates a pool whose first endpoint is the primary.
+func newEndpointPool(endpoints []string) *endpointPool {
+	p := &endpointPool{
+		endpoints: endpoints,
+		now:       time.Now,
+		interval:  failbackProbeInterval,
+	}
+	for _, e := range endpoints {
+		p.ingestURLs = append(p.ingestURLs, strings.TrimRight(e, "/")+"/v1/ingest")
+	}
+	return p
+}
+
+// pick returns the index and ingest URL for the next send: the primary when
+// a fail-back probe is due, otherwise the active endpoint.
+func (p *endpointPool) pick() (int, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.active != 0 && !p.probing && !p.now().Before(p.probeAt) {
+		p.probing = true
+		return 0, p.ingestURLs[0]
+	}
+	return p.active, p.ingestURLs[p.active]
+}
+
+// report records the outcome of a send to endpoint i. failed is true only
+// for failures that indicate the endpoint is unhealthy.
+func (p *endpointPool) report(i int, failed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i == 0 && p.probing {
+		p.probing = false
+		if !failed {
+			p.active = 0 // fail back
+			return
+		}
+		p.probeAt = p.now().Add(p.interval)
+		return
+	}
+	if !failed || i != p.active {
+		return
+	}
+	p.active = (p.active + 1) % len(p.ingestURLs)
+	if p.active != 0 {
+		p.probeAt = p.now().Add(p.interval)
+	}
+}
+
+// current returns the base URL of the active endpoint.
+func (p *endpointPool) current() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.endpoints[p.active]
+}
+
+// isEndpointFailure reports whether err means the endpoint itself is
+// unreachable or unhealthy, as opposed to rejecting the batch.
+func isEndpointFailure(err error) bool {
+	logwellErr, ok := err.(*Error)
+	if !ok {
+		return true
+	}
+	return logwellErr.Code == ErrNetworkError || logwellErr.Code == ErrServerError
+}
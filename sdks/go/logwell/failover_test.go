@@ -0,0 +1,119 @@
+package logwell
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestEndpointPoolFailoverAndFailback(t *testing.T) {
+	now := time.Unix(0, 0)
+	p := newEndpointPool([]string{"https://a", "https://b/", "https://c"})
+	p.now = func() time.Time { return now }
+
+	if i, u := p.pick(); i != 0 || u != "https://a/v1/ingest" {
+		t.Fatalf("pick() = %d, %q, want primary", i, u)
+	}
+
+	p.report(0, true)
+	if i, u := p.pick(); i != 1 || u != "https://b/v1/ingest" {
+		t.Fatalf("pick() after failure = %d, %q, want first fallback", i, u)
+	}
+
+	// A stale failure from the primary does not skip past the fallback.
+	p.report(0, true)
+	if got := p.current(); got != "https://b/" {
+		t.Fatalf("current() = %q, want https://b/", got)
+	}
+
+	// Before the probe interval, sends stay on the fallback.
+	now = now.Add(failbackProbeInterval - time.Second)
+	if i, _ := p.pick(); i != 1 {
+		t.Fatalf("pick() before probe = %d, want 1", i)
+	}
+
+	// A failed probe keeps the fallback and postpones the next probe.
+	now = now.Add(time.Second)
+	if i, _ := p.pick(); i != 0 {
+		t.Fatalf("pick() at probe time = %d, want primary probe", i)
+	}
+	if i, _ := p.pick(); i != 1 {
+		t.Fatalf("concurrent pick() during probe = %d, want 1", i)
+	}
+	p.report(0, true)
+	if got := p.current(); got != "https://b/" {
+		t.Fatalf("current() after failed probe = %q, want https://b/", got)
+	}
+	if i, _ := p.pick(); i != 1 {
+		t.Fatalf("pick() after failed probe = %d, want 1", i)
+	}
+
+	// A successful probe fails back.
+	now = now.Add(failbackProbeInterval)
+	if i, _ := p.pick(); i != 0 {
+		t.Fatalf("pick() at probe time = %d, want primary probe", i)
+	}
+	p.report(0, false)
+	if got := p.current(); got != "https://a" {
+		t.Errorf("current() after successful probe = %q, want https://a", got)
+	}
+}
+
+func TestEndpointPoolWrapsAround(t *testing.T) {
+	p := newEndpointPool([]string{"https://a", "https://b"})
+	p.report(0, true)
+	p.report(1, true)
+	if i, _ := p.pick(); i != 0 {
+		t.Errorf("pick() after all failed = %d, want 0", i)
+	}
+}
+
+func TestClientFailsOverToFallbackEndpoint(t *testing.T) {
+	primary := newTestServer()
+	defer primary.Close()
+	primary.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	fallback := newTestServer()
+	defer fallback.Close()
+
+	client := createTestClient(t, primary,
+		WithEndpoints(primary.URL, fallback.URL),
+		WithMaxRetries(1),
+	)
+	defer client.Shutdown(context.Background())
+
+	client.Info("failover")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	assertLogCount(t, fallback.getLogs(), 1)
+	if got := client.Stats().ActiveEndpoint; got != fallback.URL {
+		t.Errorf("ActiveEndpoint = %q, want %q", got, fallback.URL)
+	}
+}
+
+func TestClientDoesNotFailOverOnRejectedBatch(t *testing.T) {
+	primary := newTestServer()
+	defer primary.Close()
+	primary.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+	fallback := newTestServer()
+	defer fallback.Close()
+
+	client := createTestClient(t, primary, WithEndpoints(primary.URL, fallback.URL))
+	defer client.Shutdown(context.Background())
+
+	client.Info("rejected")
+	if err := client.Flush(context.Background()); err == nil {
+		t.Fatal("Flush() error = nil, want unauthorized")
+	}
+
+	assertLogCount(t, fallback.getLogs(), 0)
+	if got := client.Stats().ActiveEndpoint; got != primary.URL {
+		t.Errorf("ActiveEndpoint = %q, want primary %q", got, primary.URL)
+	}
+}
@@ -0,0 +1,261 @@
+package logwell
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// fallbackFile spills batches that could not be delivered to NDJSON files
+// for a later ReplayFallback. New batches are appended to path; when it would
+// exceed maxSize it is rotated to path.1 (path.1 to path.2, and so on), and
+// files beyond maxFiles are deleted, oldest first.
+type fallbackFile struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// newFallbackFile prepares a fallback file at path. The file is opened
+// lazily on the first spill.
+func newFallbackFile(path string, maxSize int64, maxFiles int) *fallbackFile {
+	return &fallbackFile{path: path, maxSize: maxSize, maxFiles: maxFiles}
+}
+
+// spill appends batch as NDJSON lines, rotating first if needed.
+func (f *fallbackFile) spill(batch []LogEntry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i := range batch {
+		if err := enc.Encode(&batch[i]); err != nil {
+			return err
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.openLocked(); err != nil {
+		return err
+	}
+	if f.size > 0 && f.size+int64(buf.Len()) > f.maxSize {
+		if err := f.rotateLocked(); err != nil {
+			return err
+		}
+		if err := f.openLocked(); err != nil {
+			return err
+		}
+	}
+	n, err := f.f.Write(buf.Bytes())
+	f.size += int64(n)
+	return err
+}
+
+// openLocked opens path for appending if it is not already open.
+func (f *fallbackFile) openLocked() error {
+	if f.f != nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	f.f, f.size = file, info.Size()
+	return nil
+}
+
+// rotateLocked closes the active file and shifts it and the rotated files
+// up one index, deleting the file that falls past maxFiles.
+func (f *fallbackFile) rotateLocked() error {
+	if f.f != nil {
+		err := f.f.Close()
+		f.f, f.size = nil, 0
+		if err != nil {
+			return err
+		}
+	}
+	if f.maxFiles <= 1 {
+		return removeIfExists(f.path)
+	}
+	if err := removeIfExists(f.rotatedPath(f.maxFiles - 1)); err != nil {
+		return err
+	}
+	for i := f.maxFiles - 2; i >= 1; i-- {
+		if err := renameIfExists(f.rotatedPath(i), f.rotatedPath(i+1)); err != nil {
+			return err
+		}
+	}
+	return renameIfExists(f.path, f.rotatedPath(1))
+}
+
+// rotatedPath returns the path of the i-th rotated file (path.i).
+func (f *fallbackFile) rotatedPath(i int) string {
+	return f.path + "." + strconv.Itoa(i)
+}
+
+// files returns the fallback files that exist, oldest first.
+func (f *fallbackFile) files() []string {
+	var paths []string
+	for i := f.maxFiles - 1; i >= 1; i-- {
+		if _, err := os.Stat(f.rotatedPath(i)); err == nil {
+			paths = append(paths, f.rotatedPath(i))
+		}
+	}
+	if _, err := os.Stat(f.path); err == nil {
+		paths = append(paths, f.path)
+	}
+	return paths
+}
+
+// close closes the active file.
+func (f *fallbackFile) close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.f == nil {
+		return nil
+	}
+	err := f.f.Close()
+	f.f = nil
+	return err
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func renameIfExists(from, to string) error {
+	if err := os.Rename(from, to); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// spill writes batch to the fallback file, if configured, and reports
+// whether it did. A spilled batch is no longer owed by the persistent queue.
+func (c *Client) spill(batch []LogEntry) bool {
+	root := c.root()
+	if root.fallback == nil {
+		return false
+	}
+	if err := root.fallback.spill(batch); err != nil {
+		c.reportError(NewErrorWithCause(ErrQueueOverflow, "failed to write fallback file", err))
+		return false
+	}
+	if root.persist != nil {
+		root.persist.ack(batch)
+	}
+	return true
+}
+
+// readFallbackFile reads the entries of one fallback file. A truncated
+// final line, left by a crash mid-write, is skipped.
+func readFallbackFile(path string) ([]LogEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []LogEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for scanner.Scan() {
+		var entry LogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// ReplayFallback resends the entries spilled by WithFallbackFile, oldest
+// file first, in BatchSize batches. Each file is deleted once all its
+// entries are accepted. On the first failed batch it stops and returns the
+// error; that batch and everything after it stay on disk for a later call.
+//
+// Batches that fail while ReplayFallback runs wait for it to finish before
+// they are spilled. Without WithFallbackFile it returns nil.
+func (c *Client) ReplayFallback(ctx context.Context) error {
+	root := c.root()
+	f := root.fallback
+	if f == nil {
+		return nil
+	}
+	if c.isClosed() {
+		return ErrClientClosed
+	}
+
+	// Holding the lock keeps spills out while files are read and removed;
+	// the next spill reopens the active file.
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.f != nil {
+		err := f.f.Close()
+		f.f, f.size = nil, 0
+		if err != nil {
+			return NewErrorWithCause(ErrQueueOverflow, "failed to close fallback file", err)
+		}
+	}
+
+	for _, path := range f.files() {
+		entries, err := readFallbackFile(path)
+		if err != nil {
+			return NewErrorWithCause(ErrQueueOverflow, "failed to read fallback file", err)
+		}
+		for sent := 0; sent < len(entries); {
+			batch := entries[sent:min(sent+root.config.BatchSize, len(entries))]
+			start := root.clock.Now()
+			if _, err := root.transport.sendWithRetry(ctx, batch); err != nil {
+				if writeErr := rewriteFallbackFile(path, entries[sent:]); writeErr != nil {
+					return NewErrorWithCause(ErrQueueOverflow, "failed to rewrite fallback file", writeErr)
+				}
+				return err
+			}
+			latency := root.clock.Now().Sub(start)
+			root.stats.batchesSent.Add(1)
+			root.stats.lastFlushLatency.Store(int64(latency))
+			root.stats.sendLatency.observe(latency)
+			sent += len(batch)
+		}
+		if err := removeIfExists(path); err != nil {
+			return NewErrorWithCause(ErrQueueOverflow, "failed to remove fallback file", err)
+		}
+	}
+	return nil
+}
+
+// rewriteFallbackFile atomically replaces path with the given entries.
+func rewriteFallbackFile(path string, entries []LogEntry) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for i := range entries {
+		if err := enc.Encode(&entries[i]); err != nil {
+			return err
+		}
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
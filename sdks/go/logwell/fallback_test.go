@@ -0,0 +1,159 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFallbackFileRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spill", "logwell.ndjson")
+	f := newFallbackFile(path, 100, 3)
+	defer f.close()
+
+	// Each batch is 37 bytes, so two fit in a file before it rotates.
+	for i := range 10 {
+		if err := f.spill([]LogEntry{{Level: LevelInfo, Message: "entry " + strconv.Itoa(i)}}); err != nil {
+			t.Fatalf("spill() error = %v", err)
+		}
+	}
+
+	files := f.files()
+	want := []string{path + ".2", path + ".1", path}
+	if len(files) != len(want) {
+		t.Fatalf("files() = %v, want %v", files, want)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("files()[%d] = %q, want %q", i, files[i], want[i])
+		}
+	}
+	if _, err := os.Stat(path + ".3"); err == nil {
+		t.Error("file beyond maxFiles was kept")
+	}
+
+	// The newest entry is in the active file, the oldest kept in path.2.
+	newest, err := readFallbackFile(path)
+	if err != nil {
+		t.Fatalf("readFallbackFile() error = %v", err)
+	}
+	if last := newest[len(newest)-1]; last.Message != "entry 9" {
+		t.Errorf("newest entry = %q, want %q", last.Message, "entry 9")
+	}
+}
+
+func TestReadFallbackFileSkipsTruncatedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logwell.ndjson")
+	data := `{"level":"info","message":"one"}` + "\n" + `{"level":"info","mess`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := readFallbackFile(path)
+	if err != nil {
+		t.Fatalf("readFallbackFile() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Message != "one" {
+		t.Errorf("entries = %+v, want the complete entry only", entries)
+	}
+}
+
+func TestClientFallbackFileSpillAndReplay(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var down atomic.Bool
+	down.Store(true)
+	ts.handler = func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var entries []LogEntry
+		json.NewDecoder(r.Body).Decode(&entries)
+		ts.mu.Lock()
+		ts.logs = append(ts.logs, entries...)
+		ts.mu.Unlock()
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: len(entries)})
+	}
+
+	path := filepath.Join(t.TempDir(), "logwell.ndjson")
+	client := createTestClient(t, ts,
+		WithMaxRetries(0),
+		WithBatchSize(10),
+		WithFallbackFile(path, 1<<20, 2),
+	)
+	defer client.Shutdown(context.Background())
+
+	client.Info("one")
+	client.Info("two")
+	if err := client.Flush(context.Background()); err == nil {
+		t.Fatal("Flush() error = nil, want server error")
+	}
+	if q := client.Stats().Queued; q != 0 {
+		t.Errorf("Queued = %d, want 0 after spilling", q)
+	}
+	spilled, err := readFallbackFile(path)
+	if err != nil || len(spilled) != 2 {
+		t.Fatalf("fallback file entries = %d (err %v), want 2", len(spilled), err)
+	}
+
+	// Replay while the server is still down keeps the file.
+	if err := client.ReplayFallback(context.Background()); err == nil {
+		t.Fatal("ReplayFallback() error = nil, want server error")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("fallback file removed after failed replay: %v", err)
+	}
+
+	down.Store(false)
+	if err := client.ReplayFallback(context.Background()); err != nil {
+		t.Fatalf("ReplayFallback() error = %v", err)
+	}
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 2)
+	if len(logs) == 2 && (logs[0].Message != "one" || logs[1].Message != "two") {
+		t.Errorf("replayed messages = %q, %q, want one, two", logs[0].Message, logs[1].Message)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("fallback file still present after replay: %v", err)
+	}
+}
+
+func TestClientFallbackFileKeepsRejectedBatchQueued(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+
+	path := filepath.Join(t.TempDir(), "logwell.ndjson")
+	client := createTestClient(t, ts, WithFallbackFile(path, 1<<20, 1))
+	defer client.Shutdown(context.Background())
+
+	client.Info("rejected")
+	if err := client.Flush(context.Background()); err == nil {
+		t.Fatal("Flush() error = nil, want validation error")
+	}
+	if q := client.Stats().Queued; q != 1 {
+		t.Errorf("Queued = %d, want the rejected batch re-queued", q)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("rejected batch was spilled: %v", err)
+	}
+}
+
+func TestClientReplayFallbackWithoutFile(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	client := createTestClient(t, ts)
+	defer client.Shutdown(context.Background())
+
+	if err := client.ReplayFallback(context.Background()); err != nil {
+		t.Errorf("ReplayFallback() error = %v, want nil", err)
+	}
+}
@@ -0,0 +1,59 @@
+package logwell
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultFatalFlushTimeout bounds how long Fatal waits for pending entries
+// to be delivered before exiting or panicking.
+const DefaultFatalFlushTimeout = 5 * time.Second
+
+// FatalBehavior controls what Fatal, Fatalf, and FatalFields do after
+// logging. Log with LevelFatal is not affected.
+type FatalBehavior uint8
+
+const (
+	// ExitProcess shuts the client down, delivering pending entries, and
+	// calls the exit function (os.Exit by default) with status 1.
+	// This is the default, matching log.Fatal.
+	ExitProcess FatalBehavior = iota
+
+	// PanicAfterLog flushes pending entries and panics with the message.
+	PanicAfterLog
+
+	// LogOnly logs the entry like any other level and returns.
+	LogOnly
+)
+
+// String returns a readable name such as "exit-process".
+func (b FatalBehavior) String() string {
+	switch b {
+	case PanicAfterLog:
+		return "panic-after-log"
+	case LogOnly:
+		return "log-only"
+	default:
+		return "exit-process"
+	}
+}
+
+// afterFatal applies the root client's FatalBehavior once a fatal entry
+// has been logged.
+func (c *Client) afterFatal(message string) {
+	root := c.root()
+	behavior := root.config.FatalBehavior
+	if behavior == LogOnly {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultFatalFlushTimeout)
+	defer cancel()
+
+	if behavior == PanicAfterLog {
+		_ = c.Flush(ctx) // send failures already reach OnError
+		panic(message)
+	}
+	_ = root.Shutdown(ctx)
+	root.config.ExitFunc(1)
+}
@@ -0,0 +1,72 @@
+package logwell
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFatalExitProcess(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var code = -1
+	client := createTestClient(t, ts, WithBatchSize(100), WithExitFunc(func(c int) { code = c }))
+	child := client.With(M{"k": "v"})
+
+	child.Info("before")
+	child.Fatal("out of memory")
+
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	// Pending entries were delivered and the root shut down before exiting.
+	assertLogCount(t, ts.getLogs(), 2)
+	if err := client.Flush(context.Background()); err != ErrClientClosed {
+		t.Errorf("Flush() error = %v, want ErrClientClosed", err)
+	}
+}
+
+func TestFatalPanicAfterLog(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithBatchSize(100), WithFatalBehavior(PanicAfterLog))
+	defer client.Shutdown(context.Background())
+
+	defer func() {
+		if r := recover(); r != "disk full: /var" {
+			t.Errorf("panic value = %v, want the message", r)
+		}
+		assertLogCount(t, ts.getLogs(), 1)
+	}()
+	client.Fatalf("disk full: %s", "/var")
+	t.Error("Fatalf returned under PanicAfterLog")
+}
+
+func TestFatalLogOnly(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithFatalBehavior(LogOnly), WithExitFunc(func(int) {
+		t.Error("exit called under LogOnly")
+	}))
+	defer client.Shutdown(context.Background())
+
+	client.FatalFields("still running", String("k", "v"))
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	assertLogCount(t, ts.getLogs(), 1)
+}
+
+func TestFatalBehaviorString(t *testing.T) {
+	for b, want := range map[FatalBehavior]string{
+		ExitProcess:   "exit-process",
+		PanicAfterLog: "panic-after-log",
+		LogOnly:       "log-only",
+	} {
+		if got := b.String(); got != want {
+			t.Errorf("String() = %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package logwell
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fieldType identifies which member of Field holds the value.
+type fieldType uint8
+
+const (
+	fieldSkip fieldType = iota
+	fieldString
+	fieldInt
+	fieldFloat
+	fieldBool
+	fieldDuration
+	fieldTime
+	fieldError
+	fieldAny
+)
+
+// Field is a strongly-typed metadata key-value pair.
+//
+// Fields are passed by value to the *Fields logging methods (InfoFields,
+// ErrorFields, ...). Unlike M, constructing a Field does not allocate a map
+// or box primitive values into interfaces; values are converted only once
+// when the entry is built.
+type Field struct {
+	Key string
+
+	typ fieldType
+	num int64
+	str string
+	val any
+}
+
+// String returns a Field holding a string value.
+func String(key, value string) Field {
+	return Field{Key: key, typ: fieldString, str: value}
+}
+
+// Int returns a Field holding an int value.
+func Int(key string, value int) Field {
+	return Field{Key: key, typ: fieldInt, num: int64(value)}
+}
+
+// Int64 returns a Field holding an int64 value.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, typ: fieldInt, num: value}
+}
+
+// Float64 returns a Field holding a float64 value.
+func Float64(key string, value float64) Field {
+	return Field{Key: key, typ: fieldFloat, num: int64(math.Float64bits(value))}
+}
+
+// Bool returns a Field holding a bool value.
+func Bool(key string, value bool) Field {
+	var n int64
+	if value {
+		n = 1
+	}
+	return Field{Key: key, typ: fieldBool, num: n}
+}
+
+// Dur returns a Field holding a duration, serialized as fractional milliseconds.
+func Dur(key string, value time.Duration) Field {
+	return Field{Key: key, typ: fieldDuration, num: int64(value)}
+}
+
+// Time returns a Field holding a time, serialized as an RFC3339Nano UTC string.
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, typ: fieldTime, num: value.UnixNano()}
+}
+
+// Err returns a Field describing err. A nil error produces a Field that is skipped.
+//
+// When the entry is built, the field expands into several metadata keys:
+//   - "error": err.Error()
+//   - "errorType": the dynamic type of err (e.g. "*fs.PathError")
+//   - "errorChain": messages of every error wrapped by err (via Unwrap), if any
+//   - "errorStack": the formatted stack trace, if err implements StackTracer
+func Err(err error) Field {
+	if err == nil {
+		return Field{typ: fieldSkip}
+	}
+	return Field{Key: "error", typ: fieldError, val: err}
+}
+
+// Any returns a Field holding an arbitrary value. Prefer the typed
+// constructors where possible; Any boxes value like M does.
+func Any(key string, value any) Field {
+	return Field{Key: key, typ: fieldAny, val: value}
+}
+
+// Value returns the field's value in the form it is serialized to JSON.
+func (f Field) Value() any {
+	switch f.typ {
+	case fieldString:
+		return f.str
+	case fieldInt:
+		return f.num
+	case fieldFloat:
+		return math.Float64frombits(uint64(f.num))
+	case fieldBool:
+		return f.num == 1
+	case fieldDuration:
+		return float64(f.num) / float64(time.Millisecond)
+	case fieldTime:
+		return time.Unix(0, f.num).UTC().Format(time.RFC3339Nano)
+	case fieldError:
+		return f.val.(error).Error()
+	case fieldAny:
+		return f.val
+	default:
+		return nil
+	}
+}
+
+// StackTracer is implemented by errors that record the call stack where they
+// were created. StackTrace returns program counters as filled in by
+// runtime.Callers. Err and WithError format the trace into "errorStack".
+type StackTracer interface {
+	StackTrace() []uintptr
+}
+
+// errorMetadata writes the structured description of err into dst.
+// See Err for the keys produced.
+func errorMetadata(dst map[string]any, err error) {
+	dst["error"] = err.Error()
+	dst["errorType"] = fmt.Sprintf("%T", err)
+
+	if chain := unwrapChain(err); len(chain) > 0 {
+		dst["errorChain"] = chain
+	}
+
+	var st StackTracer
+	if errors.As(err, &st) {
+		if stack := formatStack(st.StackTrace()); stack != "" {
+			dst["errorStack"] = stack
+		}
+	}
+}
+
+// unwrapChain returns the messages of every error wrapped by err, depth-first,
+// following both Unwrap() error and Unwrap() []error (errors.Join).
+func unwrapChain(err error) []string {
+	var chain []string
+	var walk func(error)
+	walk = func(e error) {
+		switch u := e.(type) {
+		case interface{ Unwrap() error }:
+			if inner := u.Unwrap(); inner != nil {
+				chain = append(chain, inner.Error())
+				walk(inner)
+			}
+		case interface{ Unwrap() []error }:
+			for _, inner := range u.Unwrap() {
+				if inner != nil {
+					chain = append(chain, inner.Error())
+					walk(inner)
+				}
+			}
+		}
+	}
+	walk(err)
+	return chain
+}
+
+// formatStack renders program counters as "function\n\tfile:line" lines,
+// matching the layout of runtime/debug.Stack.
+func formatStack(pcs []uintptr) string {
+	if len(pcs) == 0 {
+		return ""
+	}
+	var all []runtime.Frame
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		all = append(all, frame)
+		if !more {
+			break
+		}
+	}
+	return formatFrames(all)
+}
+
+// formatFrames renders frames in the layout of formatStack.
+func formatFrames(frames []runtime.Frame) string {
+	var b strings.Builder
+	for _, frame := range frames {
+		if frame.Function != "" {
+			b.WriteString(frame.Function)
+			b.WriteString("\n\t")
+			b.WriteString(frame.File)
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(frame.Line))
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// fieldsToMetadata builds entry metadata from base (the logger's metadata)
+// and fields in a single allocation. Later fields override earlier ones and
+// base values. Returns nil if there is nothing to record.
+func fieldsToMetadata(base map[string]any, fields []Field) map[string]any {
+	if len(base) == 0 && len(fields) == 0 {
+		return nil
+	}
+
+	result := make(map[string]any, len(base)+len(fields))
+	for k, v := range base {
+		result[k] = v
+	}
+	for _, f := range fields {
+		switch f.typ {
+		case fieldSkip:
+			continue
+		case fieldError:
+			errorMetadata(result, f.val.(error))
+		default:
+			result[f.Key] = f.Value()
+		}
+	}
+
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,188 @@
+package logwell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFieldValue(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("X", 3600))
+
+	tests := []struct {
+		name  string
+		field Field
+		want  any
+	}{
+		{"string", String("k", "v"), "v"},
+		{"int", Int("k", 42), int64(42)},
+		{"int64", Int64("k", -7), int64(-7)},
+		{"float64", Float64("k", 1.5), 1.5},
+		{"bool true", Bool("k", true), true},
+		{"bool false", Bool("k", false), false},
+		{"duration", Dur("k", 1500*time.Microsecond), 1.5},
+		{"time", Time("k", ts), "2024-01-02T02:04:05.000000006Z"},
+		{"error", Err(errors.New("boom")), "boom"},
+		{"any", Any("k", []int{1}), []int{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.field.Value()
+			if s, ok := tt.want.([]int); ok {
+				g, ok := got.([]int)
+				if !ok || len(g) != len(s) || g[0] != s[0] {
+					t.Errorf("Value() = %v, want %v", got, tt.want)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Value() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrField_Key(t *testing.T) {
+	if f := Err(errors.New("x")); f.Key != "error" {
+		t.Errorf("Key = %q, want %q", f.Key, "error")
+	}
+}
+
+func TestFieldsToMetadata(t *testing.T) {
+	t.Run("nil when empty", func(t *testing.T) {
+		if got := fieldsToMetadata(nil, nil); got != nil {
+			t.Errorf("fieldsToMetadata() = %v, want nil", got)
+		}
+	})
+
+	t.Run("nil error field is skipped", func(t *testing.T) {
+		if got := fieldsToMetadata(nil, []Field{Err(nil)}); got != nil {
+			t.Errorf("fieldsToMetadata() = %v, want nil", got)
+		}
+	})
+
+	t.Run("fields override base", func(t *testing.T) {
+		base := map[string]any{"env": "prod", "keep": true}
+		got := fieldsToMetadata(base, []Field{String("env", "dev"), Int("n", 1)})
+		if got["env"] != "dev" || got["keep"] != true || got["n"] != int64(1) {
+			t.Errorf("fieldsToMetadata() = %v", got)
+		}
+		if base["env"] != "prod" {
+			t.Error("base metadata was mutated")
+		}
+	})
+}
+
+func TestClientFieldsLogging(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts,
+		WithBatchSize(1),
+		WithMetadata(M{"env": "test"}),
+		WithCaptureSourceLocation(true),
+	)
+	defer client.Shutdown(context.Background())
+
+	entry := logAndWait(client, ts, func(msg string, _ ...map[string]any) {
+		client.ErrorFields(msg, String("path", "/x"), Int("status", 500), Err(errors.New("db down")))
+	}, "request failed")
+
+	if entry.Level != LevelError {
+		t.Errorf("Level = %q, want %q", entry.Level, LevelError)
+	}
+	assertLogMetadata(t, entry, map[string]string{"env": "test", "path": "/x", "error": "db down"})
+	if entry.Metadata["status"] != float64(500) {
+		t.Errorf("status = %v, want 500", entry.Metadata["status"])
+	}
+	if !strings.HasSuffix(entry.SourceFile, "field_test.go") {
+		t.Errorf("SourceFile = %q, want field_test.go", entry.SourceFile)
+	}
+}
+
+// stackErr is a test error that records its creation stack.
+type stackErr struct {
+	msg string
+	pcs []uintptr
+}
+
+func newStackErr(msg string) *stackErr {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	return &stackErr{msg: msg, pcs: pcs[:n]}
+}
+
+func (e *stackErr) Error() string         { return e.msg }
+func (e *stackErr) StackTrace() []uintptr { return e.pcs }
+
+func TestErrField_Expansion(t *testing.T) {
+	t.Run("wrapped chain", func(t *testing.T) {
+		root := errors.New("connection refused")
+		err := fmt.Errorf("query users: %w", fmt.Errorf("dial db: %w", root))
+
+		meta := fieldsToMetadata(nil, []Field{Err(err)})
+		if meta["error"] != "query users: dial db: connection refused" {
+			t.Errorf("error = %v", meta["error"])
+		}
+		if meta["errorType"] != "*fmt.wrapError" {
+			t.Errorf("errorType = %v", meta["errorType"])
+		}
+		chain, _ := meta["errorChain"].([]string)
+		want := []string{"dial db: connection refused", "connection refused"}
+		if len(chain) != len(want) || chain[0] != want[0] || chain[1] != want[1] {
+			t.Errorf("errorChain = %v, want %v", chain, want)
+		}
+	})
+
+	t.Run("joined errors", func(t *testing.T) {
+		err := errors.Join(errors.New("a"), errors.New("b"))
+		chain, _ := fieldsToMetadata(nil, []Field{Err(err)})["errorChain"].([]string)
+		if len(chain) != 2 || chain[0] != "a" || chain[1] != "b" {
+			t.Errorf("errorChain = %v, want [a b]", chain)
+		}
+	})
+
+	t.Run("unwrapped error has no chain", func(t *testing.T) {
+		meta := fieldsToMetadata(nil, []Field{Err(errors.New("plain"))})
+		if _, ok := meta["errorChain"]; ok {
+			t.Error("unexpected errorChain for unwrapped error")
+		}
+	})
+
+	t.Run("stack trace from StackTracer", func(t *testing.T) {
+		err := fmt.Errorf("wrapped: %w", newStackErr("deep"))
+		meta := fieldsToMetadata(nil, []Field{Err(err)})
+		stack, _ := meta["errorStack"].(string)
+		if !strings.Contains(stack, "TestErrField_Expansion") || !strings.Contains(stack, "field_test.go:") {
+			t.Errorf("errorStack = %q, want test frame", stack)
+		}
+	})
+}
+
+func TestClientWithError(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithBatchSize(1))
+	defer client.Shutdown(context.Background())
+
+	t.Run("nil error returns same logger", func(t *testing.T) {
+		if client.WithError(nil) != client {
+			t.Error("WithError(nil) should return the receiver")
+		}
+	})
+
+	t.Run("attaches error metadata", func(t *testing.T) {
+		err := fmt.Errorf("load config: %w", errors.New("file not found"))
+		entry := logAndWait(client, ts, client.WithError(err).Error, "startup failed")
+		assertLogMetadata(t, entry, map[string]string{"error": "load config: file not found"})
+		if _, ok := entry.Metadata["errorChain"]; !ok {
+			t.Error("missing errorChain metadata")
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package logwell
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Ping checks that the endpoint is healthy and accepts the client's API
+// key, so a deployment can fail fast at startup instead of discovering a
+// bad key through batches rejected in the background. It makes two
+// requests, neither of which stores a log: GET /api/health, then an empty
+// batch to the ingest API, which the server authenticates before it
+// rejects the empty body. A 404 from the health endpoint, as from a
+// gateway that does not route it, skips the health check.
+//
+// Returns ErrUnauthorized if the key is rejected, ErrServerError if the
+// server reports itself unhealthy, and ErrNetworkError if it cannot be
+// reached. With WithLevelRouting, every route's key is checked too. With
+// WithTransport, Ping calls the Transport's Ping(ctx) error method if it
+// has one, and otherwise returns nil.
+//
+// Example:
+//
+//	if err := client.Ping(ctx); err != nil {
+//	    log.Fatalf("logwell: %v", err)
+//	}
+func (c *Client) Ping(ctx context.Context) error {
+	root := c.root()
+	if err := root.transport.ping(ctx); err != nil {
+		return err
+	}
+	for _, route := range root.routeClients() {
+		if err := route.transport.ping(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ping checks the active endpoint's health and the API key.
+func (t *httpTransport) ping(ctx context.Context) error {
+	if t.custom != nil {
+		if pinger, ok := t.custom.(interface{ Ping(context.Context) error }); ok {
+			return pinger.Ping(ctx)
+		}
+		return nil
+	}
+
+	base := strings.TrimRight(t.activeEndpoint(), "/")
+	err := t.pingRequest(ctx, http.MethodGet, base+"/api/health", nil)
+	if logwellErr, ok := err.(*Error); ok && logwellErr.StatusCode == http.StatusNotFound {
+		// A gateway or server without the health endpoint; check the key alone.
+		err = nil
+	}
+	if err != nil {
+		return err
+	}
+	err = t.pingRequest(ctx, http.MethodPost, base+"/v1/ingest", []byte("[]"))
+	if logwellErr, ok := err.(*Error); ok && logwellErr.StatusCode == http.StatusBadRequest {
+		// Authenticated, then rejected for carrying no entries.
+		return nil
+	}
+	return err
+}
+
+// pingRequest makes one request for ping, bounded by the request timeout,
+// and converts a non-2xx response into an Error.
+func (t *httpTransport) pingRequest(ctx context.Context, method, url string, body []byte) error {
+	parent := ctx
+	if t.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.requestTimeout)
+		defer cancel()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return NewErrorWithCause(ErrNetworkError, "failed to create request", err)
+	}
+	t.setHeaders(req, body)
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return t.attemptError(ctx, parent, "ping failed", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return t.attemptError(ctx, parent, "failed to read response", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return createError(resp.StatusCode, parseErrorMessage(respBody, resp.StatusCode))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package logwell
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newPingServer returns a server that reports health with healthStatus
+// and accepts only validAPIKey on the ingest API.
+func newPingServer(t *testing.T, healthStatus int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/health":
+			w.WriteHeader(healthStatus)
+		case "/v1/ingest":
+			if r.Header.Get("Authorization") != "Bearer "+validAPIKey() {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"error":"unauthorized","message":"Invalid API key"}`))
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"validation_error","message":"Request body cannot be an empty array"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestPing(t *testing.T) {
+	otherKey := "lw_" + strings.Repeat("x", 32)
+	tests := []struct {
+		name         string
+		healthStatus int
+		apiKey       string
+		wantErr      error
+	}{
+		{"healthy with valid key", http.StatusOK, validAPIKey(), nil},
+		{"no health endpoint", http.StatusNotFound, validAPIKey(), nil},
+		{"rejected key", http.StatusOK, otherKey, ErrUnauthorizedErr},
+		{"unhealthy", http.StatusServiceUnavailable, validAPIKey(), ErrServerErr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newPingServer(t, tt.healthStatus)
+			client, err := New(srv.URL, tt.apiKey)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			defer client.Shutdown(context.Background())
+
+			err = client.Child().Ping(context.Background())
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("Ping() error = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Ping() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPingRoutes(t *testing.T) {
+	srv := newPingServer(t, http.StatusOK)
+	client, err := New(srv.URL, validAPIKey(),
+		WithLevelRouting(map[LogLevel]RouteConfig{LevelError: {APIKey: alertsAPIKey}}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrUnauthorizedErr) {
+		t.Errorf("Ping() error = %v, want ErrUnauthorized for the route's key", err)
+	}
+}
+
+func TestPingNetworkError(t *testing.T) {
+	srv := newPingServer(t, http.StatusOK)
+	srv.Close()
+	client, err := New(srv.URL, validAPIKey())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrNetworkErr) {
+		t.Errorf("Ping() error = %v, want ErrNetworkError", err)
+	}
+}
+
+// pingTransport is a Transport with a Ping method.
+type pingTransport struct {
+	transportFunc
+	err error
+}
+
+func (p pingTransport) Ping(context.Context) error { return p.err }
+
+func TestPingCustomTransport(t *testing.T) {
+	send := transportFunc(func(context.Context, []LogEntry) (*IngestResponse, error) { return nil, nil })
+	pingErr := NewError(ErrNetworkError, "down")
+	for _, tt := range []struct {
+		name      string
+		transport Transport
+		wantErr   error
+	}{
+		{"without Ping", send, nil},
+		{"with Ping", pingTransport{send, pingErr}, pingErr},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := New(validEndpoint(), validAPIKey(), WithTransport(tt.transport))
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			defer client.Shutdown(context.Background())
+			if err := client.Ping(context.Background()); err != tt.wantErr {
+				t.Errorf("Ping() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
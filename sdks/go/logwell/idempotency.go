@@ -0,0 +1,83 @@
+package logwell
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// IdempotencyKeyHeader carries the per-batch idempotency key. Every retry
+// of a batch sends the same key, so the server can drop a batch it already
+// ingested before a timeout hid the response.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKeyCtxKey struct{}
+
+// IdempotencyKeyFromContext returns the idempotency key of the batch being
+// sent. A custom Transport should forward it to the server alongside the
+// batch; it is the same across every attempt of that batch.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	return key, ok && key != ""
+}
+
+// withIdempotencyKey returns ctx carrying key for the send it is passed to.
+func withIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// newIdempotencyKey returns a random (version 4) UUID.
+func newIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	var s [36]byte
+	hex.Encode(s[0:8], b[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], b[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], b[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], b[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], b[10:])
+	return string(s[:])
+}
+
+// keyedEntry is the wire form of an entry sent with an idempotency key.
+// The ingest API takes a bare JSON array, so the key is repeated on each
+// entry rather than wrapping the batch; servers that do not dedupe ignore it.
+type keyedEntry struct {
+	LogEntry
+	IdempotencyKey string `json:"idempotencyKey"`
+}
+
+// encodeBatch encodes logs as the ingest request body into a pooled
+// buffer, tagging each entry with key when it is non-empty. The caller
+// must release the buffer.
+func encodeBatch(logs []LogEntry, key string) (*bodyBuffer, error) {
+	buf := bufferPool.Get().(*bodyBuffer)
+	buf.refs.Store(1)
+	enc := json.NewEncoder(buf)
+	buf.WriteByte('[')
+	for i := range logs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		var err error
+		if key != "" {
+			err = enc.Encode(keyedEntry{LogEntry: logs[i], IdempotencyKey: key})
+		} else {
+			err = enc.Encode(&logs[i])
+		}
+		if err != nil {
+			buf.release()
+			return nil, err
+		}
+	}
+	buf.WriteByte(']')
+	return buf, nil
+}
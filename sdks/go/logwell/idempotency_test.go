@@ -0,0 +1,130 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+var uuidV4Pattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 100 {
+		key := newIdempotencyKey()
+		if !uuidV4Pattern.MatchString(key) {
+			t.Fatalf("newIdempotencyKey() = %q, want a version 4 UUID", key)
+		}
+		if seen[key] {
+			t.Fatalf("newIdempotencyKey() repeated %q", key)
+		}
+		seen[key] = true
+	}
+}
+
+// TestIdempotencyKey_RetriesReuseKey tests that every attempt of a batch
+// sends the same key in the header and on each body entry, and that the
+// key is reported to OnBatchSentInfo.
+func TestIdempotencyKey_RetriesReuseKey(t *testing.T) {
+	var mu sync.Mutex
+	var headerKeys, bodyKeys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var raw []map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		mu.Lock()
+		attempt := len(headerKeys)
+		headerKeys = append(headerKeys, r.Header.Get(IdempotencyKeyHeader))
+		for _, entry := range raw {
+			key, _ := entry["idempotencyKey"].(string)
+			bodyKeys = append(bodyKeys, key)
+		}
+		mu.Unlock()
+
+		if attempt == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: len(raw)})
+	}))
+	defer server.Close()
+
+	var infos []BatchInfo
+	client, err := New(server.URL, validAPIKey(),
+		WithMaxRetries(1),
+		WithOnBatchSentInfo(func(info BatchInfo) { infos = append(infos, info) }),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("one")
+	client.Info("two")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(headerKeys) != 2 {
+		t.Fatalf("requests = %d, want 2", len(headerKeys))
+	}
+	key := headerKeys[0]
+	if !uuidV4Pattern.MatchString(key) || headerKeys[1] != key {
+		t.Errorf("%s headers = %q, want one UUID on both attempts", IdempotencyKeyHeader, headerKeys)
+	}
+	for i, got := range bodyKeys {
+		if got != key {
+			t.Errorf("body entry %d idempotencyKey = %q, want %q", i, got, key)
+		}
+	}
+	if len(infos) != 1 {
+		t.Fatalf("OnBatchSentInfo called %d times, want 1", len(infos))
+	}
+	if info := infos[0]; info.IdempotencyKey != key || info.Entries != 2 || info.Accepted != 2 || info.Latency <= 0 {
+		t.Errorf("BatchInfo = %+v, want key %q with 2 entries accepted", info, key)
+	}
+}
+
+// TestIdempotencyKey_DistinctPerBatch tests that separate batches get
+// separate keys.
+func TestIdempotencyKey_DistinctPerBatch(t *testing.T) {
+	var keys []string
+	client, err := New("http://unused.invalid", validAPIKey(),
+		WithBatchSize(1),
+		WithTransport(transportFunc(func(ctx context.Context, entries []LogEntry) (*IngestResponse, error) {
+			key, ok := IdempotencyKeyFromContext(ctx)
+			if !ok {
+				t.Error("IdempotencyKeyFromContext() ok = false, want key")
+			}
+			keys = append(keys, key)
+			return nil, nil
+		})),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("one")
+	client.Info("two")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if len(keys) != 2 || keys[0] == keys[1] {
+		t.Errorf("keys = %q, want two distinct keys", keys)
+	}
+}
+
+func TestIdempotencyKeyFromContext_Missing(t *testing.T) {
+	if key, ok := IdempotencyKeyFromContext(context.Background()); ok || key != "" {
+		t.Errorf("IdempotencyKeyFromContext() = (%q, %v), want (\"\", false)", key, ok)
+	}
+}
@@ -0,0 +1,331 @@
+package logwell
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DefaultImportChunkSize is the number of entries Import sends per request,
+// the most the server's ingest endpoint accepts in one batch.
+const DefaultImportChunkSize = 100
+
+// ImportProgress reports how far an Import has got.
+type ImportProgress struct {
+	// Offset is the byte offset in the input just past the last record of
+	// the last chunk the server accepted. Passing it to ImportWithOffset
+	// resumes the import after that chunk.
+	Offset int64
+
+	// Records is the number of records sent and accepted in this call.
+	Records int
+
+	// Accepted is the number of entries the server stored.
+	Accepted int
+
+	// Rejected is the number of entries the server refused as invalid.
+	Rejected int
+}
+
+// ImportOption configures a single Import call.
+type ImportOption func(*importConfig)
+
+type importConfig struct {
+	chunkSize  int
+	offset     int64
+	onProgress func(ImportProgress)
+}
+
+// ImportWithChunkSize sets how many entries are sent per request.
+// Values outside 1-DefaultImportChunkSize are ignored.
+func ImportWithChunkSize(n int) ImportOption {
+	return func(cfg *importConfig) {
+		if n >= MinBatchSize && n <= DefaultImportChunkSize {
+			cfg.chunkSize = n
+		}
+	}
+}
+
+// ImportWithOffset resumes an import at a byte offset previously reported
+// in ImportProgress.Offset. The reader must yield the same input as
+// before, from its start. A CSV header is still read from the start.
+func ImportWithOffset(offset int64) ImportOption {
+	return func(cfg *importConfig) {
+		cfg.offset = max(offset, 0)
+	}
+}
+
+// ImportWithProgress calls fn after each chunk the server accepts, on the
+// goroutine calling Import.
+func ImportWithProgress(fn func(ImportProgress)) ImportOption {
+	return func(cfg *importConfig) {
+		cfg.onProgress = fn
+	}
+}
+
+// Import reads historical logs from r and sends them to the project in
+// chunks, for migrating from another logging system. The format is one of
+// those written by QueryClient.Export:
+//
+//   - ExportNDJSON: one JSON object per line, either a LogRecord or a
+//     LogEntry.
+//   - ExportCSV: a header row naming the columns, then one row per log.
+//     level and message are required; timestamp, service, metadata (a JSON
+//     object), sourceFile, and lineNumber are read when present and other
+//     columns are ignored.
+//
+// Each chunk is sent on the caller's goroutine and must be accepted before
+// more input is read. This applies backpressure to the reader. The usual
+// retry policy applies to each chunk, including waiting out Retry-After
+// on rate limiting. Entries keep their timestamps and skip the client's
+// queue and entry pipeline (processors, redaction, sampling, rate
+// limiting); only a missing service is filled from the client's.
+//
+// On failure Import returns the progress made so far with the error, so
+// the import can be resumed with ImportWithOffset. Input that cannot be
+// parsed returns an ErrValidationError error naming its offset.
+//
+// Example:
+//
+//	f, err := os.Open("history.ndjson")
+//	if err != nil {
+//	    return err
+//	}
+//	defer f.Close()
+//	progress, err := client.Import(ctx, f, logwell.ExportNDJSON,
+//	    logwell.ImportWithOffset(lastOffset),
+//	    logwell.ImportWithProgress(func(p logwell.ImportProgress) { lastOffset = p.Offset }),
+//	)
+func (c *Client) Import(ctx context.Context, r io.Reader, format ExportFormat, opts ...ImportOption) (ImportProgress, error) {
+	cfg := importConfig{chunkSize: DefaultImportChunkSize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	progress := ImportProgress{Offset: cfg.offset}
+	if c.isClosed() {
+		return progress, ErrClientClosed
+	}
+
+	var dec importDecoder
+	var err error
+	switch format {
+	case ExportNDJSON, "":
+		dec, err = newNDJSONImport(r, cfg.offset)
+	case ExportCSV:
+		dec, err = newCSVImport(r, cfg.offset)
+	default:
+		return progress, NewError(ErrInvalidConfig, "import format must be ndjson or csv")
+	}
+	if err != nil {
+		return progress, err
+	}
+
+	transport := c.root().transport
+	chunk := make([]LogEntry, 0, cfg.chunkSize)
+	for {
+		entry, err := dec.next()
+		if err != nil && err != io.EOF {
+			return progress, err
+		}
+		if err == nil {
+			if entry.Service == "" {
+				entry.Service = c.config.Service
+			}
+			chunk = append(chunk, entry)
+			if len(chunk) < cfg.chunkSize {
+				continue
+			}
+		}
+
+		if len(chunk) > 0 {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return progress, NewErrorWithCause(ErrNetworkError, "import canceled", ctxErr)
+			}
+			resp, sendErr := transport.sendWithRetry(ctx, chunk)
+			if sendErr != nil {
+				return progress, sendErr
+			}
+			progress.Offset = dec.offset()
+			progress.Records += len(chunk)
+			progress.Accepted += resp.Accepted
+			progress.Rejected += resp.Rejected
+			if cfg.onProgress != nil {
+				cfg.onProgress(progress)
+			}
+			chunk = chunk[:0]
+		}
+		if err == io.EOF {
+			return progress, nil
+		}
+	}
+}
+
+// importDecoder reads entries from an import's input.
+type importDecoder interface {
+	// next returns the next entry, or io.EOF at the end of the input.
+	next() (LogEntry, error)
+
+	// offset returns the input offset just past the last entry returned.
+	offset() int64
+}
+
+// importRecord accepts both the LogRecord and LogEntry encodings.
+type importRecord struct {
+	Level       LogLevel `json:"level"`
+	Message     string   `json:"message"`
+	Timestamp   string   `json:"timestamp"`
+	Service     string   `json:"service"`
+	ServiceName string   `json:"serviceName"`
+	Metadata    M        `json:"metadata"`
+	SourceFile  string   `json:"sourceFile"`
+	LineNumber  int      `json:"lineNumber"`
+}
+
+func (r *importRecord) entry() LogEntry {
+	service := r.Service
+	if service == "" {
+		service = r.ServiceName
+	}
+	return LogEntry{
+		Level:      r.Level,
+		Message:    r.Message,
+		Timestamp:  r.Timestamp,
+		Service:    service,
+		Metadata:   r.Metadata,
+		SourceFile: r.SourceFile,
+		LineNumber: r.LineNumber,
+	}
+}
+
+// importError reports input that cannot be imported.
+func importError(offset int64, err error) error {
+	return NewErrorWithCause(ErrValidationError, fmt.Sprintf("import: invalid record at offset %d", offset), err)
+}
+
+// ndjsonImport decodes newline-delimited JSON.
+type ndjsonImport struct {
+	dec  *json.Decoder
+	base int64
+	end  int64
+}
+
+func newNDJSONImport(r io.Reader, offset int64) (*ndjsonImport, error) {
+	if offset > 0 {
+		if _, err := io.CopyN(io.Discard, r, offset); err != nil {
+			return nil, NewErrorWithCause(ErrInvalidConfig, "import offset is past the end of the input", err)
+		}
+	}
+	return &ndjsonImport{dec: json.NewDecoder(r), base: offset, end: offset}, nil
+}
+
+func (d *ndjsonImport) next() (LogEntry, error) {
+	start := d.base + d.dec.InputOffset()
+	var rec importRecord
+	if err := d.dec.Decode(&rec); err != nil {
+		if err == io.EOF {
+			return LogEntry{}, io.EOF
+		}
+		return LogEntry{}, importError(start, err)
+	}
+	d.end = d.base + d.dec.InputOffset()
+	return rec.entry(), nil
+}
+
+func (d *ndjsonImport) offset() int64 { return d.end }
+
+// csvImport decodes CSV with a header row.
+type csvImport struct {
+	r       *csv.Reader
+	columns map[string]int
+	end     int64
+}
+
+func newCSVImport(r io.Reader, offset int64) (*csvImport, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, NewError(ErrValidationError, "import: missing CSV header")
+		}
+		return nil, importError(0, err)
+	}
+	d := &csvImport{r: cr, columns: make(map[string]int, len(header)), end: cr.InputOffset()}
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, dup := d.columns[name]; !dup {
+			d.columns[name] = i
+		}
+	}
+	if _, ok := d.columns["level"]; !ok {
+		return nil, NewError(ErrValidationError, "import: CSV header has no level column")
+	}
+	if _, ok := d.columns["message"]; !ok {
+		return nil, NewError(ErrValidationError, "import: CSV header has no message column")
+	}
+
+	// Records must be parsed to skip them: quoted fields can span lines.
+	for d.end < offset {
+		if _, err := cr.Read(); err != nil {
+			if err == io.EOF {
+				return nil, NewError(ErrInvalidConfig, "import offset is past the end of the input")
+			}
+			return nil, importError(d.end, err)
+		}
+		d.end = cr.InputOffset()
+	}
+	if d.end != offset && offset > 0 {
+		return nil, NewError(ErrInvalidConfig, fmt.Sprintf("import offset %d is not at a record boundary", offset))
+	}
+	return d, nil
+}
+
+func (d *csvImport) next() (LogEntry, error) {
+	start := d.end
+	row, err := d.r.Read()
+	if err != nil {
+		if err == io.EOF {
+			return LogEntry{}, io.EOF
+		}
+		return LogEntry{}, importError(start, err)
+	}
+	field := func(name string) string {
+		if i, ok := d.columns[name]; ok && i < len(row) {
+			return row[i]
+		}
+		return ""
+	}
+
+	entry := LogEntry{
+		Level:      LogLevel(field("level")),
+		Message:    field("message"),
+		Timestamp:  field("timestamp"),
+		Service:    field("service"),
+		SourceFile: field("sourceFile"),
+	}
+	if entry.Service == "" {
+		entry.Service = field("serviceName")
+	}
+	if s := field("metadata"); s != "" {
+		if err := json.Unmarshal([]byte(s), &entry.Metadata); err != nil {
+			return LogEntry{}, importError(start, errors.New("metadata is not a JSON object"))
+		}
+	}
+	if s := field("lineNumber"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return LogEntry{}, importError(start, fmt.Errorf("lineNumber %q is not a number", s))
+		}
+		entry.LineNumber = n
+	}
+	d.end = d.r.InputOffset()
+	return entry, nil
+}
+
+func (d *csvImport) offset() int64 { return d.end }
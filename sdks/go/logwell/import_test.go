@@ -0,0 +1,169 @@
+package logwell
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClientImportNDJSON(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	client := createTestClient(t, ts, WithService("importer"))
+	defer client.Shutdown(context.Background())
+
+	input := `{"id":"a","serviceName":"api","level":"error","message":"boom","metadata":{"k":"v"},"timestamp":"2024-01-02T03:04:05Z"}
+{"level":"info","message":"from entry","service":"worker","timestamp":"2024-01-02T03:04:06Z"}
+{"level":"warn","message":"no service"}
+`
+	var calls []ImportProgress
+	progress, err := client.Import(context.Background(), strings.NewReader(input), ExportNDJSON,
+		ImportWithChunkSize(2),
+		ImportWithProgress(func(p ImportProgress) { calls = append(calls, p) }),
+	)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if progress.Records != 3 || progress.Accepted != 3 || progress.Offset != int64(len(input)-1) {
+		t.Errorf("Import() = %+v", progress)
+	}
+	if len(calls) != 2 || calls[0].Records != 2 {
+		t.Errorf("progress calls = %+v", calls)
+	}
+
+	requests := ts.getRequests()
+	if len(requests) != 2 || len(requests[0]) != 2 || len(requests[1]) != 1 {
+		t.Fatalf("requests = %+v", requests)
+	}
+	first := requests[0][0]
+	if first.Service != "api" || first.Timestamp != "2024-01-02T03:04:05Z" || first.Metadata["k"] != "v" {
+		t.Errorf("first entry = %+v", first)
+	}
+	if got := requests[0][1].Service; got != "worker" {
+		t.Errorf("second service = %q, want worker", got)
+	}
+	if got := requests[1][0].Service; got != "importer" {
+		t.Errorf("third service = %q, want importer", got)
+	}
+}
+
+func TestClientImportResume(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	client := createTestClient(t, ts, WithMaxRetries(0))
+	defer client.Shutdown(context.Background())
+
+	var input bytes.Buffer
+	for _, msg := range []string{"one", "two", "three", "four"} {
+		json.NewEncoder(&input).Encode(LogEntry{Level: LevelInfo, Message: msg})
+	}
+
+	sent := 0
+	ts.setHandler(func(w http.ResponseWriter, r *http.Request) {
+		if sent == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sent++
+		w.Write([]byte(`{"accepted":2}`))
+	})
+	progress, err := client.Import(context.Background(), bytes.NewReader(input.Bytes()), ExportNDJSON, ImportWithChunkSize(2))
+	if err == nil {
+		t.Fatal("Import() error = nil, want failure on second chunk")
+	}
+	if progress.Records != 2 {
+		t.Fatalf("progress = %+v", progress)
+	}
+
+	ts.setHandler(nil)
+	progress, err = client.Import(context.Background(), bytes.NewReader(input.Bytes()), ExportNDJSON,
+		ImportWithOffset(progress.Offset))
+	if err != nil {
+		t.Fatalf("resumed Import() error = %v", err)
+	}
+	logs := ts.getLogs()
+	if progress.Records != 2 || len(logs) != 2 || logs[0].Message != "three" || logs[1].Message != "four" {
+		t.Errorf("resumed Import() = %+v, sent %+v", progress, logs)
+	}
+}
+
+func TestClientImportCSV(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	client := createTestClient(t, ts)
+	defer client.Shutdown(context.Background())
+
+	input := "id,timestamp,level,message,metadata,sourceFile,lineNumber,requestId,userId,ipAddress\n" +
+		`a,2024-01-02T03:04:05Z,error,"multi` + "\n" + `line","{""k"":""v""}",main.go,42,,,` + "\n" +
+		"b,2024-01-02T03:04:06Z,info,second,,,,,,\n" +
+		"c,2024-01-02T03:04:07Z,info,third,,,,,,\n"
+
+	progress, err := client.Import(context.Background(), strings.NewReader(input), ExportCSV, ImportWithChunkSize(1))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	logs := ts.getLogs()
+	if progress.Records != 3 || len(logs) != 3 {
+		t.Fatalf("Import() = %+v, sent %d", progress, len(logs))
+	}
+	first := logs[0]
+	if first.Message != "multi\nline" || first.Metadata["k"] != "v" || first.SourceFile != "main.go" || first.LineNumber != 42 {
+		t.Errorf("first entry = %+v", first)
+	}
+
+	// Resume after the first record: the header is still read.
+	secondOffset := int64(strings.Index(input, "b,"))
+	clearTestLogs(ts)
+	progress, err = client.Import(context.Background(), strings.NewReader(input), ExportCSV, ImportWithOffset(secondOffset))
+	if err != nil {
+		t.Fatalf("resumed Import() error = %v", err)
+	}
+	if logs := ts.getLogs(); progress.Records != 2 || logs[0].Message != "second" {
+		t.Errorf("resumed Import() = %+v, sent %+v", progress, logs)
+	}
+
+	_, err = client.Import(context.Background(), strings.NewReader(input), ExportCSV, ImportWithOffset(secondOffset+1))
+	assertConfigError(t, err, ErrInvalidConfig)
+}
+
+func TestClientImportInvalidInput(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	client := createTestClient(t, ts)
+	defer client.Shutdown(context.Background())
+
+	tests := []struct {
+		name   string
+		format ExportFormat
+		input  string
+		code   ErrorCode
+	}{
+		{"bad json", ExportNDJSON, `{"level":"info","message":"ok"}` + "\n{oops\n", ErrValidationError},
+		{"no message column", ExportCSV, "level,text\ninfo,hi\n", ErrValidationError},
+		{"bad metadata", ExportCSV, "level,message,metadata\ninfo,hi,[1]\n", ErrValidationError},
+		{"unknown format", "xml", "", ErrInvalidConfig},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Import(context.Background(), strings.NewReader(tt.input), tt.format)
+			assertConfigError(t, err, tt.code)
+		})
+	}
+	if n := len(ts.getLogs()); n != 0 {
+		t.Errorf("sent %d logs from invalid input, want 0", n)
+	}
+}
+
+func TestClientImportAfterShutdown(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	client := createTestClient(t, ts)
+	client.Shutdown(context.Background())
+
+	if _, err := client.Import(context.Background(), strings.NewReader(""), ExportNDJSON); err != ErrClientClosed {
+		t.Errorf("Import() error = %v, want ErrClientClosed", err)
+	}
+}
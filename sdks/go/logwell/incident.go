@@ -0,0 +1,217 @@
+package logwell
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// IncidentStatus is the state of an incident.
+//
+// Status is derived by the server: an incident is open while matching
+// errors keep arriving and resolves itself once none have been seen for the
+// auto-resolve window (30 minutes by default). There is no API to
+// acknowledge or resolve an incident by hand.
+type IncidentStatus string
+
+// Incident statuses.
+const (
+	IncidentOpen     IncidentStatus = "open"
+	IncidentResolved IncidentStatus = "resolved"
+)
+
+// IncidentRange is a look-back window accepted by the incident endpoints.
+type IncidentRange string
+
+// Incident ranges.
+const (
+	IncidentRange15m IncidentRange = "15m"
+	IncidentRange1h  IncidentRange = "1h"
+	IncidentRange24h IncidentRange = "24h"
+	IncidentRange7d  IncidentRange = "7d"
+)
+
+// Incident is a group of error and fatal logs sharing a fingerprint.
+type Incident struct {
+	ID                string         `json:"id"`
+	ProjectID         string         `json:"projectId"`
+	Fingerprint       string         `json:"fingerprint"`
+	Title             string         `json:"title"`
+	NormalizedMessage string         `json:"normalizedMessage"`
+	Service           string         `json:"serviceName,omitempty"`
+	SourceFile        string         `json:"sourceFile,omitempty"`
+	LineNumber        int            `json:"lineNumber,omitempty"`
+	HighestLevel      LogLevel       `json:"highestLevel"`
+	FirstSeen         time.Time      `json:"firstSeen"`
+	LastSeen          time.Time      `json:"lastSeen"`
+	TotalEvents       int            `json:"totalEvents"`
+	Status            IncidentStatus `json:"status"`
+}
+
+// IncidentDetail is an incident with root-cause hints.
+type IncidentDetail struct {
+	Incident
+
+	// RootCauseCandidates lists the source locations that logged the
+	// incident most often (up to 5).
+	RootCauseCandidates []SourceLocationCount `json:"rootCauseCandidates"`
+
+	// Correlations lists the request and trace IDs seen most often in the
+	// incident's logs (up to 10 each).
+	Correlations IncidentCorrelations `json:"correlations"`
+}
+
+// SourceLocationCount counts an incident's logs from one source location.
+type SourceLocationCount struct {
+	SourceFile string `json:"sourceFile"`
+	LineNumber int    `json:"lineNumber"`
+	Count      int    `json:"count"`
+}
+
+// IncidentCorrelations holds the IDs most frequently attached to an
+// incident's logs.
+type IncidentCorrelations struct {
+	TopRequestIDs []RequestIDCount `json:"topRequestIds"`
+	TopTraceIDs   []TraceIDCount   `json:"topTraceIds"`
+}
+
+// RequestIDCount counts an incident's logs carrying one request ID.
+type RequestIDCount struct {
+	RequestID string `json:"requestId"`
+	Count     int    `json:"count"`
+}
+
+// TraceIDCount counts an incident's logs carrying one trace ID.
+type TraceIDCount struct {
+	TraceID string `json:"traceId"`
+	Count   int    `json:"count"`
+}
+
+// IncidentQuery describes an incident listing.
+type IncidentQuery struct {
+	// Status selects open or resolved incidents. Default: IncidentOpen.
+	Status IncidentStatus
+
+	// Range restricts results to incidents seen within the window.
+	// Default: IncidentRange24h.
+	Range IncidentRange
+
+	// Limit is the page size. Default: 50, Range: 20-200 (clamped by the server).
+	Limit int
+
+	// Cursor resumes from a previous IncidentList.NextCursor.
+	Cursor string
+}
+
+// IncidentList is one page of incidents, most recently seen first.
+type IncidentList struct {
+	Incidents []Incident `json:"incidents"`
+
+	// Total is the number of matching incidents, or nil on pages fetched
+	// with a cursor.
+	Total *int `json:"total"`
+
+	// HasMore reports whether another page exists.
+	HasMore bool `json:"has_more"`
+
+	// NextCursor fetches the next page when passed as IncidentQuery.Cursor.
+	NextCursor string `json:"nextCursor"`
+}
+
+// IncidentTimelinePoint is the event count of one timeline bucket.
+type IncidentTimelinePoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	Count     int       `json:"count"`
+}
+
+// IncidentTimeline is an incident's event volume over time.
+type IncidentTimeline struct {
+	IncidentID string                  `json:"incidentId"`
+	Range      IncidentRange           `json:"range"`
+	Buckets    []IncidentTimelinePoint `json:"buckets"`
+
+	// PeakBucket is the busiest bucket, or nil if the range has no events.
+	PeakBucket *IncidentTimelinePoint `json:"peakBucket"`
+
+	Anchors struct {
+		FirstSeen time.Time `json:"firstSeen"`
+		LastSeen  time.Time `json:"lastSeen"`
+	} `json:"anchors"`
+}
+
+// ListIncidents returns one page of incidents matching query.
+func (q *QueryClient) ListIncidents(ctx context.Context, query IncidentQuery) (*IncidentList, error) {
+	params := url.Values{}
+	if query.Status != "" {
+		params.Set("status", string(query.Status))
+	}
+	if query.Range != "" {
+		params.Set("range", string(query.Range))
+	}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.Cursor != "" {
+		params.Set("cursor", query.Cursor)
+	}
+
+	var list IncidentList
+	if err := q.session.doJSON(ctx, http.MethodGet, projectsPath(q.projectID, "/incidents"), params, nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListAllIncidents iterates over every incident matching query, fetching
+// pages on demand. Iteration stops at the first error, which is yielded
+// with a zero Incident.
+func (q *QueryClient) ListAllIncidents(ctx context.Context, query IncidentQuery) iter.Seq2[Incident, error] {
+	return func(yield func(Incident, error) bool) {
+		for {
+			page, err := q.ListIncidents(ctx, query)
+			if err != nil {
+				yield(Incident{}, err)
+				return
+			}
+			for _, inc := range page.Incidents {
+				if !yield(inc, nil) {
+					return
+				}
+			}
+			if !page.HasMore || page.NextCursor == "" {
+				return
+			}
+			query.Cursor = page.NextCursor
+		}
+	}
+}
+
+// GetIncident returns an incident with its root-cause candidates and
+// correlated request and trace IDs.
+func (q *QueryClient) GetIncident(ctx context.Context, id string) (*IncidentDetail, error) {
+	var detail IncidentDetail
+	path := projectsPath(q.projectID, "/incidents/"+url.PathEscape(id))
+	if err := q.session.doJSON(ctx, http.MethodGet, path, nil, nil, &detail); err != nil {
+		return nil, err
+	}
+	return &detail, nil
+}
+
+// IncidentTimeline returns the incident's event counts over r.
+// An empty r means IncidentRange24h.
+func (q *QueryClient) IncidentTimeline(ctx context.Context, id string, r IncidentRange) (*IncidentTimeline, error) {
+	params := url.Values{}
+	if r != "" {
+		params.Set("range", string(r))
+	}
+
+	var timeline IncidentTimeline
+	path := projectsPath(q.projectID, "/incidents/"+url.PathEscape(id)+"/timeline")
+	if err := q.session.doJSON(ctx, http.MethodGet, path, params, nil, &timeline); err != nil {
+		return nil, err
+	}
+	return &timeline, nil
+}
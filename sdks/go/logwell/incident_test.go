@@ -0,0 +1,135 @@
+package logwell
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testIncidentJSON = `{"id":"inc_1","projectId":"proj_123","fingerprint":"fp","title":"DB timeout",
+	"normalizedMessage":"db timeout after <n>ms","serviceName":"api","sourceFile":"db.go","lineNumber":12,
+	"highestLevel":"fatal","firstSeen":"2025-01-01T00:00:00.000Z","lastSeen":"2025-01-01T01:00:00.000Z",
+	"totalEvents":17,"status":"open"`
+
+func TestQueryClientListIncidents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/projects/proj_123/incidents" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("status") != "resolved" || q.Get("range") != "7d" || q.Get("limit") != "20" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"incidents":[` + testIncidentJSON + `}],"total":1,"has_more":false,"nextCursor":null,
+			"filters":{"status":"resolved","range":"7d"}}`))
+	}))
+	defer server.Close()
+
+	list, err := newQueryTestClient(t, server).ListIncidents(context.Background(), IncidentQuery{
+		Status: IncidentResolved,
+		Range:  IncidentRange7d,
+		Limit:  20,
+	})
+	if err != nil {
+		t.Fatalf("ListIncidents() error = %v", err)
+	}
+	if len(list.Incidents) != 1 {
+		t.Fatalf("len(Incidents) = %d, want 1", len(list.Incidents))
+	}
+	inc := list.Incidents[0]
+	if inc.ID != "inc_1" || inc.Service != "api" || inc.HighestLevel != LevelFatal || inc.TotalEvents != 17 || inc.Status != IncidentOpen {
+		t.Errorf("incident = %+v", inc)
+	}
+	if inc.LastSeen.Sub(inc.FirstSeen).Hours() != 1 {
+		t.Errorf("FirstSeen/LastSeen = %v/%v", inc.FirstSeen, inc.LastSeen)
+	}
+	if list.Total == nil || *list.Total != 1 {
+		t.Errorf("Total = %v, want 1", list.Total)
+	}
+}
+
+func TestQueryClientListAllIncidents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") == "" {
+			w.Write([]byte(`{"incidents":[{"id":"a"},{"id":"b"}],"total":3,"has_more":true,"nextCursor":"next"}`))
+			return
+		}
+		w.Write([]byte(`{"incidents":[{"id":"c"}],"total":null,"has_more":false,"nextCursor":null}`))
+	}))
+	defer server.Close()
+
+	var ids string
+	for inc, err := range newQueryTestClient(t, server).ListAllIncidents(context.Background(), IncidentQuery{}) {
+		if err != nil {
+			t.Fatalf("ListAllIncidents() error = %v", err)
+		}
+		ids += inc.ID
+	}
+	if ids != "abc" {
+		t.Errorf("ids = %q, want abc", ids)
+	}
+}
+
+func TestQueryClientGetIncident(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/projects/proj_123/incidents/inc_1" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		w.Write([]byte(testIncidentJSON + `,
+			"rootCauseCandidates":[{"sourceFile":"db.go","lineNumber":12,"count":15}],
+			"correlations":{"topRequestIds":[{"requestId":"req-1","count":4}],"topTraceIds":[{"traceId":"t1","count":2}]}}`))
+	}))
+	defer server.Close()
+
+	detail, err := newQueryTestClient(t, server).GetIncident(context.Background(), "inc_1")
+	if err != nil {
+		t.Fatalf("GetIncident() error = %v", err)
+	}
+	if detail.Title != "DB timeout" {
+		t.Errorf("Title = %q", detail.Title)
+	}
+	if len(detail.RootCauseCandidates) != 1 || detail.RootCauseCandidates[0].Count != 15 {
+		t.Errorf("RootCauseCandidates = %+v", detail.RootCauseCandidates)
+	}
+	if c := detail.Correlations; len(c.TopRequestIDs) != 1 || c.TopRequestIDs[0].RequestID != "req-1" || c.TopTraceIDs[0].TraceID != "t1" {
+		t.Errorf("Correlations = %+v", c)
+	}
+}
+
+func TestQueryClientIncidentTimeline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/projects/proj_123/incidents/inc_1/timeline" || r.URL.Query().Get("range") != "1h" {
+			t.Errorf("request = %s", r.URL)
+		}
+		w.Write([]byte(`{"incidentId":"inc_1","range":"1h",
+			"buckets":[{"timestamp":"2025-01-01T00:00:00.000Z","count":0},{"timestamp":"2025-01-01T00:05:00.000Z","count":9}],
+			"peakBucket":{"timestamp":"2025-01-01T00:05:00.000Z","count":9},
+			"anchors":{"firstSeen":"2025-01-01T00:00:00.000Z","lastSeen":"2025-01-01T00:06:00.000Z"}}`))
+	}))
+	defer server.Close()
+
+	tl, err := newQueryTestClient(t, server).IncidentTimeline(context.Background(), "inc_1", IncidentRange1h)
+	if err != nil {
+		t.Fatalf("IncidentTimeline() error = %v", err)
+	}
+	if len(tl.Buckets) != 2 || tl.PeakBucket == nil || tl.PeakBucket.Count != 9 {
+		t.Errorf("timeline = %+v", tl)
+	}
+	if tl.Anchors.LastSeen.IsZero() {
+		t.Error("Anchors.LastSeen not decoded")
+	}
+}
+
+func TestQueryClientGetIncidentNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"not_found","message":"Incident not found"}`))
+	}))
+	defer server.Close()
+
+	_, err := newQueryTestClient(t, server).GetIncident(context.Background(), "missing")
+	if lwErr, ok := err.(*Error); !ok || lwErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("error = %v, want status 404", err)
+	}
+}
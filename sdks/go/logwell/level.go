@@ -0,0 +1,122 @@
+package logwell
+
+import (
+	"fmt"
+	"strings"
+)
+
+// severity ranks l from 0 (debug) to 4 (fatal), or -1 for unknown levels.
+func (l LogLevel) severity() int32 {
+	switch l {
+	case LevelDebug:
+		return 0
+	case LevelInfo:
+		return 1
+	case LevelWarn:
+		return 2
+	case LevelError:
+		return 3
+	case LevelFatal:
+		return 4
+	}
+	return -1
+}
+
+// levels maps severities back to their LogLevel.
+var levels = [...]LogLevel{LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal}
+
+// ParseLevel parses a level name such as one read from a config file, flag,
+// or environment variable. Matching ignores case and surrounding space, and
+// "warning" is accepted for LevelWarn.
+//
+// Returns ErrInvalidConfig if s names none of the five log levels.
+//
+// Example:
+//
+//	level, err := logwell.ParseLevel(os.Getenv("LOG_LEVEL"))
+func ParseLevel(s string) (LogLevel, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "warning" {
+		return LevelWarn, nil
+	}
+	if l := LogLevel(name); l.severity() >= 0 {
+		return l, nil
+	}
+	return "", NewError(ErrInvalidConfig,
+		fmt.Sprintf("invalid level %q: must be one of debug, info, warn, error, fatal", s))
+}
+
+// String returns the level name.
+func (l LogLevel) String() string {
+	return string(l)
+}
+
+// Enabled reports whether an entry at l passes the minimum level min: l is
+// min or more severe. Like Client.Enabled, unrecognized levels are always
+// enabled, and an unrecognized or empty min means LevelDebug.
+func (l LogLevel) Enabled(min LogLevel) bool {
+	s := l.severity()
+	return s < 0 || s >= max(min.severity(), 0)
+}
+
+// MarshalText implements encoding.TextMarshaler. It returns the level name
+// unchanged, including unrecognized levels, which the server validates.
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(l), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler with ParseLevel, so
+// levels in JSON or YAML config and flag.TextVar flags accept any case.
+// Empty text sets the empty level, which options treat as their default.
+func (l *LogLevel) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*l = ""
+		return nil
+	}
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// SetLevel changes the minimum level at runtime. Entries below it are
+// discarded before they reach the queue. The level is shared by the root
+// client and all its child loggers, so calling SetLevel on any of them
+// affects every one. Safe for concurrent use.
+//
+// Returns ErrInvalidConfig if level is not one of the five log levels.
+//
+// Example (toggling debug logs on SIGHUP):
+//
+//	go func() {
+//	    for range sighup {
+//	        if client.Level() == logwell.LevelDebug {
+//	            client.SetLevel(logwell.LevelInfo)
+//	        } else {
+//	            client.SetLevel(logwell.LevelDebug)
+//	        }
+//	    }
+//	}()
+func (c *Client) SetLevel(level LogLevel) error {
+	if err := validateMinLevel(level); err != nil {
+		return err
+	}
+	c.root().minLevel.Store(max(level.severity(), 0))
+	return nil
+}
+
+// Level returns the current minimum level.
+func (c *Client) Level() LogLevel {
+	return levels[c.root().minLevel.Load()]
+}
+
+// Enabled reports whether an entry at level would be sent. Use it to skip
+// building expensive metadata for entries that would be discarded.
+// Entries with an unrecognized level are always enabled and left for the
+// server to validate.
+func (c *Client) Enabled(level LogLevel) bool {
+	s := level.severity()
+	return s < 0 || s >= c.root().minLevel.Load()
+}
@@ -0,0 +1,181 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestClientMinLevel(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithMinLevel(LevelWarn))
+	defer client.Shutdown(context.Background())
+
+	client.Debug("debug")
+	client.Infof("info %d", 1)
+	client.InfoFields("info fields")
+	client.Log(LogEntry{Level: LevelInfo, Message: "info entry"})
+	client.Warn("warn")
+	client.Error("error")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 2)
+	if len(logs) == 2 && (logs[0].Message != "warn" || logs[1].Message != "error") {
+		t.Errorf("messages = %q, %q, want warn, error", logs[0].Message, logs[1].Message)
+	}
+}
+
+func TestClientSetLevel(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithMinLevel(LevelInfo))
+	defer client.Shutdown(context.Background())
+	child := client.With(M{"k": "v"})
+
+	if client.Level() != LevelInfo || child.Enabled(LevelDebug) {
+		t.Fatalf("Level() = %q, want info with debug disabled", client.Level())
+	}
+
+	child.Debug("dropped")
+	if err := child.SetLevel(LevelDebug); err != nil {
+		t.Fatalf("SetLevel() error = %v", err)
+	}
+	if client.Level() != LevelDebug {
+		t.Errorf("root Level() = %q after child SetLevel, want debug", client.Level())
+	}
+	client.Debug("kept")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 1)
+	if len(logs) == 1 && logs[0].Message != "kept" {
+		t.Errorf("message = %q, want kept", logs[0].Message)
+	}
+
+	assertConfigError(t, client.SetLevel("verbose"), ErrInvalidConfig)
+	if client.Level() != LevelDebug {
+		t.Errorf("Level() = %q after invalid SetLevel, want unchanged", client.Level())
+	}
+}
+
+func TestClientEnabledUnknownLevel(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithMinLevel(LevelFatal))
+	defer client.Shutdown(context.Background())
+
+	if client.Enabled(LevelError) {
+		t.Error("Enabled(error) = true with minLevel fatal")
+	}
+	if !client.Enabled("custom") {
+		t.Error("Enabled(custom) = false, want unknown levels passed to the server")
+	}
+}
+
+func TestClientSetLevelConcurrent(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts)
+	defer client.Shutdown(context.Background())
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Go(func() {
+			for j := range 100 {
+				client.SetLevel(levels[(i+j)%len(levels)])
+				client.Debug("maybe")
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		in   string
+		want LogLevel
+	}{
+		{"debug", LevelDebug},
+		{"INFO", LevelInfo},
+		{" Warn\n", LevelWarn},
+		{"warning", LevelWarn},
+		{"Error", LevelError},
+		{"fatal", LevelFatal},
+	}
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseLevel(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "verbose", "trace"} {
+		_, err := ParseLevel(in)
+		assertConfigError(t, err, ErrInvalidConfig)
+	}
+}
+
+func TestLogLevelEnabled(t *testing.T) {
+	tests := []struct {
+		level, min LogLevel
+		want       bool
+	}{
+		{LevelError, LevelWarn, true},
+		{LevelWarn, LevelWarn, true},
+		{LevelInfo, LevelWarn, false},
+		{LevelDebug, "", true},
+		{LevelDebug, "bogus", true},
+		{"custom", LevelFatal, true},
+	}
+	for _, tt := range tests {
+		if got := tt.level.Enabled(tt.min); got != tt.want {
+			t.Errorf("%q.Enabled(%q) = %v, want %v", tt.level, tt.min, got, tt.want)
+		}
+	}
+}
+
+func TestLogLevelText(t *testing.T) {
+	if got := LevelWarn.String(); got != "warn" {
+		t.Errorf("String() = %q, want warn", got)
+	}
+
+	var cfg struct {
+		Level  LogLevel            `json:"level"`
+		Empty  LogLevel            `json:"empty"`
+		Routes map[LogLevel]string `json:"routes"`
+	}
+	if err := json.Unmarshal([]byte(`{"level":"ERROR","empty":"","routes":{"Fatal":"pager"}}`), &cfg); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if cfg.Level != LevelError || cfg.Empty != "" || cfg.Routes[LevelFatal] != "pager" {
+		t.Errorf("decoded %+v", cfg)
+	}
+	if err := json.Unmarshal([]byte(`{"level":"loud"}`), &cfg); err == nil {
+		t.Error("Unmarshal() accepted an invalid level")
+	}
+
+	data, err := json.Marshal(LogEntry{Level: "custom", Message: "m"})
+	if err != nil || !strings.Contains(string(data), `"level":"custom"`) {
+		t.Errorf("Marshal() = %s, %v, want the level unchanged", data, err)
+	}
+
+	var level LogLevel
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.TextVar(&level, "level", LevelInfo, "minimum level")
+	if err := fs.Parse([]string{"-level", "Debug"}); err != nil || level != LevelDebug {
+		t.Errorf("flag parsed %q, %v, want debug", level, err)
+	}
+}
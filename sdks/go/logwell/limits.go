@@ -0,0 +1,103 @@
+package logwell
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+)
+
+// truncatedKey marks entries shortened by WithMaxMessageBytes or
+// WithMaxMetadataBytes.
+const truncatedKey = "truncated"
+
+// limitEntry applies the message and metadata size limits to entry; a limit
+// of 0 is disabled.
+func limitEntry(entry *LogEntry, maxMessage, maxMetadata int) {
+	truncated := false
+	if maxMessage > 0 && len(entry.Message) > maxMessage {
+		entry.Message = truncateUTF8(entry.Message, maxMessage)
+		truncated = true
+	}
+	if maxMetadata > 0 && limitMetadata(entry.Metadata, maxMetadata) {
+		truncated = true
+	}
+	if truncated {
+		if entry.Metadata == nil {
+			entry.Metadata = make(M, 1)
+		}
+		entry.Metadata[truncatedKey] = true
+	}
+}
+
+// truncateUTF8 returns the longest prefix of s of at most n bytes that does
+// not split a UTF-8 sequence.
+func truncateUTF8(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// limitMetadata shrinks metadata in place until its JSON encoding fits in
+// maxBytes, leaving room for the truncated marker. The largest values are
+// first replaced with a summary of their size; if the summaries alone
+// still do not fit, the largest remaining keys are dropped.
+// Reports whether metadata was changed.
+func limitMetadata(metadata M, maxBytes int) bool {
+	type field struct {
+		key  string
+		size int // encoded `"key":value,` length
+	}
+	fields := make([]field, 0, len(metadata))
+	total := len(`{"truncated":true}`)
+	for key, value := range metadata {
+		size := len(key) + 4 + encodedSize(value)
+		fields = append(fields, field{key, size})
+		total += size
+	}
+	if total <= maxBytes {
+		return false
+	}
+
+	// Largest first; ties by key so the result is deterministic.
+	slices.SortFunc(fields, func(a, b field) int {
+		return cmp.Or(cmp.Compare(b.size, a.size), cmp.Compare(a.key, b.key))
+	})
+	for i := range fields {
+		if total <= maxBytes {
+			return true
+		}
+		summary := fmt.Sprintf("[truncated: %d bytes]", fields[i].size-len(fields[i].key)-4)
+		summarySize := len(fields[i].key) + 4 + len(summary) + 2
+		if summarySize >= fields[i].size {
+			break // the rest are already as small as a summary
+		}
+		metadata[fields[i].key] = summary
+		total -= fields[i].size - summarySize
+		fields[i].size = summarySize
+	}
+
+	slices.SortFunc(fields, func(a, b field) int {
+		return cmp.Or(cmp.Compare(b.size, a.size), cmp.Compare(a.key, b.key))
+	})
+	for _, f := range fields {
+		if total <= maxBytes {
+			break
+		}
+		delete(metadata, f.key)
+		total -= f.size
+	}
+	return true
+}
+
+// encodedSize returns the length of v's JSON encoding, or 0 if it cannot
+// be encoded (the send then reports the marshal error).
+func encodedSize(v any) int {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
@@ -0,0 +1,117 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTruncateUTF8(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"hello world", 5, "hello"},
+		{"héllo", 2, "h"}, // é is 2 bytes; do not split it
+		{"héllo", 3, "hé"},
+		{"日本語", 4, "日"},
+	}
+	for _, tt := range tests {
+		if got := truncateUTF8(tt.s, tt.n); got != tt.want {
+			t.Errorf("truncateUTF8(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestLimitEntry_Message(t *testing.T) {
+	entry := LogEntry{Message: strings.Repeat("x", 100)}
+	limitEntry(&entry, 16, 0)
+
+	if len(entry.Message) != 16 {
+		t.Errorf("len(Message) = %d, want 16", len(entry.Message))
+	}
+	if entry.Metadata[truncatedKey] != true {
+		t.Errorf("Metadata = %v, want truncated marker", entry.Metadata)
+	}
+
+	short := LogEntry{Message: "short"}
+	limitEntry(&short, 16, 64)
+	if short.Message != "short" || short.Metadata != nil {
+		t.Errorf("entry within limits = %+v, want unchanged", short)
+	}
+}
+
+func TestLimitEntry_MetadataSummarizesLargest(t *testing.T) {
+	entry := LogEntry{
+		Message: "upload",
+		Metadata: M{
+			"body":   strings.Repeat("a", 10000),
+			"userId": "u-1",
+			"size":   10000,
+		},
+	}
+	limitEntry(&entry, 0, 256)
+
+	if entry.Metadata["userId"] != "u-1" || entry.Metadata["size"] != 10000 {
+		t.Errorf("small values = %v, want them kept", entry.Metadata)
+	}
+	if got := entry.Metadata["body"]; got != "[truncated: 10002 bytes]" {
+		t.Errorf("body = %v, want a size summary", got)
+	}
+	if entry.Metadata[truncatedKey] != true {
+		t.Error("missing truncated marker")
+	}
+	assertMetadataFits(t, entry.Metadata, 256)
+}
+
+func TestLimitEntry_MetadataDropsKeys(t *testing.T) {
+	metadata := M{}
+	for i := range 50 {
+		metadata[fmt.Sprintf("key%02d", i)] = strings.Repeat("v", 40)
+	}
+	entry := LogEntry{Message: "many keys", Metadata: metadata}
+	limitEntry(&entry, 0, 512)
+
+	if len(entry.Metadata) >= 50 {
+		t.Errorf("len(Metadata) = %d, want keys dropped", len(entry.Metadata))
+	}
+	if entry.Metadata[truncatedKey] != true {
+		t.Error("missing truncated marker")
+	}
+	assertMetadataFits(t, entry.Metadata, 512)
+}
+
+func TestClientSizeLimits(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts,
+		WithBatchSize(1),
+		WithMaxMessageBytes(32),
+		WithMaxMetadataBytes(128),
+	)
+	defer client.Shutdown(context.Background())
+
+	log := logAndWait(client, ts, client.Info, strings.Repeat("m", 1000), M{"dump": strings.Repeat("d", 5000)})
+	if len(log.Message) != 32 {
+		t.Errorf("len(Message) = %d, want 32", len(log.Message))
+	}
+	if log.Metadata["truncated"] != true {
+		t.Errorf("Metadata = %v, want truncated marker", log.Metadata)
+	}
+	assertMetadataFits(t, log.Metadata, 128)
+}
+
+func assertMetadataFits(t *testing.T, metadata M, maxBytes int) {
+	t.Helper()
+	b, err := json.Marshal(metadata)
+	if err != nil {
+		t.Fatalf("json.Marshal(metadata) error = %v", err)
+	}
+	if len(b) > maxBytes {
+		t.Errorf("encoded metadata is %d bytes, want at most %d: %s", len(b), maxBytes, b)
+	}
+}
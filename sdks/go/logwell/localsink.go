@@ -0,0 +1,108 @@
+package logwell
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// SinkFormat selects how WithLocalSink renders entries.
+type SinkFormat int
+
+const (
+	// SinkText writes one human-readable line per entry:
+	//	2024-01-15T10:30:00Z INFO  [api] request served status=200 (main.go:42)
+	SinkText SinkFormat = iota
+
+	// SinkJSON writes one JSON object per line, in the ingest wire format.
+	SinkJSON
+)
+
+// String returns "text" or "json".
+func (f SinkFormat) String() string {
+	switch f {
+	case SinkText:
+		return "text"
+	case SinkJSON:
+		return "json"
+	default:
+		return "SinkFormat(" + strconv.Itoa(int(f)) + ")"
+	}
+}
+
+// localSink mirrors admitted entries to a local writer. Writes are
+// serialized, so the writer need not be safe for concurrent use.
+type localSink struct {
+	format SinkFormat
+
+	mu  sync.Mutex
+	w   io.Writer
+	buf bytes.Buffer
+
+	// failed latches after the first write error, which is reported once.
+	failed atomic.Bool
+}
+
+// write renders entry and writes it as a single Write call.
+func (s *localSink) write(entry *LogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buf.Reset()
+	switch s.format {
+	case SinkJSON:
+		if err := json.NewEncoder(&s.buf).Encode(entry); err != nil {
+			return err
+		}
+	default:
+		formatTextEntry(&s.buf, entry)
+	}
+	_, err := s.w.Write(s.buf.Bytes())
+	return err
+}
+
+// formatTextEntry renders entry as a text line with sorted metadata.
+func formatTextEntry(buf *bytes.Buffer, entry *LogEntry) {
+	buf.WriteString(entry.Timestamp)
+	fmt.Fprintf(buf, " %-5s ", strings.ToUpper(string(entry.Level)))
+	if entry.Service != "" {
+		buf.WriteString("[" + entry.Service + "] ")
+	}
+	buf.WriteString(entry.Message)
+
+	keys := make([]string, 0, len(entry.Metadata))
+	for k := range entry.Metadata {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		buf.WriteByte(' ')
+		buf.WriteString(k)
+		buf.WriteByte('=')
+		buf.WriteString(formatTextValue(entry.Metadata[k]))
+	}
+
+	if entry.SourceFile != "" {
+		fmt.Fprintf(buf, " (%s:%d)", entry.SourceFile, entry.LineNumber)
+	}
+	buf.WriteByte('\n')
+}
+
+// formatTextValue renders a metadata value, quoting strings that would
+// otherwise be ambiguous in a key=value line.
+func formatTextValue(v any) string {
+	s, ok := v.(string)
+	if !ok {
+		s = fmt.Sprint(v)
+	}
+	if s == "" || strings.ContainsAny(s, " =\"\n\t") {
+		return strconv.Quote(s)
+	}
+	return s
+}
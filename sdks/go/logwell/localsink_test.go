@@ -0,0 +1,122 @@
+package logwell
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFormatTextEntry(t *testing.T) {
+	var buf bytes.Buffer
+	formatTextEntry(&buf, &LogEntry{
+		Level:      LevelWarn,
+		Message:    "slow request",
+		Timestamp:  "2024-01-15T10:30:00Z",
+		Service:    "api",
+		Metadata:   M{"status": 200, "path": "/users", "user": "Jane Doe", "empty": ""},
+		SourceFile: "main.go",
+		LineNumber: 42,
+	})
+
+	want := `2024-01-15T10:30:00Z WARN  [api] slow request empty="" path=/users status=200 user="Jane Doe" (main.go:42)` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("formatTextEntry() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestClientLocalSinkJSON(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	client := createTestClient(t, ts,
+		WithLocalSink(&buf, SinkJSON),
+		WithService("api"),
+		WithRedaction(RedactKeys("password")),
+	)
+	defer client.Shutdown(context.Background())
+
+	client.Info("login", M{"password": "hunter2"})
+	client.Error("failed")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("sink lines = %d, want 2:\n%s", len(lines), buf.String())
+	}
+	var entry LogEntry
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("unmarshal sink line: %v", err)
+	}
+	if entry.Message != "login" || entry.Service != "api" || entry.Metadata["password"] != DefaultRedactReplacement {
+		t.Errorf("sink entry = %+v, want redacted login entry", entry)
+	}
+	assertLogCount(t, ts.getLogs(), 2)
+}
+
+func TestClientLocalSinkSeesDroppedEntries(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	client := createTestClient(t, ts,
+		WithLocalSink(&buf, SinkText),
+		WithBatchSize(10),
+		WithMaxQueueSize(1),
+		WithOverflowStrategy(DropNewest),
+	)
+	defer client.Shutdown(context.Background())
+
+	client.Info("first")
+	client.Info("second")
+
+	if got := strings.Count(buf.String(), "\n"); got != 2 {
+		t.Errorf("sink lines = %d, want 2:\n%s", got, buf.String())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestClientLocalSinkReportsFirstError(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var mu sync.Mutex
+	var reported []*Error
+	client := createTestClient(t, ts,
+		WithLocalSink(failingWriter{}, SinkText),
+		WithOnError(func(err *Error) {
+			mu.Lock()
+			reported = append(reported, err)
+			mu.Unlock()
+		}),
+	)
+	defer client.Shutdown(context.Background())
+
+	client.Info("one")
+	client.Info("two")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reported) != 1 || !strings.Contains(reported[0].Message, "local sink") {
+		t.Errorf("reported errors = %v, want one local sink error", reported)
+	}
+	assertLogCount(t, ts.getLogs(), 2)
+}
+
+func TestSinkFormatString(t *testing.T) {
+	if SinkText.String() != "text" || SinkJSON.String() != "json" || SinkFormat(9).String() != "SinkFormat(9)" {
+		t.Errorf("String() = %q, %q, %q", SinkText, SinkJSON, SinkFormat(9))
+	}
+}
@@ -0,0 +1,46 @@
+package logwell
+
+import "context"
+
+// Logger is the logging surface of a Client. Client.Logger, MultiLogger,
+// and logwelltest.Recorder implement it, so application code can depend on
+// Logger and receive any of them, or a custom implementation, in tests.
+type Logger interface {
+	Debug(message string, metadata ...map[string]any)
+	Info(message string, metadata ...map[string]any)
+	Warn(message string, metadata ...map[string]any)
+	Error(message string, metadata ...map[string]any)
+	Fatal(message string, metadata ...map[string]any)
+
+	// With returns a Logger whose entries carry fields in addition to
+	// this logger's metadata; see Client.With.
+	With(fields M) Logger
+
+	// Flush sends every pending entry; see Client.Flush.
+	Flush(ctx context.Context) error
+}
+
+// Logger returns c as a Logger. *Client does not implement Logger itself
+// because its With returns the concrete *Client; the returned Logger logs
+// through c, and its With scopes a child of c.
+//
+// Example:
+//
+//	svc := OrderService{log: client.Logger()}
+func (c *Client) Logger() Logger {
+	return clientAdapter{c}
+}
+
+// clientAdapter adapts *Client to Logger. Embedding keeps the rest of the
+// client's methods, so MultiLogger still finds Log, Shutdown, and the
+// source-location hooks on it.
+type clientAdapter struct {
+	*Client
+}
+
+var _ Logger = clientAdapter{}
+
+// With returns a Logger over c.With(fields).
+func (a clientAdapter) With(fields M) Logger {
+	return clientAdapter{a.Client.With(fields)}
+}
@@ -0,0 +1,33 @@
+package logwell
+
+import (
+	"context"
+	"testing"
+)
+
+// logOrder logs through the Logger interface, as application code would.
+func logOrder(l Logger, id string) error {
+	l.Info("order placed", M{"orderId": id})
+	return l.Flush(context.Background())
+}
+
+func TestClientAsLogger(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts)
+	defer client.Shutdown(context.Background())
+
+	logger := client.Logger()
+	for _, l := range []Logger{logger, logger.With(M{"scope": "child"}), client.Child().Logger()} {
+		if err := logOrder(l, "o-1"); err != nil {
+			t.Fatalf("logOrder() error = %v", err)
+		}
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 3)
+	if len(logs) == 3 {
+		assertLogMetadata(t, logs[1], map[string]string{"orderId": "o-1", "scope": "child"})
+	}
+}
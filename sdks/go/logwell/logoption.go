@@ -0,0 +1,58 @@
+package logwell
+
+import "time"
+
+// LogOption adjusts a single log call made with Client.LogOpts. Options are
+// a separate argument from metadata, so they never appear in the entry's
+// metadata:
+//
+//	client.LogOpts(logwell.LevelInfo, "Replayed", logwell.M{"jobId": id}, logwell.At(ts), logwell.CallerSkip(1))
+type LogOption func(*callOptions)
+
+// callOptions holds what LogOptions set for one log call.
+type callOptions struct {
+	callerSkip int
+	at         time.Time
+}
+
+// CallerSkip skips n additional stack frames when capturing the source
+// location and stack trace of the call, so a logging wrapper reports its
+// caller instead of itself. CallerSkip(1) suits a wrapper function calling
+// the client directly. Negative values are ignored; repeated options add up.
+func CallerSkip(n int) LogOption {
+	return func(o *callOptions) {
+		o.callerSkip += max(n, 0)
+	}
+}
+
+// At sets the entry's timestamp to t instead of the time of the call, for
+// replaying historical logs. The timestamp is written in the client's
+// TimestampFormat. The zero time is ignored.
+func At(t time.Time) LogOption {
+	return func(o *callOptions) {
+		if !t.IsZero() {
+			o.at = t
+		}
+	}
+}
+
+// LogOpts logs message at level with metadata, adjusted by opts. It is the
+// level methods' counterpart for calls that need LogOptions, such as
+// logging wrappers and replayers. At LevelFatal it then applies the
+// FatalBehavior as Fatal does.
+//
+// Example:
+//
+//	func logRequest(msg string, metadata logwell.M) {
+//		client.LogOpts(logwell.LevelInfo, msg, metadata, logwell.CallerSkip(1))
+//	}
+func (c *Client) LogOpts(level LogLevel, message string, metadata M, opts ...LogOption) {
+	var o callOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	c.logWith(1, o, level, message, []map[string]any{metadata})
+	if level == LevelFatal {
+		c.afterFatal(message)
+	}
+}
@@ -0,0 +1,79 @@
+package logwell
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// logViaWrapper logs through one wrapper frame, as a logging library would.
+func logViaWrapper(client *Client, message string) {
+	client.LogOpts(LevelInfo, message, nil, CallerSkip(1))
+}
+
+func TestLogOptions(t *testing.T) {
+	var got []LogEntry
+	var exits atomic.Int32
+	client, err := New(validEndpoint(), validAPIKey(),
+		WithCaptureSourceLocation(true),
+		WithExitFunc(func(int) { exits.Add(1) }),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			got = append(got, logs...)
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	ts := time.Date(2024, 1, 15, 10, 30, 0, 123456789, time.FixedZone("X", 3600))
+	client.LogOpts(LevelInfo, "replayed", M{"jobId": "j-1"}, At(ts), At(time.Time{}))
+	logViaWrapper(client, "wrapped")
+	client.LogOpts(LevelInfo, "unwrapped", nil, CallerSkip(-5))
+	client.LogOpts(LevelFatal, "fatal", nil)
+	if len(got) != 4 {
+		t.Fatalf("sent %d entries, want 4", len(got))
+	}
+	if got := exits.Load(); got != 1 {
+		t.Errorf("exit calls = %d, want 1 for LevelFatal", got)
+	}
+
+	if got[0].Timestamp != "2024-01-15T09:30:00.123456789Z" {
+		t.Errorf("Timestamp = %q, want the At time in UTC", got[0].Timestamp)
+	}
+	if len(got[0].Metadata) != 1 || got[0].Metadata["jobId"] != "j-1" {
+		t.Errorf("Metadata = %v, want only jobId", got[0].Metadata)
+	}
+
+	// The wrapper's caller is this test, which sits below logViaWrapper.
+	if !strings.HasSuffix(got[1].SourceFile, "logoption_test.go") || got[1].LineNumber <= 20 {
+		t.Errorf("source = %s:%d, want the wrapper's caller", got[1].SourceFile, got[1].LineNumber)
+	}
+	if got[1].Metadata != nil {
+		t.Errorf("Metadata = %v, want none", got[1].Metadata)
+	}
+	if got[2].LineNumber != got[1].LineNumber+1 {
+		t.Errorf("negative CallerSkip line = %d, want %d", got[2].LineNumber, got[1].LineNumber+1)
+	}
+}
+
+func TestLogOptionAtFormats(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 10, 30, 0, 123456789, time.UTC)
+	tests := []struct {
+		format TimestampFormat
+		want   string
+	}{
+		{TimestampRFC3339Millis, "2024-01-15T10:30:00.123Z"},
+		{TimestampRFC3339Nano, "2024-01-15T10:30:00.123456789Z"},
+		{TimestampUnixMillis, "1705314600123"},
+		{TimestampUnixNanos, "1705314600123456789"},
+	}
+	for _, tt := range tests {
+		stamps := timestamper{format: tt.format}
+		if got := stamps.at(ts); got != tt.want {
+			t.Errorf("%v: at() = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
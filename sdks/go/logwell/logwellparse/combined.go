@@ -0,0 +1,97 @@
+package logwellparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// accessLogPattern matches the Common Log Format, optionally followed by
+// the referer and user agent of the Combined Log Format. Fields nginx or
+// Apache configurations append after these are ignored.
+var accessLogPattern = regexp.MustCompile(
+	`^(\S+) \S+ (\S+) \[([^\]]+)\] "((?:[^"\\]|\\.)*)" (\d{3}|-) (\d+|-)(?: "((?:[^"\\]|\\.)*)" "((?:[^"\\]|\\.)*)")?`)
+
+// Combined returns a Parser for web server access logs in the Combined
+// Log Format, the default of nginx and the usual Apache "combined"
+// LogFormat, and in the Common Log Format it extends:
+//
+//	203.0.113.7 - alice [05/Jan/2026:10:20:30 +0000] "GET /orders?page=2 HTTP/1.1" 200 5120 "https://example.com/" "curl/8.5.0"
+//
+// The message is "METHOD PATH STATUS", the level follows the status (5xx
+// ERROR, 4xx WARN, otherwise INFO), and the request time is kept. The
+// client address, user, method, path, protocol, status, response size,
+// referer, and user agent become metadata ("clientIp", "user", "method",
+// "path", "protocol", "status", "bytes", "referer", "userAgent"), each
+// omitted when logged as "-".
+func Combined() Parser {
+	return ParserFunc(func(line string) (logwell.LogEntry, error) {
+		m := accessLogPattern.FindStringSubmatch(line)
+		if m == nil {
+			return logwell.LogEntry{}, fmt.Errorf("logwellparse: not an access log line: %q", line)
+		}
+		meta := logwell.M{}
+		setField(meta, "clientIp", m[1])
+		setField(meta, "user", m[2])
+
+		request := unescapeAccessLog(m[4])
+		method, rest, _ := strings.Cut(request, " ")
+		target, protocol, _ := strings.Cut(rest, " ")
+		if target == "" {
+			// Malformed or empty request line, such as a bare TLS probe.
+			setField(meta, "request", request)
+		} else {
+			meta["method"] = method
+			meta["path"] = target
+			setField(meta, "protocol", protocol)
+		}
+
+		status, _ := strconv.Atoi(m[5])
+		if status > 0 {
+			meta["status"] = status
+		}
+		if n, err := strconv.ParseInt(m[6], 10, 64); err == nil {
+			meta["bytes"] = n
+		}
+		setField(meta, "referer", unescapeAccessLog(m[7]))
+		setField(meta, "userAgent", unescapeAccessLog(m[8]))
+
+		e := logwell.LogEntry{Level: logwell.LevelInfo, Metadata: meta}
+		switch {
+		case status >= 500:
+			e.Level = logwell.LevelError
+		case status >= 400:
+			e.Level = logwell.LevelWarn
+		}
+		if target != "" {
+			e.Message = fmt.Sprintf("%s %s %s", method, target, m[5])
+		} else {
+			e.Message = strings.TrimSpace(request + " " + m[5])
+		}
+		if ts, ok := Timestamp(m[3]); ok {
+			e.Timestamp = ts
+		} else {
+			meta["time"] = m[3]
+		}
+		return e, nil
+	})
+}
+
+// setField sets meta[key] to v unless v is empty or the "-" placeholder.
+func setField(meta logwell.M, key, v string) {
+	if v != "" && v != "-" {
+		meta[key] = v
+	}
+}
+
+// unescapeAccessLog undoes the backslash escaping nginx and Apache apply
+// to quoted fields. Unknown escapes, such as nginx's \xHH, are kept.
+func unescapeAccessLog(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(s)
+}
@@ -0,0 +1,70 @@
+package logwellparse
+
+import (
+	"testing"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+func TestCombined(t *testing.T) {
+	line := `203.0.113.7 - alice [05/Jan/2026:10:20:30 +0100] "GET /orders?page=2 HTTP/1.1" 503 5120 "https://example.com/" "Mozilla/5.0 \"quoted\"" 0.042`
+	e, err := Combined().Parse(line)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if e.Level != logwell.LevelError || e.Message != "GET /orders?page=2 503" {
+		t.Errorf("entry = %+v", e)
+	}
+	if e.Timestamp != "2026-01-05T09:20:30.000Z" {
+		t.Errorf("timestamp = %q", e.Timestamp)
+	}
+	want := logwell.M{
+		"clientIp": "203.0.113.7", "user": "alice", "method": "GET", "path": "/orders?page=2",
+		"protocol": "HTTP/1.1", "status": 503, "bytes": int64(5120),
+		"referer": "https://example.com/", "userAgent": `Mozilla/5.0 "quoted"`,
+	}
+	for key, v := range want {
+		if e.Metadata[key] != v {
+			t.Errorf("metadata[%q] = %#v, want %#v", key, e.Metadata[key], v)
+		}
+	}
+	if len(e.Metadata) != len(want) {
+		t.Errorf("metadata = %v, want %d fields", e.Metadata, len(want))
+	}
+}
+
+func TestCombinedCommonFormat(t *testing.T) {
+	e, err := Combined().Parse(`127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "POST /login HTTP/1.0" 401 -`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if e.Level != logwell.LevelWarn || e.Message != "POST /login 401" {
+		t.Errorf("entry = %+v", e)
+	}
+	for _, key := range []string{"user", "bytes", "referer", "userAgent"} {
+		if _, ok := e.Metadata[key]; ok {
+			t.Errorf("metadata has %q, want fields logged as - omitted", key)
+		}
+	}
+}
+
+func TestCombinedMalformedRequest(t *testing.T) {
+	e, err := Combined().Parse(`198.51.100.2 - - [05/Jan/2026:10:20:30 +0000] "\x16\x03\x01" 400 157 "-" "-"`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if e.Message != `\x16\x03\x01 400` || e.Metadata["request"] != `\x16\x03\x01` {
+		t.Errorf("entry = %+v", e)
+	}
+	if _, ok := e.Metadata["method"]; ok {
+		t.Errorf("metadata = %v, want no method", e.Metadata)
+	}
+}
+
+func TestCombinedInvalid(t *testing.T) {
+	for _, line := range []string{"", "GET / 200", `{"msg":"json"}`} {
+		if _, err := Combined().Parse(line); err == nil {
+			t.Errorf("Parse(%q): want error", line)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Package logwellparse converts lines of text into Logwell entries, for
+// forwarding logs that another program wrote: files, pipes, and syslog
+// message bodies.
+//
+// # Usage
+//
+//	p := logwellparse.JSON()
+//	entry, err := p.Parse(`{"level":"warn","msg":"slow query","ms":812}`)
+//	if err != nil {
+//		entry = logwell.LogEntry{Level: logwell.LevelInfo, Message: line}
+//	}
+//	client.Log(entry)
+//
+// JSON and Logfmt recognize the field names common loggers use for the
+// level, message, timestamp, and service, and keep every other field as
+// metadata. Combined parses nginx and Apache access logs. Regexp maps
+// the named groups of a pattern, for other text formats. Implement
+// Parser, or use ParserFunc and FromFields, for anything else.
+//
+// The file tailer (contrib/tail) and the syslog receiver (contrib/syslog)
+// take a Parser for the lines they forward.
+package logwellparse
@@ -0,0 +1,335 @@
+package logwellparse
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// Parser converts one line of text, without its line ending, into an
+// entry. It returns an error when the line is not in its format; callers
+// such as tailers then typically forward the raw line instead.
+type Parser interface {
+	Parse(line string) (logwell.LogEntry, error)
+}
+
+// ParserFunc adapts a function to the Parser interface.
+type ParserFunc func(line string) (logwell.LogEntry, error)
+
+// Parse calls f(line).
+func (f ParserFunc) Parse(line string) (logwell.LogEntry, error) {
+	return f(line)
+}
+
+// Field names recognized by the structured parsers, checked in order.
+// Other fields become metadata.
+var (
+	levelKeys     = []string{"level", "lvl", "severity"}
+	messageKeys   = []string{"message", "msg"}
+	timestampKeys = []string{"timestamp", "time", "ts", "@timestamp"}
+	serviceKeys   = []string{"service"}
+)
+
+// Plain returns a Parser that uses each line as the message, at level.
+// It never fails.
+func Plain(level logwell.LogLevel) Parser {
+	return ParserFunc(func(line string) (logwell.LogEntry, error) {
+		return logwell.LogEntry{Level: level, Message: line}, nil
+	})
+}
+
+// JSON returns a Parser for JSON object lines, as written by most
+// structured loggers (slog, zap, zerolog, logrus, bunyan). The level
+// ("level", "lvl", or "severity"), message ("message" or "msg"),
+// timestamp ("timestamp", "time", "ts", or "@timestamp"), and "service"
+// fields fill the entry; every other field becomes metadata.
+func JSON() Parser {
+	return ParserFunc(func(line string) (logwell.LogEntry, error) {
+		var fields map[string]any
+		dec := json.NewDecoder(strings.NewReader(line))
+		dec.UseNumber()
+		if err := dec.Decode(&fields); err != nil {
+			return logwell.LogEntry{}, fmt.Errorf("logwellparse: invalid JSON: %w", err)
+		}
+		if fields == nil {
+			return logwell.LogEntry{}, errors.New("logwellparse: JSON line is not an object")
+		}
+		for k, v := range fields {
+			if n, ok := v.(json.Number); ok {
+				fields[k] = jsonNumber(n)
+			}
+		}
+		return FromFields(fields), nil
+	})
+}
+
+// jsonNumber converts n to an int64 when it is integral, else a float64.
+func jsonNumber(n json.Number) any {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+// Logfmt returns a Parser for logfmt lines (key=value pairs, with values
+// optionally double-quoted), as written by logrus's text formatter, go-kit
+// log, and Heroku. Fields map onto the entry as for JSON; a bare key is
+// metadata with the value true.
+func Logfmt() Parser {
+	return ParserFunc(func(line string) (logwell.LogEntry, error) {
+		fields, err := parseLogfmt(line)
+		if err != nil {
+			return logwell.LogEntry{}, err
+		}
+		return FromFields(fields), nil
+	})
+}
+
+// parseLogfmt splits a logfmt line into fields.
+func parseLogfmt(line string) (map[string]any, error) {
+	fields := make(map[string]any)
+	s := line
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if s == "" {
+			break
+		}
+		end := strings.IndexAny(s, "= \t")
+		if end == 0 {
+			return nil, fmt.Errorf("logwellparse: invalid logfmt: missing key in %q", line)
+		}
+		if end < 0 {
+			end = len(s)
+		}
+		key := s[:end]
+		s = s[end:]
+		if !strings.HasPrefix(s, "=") {
+			fields[key] = true
+			continue
+		}
+		s = s[1:]
+		if strings.HasPrefix(s, `"`) {
+			value, rest, err := unquotePrefix(s)
+			if err != nil {
+				return nil, fmt.Errorf("logwellparse: invalid logfmt value for %q: %w", key, err)
+			}
+			fields[key] = value
+			s = rest
+			continue
+		}
+		end = strings.IndexAny(s, " \t")
+		if end < 0 {
+			end = len(s)
+		}
+		fields[key] = s[:end]
+		s = s[end:]
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("logwellparse: invalid logfmt: no fields")
+	}
+	return fields, nil
+}
+
+// unquotePrefix unquotes the Go-style quoted string at the start of s and
+// returns it with the rest of s.
+func unquotePrefix(s string) (string, string, error) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			value, err := strconv.Unquote(s[:i+1])
+			return value, s[i+1:], err
+		}
+	}
+	return "", "", errors.New("unterminated quoted value")
+}
+
+// Regexp returns a Parser that matches each line against re. The named
+// groups "level", "message" (or "msg"), "timestamp" (or "time"), and
+// "service" fill the entry, and other named groups become metadata; empty
+// groups are omitted. A line re does not match is an error.
+//
+// Example:
+//
+//	p := logwellparse.Regexp(regexp.MustCompile(
+//		`^(?P<time>\S+) \[(?P<level>\w+)\] (?P<component>\w+): (?P<message>.*)$`))
+func Regexp(re *regexp.Regexp) Parser {
+	names := re.SubexpNames()
+	return ParserFunc(func(line string) (logwell.LogEntry, error) {
+		match := re.FindStringSubmatch(line)
+		if match == nil {
+			return logwell.LogEntry{}, fmt.Errorf("logwellparse: line does not match %s", re)
+		}
+		fields := make(map[string]any, len(names))
+		for i, name := range names {
+			if name != "" && match[i] != "" {
+				fields[name] = match[i]
+			}
+		}
+		return FromFields(fields), nil
+	})
+}
+
+// FromFields builds an entry from decoded fields, for custom parsers.
+// The level, message, timestamp, and service fields fill the entry as
+// described for JSON, and the rest become metadata. Level names other
+// loggers use (trace, critical, ...) and bunyan/pino numeric levels are
+// mapped onto the nearest Logwell level. A missing or unrecognized level
+// is info, keeping the original value in metadata
+// under its key; a timestamp that cannot be parsed likewise stays in
+// metadata and the client stamps the entry. fields is modified.
+func FromFields(fields map[string]any) logwell.LogEntry {
+	e := logwell.LogEntry{Level: logwell.LevelInfo}
+	if key, v, ok := take(fields, levelKeys); ok {
+		if level, ok := parseLevel(v); ok {
+			e.Level = level
+			delete(fields, key)
+		}
+	}
+	if key, v, ok := take(fields, messageKeys); ok {
+		if s, isString := v.(string); isString {
+			e.Message = s
+			delete(fields, key)
+		}
+	}
+	if key, v, ok := take(fields, timestampKeys); ok {
+		if ts, ok := Timestamp(v); ok {
+			e.Timestamp = ts
+			delete(fields, key)
+		}
+	}
+	if key, v, ok := take(fields, serviceKeys); ok {
+		if s, isString := v.(string); isString {
+			e.Service = s
+			delete(fields, key)
+		}
+	}
+	if len(fields) > 0 {
+		e.Metadata = logwell.M(fields)
+	}
+	return e
+}
+
+// levelAliases maps level names other loggers use onto Logwell levels.
+var levelAliases = map[string]logwell.LogLevel{
+	"trace":       logwell.LevelDebug,
+	"dbug":        logwell.LevelDebug,
+	"notice":      logwell.LevelInfo,
+	"information": logwell.LevelInfo,
+	"eror":        logwell.LevelError,
+	"err":         logwell.LevelError,
+	"critical":    logwell.LevelFatal,
+	"crit":        logwell.LevelFatal,
+	"panic":       logwell.LevelFatal,
+	"emerg":       logwell.LevelFatal,
+	"alert":       logwell.LevelFatal,
+}
+
+// parseLevel converts a level name, or a bunyan/pino numeric level, to a
+// Logwell level.
+func parseLevel(v any) (logwell.LogLevel, bool) {
+	switch v := v.(type) {
+	case string:
+		if level, err := logwell.ParseLevel(v); err == nil {
+			return level, true
+		}
+		level, ok := levelAliases[strings.ToLower(v)]
+		return level, ok
+	case int64:
+		switch {
+		case v >= 60:
+			return logwell.LevelFatal, true
+		case v >= 50:
+			return logwell.LevelError, true
+		case v >= 40:
+			return logwell.LevelWarn, true
+		case v >= 30:
+			return logwell.LevelInfo, true
+		case v >= 10:
+			return logwell.LevelDebug, true
+		}
+	}
+	return "", false
+}
+
+// take returns the first of keys present in fields.
+func take(fields map[string]any, keys []string) (string, any, bool) {
+	for _, key := range keys {
+		if v, ok := fields[key]; ok {
+			return key, v, true
+		}
+	}
+	return "", nil, false
+}
+
+// timestampLayouts are the string layouts Timestamp accepts, besides
+// numbers.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"02/Jan/2006:15:04:05 -0700",
+}
+
+// Timestamp converts a decoded timestamp value into the entry timestamp
+// format: RFC 3339 strings (with a space or T separator; UTC when there
+// is no offset), Apache/Nginx "02/Jan/2006:15:04:05 -0700" strings, and
+// Unix times in seconds, milliseconds, microseconds, or nanoseconds, as
+// numbers or digit strings. It reports false for anything else.
+func Timestamp(v any) (string, bool) {
+	var t time.Time
+	switch v := v.(type) {
+	case string:
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return Timestamp(n)
+		}
+		var err error
+		for _, layout := range timestampLayouts {
+			if t, err = time.Parse(layout, v); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return "", false
+		}
+	case int64:
+		return Timestamp(float64(v))
+	case int:
+		return Timestamp(float64(v))
+	case float64:
+		t = unixTime(v)
+		if t.IsZero() {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+	return t.UTC().Format("2006-01-02T15:04:05.000Z07:00"), true
+}
+
+// unixTime interprets n as a Unix time in the unit its magnitude implies
+// for dates after 2001, or returns the zero time.
+func unixTime(n float64) time.Time {
+	switch {
+	case n < 1e9 || math.IsInf(n, 0) || math.IsNaN(n):
+		return time.Time{}
+	case n < 1e11:
+		return time.UnixMilli(int64(n * 1e3))
+	case n < 1e14:
+		return time.UnixMilli(int64(n))
+	case n < 1e17:
+		return time.UnixMicro(int64(n))
+	default:
+		return time.Unix(0, int64(n))
+	}
+}
@@ -0,0 +1,144 @@
+package logwellparse
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+func TestJSON(t *testing.T) {
+	e, err := JSON().Parse(`{"time":"2026-01-05T10:20:30.123456+02:00","level":"WARNING","msg":"slow query","service":"db","ms":812,"ratio":0.5,"tags":["a"]}`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if e.Level != logwell.LevelWarn || e.Message != "slow query" || e.Service != "db" {
+		t.Errorf("entry = %+v", e)
+	}
+	if e.Timestamp != "2026-01-05T08:20:30.123Z" {
+		t.Errorf("timestamp = %q", e.Timestamp)
+	}
+	if e.Metadata["ms"] != int64(812) || e.Metadata["ratio"] != 0.5 {
+		t.Errorf("metadata = %v, want numbers kept", e.Metadata)
+	}
+	for _, key := range []string{"time", "level", "msg", "service"} {
+		if _, ok := e.Metadata[key]; ok {
+			t.Errorf("metadata has %q, want it moved to the entry", key)
+		}
+	}
+}
+
+func TestJSONInvalid(t *testing.T) {
+	for _, line := range []string{"not json", "[1,2]", "null", `"text"`} {
+		if _, err := JSON().Parse(line); err == nil {
+			t.Errorf("Parse(%q): want error", line)
+		}
+	}
+}
+
+func TestJSONNumericLevels(t *testing.T) {
+	levels := map[string]logwell.LogLevel{
+		"10": logwell.LevelDebug, "20": logwell.LevelDebug, "30": logwell.LevelInfo,
+		"40": logwell.LevelWarn, "50": logwell.LevelError, "60": logwell.LevelFatal,
+	}
+	for n, want := range levels {
+		e, err := JSON().Parse(`{"level":` + n + `,"msg":"m"}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Level != want {
+			t.Errorf("level %s = %s, want %s", n, e.Level, want)
+		}
+	}
+}
+
+func TestLogfmt(t *testing.T) {
+	e, err := Logfmt().Parse(`ts=1767608430.5 lvl=eror msg="upstream \"api\" timed out" host=web-1 retry`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if e.Level != logwell.LevelError || e.Message != `upstream "api" timed out` {
+		t.Errorf("entry = %+v", e)
+	}
+	if e.Timestamp != "2026-01-05T10:20:30.500Z" {
+		t.Errorf("timestamp = %q", e.Timestamp)
+	}
+	if e.Metadata["host"] != "web-1" || e.Metadata["retry"] != true {
+		t.Errorf("metadata = %v", e.Metadata)
+	}
+}
+
+func TestLogfmtInvalid(t *testing.T) {
+	for _, line := range []string{"", "=value", `msg="unterminated`} {
+		if _, err := Logfmt().Parse(line); err == nil {
+			t.Errorf("Parse(%q): want error", line)
+		}
+	}
+}
+
+func TestRegexp(t *testing.T) {
+	p := Regexp(regexp.MustCompile(`^(?P<time>\S+) \[(?P<level>\w+)\] (?P<component>\w+): (?P<message>.*?)(?: id=(?P<id>\d+))?$`))
+
+	e, err := p.Parse("2026-01-05T10:20:30Z [critical] billing: charge failed")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if e.Level != logwell.LevelFatal || e.Message != "charge failed" || e.Timestamp != "2026-01-05T10:20:30.000Z" {
+		t.Errorf("entry = %+v", e)
+	}
+	if e.Metadata["component"] != "billing" {
+		t.Errorf("metadata = %v", e.Metadata)
+	}
+	if _, ok := e.Metadata["id"]; ok {
+		t.Errorf("metadata = %v, want empty group omitted", e.Metadata)
+	}
+
+	if _, err := p.Parse("free text"); err == nil {
+		t.Error("Parse of non-matching line: want error")
+	}
+}
+
+func TestPlain(t *testing.T) {
+	e, err := Plain(logwell.LevelWarn).Parse("anything at all")
+	if err != nil || e.Level != logwell.LevelWarn || e.Message != "anything at all" {
+		t.Errorf("Parse = %+v, %v", e, err)
+	}
+}
+
+func TestFromFieldsKeepsUnrecognized(t *testing.T) {
+	e := FromFields(map[string]any{"level": "verbose", "time": "yesterday", "message": 42})
+	if e.Level != logwell.LevelInfo || e.Timestamp != "" || e.Message != "" {
+		t.Errorf("entry = %+v", e)
+	}
+	if e.Metadata["level"] != "verbose" || e.Metadata["time"] != "yesterday" || e.Metadata["message"] != 42 {
+		t.Errorf("metadata = %v, want unrecognized values kept", e.Metadata)
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{"2026-01-05T10:20:30Z", "2026-01-05T10:20:30.000Z"},
+		{"2026-01-05 10:20:30.25", "2026-01-05T10:20:30.250Z"},
+		{"2026-01-05 10:20:30+01:00", "2026-01-05T09:20:30.000Z"},
+		{"05/Jan/2026:10:20:30 -0500", "2026-01-05T15:20:30.000Z"},
+		{int64(1767608430), "2026-01-05T10:20:30.000Z"},
+		{int64(1767608430123), "2026-01-05T10:20:30.123Z"},
+		{int64(1767608430123456), "2026-01-05T10:20:30.123Z"},
+		{1767608430123456789.0, "2026-01-05T10:20:30.123Z"},
+		{"1767608430", "2026-01-05T10:20:30.000Z"},
+	}
+	for _, tt := range tests {
+		got, ok := Timestamp(tt.in)
+		if !ok || got != tt.want {
+			t.Errorf("Timestamp(%v) = %q, %v; want %q", tt.in, got, ok, tt.want)
+		}
+	}
+	for _, in := range []any{"soon", int64(42), true, nil} {
+		if got, ok := Timestamp(in); ok {
+			t.Errorf("Timestamp(%v) = %q, want false", in, got)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package logwelltest
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// Clock is a manually advanced logwell.Clock. Pass it to WithClock so that
+// flush intervals, retry backoff, and timestamps only move when the test
+// calls Advance.
+//
+//	clock := logwelltest.NewClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+//	rec := logwelltest.NewRecorder(logwell.WithClock(clock))
+//	rec.Info("hello")
+//	clock.Advance(logwell.DefaultFlushInterval) // the batch is sent now
+type Clock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*clockTimer
+}
+
+var _ logwell.Clock = (*Clock)(nil)
+
+// NewClock returns a Clock reading start.
+func NewClock(start time.Time) *Clock {
+	c := &Clock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the clock's current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// AfterFunc schedules f to run once the clock has been advanced by d.
+func (c *Clock) AfterFunc(d time.Duration, f func()) logwell.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &clockTimer{c: c, f: f}
+	c.scheduleLocked(t, d)
+	return t
+}
+
+// Advance moves the clock forward by d, running every timer that comes due
+// in the order they are due. Timer functions run synchronously on the
+// calling goroutine, with the clock reading their due time.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	end := c.now.Add(d)
+	for len(c.timers) > 0 && !c.timers[0].when.After(end) {
+		t := c.timers[0]
+		c.timers = c.timers[1:]
+		c.now = t.when
+		c.mu.Unlock()
+		t.f()
+		c.mu.Lock()
+	}
+	c.now = end
+	c.mu.Unlock()
+}
+
+// BlockUntil waits until at least n timers are pending. Use it before
+// Advance when the code under test arms its timer on another goroutine,
+// such as a retry waiting out its backoff.
+func (c *Clock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// scheduleLocked queues t to fire d from now.
+func (c *Clock) scheduleLocked(t *clockTimer, d time.Duration) {
+	t.when = c.now.Add(d)
+	i, _ := slices.BinarySearchFunc(c.timers, t.when, func(t *clockTimer, when time.Time) int {
+		if t.when.After(when) {
+			return 1
+		}
+		return -1 // after timers due at the same time
+	})
+	c.timers = slices.Insert(c.timers, i, t)
+	c.cond.Broadcast()
+}
+
+// removeLocked unqueues t and reports whether it was pending.
+func (c *Clock) removeLocked(t *clockTimer) bool {
+	i := slices.Index(c.timers, t)
+	if i < 0 {
+		return false
+	}
+	c.timers = slices.Delete(c.timers, i, i+1)
+	return true
+}
+
+// clockTimer is a timer created by Clock.AfterFunc.
+type clockTimer struct {
+	c    *Clock
+	f    func()
+	when time.Time
+}
+
+func (t *clockTimer) Stop() bool {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	return t.c.removeLocked(t)
+}
+
+func (t *clockTimer) Reset(d time.Duration) bool {
+	t.c.mu.Lock()
+	defer t.c.mu.Unlock()
+	pending := t.c.removeLocked(t)
+	t.c.scheduleLocked(t, d)
+	return pending
+}
@@ -0,0 +1,166 @@
+package logwelltest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+var clockStart = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestClockAdvance(t *testing.T) {
+	clock := NewClock(clockStart)
+	var fired []string
+	clock.AfterFunc(2*time.Second, func() { fired = append(fired, "b") })
+	clock.AfterFunc(time.Second, func() {
+		fired = append(fired, "a")
+		if got := clock.Now(); !got.Equal(clockStart.Add(time.Second)) {
+			t.Errorf("Now() in timer = %v, want %v", got, clockStart.Add(time.Second))
+		}
+	})
+	stopped := clock.AfterFunc(time.Second, func() { fired = append(fired, "stopped") })
+	if !stopped.Stop() {
+		t.Error("Stop() on a pending timer = false, want true")
+	}
+
+	clock.Advance(1500 * time.Millisecond)
+	if strings.Join(fired, ",") != "a" {
+		t.Errorf("fired %v after 1.5s, want [a]", fired)
+	}
+	clock.Advance(time.Second)
+	if strings.Join(fired, ",") != "a,b" {
+		t.Errorf("fired %v after 2.5s, want [a b]", fired)
+	}
+	if got := clock.Now(); !got.Equal(clockStart.Add(2500 * time.Millisecond)) {
+		t.Errorf("Now() = %v, want %v", got, clockStart.Add(2500*time.Millisecond))
+	}
+	if stopped.Stop() {
+		t.Error("Stop() on a stopped timer = true, want false")
+	}
+}
+
+func TestClockReset(t *testing.T) {
+	clock := NewClock(clockStart)
+	fired := 0
+	timer := clock.AfterFunc(time.Second, func() { fired++ })
+	clock.Advance(900 * time.Millisecond)
+	if !timer.Reset(time.Second) {
+		t.Error("Reset() on a pending timer = false, want true")
+	}
+	clock.Advance(900 * time.Millisecond)
+	if fired != 0 {
+		t.Fatalf("timer fired before its reset deadline")
+	}
+	clock.Advance(100 * time.Millisecond)
+	if fired != 1 {
+		t.Fatalf("fired %d times, want 1", fired)
+	}
+}
+
+// chanTransport passes each batch to a channel.
+type chanTransport chan []logwell.LogEntry
+
+func (c chanTransport) Send(_ context.Context, logs []logwell.LogEntry) (*logwell.IngestResponse, error) {
+	c <- append([]logwell.LogEntry(nil), logs...)
+	return &logwell.IngestResponse{Accepted: len(logs)}, nil
+}
+
+// TestClockFlushInterval tests that a client with a Clock stamps entries
+// with the clock's time and flushes them only once the clock reaches the
+// flush interval.
+func TestClockFlushInterval(t *testing.T) {
+	clock := NewClock(clockStart)
+	batches := make(chanTransport, 1)
+	client, err := logwell.New(recorderEndpoint, recorderAPIKey,
+		logwell.WithTransport(batches), logwell.WithClock(clock))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("hello")
+	clock.BlockUntil(1) // the flush timer
+	select {
+	case <-batches:
+		t.Fatal("batch sent before the flush interval elapsed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	clock.Advance(logwell.DefaultFlushInterval)
+	select {
+	case batch := <-batches:
+		if len(batch) != 1 || batch[0].Message != "hello" {
+			t.Fatalf("batch = %+v, want the hello entry", batch)
+		}
+		if want := "2026-01-01T00:00:00Z"; batch[0].Timestamp != want {
+			t.Errorf("Timestamp = %q, want %q", batch[0].Timestamp, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no batch sent after advancing past the flush interval")
+	}
+}
+
+// failOnceTransport fails the first request with a 503, closing failed
+// once it has, and accepts the rest.
+type failOnceTransport struct {
+	calls  atomic.Int32
+	failed chan struct{}
+}
+
+func (f *failOnceTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	status, body := http.StatusOK, `{"accepted":1}`
+	if f.calls.Add(1) == 1 {
+		status, body = http.StatusServiceUnavailable, `{"error":"unavailable"}`
+		defer close(f.failed)
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+// TestClockRetryBackoff tests that a retry waits out its backoff on the
+// Clock rather than in real time.
+func TestClockRetryBackoff(t *testing.T) {
+	clock := NewClock(clockStart)
+	rt := &failOnceTransport{failed: make(chan struct{})}
+	client, err := logwell.New(recorderEndpoint, recorderAPIKey,
+		logwell.WithHTTPClient(&http.Client{Transport: rt}),
+		logwell.WithClock(clock),
+		logwell.WithBackoff(logwell.ConstantBackoff(time.Hour)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("hello")
+	done := make(chan error, 1)
+	go func() { done <- client.Flush(context.Background()) }()
+
+	// Flush took the entry, stopping the flush timer, before sending it;
+	// the one timer left is the backoff.
+	<-rt.failed
+	clock.BlockUntil(1)
+	if got := rt.calls.Load(); got != 1 {
+		t.Fatalf("requests before backoff elapsed = %d, want 1", got)
+	}
+	clock.Advance(time.Hour)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Flush() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Flush() did not return after advancing past the backoff")
+	}
+	if got := rt.calls.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
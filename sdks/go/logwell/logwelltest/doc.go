@@ -0,0 +1,25 @@
+// Package logwelltest provides an in-memory Logwell client for unit-testing
+// code that logs, without an HTTP server.
+//
+// # Usage
+//
+//	rec := logwelltest.NewRecorder()
+//	defer rec.Shutdown(context.Background())
+//
+//	svc := NewService(rec.Client)
+//	svc.PlaceOrder(ctx, order)
+//
+//	rec.AssertLogged(t, logwell.LevelInfo, "order placed")
+//	if e, ok := rec.LastEntry(); ok && e.Metadata["orderId"] != order.ID {
+//		t.Errorf("orderId = %v", e.Metadata["orderId"])
+//	}
+//
+// A Recorder is a real *logwell.Client whose transport captures batches in
+// memory, so processors, redaction, sampling, and child loggers behave as in
+// production. Entries are recorded as the server would receive them: after a
+// JSON round trip, so numeric metadata values are float64.
+//
+// TB returns a logger that buffers a test's entries and ships them to a
+// real client only if the test fails, so CI failures arrive in Logwell
+// with their full debug logs.
+package logwelltest
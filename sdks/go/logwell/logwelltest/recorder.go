@@ -0,0 +1,145 @@
+package logwelltest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+const (
+	// recorderEndpoint and recorderAPIKey satisfy the client's validation;
+	// requests never leave the process.
+	recorderEndpoint = "http://logwell.test"
+	recorderAPIKey   = "lw_00000000000000000000000000000000"
+)
+
+// Recorder is a Logwell client that records entries in memory. Log through
+// the embedded Client (or its child loggers); entries from all of them are
+// recorded together.
+type Recorder struct {
+	*logwell.Client
+
+	mu      sync.Mutex
+	entries []logwell.LogEntry
+}
+
+var _ logwell.Logger = (*Recorder)(nil)
+
+// NewRecorder returns a Recorder configured with opts. Fatal is LogOnly
+// unless opts set another FatalBehavior, so tests are not terminated.
+// Options that change the transport (WithHTTPClient) are overridden.
+//
+// It panics if opts are invalid, as a test setup error.
+func NewRecorder(opts ...logwell.Option) *Recorder {
+	r := &Recorder{}
+	all := append([]logwell.Option{logwell.WithFatalBehavior(logwell.LogOnly)}, opts...)
+	all = append(all, logwell.WithHTTPClient(&http.Client{Transport: recorderTransport{r}}))
+	client, err := logwell.New(recorderEndpoint, recorderAPIKey, all...)
+	if err != nil {
+		panic("logwelltest: " + err.Error())
+	}
+	r.Client = client
+	return r
+}
+
+// With returns a Logger over r.Client.With(fields), so code that takes a
+// logwell.Logger can receive r. Entries it logs are recorded by r.
+func (r *Recorder) With(fields logwell.M) logwell.Logger {
+	return r.Client.With(fields).Logger()
+}
+
+// Entries flushes pending entries and returns a copy of every entry
+// recorded so far, in the order they were sent.
+func (r *Recorder) Entries() []logwell.LogEntry {
+	_ = r.Flush(context.Background())
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]logwell.LogEntry(nil), r.entries...)
+}
+
+// LastEntry returns the most recent entry, or false if none was recorded.
+func (r *Recorder) LastEntry() (logwell.LogEntry, bool) {
+	entries := r.Entries()
+	if len(entries) == 0 {
+		return logwell.LogEntry{}, false
+	}
+	return entries[len(entries)-1], true
+}
+
+// Reset discards the recorded entries.
+func (r *Recorder) Reset() {
+	_ = r.Flush(context.Background())
+	r.mu.Lock()
+	r.entries = nil
+	r.mu.Unlock()
+}
+
+// AssertLogged fails t unless an entry at level whose message contains
+// msgContains was recorded. It returns the first matching entry.
+func (r *Recorder) AssertLogged(t testing.TB, level logwell.LogLevel, msgContains string) logwell.LogEntry {
+	t.Helper()
+	entries := r.Entries()
+	for _, e := range entries {
+		if e.Level == level && strings.Contains(e.Message, msgContains) {
+			return e
+		}
+	}
+	t.Errorf("no %s entry containing %q; recorded:%s", level, msgContains, formatEntries(entries))
+	return logwell.LogEntry{}
+}
+
+// AssertNotLogged fails t if an entry at level whose message contains
+// msgContains was recorded.
+func (r *Recorder) AssertNotLogged(t testing.TB, level logwell.LogLevel, msgContains string) {
+	t.Helper()
+	for _, e := range r.Entries() {
+		if e.Level == level && strings.Contains(e.Message, msgContains) {
+			t.Errorf("unexpected %s entry %q", level, e.Message)
+			return
+		}
+	}
+}
+
+// formatEntries lists entries one per line for failure messages.
+func formatEntries(entries []logwell.LogEntry) string {
+	if len(entries) == 0 {
+		return " (none)"
+	}
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString("\n\t" + string(e.Level) + " " + strconv.Quote(e.Message))
+	}
+	return b.String()
+}
+
+// recorderTransport accepts ingest requests and records their entries.
+type recorderTransport struct {
+	r *Recorder
+}
+
+func (rt recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	defer req.Body.Close()
+	var batch []logwell.LogEntry
+	if err := json.NewDecoder(req.Body).Decode(&batch); err != nil {
+		return nil, err
+	}
+
+	rt.r.mu.Lock()
+	rt.r.entries = append(rt.r.entries, batch...)
+	rt.r.mu.Unlock()
+
+	body, _ := json.Marshal(logwell.IngestResponse{Accepted: len(batch)})
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(string(body))),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,96 @@
+package logwelltest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// fakeTB records failures instead of failing the test.
+type fakeTB struct {
+	testing.TB
+	failures []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func TestRecorderRecordsEntries(t *testing.T) {
+	rec := NewRecorder(logwell.WithService("orders"))
+	defer rec.Shutdown(context.Background())
+
+	rec.Info("order placed", logwell.M{"orderId": "o-1", "items": 3})
+	rec.With(logwell.M{"requestId": "r-9"}).Warn("slow payment")
+	rec.Fatal("cannot continue")
+
+	entries := rec.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("len(Entries()) = %d, want 3", len(entries))
+	}
+	if e := entries[0]; e.Service != "orders" || e.Metadata["orderId"] != "o-1" || e.Metadata["items"] != float64(3) {
+		t.Errorf("entries[0] = %+v", e)
+	}
+	if e := entries[1]; e.Level != logwell.LevelWarn || e.Metadata["requestId"] != "r-9" {
+		t.Errorf("entries[1] = %+v, want child logger entry", e)
+	}
+
+	last, ok := rec.LastEntry()
+	if !ok || last.Level != logwell.LevelFatal || last.Message != "cannot continue" {
+		t.Errorf("LastEntry() = %+v, %v", last, ok)
+	}
+
+	rec.AssertLogged(t, logwell.LevelInfo, "placed")
+	rec.AssertNotLogged(t, logwell.LevelError, "placed")
+}
+
+func TestRecorderAppliesOptions(t *testing.T) {
+	rec := NewRecorder(
+		logwell.WithMinLevel(logwell.LevelInfo),
+		logwell.WithRedaction(logwell.RedactKeys("password")),
+	)
+	defer rec.Shutdown(context.Background())
+
+	rec.Debug("hidden")
+	rec.Info("login", logwell.M{"password": "hunter2"})
+
+	entries := rec.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("len(Entries()) = %d, want 1", len(entries))
+	}
+	if got := entries[0].Metadata["password"]; got != logwell.DefaultRedactReplacement {
+		t.Errorf("password = %v, want redacted", got)
+	}
+}
+
+func TestRecorderAssertionFailures(t *testing.T) {
+	rec := NewRecorder()
+	defer rec.Shutdown(context.Background())
+	rec.Info("hello")
+
+	tb := &fakeTB{TB: t}
+	rec.AssertLogged(tb, logwell.LevelError, "hello")
+	rec.AssertNotLogged(tb, logwell.LevelInfo, "hell")
+	if len(tb.failures) != 2 {
+		t.Fatalf("failures = %q, want 2", tb.failures)
+	}
+}
+
+func TestRecorderReset(t *testing.T) {
+	rec := NewRecorder()
+	defer rec.Shutdown(context.Background())
+
+	rec.Info("before")
+	rec.Reset()
+	if _, ok := rec.LastEntry(); ok {
+		t.Error("LastEntry() ok after Reset, want none")
+	}
+	rec.Info("after")
+	if e, _ := rec.LastEntry(); e.Message != "after" {
+		t.Errorf("LastEntry().Message = %q, want %q", e.Message, "after")
+	}
+}
@@ -0,0 +1,99 @@
+package logwelltest
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// MaxTBEntries bounds the entries a TB logger buffers; beyond it the
+// oldest are discarded.
+const MaxTBEntries = 10000
+
+// TBShipTimeout bounds flushing a failed test's entries to the client.
+const TBShipTimeout = 10 * time.Second
+
+// TB returns a logger for the duration of t that buffers every entry, down
+// to DEBUG, in memory. If t has failed when it ends, the entries are sent
+// through client with their original timestamps and a "test" metadata
+// field naming t, and client is flushed; otherwise they are discarded. CI
+// failures thereby ship their full logs to Logwell for inspection while
+// passing tests send nothing.
+//
+// Entries below client's minimum level are dropped when shipped, so give
+// client LevelDebug to keep everything. opts configure the buffering
+// logger, for example WithMetadata or WithCaptureSourceLocation; Fatal is
+// LogOnly unless opts set another FatalBehavior. A failure to ship is
+// reported with t.Log.
+//
+// Example:
+//
+//	func TestCheckout(t *testing.T) {
+//		log := logwelltest.TB(t, ciClient)
+//		svc := NewService(log)
+//		...
+//	}
+func TB(t testing.TB, client *logwell.Client, opts ...logwell.Option) *logwell.Client {
+	t.Helper()
+	buf := &tbBuffer{}
+	all := append([]logwell.Option{logwell.WithFatalBehavior(logwell.LogOnly)}, opts...)
+	all = append(all, logwell.WithMinLevel(logwell.LevelDebug), logwell.WithProcessor(buf.add))
+	logger, err := logwell.New(recorderEndpoint, recorderAPIKey, all...)
+	if err != nil {
+		t.Fatalf("logwelltest: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_ = logger.Shutdown(context.Background())
+		if !t.Failed() {
+			return
+		}
+		for _, entry := range buf.take() {
+			if entry.Metadata == nil {
+				entry.Metadata = logwell.M{}
+			}
+			entry.Metadata["test"] = t.Name()
+			client.Log(entry)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), TBShipTimeout)
+		defer cancel()
+		if err := client.Flush(ctx); err != nil {
+			t.Logf("logwelltest: failed to ship test logs: %v", err)
+		}
+	})
+	return logger
+}
+
+// tbBuffer holds the entries of a TB logger.
+type tbBuffer struct {
+	mu      sync.Mutex
+	entries []logwell.LogEntry
+}
+
+// add is a logwell.Processor that keeps a copy of entry and drops it from
+// the buffering logger's queue.
+func (b *tbBuffer) add(entry *logwell.LogEntry) bool {
+	e := *entry
+	e.Metadata = maps.Clone(e.Metadata)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.entries) == MaxTBEntries {
+		b.entries = b.entries[1:]
+	}
+	b.entries = append(b.entries, e)
+	return false
+}
+
+// take returns the buffered entries and empties the buffer.
+func (b *tbBuffer) take() []logwell.LogEntry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entries := b.entries
+	b.entries = nil
+	return entries
+}
@@ -0,0 +1,87 @@
+package logwelltest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Divkix/Logwell/sdks/go/logwell"
+)
+
+// cleanupTB runs cleanups on demand and reports a settable failure state.
+type cleanupTB struct {
+	testing.TB
+	failed   bool
+	cleanups []func()
+	logs     []string
+}
+
+func (c *cleanupTB) Helper()             {}
+func (c *cleanupTB) Name() string        { return "TestCheckout" }
+func (c *cleanupTB) Failed() bool        { return c.failed }
+func (c *cleanupTB) Cleanup(f func())    { c.cleanups = append(c.cleanups, f) }
+func (c *cleanupTB) Logf(string, ...any) { c.logs = append(c.logs, "log") }
+
+func (c *cleanupTB) end() {
+	for i := len(c.cleanups) - 1; i >= 0; i-- {
+		c.cleanups[i]()
+	}
+}
+
+func TestTBShipsOnFailure(t *testing.T) {
+	rec := NewRecorder(logwell.WithService("ci"))
+	defer rec.Shutdown(context.Background())
+
+	tb := &cleanupTB{}
+	log := TB(tb, rec.Client, logwell.WithMetadata(logwell.M{"suite": "orders"}))
+	log.Debug("cart loaded", logwell.M{"items": 2})
+	log.With(logwell.M{"orderId": "o-1"}).Error("charge declined")
+
+	if len(rec.Entries()) != 0 {
+		t.Fatal("entries shipped before the test ended")
+	}
+	tb.failed = true
+	tb.end()
+
+	entries := rec.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("shipped %d entries, want 2", len(entries))
+	}
+	for _, e := range entries {
+		if e.Metadata["test"] != "TestCheckout" || e.Metadata["suite"] != "orders" || e.Service != "ci" {
+			t.Errorf("entry = %+v, want test, suite, and the client's service", e)
+		}
+		if e.Timestamp == "" {
+			t.Errorf("entry %q lost its timestamp", e.Message)
+		}
+	}
+	if entries[0].Level != logwell.LevelDebug || entries[1].Metadata["orderId"] != "o-1" {
+		t.Errorf("entries = %+v", entries)
+	}
+	if len(tb.logs) != 0 {
+		t.Errorf("Logf called %d times, want none", len(tb.logs))
+	}
+}
+
+func TestTBDiscardsOnSuccess(t *testing.T) {
+	rec := NewRecorder()
+	defer rec.Shutdown(context.Background())
+
+	tb := &cleanupTB{}
+	TB(tb, rec.Client).Info("all good")
+	tb.end()
+
+	if entries := rec.Entries(); len(entries) != 0 {
+		t.Errorf("shipped %d entries for a passing test, want 0", len(entries))
+	}
+}
+
+func TestTBBufferBound(t *testing.T) {
+	buf := &tbBuffer{}
+	for i := range MaxTBEntries + 5 {
+		buf.add(&logwell.LogEntry{Message: "m", Metadata: logwell.M{"i": i}})
+	}
+	entries := buf.take()
+	if len(entries) != MaxTBEntries || entries[0].Metadata["i"] != 5 {
+		t.Errorf("buffer kept %d entries starting at %v, want the newest %d", len(entries), entries[0].Metadata["i"], MaxTBEntries)
+	}
+}
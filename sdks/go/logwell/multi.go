@@ -0,0 +1,158 @@
+package logwell
+
+import (
+	"context"
+	"errors"
+)
+
+// MultiLogger duplicates every entry to several loggers, such as a regional
+// Logwell instance and a central aggregator. Each destination keeps its own
+// queue, retries, and circuit breaker, so one failing destination does not
+// delay or drop entries bound for the others.
+type MultiLogger struct {
+	loggers []Logger
+}
+
+var _ Logger = (*MultiLogger)(nil)
+
+// clientLogger is implemented by Client.Logger and by Loggers embedding
+// *Client, such as logwelltest.Recorder.
+// MultiLogger's level methods call its log method directly, so captured
+// source locations point at MultiLogger's caller rather than at MultiLogger.
+type clientLogger interface {
+	Logger
+	root() *Client
+	log(level LogLevel, message string, metadata ...map[string]any)
+	afterFatal(message string)
+}
+
+// MultiClient returns a logger that sends every entry to each of loggers.
+//
+// Example:
+//
+//	regional, _ := logwell.New(regionalEndpoint, regionalKey)
+//	central, _ := logwell.New(centralEndpoint, centralKey)
+//	logger := logwell.MultiClient(regional.Logger(), central.Logger())
+//	defer logger.Shutdown(context.Background())
+func MultiClient(loggers ...Logger) *MultiLogger {
+	return &MultiLogger{loggers: loggers}
+}
+
+// Debug logs a message at DEBUG level to every destination.
+func (m *MultiLogger) Debug(message string, metadata ...map[string]any) {
+	for _, l := range m.loggers {
+		if c, ok := l.(clientLogger); ok {
+			c.log(LevelDebug, message, metadata...)
+		} else {
+			l.Debug(message, metadata...)
+		}
+	}
+}
+
+// Info logs a message at INFO level to every destination.
+func (m *MultiLogger) Info(message string, metadata ...map[string]any) {
+	for _, l := range m.loggers {
+		if c, ok := l.(clientLogger); ok {
+			c.log(LevelInfo, message, metadata...)
+		} else {
+			l.Info(message, metadata...)
+		}
+	}
+}
+
+// Warn logs a message at WARN level to every destination.
+func (m *MultiLogger) Warn(message string, metadata ...map[string]any) {
+	for _, l := range m.loggers {
+		if c, ok := l.(clientLogger); ok {
+			c.log(LevelWarn, message, metadata...)
+		} else {
+			l.Warn(message, metadata...)
+		}
+	}
+}
+
+// Error logs a message at ERROR level to every destination.
+func (m *MultiLogger) Error(message string, metadata ...map[string]any) {
+	for _, l := range m.loggers {
+		if c, ok := l.(clientLogger); ok {
+			c.log(LevelError, message, metadata...)
+		} else {
+			l.Error(message, metadata...)
+		}
+	}
+}
+
+// Fatal logs a message at FATAL level to every destination, then applies
+// each client's FatalBehavior. The entry is logged everywhere, and flushed
+// to every client that will exit or panic, before any of them does.
+func (m *MultiLogger) Fatal(message string, metadata ...map[string]any) {
+	var clients []clientLogger
+	for _, l := range m.loggers {
+		if c, ok := l.(clientLogger); ok {
+			c.log(LevelFatal, message, metadata...)
+			clients = append(clients, c)
+		}
+	}
+	for _, l := range m.loggers {
+		if _, ok := l.(clientLogger); !ok {
+			l.Fatal(message, metadata...)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultFatalFlushTimeout)
+	defer cancel()
+	for _, c := range clients {
+		if c.root().config.FatalBehavior != LogOnly {
+			_ = c.Flush(ctx) // send failures already reach OnError
+		}
+	}
+	for _, c := range clients {
+		c.afterFatal(message)
+	}
+}
+
+// With returns a MultiLogger whose destinations are each destination's
+// With(fields), so every entry carries fields wherever it is sent.
+func (m *MultiLogger) With(fields M) Logger {
+	loggers := make([]Logger, len(m.loggers))
+	for i, l := range m.loggers {
+		loggers[i] = l.With(fields)
+	}
+	return &MultiLogger{loggers: loggers}
+}
+
+// Log sends entry to every destination that has a Log method, such as
+// *Client. Each destination fills in its own defaults (see Client.Log).
+func (m *MultiLogger) Log(entry LogEntry) {
+	for _, l := range m.loggers {
+		if s, ok := l.(interface{ Log(LogEntry) }); ok {
+			s.Log(entry)
+		}
+	}
+}
+
+// Flush flushes every destination, even after one fails, and returns the
+// failures joined with errors.Join; use errors.As to inspect a *Error.
+func (m *MultiLogger) Flush(ctx context.Context) error {
+	var errs []error
+	for _, l := range m.loggers {
+		if err := l.Flush(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown shuts down every destination that has a Shutdown method, such
+// as *Client, and returns the failures joined with errors.Join.
+func (m *MultiLogger) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, l := range m.loggers {
+		if s, ok := l.(interface{ Shutdown(context.Context) error }); ok {
+			if err := s.Shutdown(ctx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,194 @@
+package logwell
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// countingLogger is a Logger that is not a *Client.
+type countingLogger struct {
+	calls atomic.Int32
+	fatal atomic.Int32
+}
+
+func (l *countingLogger) Debug(string, ...map[string]any) { l.calls.Add(1) }
+func (l *countingLogger) Info(string, ...map[string]any)  { l.calls.Add(1) }
+func (l *countingLogger) Warn(string, ...map[string]any)  { l.calls.Add(1) }
+func (l *countingLogger) Error(string, ...map[string]any) { l.calls.Add(1) }
+func (l *countingLogger) Fatal(string, ...map[string]any) { l.fatal.Add(1) }
+func (l *countingLogger) With(M) Logger                   { return l }
+func (l *countingLogger) Flush(context.Context) error     { return nil }
+
+func TestMultiClient(t *testing.T) {
+	regional := newProjectServer(t)
+	central := newProjectServer(t)
+	a, err := New(regional.URL, validAPIKey(), WithCaptureSourceLocation(true))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	b, err := New(central.URL, validAPIKey())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	other := &countingLogger{}
+	logger := MultiClient(a.Logger(), b.Logger(), other)
+
+	logger.Debug("debug")
+	logger.Info("info", M{"k": "v"})
+	logger.Warn("warn")
+	logger.Error("error")
+	if err := logger.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	want := []string{"debug", "info", "warn", "error"}
+	for name, srv := range map[string]*projectServer{"regional": regional, "central": central} {
+		if got := srv.received(validAPIKey()); !slices.Equal(got, want) {
+			t.Errorf("%s received %v, want %v", name, got, want)
+		}
+	}
+	if got := other.calls.Load(); got != 4 {
+		t.Errorf("custom logger calls = %d, want 4", got)
+	}
+	regional.mu.Lock()
+	defer regional.mu.Unlock()
+	for _, e := range regional.entries[validAPIKey()] {
+		if !strings.HasSuffix(e.SourceFile, "multi_test.go") {
+			t.Errorf("%s SourceFile = %q, want the caller's file", e.Message, e.SourceFile)
+		}
+	}
+}
+
+// TestMultiClientWith tests that With scopes every destination.
+func TestMultiClientWith(t *testing.T) {
+	regional := newProjectServer(t)
+	central := newProjectServer(t)
+	a, err := New(regional.URL, validAPIKey())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	b, err := New(central.URL, validAPIKey())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	logger := MultiClient(a.Logger(), b.Logger(), &countingLogger{})
+	defer logger.Shutdown(context.Background())
+
+	logger.With(M{"requestId": "r-1"}).Info("scoped")
+	if err := logger.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	for name, srv := range map[string]*projectServer{"regional": regional, "central": central} {
+		srv.mu.Lock()
+		entries := srv.entries[validAPIKey()]
+		if len(entries) != 1 || entries[0].Metadata["requestId"] != "r-1" {
+			t.Errorf("%s received %+v, want one entry with requestId", name, entries)
+		}
+		srv.mu.Unlock()
+	}
+}
+
+// TestMultiClientPartialFailure tests that a failing destination neither
+// blocks the others nor hides its error.
+func TestMultiClientPartialFailure(t *testing.T) {
+	healthy := newProjectServer(t)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+
+	down, err := New(failing.URL, validAPIKey(), WithMaxRetries(0))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	up, err := New(healthy.URL, validAPIKey())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	logger := MultiClient(down.Logger(), up.Logger())
+	defer logger.Shutdown(context.Background())
+
+	logger.Info("one")
+	err = logger.Flush(context.Background())
+	var logwellErr *Error
+	if !errors.As(err, &logwellErr) || logwellErr.Code != ErrServerError {
+		t.Fatalf("Flush() error = %v, want the failing destination's SERVER_ERROR", err)
+	}
+	if got := healthy.received(validAPIKey()); !slices.Equal(got, []string{"one"}) {
+		t.Errorf("healthy destination received %v, want [one]", got)
+	}
+	if got := down.Stats().Queued; got != 1 {
+		t.Errorf("failing destination Queued = %d, want 1 kept for retry", got)
+	}
+}
+
+// TestMultiClientFatal tests that the fatal entry reaches every destination
+// before any of them exits.
+func TestMultiClientFatal(t *testing.T) {
+	first := newProjectServer(t)
+	second := newProjectServer(t)
+	var exits atomic.Int32
+	exit := func(int) {
+		if got := len(second.received(validAPIKey())); got != 1 {
+			t.Errorf("second destination had %d entries at exit, want 1", got)
+		}
+		exits.Add(1)
+	}
+	a, err := New(first.URL, validAPIKey(), WithExitFunc(exit))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	b, err := New(second.URL, validAPIKey(), WithExitFunc(exit))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	other := &countingLogger{}
+
+	MultiClient(a.Logger(), b.Logger(), other).Fatal("boom")
+
+	for name, srv := range map[string]*projectServer{"first": first, "second": second} {
+		if got := srv.received(validAPIKey()); !slices.Equal(got, []string{"boom"}) {
+			t.Errorf("%s received %v, want [boom]", name, got)
+		}
+	}
+	if got := other.fatal.Load(); got != 1 {
+		t.Errorf("custom logger Fatal calls = %d, want 1", got)
+	}
+	if got := exits.Load(); got != 2 {
+		t.Errorf("exit calls = %d, want 2", got)
+	}
+}
+
+// TestMultiClientLog tests that Log fans an entry out to every destination
+// that accepts entries, skipping loggers without a Log method.
+func TestMultiClientLog(t *testing.T) {
+	regional := newProjectServer(t)
+	central := newProjectServer(t)
+	a, err := New(regional.URL, validAPIKey())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	b, err := New(central.URL, validAPIKey())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	var sink Sink = MultiClient(a.Logger(), b.Logger(), &countingLogger{})
+
+	sink.Log(LogEntry{Level: LevelWarn, Message: "shipped"})
+	if err := sink.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	for name, srv := range map[string]*projectServer{"regional": regional, "central": central} {
+		if got := srv.received(validAPIKey()); !slices.Equal(got, []string{"shipped"}) {
+			t.Errorf("%s received %v, want [shipped]", name, got)
+		}
+	}
+}
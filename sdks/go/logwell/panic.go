@@ -0,0 +1,126 @@
+package logwell
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// DefaultPanicFlushTimeout bounds the synchronous flush after a panic is
+// logged.
+const DefaultPanicFlushTimeout = 5 * time.Second
+
+// PanicOption configures RecoverAndLog and Client.CapturePanic.
+type PanicOption func(*panicConfig)
+
+type panicConfig struct {
+	repanic      bool
+	flushTimeout time.Duration
+}
+
+// WithPanicRecover stops the panic after it is logged instead of
+// re-panicking. CapturePanic then returns the recovered value.
+func WithPanicRecover() PanicOption {
+	return func(c *panicConfig) {
+		c.repanic = false
+	}
+}
+
+// WithPanicFlushTimeout sets how long to wait for the flush after logging
+// a panic. Default: DefaultPanicFlushTimeout.
+func WithPanicFlushTimeout(d time.Duration) PanicOption {
+	return func(c *panicConfig) {
+		c.flushTimeout = d
+	}
+}
+
+// RecoverAndLog recovers a panic in the calling goroutine, logs it at
+// FATAL level with the stack trace, flushes synchronously, and re-panics
+// with the original value (unless WithPanicRecover is given).
+// It must be deferred directly:
+//
+//	func worker(client *logwell.Client) {
+//	    defer logwell.RecoverAndLog(client)
+//	    ...
+//	}
+func RecoverAndLog(client *Client, opts ...PanicOption) {
+	if r := recover(); r != nil {
+		client.handlePanic(r, opts)
+	}
+}
+
+// CapturePanic runs fn and, if it panics, logs the panic at FATAL level
+// with the stack trace, flushes synchronously, and re-panics with the
+// original value. With WithPanicRecover the panic is stopped instead and
+// its value returned. Returns nil if fn does not panic.
+func (c *Client) CapturePanic(fn func(), opts ...PanicOption) (recovered any) {
+	defer func() {
+		if r := recover(); r != nil {
+			recovered = r
+			c.handlePanic(r, opts)
+		}
+	}()
+	fn()
+	return nil
+}
+
+// handlePanic logs and flushes a recovered panic value, then re-panics
+// unless configured otherwise. Must be called from the deferred function
+// that recovered, so the panicking frames are still on the stack.
+func (c *Client) handlePanic(r any, opts []PanicOption) {
+	cfg := panicConfig{repanic: true, flushTimeout: DefaultPanicFlushTimeout}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	metadata := M{"panic": fmt.Sprint(r)}
+	if err, ok := r.(error); ok {
+		errorMetadata(metadata, err)
+	}
+	entry := LogEntry{
+		Level:    LevelFatal,
+		Message:  fmt.Sprintf("panic: %v", r),
+		Metadata: metadata,
+	}
+	if frames := panicFrames(); len(frames) > 0 {
+		metadata["stack"] = formatFrames(frames)
+		entry.SourceFile, entry.LineNumber = frames[0].File, frames[0].Line
+	}
+	c.Log(entry)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.flushTimeout)
+	defer cancel()
+	_ = c.Flush(ctx) // send failures already reach OnError
+
+	if cfg.repanic {
+		panic(r)
+	}
+}
+
+// panicFrames returns the stack of the current panic, starting at the
+// frame that panicked; runtime frames raising the panic (e.g. for a nil
+// dereference) are skipped. Returns nil when called outside a panic.
+func panicFrames() []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	var stack []runtime.Frame
+	inPanic := false
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if inPanic {
+			if len(stack) > 0 || !strings.HasPrefix(frame.Function, "runtime.") {
+				stack = append(stack, frame)
+			}
+		} else if frame.Function == "runtime.gopanic" {
+			inPanic = true
+		}
+		if !more {
+			break
+		}
+	}
+	return stack
+}
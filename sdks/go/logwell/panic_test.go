@@ -0,0 +1,87 @@
+package logwell
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecoverAndLogRepanics(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	client := createTestClient(t, ts)
+	defer client.Shutdown(context.Background())
+
+	var repanicked any
+	func() {
+		defer func() { repanicked = recover() }()
+		func() {
+			defer RecoverAndLog(client)
+			panic("boom")
+		}()
+	}()
+
+	if repanicked != "boom" {
+		t.Errorf("re-panic value = %v, want boom", repanicked)
+	}
+
+	// Flushed synchronously before re-panicking.
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 1)
+	if len(logs) == 1 {
+		log := logs[0]
+		if log.Level != LevelFatal || log.Message != "panic: boom" || log.Metadata["panic"] != "boom" {
+			t.Errorf("entry = %+v", log)
+		}
+		stack, _ := log.Metadata["stack"].(string)
+		if !strings.HasPrefix(stack, "github.com/Divkix/Logwell/sdks/go/logwell.TestRecoverAndLogRepanics") {
+			t.Errorf("stack does not start at the panic site:\n%s", stack)
+		}
+		if !strings.HasSuffix(log.SourceFile, "panic_test.go") || log.LineNumber == 0 {
+			t.Errorf("source = %s:%d, want the panic site", log.SourceFile, log.LineNumber)
+		}
+	}
+}
+
+func TestCapturePanicRecover(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	client := createTestClient(t, ts)
+	defer client.Shutdown(context.Background())
+
+	errBoom := errors.New("boom")
+	got := client.CapturePanic(func() { panic(errBoom) }, WithPanicRecover())
+	if got != errBoom {
+		t.Errorf("CapturePanic() = %v, want errBoom", got)
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 1)
+	if len(logs) == 1 {
+		assertLogMetadata(t, logs[0], map[string]string{"error": "boom", "errorType": "*errors.errorString"})
+	}
+
+	if got := client.CapturePanic(func() {}); got != nil {
+		t.Errorf("CapturePanic() = %v without a panic, want nil", got)
+	}
+}
+
+func TestCapturePanicRuntimeError(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	client := createTestClient(t, ts)
+	defer client.Shutdown(context.Background())
+
+	var m map[string]int
+	client.CapturePanic(func() { m["x"] = 1 }, WithPanicRecover())
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 1)
+	if len(logs) == 1 {
+		stack, _ := logs[0].Metadata["stack"].(string)
+		if strings.HasPrefix(stack, "runtime.") {
+			t.Errorf("stack starts in the runtime:\n%s", stack)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package logwell
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"hash/maphash"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	walExt             = ".wal"
+	maxWALSegmentBytes = 4 << 20 // 4MB
+)
+
+// persistentQueue is a write-ahead log that mirrors every admitted entry to
+// disk so entries survive process crashes and endpoint outages.
+//
+// Entries are appended as NDJSON lines to numbered segment files. The queue
+// remembers which segments hold each entry, keyed by a hash of its encoding;
+// once every entry of a rotated segment has been delivered, the segment file
+// is deleted. Segments left on disk are replayed by the next process that
+// opens the directory, giving at-least-once delivery (entries delivered from
+// a partially acknowledged segment may be sent again).
+//
+// Writes go straight to the OS, so a process crash loses nothing; segments
+// are synced to stable storage when they are rotated or closed, so a power
+// loss or OS crash can lose the tail of the active segment.
+type persistentQueue struct {
+	dir        string
+	maxBytes   int64
+	segLimit   int64
+	seed       maphash.Seed
+	mu         sync.Mutex
+	cur        *os.File
+	curSeq     uint64
+	segments   map[uint64]*walSegment
+	pending    map[uint64][]uint64 // entry hash -> segments holding it, oldest first
+	totalBytes int64
+}
+
+// walSegment tracks one segment file.
+type walSegment struct {
+	bytes       int64
+	outstanding int      // entries written but not yet delivered
+	keys        []uint64 // hashes of the entries written, for cleanup
+	closed      bool     // no further appends; deletable once outstanding == 0
+}
+
+// openPersistentQueue opens (creating if needed) the WAL in dir and returns
+// the entries left undelivered by a previous process, oldest first.
+func openPersistentQueue(dir string, maxBytes int64) (*persistentQueue, []LogEntry, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, nil, err
+	}
+
+	p := &persistentQueue{
+		dir:      dir,
+		maxBytes: maxBytes,
+		segLimit: min(max(maxBytes/4, 1), maxWALSegmentBytes),
+		seed:     maphash.MakeSeed(),
+		segments: make(map[uint64]*walSegment),
+		pending:  make(map[uint64][]uint64),
+	}
+
+	replay, err := p.load()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := p.rotate(); err != nil {
+		return nil, nil, err
+	}
+	return p, replay, nil
+}
+
+// load reads existing segments into memory as closed segments.
+func (p *persistentQueue) load() ([]LogEntry, error) {
+	names, err := filepath.Glob(filepath.Join(p.dir, "*"+walExt))
+	if err != nil {
+		return nil, err
+	}
+
+	seqs := make([]uint64, 0, len(names))
+	for _, name := range names {
+		seq, err := strconv.ParseUint(strings.TrimSuffix(filepath.Base(name), walExt), 10, 64)
+		if err != nil {
+			continue // not one of ours
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	var replay []LogEntry
+	for _, seq := range seqs {
+		entries, size, err := readSegment(p.segmentPath(seq))
+		if err != nil {
+			return nil, err
+		}
+		seg := &walSegment{bytes: size, closed: true}
+		p.segments[seq] = seg
+		p.totalBytes += size
+		for i := range entries {
+			line, err := json.Marshal(&entries[i])
+			if err != nil {
+				continue // acked entries are matched by re-encoding them
+			}
+			p.trackLocked(seg, seq, p.key(line))
+		}
+		p.curSeq = seq
+		replay = append(replay, entries...)
+	}
+
+	// Drop segments that held nothing parseable.
+	for seq, seg := range p.segments {
+		if seg.outstanding == 0 {
+			p.removeLocked(seq)
+		}
+	}
+
+	return replay, nil
+}
+
+// readSegment parses a segment file. Lines that fail to parse (such as a
+// partial final line after a crash) are skipped.
+func readSegment(path string) ([]LogEntry, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var entries []LogEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		var entry LogEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, err
+	}
+	return entries, info.Size(), nil
+}
+
+// append writes entry to the current segment. If the write pushed the log
+// over maxBytes, the oldest segments are evicted and the number of
+// undelivered entries they held is returned for the caller to report.
+func (p *persistentQueue) append(entry *LogEntry) (evicted int, err error) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return 0, err
+	}
+	key := p.key(line)
+	line = append(line, '\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cur == nil {
+		return 0, os.ErrClosed
+	}
+	if _, err := p.cur.Write(line); err != nil {
+		return 0, err
+	}
+
+	seg := p.segments[p.curSeq]
+	seg.bytes += int64(len(line))
+	p.totalBytes += int64(len(line))
+	p.trackLocked(seg, p.curSeq, key)
+
+	if seg.bytes >= p.segLimit {
+		if err := p.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+	return p.enforceLimitLocked(), nil
+}
+
+// ack marks delivered entries, deleting segments that are fully delivered.
+// Entries that were never persisted, or whose segment was evicted, are
+// ignored.
+func (p *persistentQueue) ack(entries []LogEntry) {
+	keys := make([]uint64, 0, len(entries))
+	for i := range entries {
+		if line, err := json.Marshal(&entries[i]); err == nil {
+			keys = append(keys, p.key(line))
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, key := range keys {
+		seqs := p.pending[key]
+		if len(seqs) == 0 {
+			continue
+		}
+		seq := seqs[0]
+		p.untrackLocked(key, seq)
+		seg := p.segments[seq]
+		seg.outstanding--
+		if seg.closed && seg.outstanding <= 0 {
+			p.removeLocked(seq)
+		}
+	}
+}
+
+// key hashes an entry's encoding. Identical entries share a key, which is
+// fine: whichever copy is delivered, the other still replays.
+func (p *persistentQueue) key(line []byte) uint64 {
+	return maphash.Bytes(p.seed, line)
+}
+
+// trackLocked records that segment seq holds the entry with the given key.
+func (p *persistentQueue) trackLocked(seg *walSegment, seq, key uint64) {
+	seg.outstanding++
+	seg.keys = append(seg.keys, key)
+	p.pending[key] = append(p.pending[key], seq)
+}
+
+// untrackLocked forgets one record of segment seq holding key.
+func (p *persistentQueue) untrackLocked(key, seq uint64) {
+	seqs := p.pending[key]
+	for i, s := range seqs {
+		if s == seq {
+			seqs = append(seqs[:i], seqs[i+1:]...)
+			break
+		}
+	}
+	if len(seqs) == 0 {
+		delete(p.pending, key)
+	} else {
+		p.pending[key] = seqs
+	}
+}
+
+// close closes the current segment, deleting it if everything in it was delivered.
+func (p *persistentQueue) close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cur == nil {
+		return nil
+	}
+	err := syncClose(p.cur)
+	p.cur = nil
+	if seg := p.segments[p.curSeq]; seg != nil {
+		seg.closed = true
+		if seg.outstanding <= 0 {
+			p.removeLocked(p.curSeq)
+		}
+	}
+	return err
+}
+
+// rotate starts a new segment.
+func (p *persistentQueue) rotate() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.rotateLocked()
+}
+
+func (p *persistentQueue) rotateLocked() error {
+	if p.cur != nil {
+		if err := syncClose(p.cur); err != nil {
+			return err
+		}
+		seg := p.segments[p.curSeq]
+		seg.closed = true
+		if seg.outstanding <= 0 {
+			p.removeLocked(p.curSeq)
+		}
+	}
+
+	p.curSeq++
+	f, err := os.OpenFile(p.segmentPath(p.curSeq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		p.cur = nil
+		return err
+	}
+	p.cur = f
+	p.segments[p.curSeq] = &walSegment{}
+	return nil
+}
+
+// enforceLimitLocked evicts the oldest closed segments while the WAL
+// exceeds maxBytes and returns the number of undelivered entries dropped.
+func (p *persistentQueue) enforceLimitLocked() int {
+	dropped := 0
+	for p.totalBytes > p.maxBytes {
+		oldest := uint64(0)
+		for seq, seg := range p.segments {
+			if seg.closed && (oldest == 0 || seq < oldest) {
+				oldest = seq
+			}
+		}
+		if oldest == 0 {
+			break // only the active segment remains
+		}
+		dropped += p.segments[oldest].outstanding
+		p.removeLocked(oldest)
+	}
+	return dropped
+}
+
+// removeLocked deletes a segment file and forgets it and its entries.
+func (p *persistentQueue) removeLocked(seq uint64) {
+	if seg, ok := p.segments[seq]; ok {
+		p.totalBytes -= seg.bytes
+		if seg.outstanding > 0 {
+			for _, key := range seg.keys {
+				p.untrackLocked(key, seq)
+			}
+		}
+		delete(p.segments, seq)
+	}
+	_ = os.Remove(p.segmentPath(seq))
+}
+
+// syncClose flushes f to stable storage and closes it.
+func syncClose(f *os.File) error {
+	err := f.Sync()
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (p *persistentQueue) segmentPath(seq uint64) string {
+	return filepath.Join(p.dir, fmt.Sprintf("%020d%s", seq, walExt))
+}
@@ -0,0 +1,298 @@
+package logwell
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// walFiles returns the segment files in dir.
+func walFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"+walExt))
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+	return files
+}
+
+// TestPersistentQueue_ReplaysUnacked tests that entries not acknowledged
+// before close are returned by the next open.
+func TestPersistentQueue_ReplaysUnacked(t *testing.T) {
+	dir := t.TempDir()
+
+	p, replay, err := openPersistentQueue(dir, 1<<20)
+	if err != nil {
+		t.Fatalf("openPersistentQueue() error = %v", err)
+	}
+	if len(replay) != 0 {
+		t.Fatalf("replay on empty dir = %d entries, want 0", len(replay))
+	}
+
+	entries := []LogEntry{
+		{Level: LevelInfo, Message: "delivered"},
+		{Level: LevelWarn, Message: "pending 1"},
+		{Level: LevelError, Message: "pending 2", Metadata: M{"k": "v"}},
+	}
+	for i := range entries {
+		if _, err := p.append(&entries[i]); err != nil {
+			t.Fatalf("append() error = %v", err)
+		}
+	}
+	p.ack(entries[:1])
+	if err := p.close(); err != nil {
+		t.Fatalf("close() error = %v", err)
+	}
+
+	p2, replay, err := openPersistentQueue(dir, 1<<20)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	defer func() { _ = p2.close() }()
+
+	// The segment was only partially acknowledged, so it replays in full.
+	if len(replay) != 3 {
+		t.Fatalf("replay = %d entries, want 3", len(replay))
+	}
+	if replay[2].Message != "pending 2" || replay[2].Metadata["k"] != "v" {
+		t.Errorf("replay[2] = %+v, want pending 2 with metadata", replay[2])
+	}
+}
+
+// TestPersistentQueue_DeletesDeliveredSegments tests that fully acknowledged
+// segments are removed from disk.
+func TestPersistentQueue_DeletesDeliveredSegments(t *testing.T) {
+	dir := t.TempDir()
+
+	p, _, err := openPersistentQueue(dir, 1<<20)
+	if err != nil {
+		t.Fatalf("openPersistentQueue() error = %v", err)
+	}
+
+	entries := make([]LogEntry, 10)
+	for i := range entries {
+		entries[i] = LogEntry{Level: LevelInfo, Message: "m"}
+		if _, err := p.append(&entries[i]); err != nil {
+			t.Fatalf("append() error = %v", err)
+		}
+	}
+	p.ack(entries)
+	if err := p.close(); err != nil {
+		t.Fatalf("close() error = %v", err)
+	}
+
+	if files := walFiles(t, dir); len(files) != 0 {
+		t.Errorf("segments left after full ack = %v, want none", files)
+	}
+
+	_, replay, err := openPersistentQueue(dir, 1<<20)
+	if err != nil {
+		t.Fatalf("reopen error = %v", err)
+	}
+	if len(replay) != 0 {
+		t.Errorf("replay = %d entries, want 0", len(replay))
+	}
+}
+
+// TestPersistentQueue_SkipsTornLine tests that a partially written final
+// line (as left by a crash) is ignored on replay.
+func TestPersistentQueue_SkipsTornLine(t *testing.T) {
+	dir := t.TempDir()
+	content := `{"level":"info","message":"ok"}` + "\n" + `{"level":"info","mess`
+	if err := os.WriteFile(filepath.Join(dir, "00000000000000000007.wal"), []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	p, replay, err := openPersistentQueue(dir, 1<<20)
+	if err != nil {
+		t.Fatalf("openPersistentQueue() error = %v", err)
+	}
+	defer func() { _ = p.close() }()
+
+	if len(replay) != 1 || replay[0].Message != "ok" {
+		t.Fatalf("replay = %+v, want the single complete entry", replay)
+	}
+
+	// New segments continue numbering after existing ones.
+	entry := LogEntry{Level: LevelInfo, Message: "new"}
+	if _, err := p.append(&entry); err != nil {
+		t.Fatalf("append() error = %v", err)
+	}
+	if p.curSeq <= 7 {
+		t.Errorf("new entry segment = %d, want > 7", p.curSeq)
+	}
+}
+
+// TestPersistentQueue_EvictsOldestOverLimit tests that the oldest segments
+// are dropped, and reported, once maxBytes is exceeded.
+func TestPersistentQueue_EvictsOldestOverLimit(t *testing.T) {
+	dir := t.TempDir()
+
+	const maxBytes = 2048
+	p, _, err := openPersistentQueue(dir, maxBytes)
+	if err != nil {
+		t.Fatalf("openPersistentQueue() error = %v", err)
+	}
+	defer func() { _ = p.close() }()
+
+	evicted := 0
+	for i := 0; i < 100; i++ {
+		entry := LogEntry{Level: LevelInfo, Message: fmt.Sprintf("a message long enough to fill segments quickly %d", i)}
+		n, err := p.append(&entry)
+		if err != nil {
+			t.Fatalf("append() error = %v", err)
+		}
+		evicted += n
+	}
+
+	var total int64
+	for _, f := range walFiles(t, dir) {
+		info, err := os.Stat(f)
+		if err != nil {
+			t.Fatalf("Stat() error = %v", err)
+		}
+		total += info.Size()
+	}
+	if total > maxBytes {
+		t.Errorf("disk usage = %d bytes, want <= %d", total, maxBytes)
+	}
+	if evicted == 0 {
+		t.Fatal("expected eviction to be reported by append")
+	}
+
+	// Evicted entries are forgotten along with their segments.
+	tracked := 0
+	for _, seqs := range p.pending {
+		tracked += len(seqs)
+	}
+	if tracked != 100-evicted {
+		t.Errorf("tracked entries = %d, want %d", tracked, 100-evicted)
+	}
+}
+
+// TestPersistentQueue_AcksDuplicates tests that identical entries in
+// different segments are each acknowledged once, oldest first.
+func TestPersistentQueue_AcksDuplicates(t *testing.T) {
+	dir := t.TempDir()
+
+	p, _, err := openPersistentQueue(dir, 1<<20)
+	if err != nil {
+		t.Fatalf("openPersistentQueue() error = %v", err)
+	}
+	defer func() { _ = p.close() }()
+
+	entry := LogEntry{Level: LevelInfo, Message: "same"}
+	for range 2 {
+		if _, err := p.append(&entry); err != nil {
+			t.Fatalf("append() error = %v", err)
+		}
+		if err := p.rotate(); err != nil {
+			t.Fatalf("rotate() error = %v", err)
+		}
+	}
+	if files := walFiles(t, dir); len(files) != 3 {
+		t.Fatalf("segments = %v, want two full and one active", files)
+	}
+
+	p.ack([]LogEntry{entry})
+	if files := walFiles(t, dir); len(files) != 2 {
+		t.Errorf("segments after one ack = %v, want the oldest deleted", files)
+	}
+	p.ack([]LogEntry{entry, entry})
+	if files := walFiles(t, dir); len(files) != 1 {
+		t.Errorf("segments after acking both = %v, want only the active one", files)
+	}
+}
+
+// TestClientPersistentQueueEvictionCallbackLogs tests that a persistent
+// queue eviction is reported outside the client lock, so OnError may log.
+func TestClientPersistentQueueEvictionCallbackLogs(t *testing.T) {
+	var client *Client
+	var reported atomic.Bool
+	var err error
+	client, err = New(validEndpoint(), validAPIKey(),
+		WithPersistentQueue(t.TempDir(), 1024),
+		WithBatchSize(MaxBatchSize),
+		WithFlushInterval(MaxFlushInterval),
+		WithTransport(discardTransport{}),
+		WithOnError(func(err *Error) {
+			if err.Code == ErrQueueOverflow && reported.CompareAndSwap(false, true) {
+				client.Warn("eviction: " + err.Message)
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 200 {
+			client.Info(fmt.Sprintf("filling the persistent queue %d", i))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("log calls deadlocked in the eviction callback")
+	}
+	if !reported.Load() {
+		t.Error("eviction was not reported to OnError")
+	}
+}
+
+// TestClientPersistentQueue_ReplaysAfterOutage tests that entries a client
+// could not deliver are sent by the next client opened on the same directory.
+func TestClientPersistentQueue_ReplaysAfterOutage(t *testing.T) {
+	dir := t.TempDir()
+	ts := newTestServer()
+	defer ts.Close()
+
+	ts.setHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	down := createTestClient(t, ts,
+		WithPersistentQueue(dir, 1<<20),
+		WithMaxRetries(0),
+		WithFlushInterval(MaxFlushInterval),
+	)
+	down.Info("survives outage 1")
+	down.Info("survives outage 2")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := down.Shutdown(ctx); err == nil {
+		t.Fatal("Shutdown() error = nil, want delivery failure")
+	}
+	if len(walFiles(t, dir)) == 0 {
+		t.Fatal("expected undelivered entries to remain on disk")
+	}
+
+	ts.setHandler(nil)
+
+	up := createTestClient(t, ts, WithPersistentQueue(dir, 1<<20))
+	if err := up.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 2)
+	if logs[0].Message != "survives outage 1" || logs[1].Message != "survives outage 2" {
+		t.Errorf("replayed messages = %q, %q", logs[0].Message, logs[1].Message)
+	}
+
+	if err := up.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if files := walFiles(t, dir); len(files) != 0 {
+		t.Errorf("segments left after delivery = %v, want none", files)
+	}
+}
@@ -0,0 +1,95 @@
+package logwell
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// maxPooledBufferSize caps the request body buffers kept for reuse, so one
+// unusually large batch does not pin its buffer for the process lifetime.
+const maxPooledBufferSize = 1 << 20
+
+// entryPool recycles the entries built by the level methods. An entry is
+// only borrowed for the duration of the log call: the queue stores a copy.
+var entryPool = sync.Pool{New: func() any { return new(LogEntry) }}
+
+// getEntry returns a zeroed entry from entryPool.
+func getEntry() *LogEntry {
+	return entryPool.Get().(*LogEntry)
+}
+
+// putEntry clears entry, dropping its references, and returns it to entryPool.
+func putEntry(entry *LogEntry) {
+	*entry = LogEntry{}
+	entryPool.Put(entry)
+}
+
+// batchPool recycles the slices batches are taken from the queue into.
+var batchPool sync.Pool
+
+// getBatch returns an empty slice with room for n entries.
+func getBatch(n int) []LogEntry {
+	if p, ok := batchPool.Get().(*[]LogEntry); ok && cap(*p) >= n {
+		return (*p)[:0]
+	}
+	return make([]LogEntry, 0, n)
+}
+
+// putBatch clears batch, dropping its references, and returns it to
+// batchPool. batch must not be used afterwards.
+func putBatch(batch []LogEntry) {
+	if cap(batch) == 0 {
+		return
+	}
+	batch = batch[:cap(batch)]
+	clear(batch)
+	batchPool.Put(&batch)
+}
+
+// bufferPool recycles request body buffers.
+var bufferPool = sync.Pool{New: func() any { return new(bodyBuffer) }}
+
+// bodyBuffer is a pooled, encoded request body. It is shared by the
+// attempts of one send and returned to bufferPool once the send and every
+// request reading it are done with it: the HTTP transport may close a
+// request body after the request returns.
+type bodyBuffer struct {
+	bytes.Buffer
+	refs atomic.Int32
+}
+
+// release drops one reference, returning b to bufferPool after the last.
+func (b *bodyBuffer) release() {
+	if b.refs.Add(-1) != 0 {
+		return
+	}
+	if b.Cap() > maxPooledBufferSize {
+		return
+	}
+	b.Reset()
+	bufferPool.Put(b)
+}
+
+// reader returns a request body reading b; closing it releases the
+// reference it holds.
+func (b *bodyBuffer) reader() io.ReadCloser {
+	b.refs.Add(1)
+	return &bodyReader{Reader: bytes.NewReader(b.Bytes()), buf: b}
+}
+
+// bodyReader is a request body over a bodyBuffer.
+type bodyReader struct {
+	*bytes.Reader
+	buf    *bodyBuffer
+	closed atomic.Bool
+}
+
+// Close releases the buffer; later calls do nothing.
+func (r *bodyReader) Close() error {
+	if r.closed.CompareAndSwap(false, true) {
+		r.buf.release()
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package logwell
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+)
+
+func TestEncodeBatch(t *testing.T) {
+	logs := []LogEntry{
+		{Level: LevelInfo, Message: "one"},
+		{Level: LevelWarn, Message: "two", Metadata: M{"k": "<v>"}},
+	}
+	body, err := encodeBatch(logs, "key-1")
+	if err != nil {
+		t.Fatalf("encodeBatch() error = %v", err)
+	}
+	defer body.release()
+
+	var decoded []keyedEntry
+	if err := json.Unmarshal(body.Bytes(), &decoded); err != nil {
+		t.Fatalf("body %q is not a JSON array: %v", body.Bytes(), err)
+	}
+	if len(decoded) != 2 || decoded[1].Message != "two" || decoded[1].Metadata["k"] != "<v>" {
+		t.Errorf("decoded = %+v, want both entries", decoded)
+	}
+	for _, e := range decoded {
+		if e.IdempotencyKey != "key-1" {
+			t.Errorf("idempotencyKey = %q, want key-1", e.IdempotencyKey)
+		}
+	}
+
+	empty, err := encodeBatch(nil, "")
+	if err != nil {
+		t.Fatalf("encodeBatch(nil) error = %v", err)
+	}
+	defer empty.release()
+	if got := empty.String(); got != "[]" {
+		t.Errorf("empty body = %q, want []", got)
+	}
+}
+
+// TestBodyBufferRelease tests that a body buffer is only released once the
+// send and every request reading it are done with it.
+func TestBodyBufferRelease(t *testing.T) {
+	body, err := encodeBatch([]LogEntry{{Level: LevelInfo, Message: "m"}}, "")
+	if err != nil {
+		t.Fatalf("encodeBatch() error = %v", err)
+	}
+	want := body.String()
+
+	r := body.reader()
+	body.release()
+	if got := body.refs.Load(); got != 1 {
+		t.Fatalf("refs = %d with an open reader, want 1", got)
+	}
+	b, _ := io.ReadAll(r)
+	if string(b) != want {
+		t.Errorf("reader read %q, want %q", b, want)
+	}
+	r.Close()
+	r.Close() // a second Close must not release again
+	if got := body.refs.Load(); got != 0 {
+		t.Errorf("refs = %d after Close, want 0", got)
+	}
+}
+
+func TestBatchPool(t *testing.T) {
+	batch := append(getBatch(2), LogEntry{Message: "a", Metadata: M{"k": 1}}, LogEntry{Message: "b"})
+	backing := batch[:cap(batch)]
+	putBatch(batch)
+
+	for i, e := range backing {
+		if e.Message != "" || e.Metadata != nil {
+			t.Errorf("backing[%d] = %+v after putBatch, want cleared", i, e)
+		}
+	}
+	if got := getBatch(1); len(got) != 0 {
+		t.Errorf("len(getBatch(1)) = %d, want 0", len(got))
+	}
+}
@@ -0,0 +1,78 @@
+package logwell
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestClientProcessorChain(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var order []string
+	client := createTestClient(t, ts,
+		WithProcessor(func(e *LogEntry) bool {
+			order = append(order, "enrich")
+			if e.Metadata == nil {
+				e.Metadata = M{}
+			}
+			e.Metadata["region"] = "eu-west-1"
+			return true
+		}),
+		WithProcessor(func(e *LogEntry) bool {
+			order = append(order, "filter")
+			return !strings.HasPrefix(e.Message, "healthz")
+		}),
+		WithProcessor(func(e *LogEntry) bool {
+			order = append(order, "rewrite")
+			e.Message = strings.ToUpper(e.Message)
+			return true
+		}),
+	)
+	defer client.Shutdown(context.Background())
+
+	client.Info("healthz ok")
+	if got := strings.Join(order, ","); got != "enrich,filter" {
+		t.Errorf("dropped entry ran %q, want chain stopped after filter", got)
+	}
+	client.Info("order placed")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 1)
+	if len(logs) == 1 {
+		if logs[0].Message != "ORDER PLACED" {
+			t.Errorf("Message = %q, want ORDER PLACED", logs[0].Message)
+		}
+		assertLogMetadata(t, logs[0], map[string]string{"region": "eu-west-1"})
+	}
+}
+
+func TestClientProcessorRunsBeforeRedaction(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts,
+		WithRedaction(RedactKeys("token")),
+		WithProcessor(func(e *LogEntry) bool {
+			e.Metadata = M{"token": "added-by-processor"}
+			return true
+		}),
+	)
+	defer client.Shutdown(context.Background())
+
+	child := client.With(M{"k": "v"})
+	child.Info("hello")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 1)
+	if len(logs) == 1 {
+		assertLogMetadata(t, logs[0], map[string]string{"token": DefaultRedactReplacement})
+	}
+}
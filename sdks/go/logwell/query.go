@@ -0,0 +1,206 @@
+package logwell
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Search limits enforced by the server.
+const (
+	DefaultSearchLimit = 100
+	MaxSearchLimit     = 500
+)
+
+// QueryClient reads logs back from a Logwell project.
+//
+// The read API is served under /api/projects/{id} and, unlike ingestion,
+// is authenticated with a dashboard session rather than an API key: pass the
+// value of the session cookie from a logged-in browser via WithSessionToken.
+//
+// A QueryClient is safe for concurrent use.
+type QueryClient struct {
+	session   *sessionClient
+	projectID string
+}
+
+// NewQueryClient creates a client for reading the logs of projectID.
+// Returns an error if the endpoint is invalid or projectID or the session
+// token is missing.
+//
+// Example:
+//
+//	qc, err := logwell.NewQueryClient(
+//	    "https://logs.example.com",
+//	    "proj_123",
+//	    logwell.WithSessionToken(os.Getenv("LOGWELL_SESSION")),
+//	)
+func NewQueryClient(endpoint, projectID string, opts ...SessionOption) (*QueryClient, error) {
+	session, err := newSessionClient(endpoint, opts)
+	if err != nil {
+		return nil, err
+	}
+	if projectID == "" {
+		return nil, NewError(ErrInvalidConfig, "projectID is required")
+	}
+	return &QueryClient{session: session, projectID: projectID}, nil
+}
+
+// TimeRange bounds a query by timestamp. A zero From or To leaves that side open.
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Query describes a log search.
+type Query struct {
+	// Text is a full-text search query. Empty matches everything.
+	Text string
+
+	// Levels restricts results to these levels. Empty matches all levels.
+	Levels []LogLevel
+
+	// Service restricts results to one service name.
+	// The server does not filter by service yet, so this is applied to each
+	// page after it is fetched; pages may then hold fewer than Limit logs.
+	Service string
+
+	// TimeRange restricts results to a timestamp window (inclusive).
+	TimeRange TimeRange
+
+	// Limit is the page size. Default: 100, Range: 1-500 (clamped by the server).
+	Limit int
+
+	// Cursor resumes from a previous SearchResult.NextCursor.
+	Cursor string
+}
+
+// LogRecord is a stored log as returned by the read API.
+type LogRecord struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"projectId"`
+	IncidentID  string    `json:"incidentId,omitempty"`
+	Fingerprint string    `json:"fingerprint,omitempty"`
+	Service     string    `json:"serviceName,omitempty"`
+	Level       LogLevel  `json:"level"`
+	Message     string    `json:"message"`
+	Metadata    M         `json:"metadata,omitempty"`
+	SourceFile  string    `json:"sourceFile,omitempty"`
+	LineNumber  int       `json:"lineNumber,omitempty"`
+	RequestID   string    `json:"requestId,omitempty"`
+	UserID      string    `json:"userId,omitempty"`
+	IPAddress   string    `json:"ipAddress,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// SearchResult is one page of search results, newest first.
+type SearchResult struct {
+	// Logs holds the matching logs on this page.
+	Logs []LogRecord `json:"logs"`
+
+	// Total is the number of matching logs, or nil on pages fetched with a
+	// cursor (the server only counts on the first page).
+	Total *int `json:"total"`
+
+	// TotalIsCapped reports that Total stopped counting at the server's ceiling.
+	TotalIsCapped bool `json:"total_is_capped"`
+
+	// HasMore reports whether another page exists.
+	HasMore bool `json:"has_more"`
+
+	// NextCursor fetches the next page when passed as Query.Cursor.
+	NextCursor string `json:"nextCursor"`
+}
+
+// Encode returns the URL query string Search sends for q, such as
+// "level=error&limit=100&search=timeout". Service is not included; it is
+// filtered on the client.
+func (q Query) Encode() string {
+	return q.params().Encode()
+}
+
+// params returns the search request parameters for q.
+func (q *Query) params() url.Values {
+	params := url.Values{}
+	if q.Text != "" {
+		params.Set("search", q.Text)
+	}
+	if len(q.Levels) > 0 {
+		levels := make([]string, len(q.Levels))
+		for i, l := range q.Levels {
+			levels[i] = string(l)
+		}
+		params.Set("level", strings.Join(levels, ","))
+	}
+	if !q.TimeRange.From.IsZero() {
+		params.Set("from", q.TimeRange.From.UTC().Format(time.RFC3339Nano))
+	}
+	if !q.TimeRange.To.IsZero() {
+		params.Set("to", q.TimeRange.To.UTC().Format(time.RFC3339Nano))
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	params.Set("limit", strconv.Itoa(limit))
+	if q.Cursor != "" {
+		params.Set("cursor", q.Cursor)
+	}
+	return params
+}
+
+// Search returns one page of logs matching query.
+func (q *QueryClient) Search(ctx context.Context, query Query) (*SearchResult, error) {
+	var result SearchResult
+	if err := q.session.doJSON(ctx, http.MethodGet, projectsPath(q.projectID, "/logs"), query.params(), nil, &result); err != nil {
+		return nil, err
+	}
+
+	if query.Service != "" {
+		filtered := result.Logs[:0]
+		for _, l := range result.Logs {
+			if l.Service == query.Service {
+				filtered = append(filtered, l)
+			}
+		}
+		result.Logs = filtered
+	}
+	return &result, nil
+}
+
+// SearchAll iterates over every log matching query, newest first, fetching
+// pages on demand. Iteration stops at the first error, which is yielded with
+// a zero LogRecord.
+//
+// Example:
+//
+//	for rec, err := range qc.SearchAll(ctx, logwell.Query{Levels: []logwell.LogLevel{logwell.LevelError}}) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(rec.Timestamp, rec.Message)
+//	}
+func (q *QueryClient) SearchAll(ctx context.Context, query Query) iter.Seq2[LogRecord, error] {
+	return func(yield func(LogRecord, error) bool) {
+		for {
+			page, err := q.Search(ctx, query)
+			if err != nil {
+				yield(LogRecord{}, err)
+				return
+			}
+			for _, rec := range page.Logs {
+				if !yield(rec, nil) {
+					return
+				}
+			}
+			if !page.HasMore || page.NextCursor == "" {
+				return
+			}
+			query.Cursor = page.NextCursor
+		}
+	}
+}
@@ -0,0 +1,222 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testProjectID = "proj_123"
+
+// newQueryTestClient creates a QueryClient pointed at server.
+func newQueryTestClient(t *testing.T, server *httptest.Server) *QueryClient {
+	t.Helper()
+	qc, err := NewQueryClient(server.URL, testProjectID, WithSessionToken("session-abc"))
+	if err != nil {
+		t.Fatalf("NewQueryClient() error = %v", err)
+	}
+	return qc
+}
+
+func TestNewQueryClientValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoint  string
+		projectID string
+		opts      []SessionOption
+	}{
+		{"invalid endpoint", "not-a-url", testProjectID, []SessionOption{WithSessionToken("s")}},
+		{"missing project", validEndpoint(), "", []SessionOption{WithSessionToken("s")}},
+		{"missing session token", validEndpoint(), testProjectID, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewQueryClient(tt.endpoint, tt.projectID, tt.opts...)
+			assertConfigError(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestNewQueryClientSecureCookieName(t *testing.T) {
+	qc, err := NewQueryClient("https://logs.example.com", testProjectID, WithSessionToken("s"))
+	if err != nil {
+		t.Fatalf("NewQueryClient() error = %v", err)
+	}
+	if qc.session.cookieName != "__Secure-better-auth.session_token" {
+		t.Errorf("cookieName = %q, want __Secure- prefix over HTTPS", qc.session.cookieName)
+	}
+}
+
+func TestQueryClientSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/projects/proj_123/logs" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if c, err := r.Cookie("better-auth.session_token"); err != nil || c.Value != "session-abc" {
+			t.Errorf("session cookie = %v, %v", c, err)
+		}
+
+		q := r.URL.Query()
+		want := map[string]string{
+			"search": "timeout",
+			"level":  "error,fatal",
+			"from":   "2025-01-01T00:00:00Z",
+			"to":     "2025-01-02T00:00:00Z",
+			"limit":  "50",
+			"cursor": "cur-1",
+		}
+		for k, v := range want {
+			if got := q.Get(k); got != v {
+				t.Errorf("param %s = %q, want %q", k, got, v)
+			}
+		}
+
+		w.Write([]byte(`{
+			"logs": [
+				{"id":"l1","projectId":"proj_123","serviceName":"api","level":"error","message":"db timeout",
+				 "metadata":{"attempt":3},"incidentId":null,"timestamp":"2025-01-01T12:00:00.000Z"},
+				{"id":"l2","projectId":"proj_123","serviceName":"worker","level":"fatal","message":"timeout",
+				 "timestamp":"2025-01-01T11:00:00.000Z"}
+			],
+			"total": 42,
+			"total_is_capped": false,
+			"has_more": true,
+			"nextCursor": "cur-2"
+		}`))
+	}))
+	defer server.Close()
+
+	qc := newQueryTestClient(t, server)
+	result, err := qc.Search(context.Background(), Query{
+		Text:   "timeout",
+		Levels: []LogLevel{LevelError, LevelFatal},
+		TimeRange: TimeRange{
+			From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+		Limit:  50,
+		Cursor: "cur-1",
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	if len(result.Logs) != 2 {
+		t.Fatalf("len(Logs) = %d, want 2", len(result.Logs))
+	}
+	first := result.Logs[0]
+	if first.ID != "l1" || first.Service != "api" || first.Level != LevelError || first.Message != "db timeout" {
+		t.Errorf("Logs[0] = %+v", first)
+	}
+	if first.Metadata["attempt"] != float64(3) {
+		t.Errorf("Logs[0].Metadata = %v", first.Metadata)
+	}
+	if !first.Timestamp.Equal(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Logs[0].Timestamp = %v", first.Timestamp)
+	}
+	if result.Total == nil || *result.Total != 42 {
+		t.Errorf("Total = %v, want 42", result.Total)
+	}
+	if !result.HasMore || result.NextCursor != "cur-2" {
+		t.Errorf("HasMore = %v, NextCursor = %q", result.HasMore, result.NextCursor)
+	}
+}
+
+func TestQueryClientSearchServiceFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"logs":[
+			{"id":"1","serviceName":"api","level":"info","message":"a","timestamp":"2025-01-01T00:00:00Z"},
+			{"id":"2","serviceName":"worker","level":"info","message":"b","timestamp":"2025-01-01T00:00:00Z"}
+		],"total":null,"has_more":false,"nextCursor":null}`))
+	}))
+	defer server.Close()
+
+	result, err := newQueryTestClient(t, server).Search(context.Background(), Query{Service: "worker"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(result.Logs) != 1 || result.Logs[0].ID != "2" {
+		t.Errorf("Logs = %+v, want only the worker log", result.Logs)
+	}
+	if result.Total != nil {
+		t.Errorf("Total = %v, want nil for a null total", *result.Total)
+	}
+}
+
+func TestQueryClientSearchAll(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"logs":[{"id":"1","level":"info","message":"a","timestamp":"2025-01-01T00:00:03Z"},{"id":"2","level":"info","message":"b","timestamp":"2025-01-01T00:00:02Z"}],"total":3,"has_more":true,"nextCursor":"c2"}`,
+		"c2": `{"logs":[{"id":"3","level":"info","message":"c","timestamp":"2025-01-01T00:00:01Z"}],"total":null,"has_more":false,"nextCursor":null}`,
+	}
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(pages[r.URL.Query().Get("cursor")]))
+	}))
+	defer server.Close()
+
+	var ids []string
+	for rec, err := range newQueryTestClient(t, server).SearchAll(context.Background(), Query{Limit: 2}) {
+		if err != nil {
+			t.Fatalf("SearchAll() error = %v", err)
+		}
+		ids = append(ids, rec.ID)
+	}
+
+	if strings.Join(ids, ",") != "1,2,3" {
+		t.Errorf("ids = %v, want 1,2,3", ids)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
+
+func TestQueryClientSearchAllStopsEarly(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"logs":[{"id":"1","level":"info","message":"a","timestamp":"2025-01-01T00:00:00Z"},{"id":"2","level":"info","message":"b","timestamp":"2025-01-01T00:00:00Z"}],"has_more":true,"nextCursor":"next"}`))
+	}))
+	defer server.Close()
+
+	for range newQueryTestClient(t, server).SearchAll(context.Background(), Query{}) {
+		break
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 after breaking out of the loop", requests)
+	}
+}
+
+func TestQueryClientErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		code   ErrorCode
+	}{
+		{http.StatusUnauthorized, `{"message":"Unauthorized"}`, ErrUnauthorized},
+		{http.StatusBadRequest, `{"error":"invalid_cursor","message":"Invalid cursor"}`, ErrValidationError},
+		{http.StatusNotFound, `{"error":"not_found"}`, ErrServerError},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				json.NewEncoder(w).Encode(json.RawMessage(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := newQueryTestClient(t, server).Search(context.Background(), Query{})
+			lwErr, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("error = %v (%T), want *Error", err, err)
+			}
+			if lwErr.Code != tt.code || lwErr.StatusCode != tt.status {
+				t.Errorf("error = %v, want code %s status %d", lwErr, tt.code, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package logwell
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// QueryBuilder builds a validated Query step by step. Each method records
+// the first invalid argument, which Build returns, so a chain needs one
+// error check.
+//
+// Example:
+//
+//	query, err := logwell.NewQuery().
+//	    Level(logwell.LevelError, logwell.LevelFatal).
+//	    Service("api").
+//	    TextSearch("timeout").
+//	    Between(start, end).
+//	    Limit(100).
+//	    Build()
+//	if err != nil {
+//	    return err
+//	}
+//	page, err := qc.Search(ctx, query)
+type QueryBuilder struct {
+	query Query
+	err   error
+}
+
+// NewQuery returns an empty QueryBuilder, which matches every log.
+func NewQuery() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// fail records err unless an earlier call failed.
+func (b *QueryBuilder) fail(format string, args ...any) *QueryBuilder {
+	if b.err == nil {
+		b.err = NewError(ErrInvalidConfig, fmt.Sprintf(format, args...))
+	}
+	return b
+}
+
+// Level restricts results to the given levels, adding to earlier calls.
+func (b *QueryBuilder) Level(levels ...LogLevel) *QueryBuilder {
+	for _, l := range levels {
+		if l.severity() < 0 {
+			return b.fail("invalid query level %q: must be one of debug, info, warn, error, fatal", l)
+		}
+		if !slices.Contains(b.query.Levels, l) {
+			b.query.Levels = append(b.query.Levels, l)
+		}
+	}
+	return b
+}
+
+// MinLevel restricts results to min and the levels more severe than it,
+// replacing earlier Level and MinLevel calls.
+func (b *QueryBuilder) MinLevel(min LogLevel) *QueryBuilder {
+	if min.severity() < 0 {
+		return b.fail("invalid query level %q: must be one of debug, info, warn, error, fatal", min)
+	}
+	b.query.Levels = nil
+	for _, l := range []LogLevel{LevelDebug, LevelInfo, LevelWarn, LevelError, LevelFatal} {
+		if l.Enabled(min) {
+			b.query.Levels = append(b.query.Levels, l)
+		}
+	}
+	return b
+}
+
+// Service restricts results to one service. See Query.Service.
+func (b *QueryBuilder) Service(name string) *QueryBuilder {
+	if strings.TrimSpace(name) == "" {
+		return b.fail("query service must not be empty")
+	}
+	b.query.Service = name
+	return b
+}
+
+// TextSearch restricts results to logs matching the full-text query text.
+func (b *QueryBuilder) TextSearch(text string) *QueryBuilder {
+	if strings.TrimSpace(text) == "" {
+		return b.fail("query text must not be empty")
+	}
+	b.query.Text = text
+	return b
+}
+
+// Between restricts results to timestamps from from to to, inclusive. A
+// zero from or to leaves that side open.
+func (b *QueryBuilder) Between(from, to time.Time) *QueryBuilder {
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return b.fail("query range start %s is after its end %s", from.Format(time.RFC3339), to.Format(time.RFC3339))
+	}
+	b.query.TimeRange = TimeRange{From: from, To: to}
+	return b
+}
+
+// Since restricts results to the last d, up to now.
+func (b *QueryBuilder) Since(d time.Duration) *QueryBuilder {
+	if d <= 0 {
+		return b.fail("query duration must be positive, got %s", d)
+	}
+	b.query.TimeRange = TimeRange{From: time.Now().Add(-d)}
+	return b
+}
+
+// Limit sets the page size. Range: 1-MaxSearchLimit.
+func (b *QueryBuilder) Limit(n int) *QueryBuilder {
+	if n < 1 || n > MaxSearchLimit {
+		return b.fail("query limit must be between 1 and %d, got %d", MaxSearchLimit, n)
+	}
+	b.query.Limit = n
+	return b
+}
+
+// Cursor resumes from a previous SearchResult.NextCursor.
+func (b *QueryBuilder) Cursor(cursor string) *QueryBuilder {
+	b.query.Cursor = cursor
+	return b
+}
+
+// Build returns the query, or an ErrInvalidConfig error describing the
+// first invalid argument.
+func (b *QueryBuilder) Build() (Query, error) {
+	if b.err != nil {
+		return Query{}, b.err
+	}
+	query := b.query
+	query.Levels = slices.Clone(query.Levels)
+	return query, nil
+}
+
+// String returns the query string the built query sends (see
+// Query.Encode), or a description of the error.
+func (b *QueryBuilder) String() string {
+	query, err := b.Build()
+	if err != nil {
+		return "invalid query: " + err.Error()
+	}
+	return query.Encode()
+}
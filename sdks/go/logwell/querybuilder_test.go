@@ -0,0 +1,131 @@
+package logwell
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	from := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	query, err := NewQuery().
+		Level(LevelError).
+		Level(LevelFatal, LevelError).
+		Service("api").
+		TextSearch("timeout").
+		Between(from, to).
+		Limit(100).
+		Cursor("c-1").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	want := Query{
+		Text:      "timeout",
+		Levels:    []LogLevel{LevelError, LevelFatal},
+		Service:   "api",
+		TimeRange: TimeRange{From: from, To: to},
+		Limit:     100,
+		Cursor:    "c-1",
+	}
+	if query.Text != want.Text || !slices.Equal(query.Levels, want.Levels) || query.Service != want.Service ||
+		query.TimeRange != want.TimeRange || query.Limit != want.Limit || query.Cursor != want.Cursor {
+		t.Errorf("Build() = %+v, want %+v", query, want)
+	}
+}
+
+func TestQueryBuilderMinLevel(t *testing.T) {
+	query, err := NewQuery().Level(LevelDebug).MinLevel(LevelWarn).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if want := []LogLevel{LevelWarn, LevelError, LevelFatal}; !slices.Equal(query.Levels, want) {
+		t.Errorf("Levels = %v, want %v", query.Levels, want)
+	}
+}
+
+func TestQueryBuilderSince(t *testing.T) {
+	before := time.Now()
+	query, err := NewQuery().Since(time.Hour).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if from := query.TimeRange.From; from.Before(before.Add(-time.Hour)) || from.After(time.Now().Add(-time.Hour)) {
+		t.Errorf("From = %v, want an hour ago", from)
+	}
+	if !query.TimeRange.To.IsZero() {
+		t.Errorf("To = %v, want open", query.TimeRange.To)
+	}
+}
+
+func TestQueryBuilderValidation(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name    string
+		builder *QueryBuilder
+	}{
+		{"unknown level", NewQuery().Level("loud")},
+		{"unknown min level", NewQuery().MinLevel("")},
+		{"empty service", NewQuery().Service(" ")},
+		{"empty text", NewQuery().TextSearch("")},
+		{"reversed range", NewQuery().Between(now, now.Add(-time.Minute))},
+		{"non-positive since", NewQuery().Since(0)},
+		{"zero limit", NewQuery().Limit(0)},
+		{"limit over max", NewQuery().Limit(MaxSearchLimit + 1)},
+		{"error kept through chain", NewQuery().Limit(-1).Service("api").TextSearch("ok")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			assertConfigError(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestQueryBuilderOpenRange(t *testing.T) {
+	from := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	query, err := NewQuery().Between(from, time.Time{}).Build()
+	if err != nil || !query.TimeRange.From.Equal(from) || !query.TimeRange.To.IsZero() {
+		t.Errorf("Build() = %+v, %v", query, err)
+	}
+}
+
+func TestQueryEncode(t *testing.T) {
+	from := time.Date(2026, 1, 5, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+	b := NewQuery().Level(LevelError, LevelFatal).TextSearch("timed out").Between(from, time.Time{}).Service("api")
+
+	want := "from=2026-01-05T09%3A00%3A00Z&level=error%2Cfatal&limit=100&search=timed+out"
+	if got := b.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if got := NewQuery().Limit(0).String(); got[:len("invalid query: ")] != "invalid query: " {
+		t.Errorf("String() of invalid builder = %q", got)
+	}
+}
+
+func TestQueryBuilderSearch(t *testing.T) {
+	var rawQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rawQuery = r.URL.RawQuery
+		w.Write([]byte(`{"logs":[],"has_more":false}`))
+	}))
+	defer server.Close()
+	qc := newQueryTestClient(t, server)
+
+	b := NewQuery().MinLevel(LevelError).TextSearch("timeout").Limit(20)
+	query, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := qc.Search(context.Background(), query); err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if rawQuery != b.String() {
+		t.Errorf("sent %q, want %q", rawQuery, b.String())
+	}
+}
@@ -2,6 +2,8 @@ package logwell
 
 import (
 	"fmt"
+	"math/rand"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,13 +18,36 @@ type batchQueue struct {
 
 	// Timer-based auto-flush
 	flushInterval time.Duration
+	flushJitter   float64        // fraction of flushInterval to randomize by
+	random        func() float64 // source of jitter in [0, 1)
 	flushFn       func()
-	timer         *time.Timer
+	timer         Timer
+	clock         Clock
 	generation    int64 // incremented on each timer stop/restart to detect stale callbacks
 
 	// Overflow protection
 	maxQueueSize int
 	onError      func(*Error)
+	dropNewest   bool          // reject new entries instead of evicting the oldest
+	dropped      atomic.Uint64 // entries discarded on overflow
+	onDrop       func(LogEntry)
+
+	// Overflow notices are recorded under mu and delivered by notify. With
+	// deferNotify, add and prepend leave delivery to the caller, so the
+	// callbacks can run after the caller's own locks are released.
+	notices     []overflowNotice
+	deferNotify bool
+
+	// space is closed (and replaced) whenever entries leave the queue,
+	// waking producers blocked by the Block overflow strategy.
+	space chan struct{}
+}
+
+// overflowNotice is an overflow error and the entries it dropped, waiting
+// to be passed to onError and onDrop.
+type overflowNotice struct {
+	err     *Error
+	dropped []LogEntry
 }
 
 // newBatchQueue creates a new batch queue with optional auto-flush and overflow protection.
@@ -36,27 +61,31 @@ func newBatchQueue(flushInterval time.Duration, flushFn func(), maxQueueSize int
 		flushFn:       flushFn,
 		maxQueueSize:  maxQueueSize,
 		onError:       onError,
+		clock:         systemClock{},
+		random:        rand.Float64,
+		space:         make(chan struct{}),
 	}
 }
 
-// add appends a log entry to the queue.
+// add appends a log entry to the queue and reports whether it was accepted.
 // If timer-based auto-flush is configured, starts or resets the timer.
-// If the queue is at max capacity, drops the oldest entry and calls onError.
-func (q *batchQueue) add(entry LogEntry) {
+// If the queue is at max capacity, drops the oldest entry (or, with
+// dropNewest, rejects entry) and notifies onError and onDrop.
+func (q *batchQueue) add(entry LogEntry) bool {
 	q.mu.Lock()
+	defer q.notifyUnlock()
 
-	// Check for overflow - drop oldest entry if at max capacity
+	// Check for overflow
 	if q.maxQueueSize > 0 && len(q.entries) >= q.maxQueueSize {
+		q.dropped.Add(1)
+		if q.dropNewest {
+			q.noticeLocked(NewError(ErrQueueOverflow, "queue overflow: dropping newest entry"), entry)
+			return false
+		}
+
 		// Drop oldest entry (FIFO)
+		q.noticeLocked(NewError(ErrQueueOverflow, "queue overflow: dropping oldest entry"), q.entries[0])
 		q.entries = q.entries[1:]
-
-		// Call onError callback outside the lock to avoid deadlock
-		if q.onError != nil {
-			onError := q.onError
-			q.mu.Unlock()
-			onError(NewError(ErrQueueOverflow, "queue overflow: dropping oldest entry"))
-			q.mu.Lock()
-		}
 	}
 
 	q.entries = append(q.entries, entry)
@@ -67,7 +96,7 @@ func (q *batchQueue) add(entry LogEntry) {
 			// Start new timer with current generation
 			gen := atomic.LoadInt64(&q.generation)
 			flushFn := q.flushFn
-			q.timer = time.AfterFunc(q.flushInterval, func() {
+			q.timer = q.clock.AfterFunc(q.interval(), func() {
 				if atomic.LoadInt64(&q.generation) != gen {
 					return // stale callback, ignore
 				}
@@ -75,11 +104,19 @@ func (q *batchQueue) add(entry LogEntry) {
 			})
 		} else {
 			// Reset existing timer
-			q.timer.Reset(q.flushInterval)
+			q.timer.Reset(q.interval())
 		}
 	}
+	return true
+}
 
-	q.mu.Unlock()
+// interval returns the duration of the next flush timer: flushInterval,
+// randomized by up to flushJitter of it either way.
+func (q *batchQueue) interval() time.Duration {
+	if q.flushJitter == 0 {
+		return q.flushInterval
+	}
+	return time.Duration(float64(q.flushInterval) * (1 + q.flushJitter*(2*q.random()-1)))
 }
 
 // prepend adds entries to the front of the queue.
@@ -88,7 +125,7 @@ func (q *batchQueue) add(entry LogEntry) {
 // Starts or resets the flush timer if auto-flush is enabled.
 func (q *batchQueue) prepend(entries []LogEntry) {
 	q.mu.Lock()
-	defer q.mu.Unlock()
+	defer q.notifyUnlock()
 
 	if len(entries) == 0 {
 		return
@@ -100,16 +137,14 @@ func (q *batchQueue) prepend(entries []LogEntry) {
 	combined = append(combined, entries...)
 	combined = append(combined, q.entries...)
 	if q.maxQueueSize > 0 && len(combined) > q.maxQueueSize {
-		dropped := len(combined) - q.maxQueueSize
+		discarded := combined[q.maxQueueSize:]
+		dropped := len(discarded)
 		combined = combined[:q.maxQueueSize] // keep newest (prepended) entries
+		q.dropped.Add(uint64(dropped))
 
-		// Surface overflow via the same onError path add() uses.
-		if q.onError != nil {
-			onError := q.onError
-			q.mu.Unlock()
-			onError(NewError(ErrQueueOverflow, fmt.Sprintf("queue overflow: dropping %d oldest entries", dropped)))
-			q.mu.Lock()
-		}
+		// Surface overflow via the same callbacks add() uses.
+		q.noticeLocked(NewError(ErrQueueOverflow, fmt.Sprintf("queue overflow: dropping %d oldest entries", dropped)),
+			slices.Clone(discarded)...)
 	}
 	q.entries = combined
 
@@ -118,14 +153,60 @@ func (q *batchQueue) prepend(entries []LogEntry) {
 		if q.timer == nil {
 			gen := atomic.LoadInt64(&q.generation)
 			flushFn := q.flushFn
-			q.timer = time.AfterFunc(q.flushInterval, func() {
+			q.timer = q.clock.AfterFunc(q.interval(), func() {
 				if atomic.LoadInt64(&q.generation) != gen {
 					return // stale callback, ignore
 				}
 				flushFn()
 			})
 		} else {
-			q.timer.Reset(q.flushInterval)
+			q.timer.Reset(q.interval())
+		}
+	}
+}
+
+// noticeLocked records an overflow notice, if anyone is listening.
+// Must be called with q.mu held.
+func (q *batchQueue) noticeLocked(err *Error, dropped ...LogEntry) {
+	if q.onError == nil && q.onDrop == nil {
+		return
+	}
+	q.notices = append(q.notices, overflowNotice{err: err, dropped: dropped})
+}
+
+// notice records an error to be delivered to onError by the next notify.
+func (q *batchQueue) notice(err *Error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.noticeLocked(err)
+}
+
+// notifyUnlock releases q.mu and, unless deferNotify is set, delivers the
+// recorded overflow notices.
+func (q *batchQueue) notifyUnlock() {
+	q.mu.Unlock()
+	if !q.deferNotify {
+		q.notify()
+	}
+}
+
+// notify delivers the recorded overflow notices to onError and onDrop.
+// Callbacks run without any queue lock held, so they may log.
+func (q *batchQueue) notify() {
+	q.mu.Lock()
+	notices := q.notices
+	q.notices = nil
+	onError, onDrop := q.onError, q.onDrop
+	q.mu.Unlock()
+
+	for _, n := range notices {
+		if onError != nil && n.err != nil {
+			onError(n.err)
+		}
+		if onDrop != nil {
+			for _, entry := range n.dropped {
+				onDrop(entry)
+			}
 		}
 	}
 }
@@ -151,10 +232,59 @@ func (q *batchQueue) flush() []LogEntry {
 	entries := q.entries
 	// Allocate new slice for future entries
 	q.entries = make([]LogEntry, 0)
+	q.signalSpaceLocked()
 
 	return entries
 }
 
+// take removes and returns up to n entries from the front of the queue.
+// Stops the flush timer if the queue becomes empty.
+func (q *batchQueue) take(n int) []LogEntry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.entries) == 0 {
+		return nil
+	}
+	if n > len(q.entries) {
+		n = len(q.entries)
+	}
+
+	// Copy out so the batch never aliases the queue's backing array.
+	batch := append(getBatch(n), q.entries[:n]...)
+	q.entries = q.entries[n:]
+	q.signalSpaceLocked()
+
+	if len(q.entries) == 0 && q.timer != nil {
+		atomic.AddInt64(&q.generation, 1)
+		q.timer.Stop()
+		q.timer = nil
+	}
+
+	return batch
+}
+
+// full reports whether the queue is at max capacity.
+func (q *batchQueue) full() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.maxQueueSize > 0 && len(q.entries) >= q.maxQueueSize
+}
+
+// spaceFreed returns a channel that is closed the next time entries leave
+// the queue. Obtain it before re-checking full to avoid missed wakeups.
+func (q *batchQueue) spaceFreed() <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.space
+}
+
+// signalSpaceLocked wakes waiters on spaceFreed. Must be called with q.mu held.
+func (q *batchQueue) signalSpaceLocked() {
+	close(q.space)
+	q.space = make(chan struct{})
+}
+
 // size returns the current number of entries in the queue.
 func (q *batchQueue) size() int {
 	q.mu.Lock()
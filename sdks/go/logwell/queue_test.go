@@ -1,6 +1,7 @@
 package logwell
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -142,6 +143,58 @@ func TestQueue_TimerFlush(t *testing.T) {
 	}
 }
 
+// TestQueue_FlushJitter tests that timer intervals are randomized within
+// the jitter fraction.
+func TestQueue_FlushJitter(t *testing.T) {
+	q := newBatchQueue(10*time.Second, func() {}, 0, nil)
+	q.flushJitter = 0.2
+	for _, tt := range []struct {
+		random float64
+		want   time.Duration
+	}{
+		{0, 8 * time.Second},
+		{0.5, 10 * time.Second},
+		{0.75, 11 * time.Second},
+	} {
+		q.random = func() float64 { return tt.random }
+		if got := q.interval(); got != tt.want {
+			t.Errorf("interval() with random %v = %v, want %v", tt.random, got, tt.want)
+		}
+	}
+
+	q.flushJitter = 0
+	q.random = func() float64 { return 0 }
+	if got := q.interval(); got != 10*time.Second {
+		t.Errorf("interval() without jitter = %v, want 10s", got)
+	}
+}
+
+// TestClientFlushJitter tests that WithFlushJitter applies to the
+// client's flush timer.
+func TestClientFlushJitter(t *testing.T) {
+	clock := &skipClock{now: time.Unix(0, 0)}
+	client, err := New(validEndpoint(), validAPIKey(), WithClock(clock), WithFlushJitter(0.5),
+		WithTransport(discardTransport{}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("jittered")
+	deadline := time.Now().Add(2 * time.Second)
+	waits := clock.waits()
+	for len(waits) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("no flush timer started")
+		}
+		time.Sleep(time.Millisecond)
+		waits = clock.waits()
+	}
+	if d := waits[0]; d < DefaultFlushInterval/2 || d > DefaultFlushInterval*3/2 || d == DefaultFlushInterval {
+		t.Errorf("flush timer = %v, want a jittered %v", d, DefaultFlushInterval)
+	}
+}
+
 // TestQueue_TimerResetOnAdd tests that timer resets on each add.
 func TestQueue_TimerResetOnAdd(t *testing.T) {
 	var flushed int32
@@ -238,6 +291,58 @@ func TestQueue_OverflowDropsOldest(t *testing.T) {
 	}
 }
 
+// TestQueue_OverflowDropsNewest tests that a dropNewest queue rejects the
+// new entry and keeps existing ones.
+func TestQueue_OverflowDropsNewest(t *testing.T) {
+	var errorCount int32
+	q := newBatchQueue(0, nil, 2, func(*Error) { atomic.AddInt32(&errorCount, 1) })
+	q.dropNewest = true
+
+	if !q.add(LogEntry{Level: LevelInfo, Message: "first"}) {
+		t.Fatal("add(first) = false, want true")
+	}
+	q.add(LogEntry{Level: LevelInfo, Message: "second"})
+
+	if q.add(LogEntry{Level: LevelInfo, Message: "third"}) {
+		t.Error("add(third) = true on full queue, want false")
+	}
+	if atomic.LoadInt32(&errorCount) != 1 {
+		t.Errorf("errorCount = %d, want 1", errorCount)
+	}
+
+	entries := q.flush()
+	if len(entries) != 2 || entries[0].Message != "first" || entries[1].Message != "second" {
+		t.Errorf("entries = %+v, want first and second", entries)
+	}
+}
+
+// TestQueue_SpaceFreed tests that removing entries wakes spaceFreed waiters.
+func TestQueue_SpaceFreed(t *testing.T) {
+	q := newBatchQueue(0, nil, 1, nil)
+	q.add(LogEntry{Level: LevelInfo, Message: "first"})
+
+	if !q.full() {
+		t.Fatal("full() = false, want true")
+	}
+	space := q.spaceFreed()
+	select {
+	case <-space:
+		t.Fatal("spaceFreed closed before anything was removed")
+	default:
+	}
+
+	q.take(1)
+
+	select {
+	case <-space:
+	default:
+		t.Fatal("spaceFreed not closed after take")
+	}
+	if q.full() {
+		t.Error("full() = true after take, want false")
+	}
+}
+
 // TestQueue_OverflowCallsOnError tests that overflow calls the error callback.
 func TestQueue_OverflowCallsOnError(t *testing.T) {
 	var errorCount int32
@@ -0,0 +1,168 @@
+package logwell
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimitSummaryInterval is how often a rate-limited client reports the
+// entries it suppressed.
+const RateLimitSummaryInterval = 10 * time.Second
+
+// maxRateLimitKeys bounds the number of keys tracked at once. Entries with
+// a new key are let through untracked while the table is full.
+const maxRateLimitKeys = 10000
+
+// RateLimitKey is the default key function for WithRateLimit: entries with
+// the same level and message share a limit.
+func RateLimitKey(entry LogEntry) string {
+	return string(entry.Level) + "\x00" + entry.Message
+}
+
+// rateLimiter throttles entries with a token bucket per key and
+// periodically emits a summary entry for each key that was throttled.
+type rateLimiter struct {
+	rate  float64 // tokens per second
+	burst float64
+	key   func(LogEntry) string
+	emit  func(LogEntry)
+	now   func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*rateBucket
+	timer   Timer
+	stopped bool
+
+	// suppressed counts throttled entries, for Client.Stats.
+	suppressed atomic.Uint64
+}
+
+// rateBucket is the limiter state of one key.
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+
+	// Entries suppressed since the last summary; sample is the most
+	// recent of them and since the time of the first.
+	dropped int
+	since   time.Time
+	sample  LogEntry
+}
+
+// newRateLimiter creates a limiter allowing rate entries per second per key
+// with bursts of up to burst. Summaries are passed to emit every
+// RateLimitSummaryInterval on clock until stop is called.
+func newRateLimiter(rate float64, burst int, key func(LogEntry) string, emit func(LogEntry), clock Clock) *rateLimiter {
+	if key == nil {
+		key = RateLimitKey
+	}
+	r := &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		key:     key,
+		emit:    emit,
+		now:     clock.Now,
+		buckets: make(map[string]*rateBucket),
+	}
+	r.timer = clock.AfterFunc(RateLimitSummaryInterval, r.tick)
+	return r
+}
+
+// allow reports whether entry may be queued, consuming a token if so.
+func (r *rateLimiter) allow(entry LogEntry) bool {
+	key := r.key(entry)
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.buckets[key]
+	if !ok {
+		if len(r.buckets) >= maxRateLimitKeys {
+			return true
+		}
+		r.buckets[key] = &rateBucket{tokens: r.burst - 1, last: now}
+		return true
+	}
+
+	b.refill(now, r.rate, r.burst)
+	if b.tokens >= 1 {
+		b.tokens--
+		return true
+	}
+
+	if b.dropped == 0 {
+		b.since = now
+	}
+	b.dropped++
+	b.sample = entry
+	r.suppressed.Add(1)
+	return false
+}
+
+// refill adds the tokens accrued since the bucket was last touched.
+func (b *rateBucket) refill(now time.Time, rate, burst float64) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(burst, b.tokens+elapsed*rate)
+	}
+	b.last = now
+}
+
+// summaries returns a summary entry for every key with suppressed entries
+// and resets their counts. Idle keys whose bucket has refilled are evicted.
+func (r *rateLimiter) summaries() []LogEntry {
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var out []LogEntry
+	for key, b := range r.buckets {
+		if b.dropped == 0 {
+			b.refill(now, r.rate, r.burst)
+			if b.tokens >= r.burst {
+				delete(r.buckets, key)
+			}
+			continue
+		}
+		window := max(now.Sub(b.since).Round(time.Second), time.Second)
+		out = append(out, LogEntry{
+			Level:    b.sample.Level,
+			Message:  fmt.Sprintf("suppressed %d duplicates in %s: %s", b.dropped, window, b.sample.Message),
+			Service:  b.sample.Service,
+			Metadata: M{"suppressed": b.dropped, "rateLimitKey": key},
+		})
+		b.dropped = 0
+		b.sample = LogEntry{}
+	}
+	return out
+}
+
+// tick emits pending summaries and re-arms the timer.
+func (r *rateLimiter) tick() {
+	for _, entry := range r.summaries() {
+		r.emit(entry)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.stopped {
+		r.timer.Reset(RateLimitSummaryInterval)
+	}
+}
+
+// stop halts the summary timer and returns the final summaries.
+// Subsequent calls return nil.
+func (r *rateLimiter) stop() []LogEntry {
+	r.mu.Lock()
+	if r.stopped {
+		r.mu.Unlock()
+		return nil
+	}
+	r.stopped = true
+	r.timer.Stop()
+	r.mu.Unlock()
+	return r.summaries()
+}
@@ -0,0 +1,115 @@
+package logwell
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestRateLimiter returns a limiter on a manual clock, with its summary
+// timer stopped.
+func newTestRateLimiter(rate float64, burst int, key func(LogEntry) string) (*rateLimiter, *time.Time) {
+	clock := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := newRateLimiter(rate, burst, key, func(LogEntry) {}, systemClock{})
+	r.timer.Stop()
+	r.now = func() time.Time { return clock }
+	return r, &clock
+}
+
+func TestRateLimiterBurstAndRefill(t *testing.T) {
+	r, clock := newTestRateLimiter(2, 3, nil)
+	entry := LogEntry{Level: LevelError, Message: "db timeout"}
+
+	for i := range 3 {
+		if !r.allow(entry) {
+			t.Fatalf("allow() #%d = false within burst", i+1)
+		}
+	}
+	if r.allow(entry) {
+		t.Fatal("allow() = true after burst exhausted")
+	}
+	if !r.allow(LogEntry{Level: LevelWarn, Message: "db timeout"}) {
+		t.Error("allow() = false for a different key")
+	}
+
+	*clock = clock.Add(500 * time.Millisecond) // one token at 2/s
+	if !r.allow(entry) {
+		t.Error("allow() = false after refill")
+	}
+	if r.allow(entry) {
+		t.Error("allow() = true, want only one refilled token")
+	}
+	if got := r.suppressed.Load(); got != 2 {
+		t.Errorf("suppressed = %d, want 2", got)
+	}
+}
+
+func TestRateLimiterSummaries(t *testing.T) {
+	r, clock := newTestRateLimiter(1, 1, func(e LogEntry) string { return e.Service })
+
+	r.allow(LogEntry{Level: LevelInfo, Message: "first", Service: "api"})
+	for range 5 {
+		r.allow(LogEntry{Level: LevelWarn, Message: "noisy", Service: "api"})
+	}
+	*clock = clock.Add(RateLimitSummaryInterval)
+
+	summaries := r.summaries()
+	if len(summaries) != 1 {
+		t.Fatalf("len(summaries) = %d, want 1", len(summaries))
+	}
+	s := summaries[0]
+	if s.Message != "suppressed 5 duplicates in 10s: noisy" || s.Level != LevelWarn || s.Service != "api" {
+		t.Errorf("summary = %+v", s)
+	}
+	if s.Metadata["suppressed"] != 5 || s.Metadata["rateLimitKey"] != "api" {
+		t.Errorf("summary metadata = %v", s.Metadata)
+	}
+
+	// Counts reset, and the now idle, refilled key is evicted.
+	if len(r.summaries()) != 0 {
+		t.Error("summaries() repeated a reported key")
+	}
+	if len(r.buckets) != 0 {
+		t.Errorf("len(buckets) = %d, want idle key evicted", len(r.buckets))
+	}
+}
+
+func TestRateLimiterKeyCap(t *testing.T) {
+	r, _ := newTestRateLimiter(1, 1, nil)
+	for i := range maxRateLimitKeys {
+		r.buckets[strings.Repeat("k", i+1)] = &rateBucket{}
+	}
+	entry := LogEntry{Level: LevelInfo, Message: "untracked"}
+	if !r.allow(entry) || !r.allow(entry) {
+		t.Error("allow() = false for an untracked key")
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithBatchSize(100), WithRateLimit(0.001, 2, nil))
+	child := client.With(M{"k": "v"})
+	for range 10 {
+		child.Error("connection refused")
+	}
+	client.Info("unrelated")
+
+	if got := client.Stats().Throttled; got != 8 {
+		t.Errorf("Throttled = %d, want 8", got)
+	}
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 4)
+	if len(logs) == 4 {
+		last := logs[3]
+		if !strings.HasPrefix(last.Message, "suppressed 8 duplicates in ") || last.Level != LevelError {
+			t.Errorf("summary = %+v, want suppressed 8 at error level", last)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package logwell
+
+import "context"
+
+// FlushReceipt reports what a FlushWithReceipt call delivered.
+type FlushReceipt struct {
+	// Batches is the number of batches the server accepted.
+	Batches int
+
+	// Entries is the number of entries in those batches.
+	Entries int
+
+	// Accepted and Rejected are the server's counts, summed over the
+	// batches.
+	Accepted int
+	Rejected int
+
+	// DeadLettered is the number of entries dropped as too large to
+	// ingest (see WithOnDeadLetter).
+	DeadLettered int
+
+	// IDs are the IDs the server assigned to the accepted entries, in the
+	// order they were sent, if it reports them (IngestResponse.IDs).
+	IDs []string
+}
+
+// add records an accepted batch of n entries.
+func (r *FlushReceipt) add(n int, resp *IngestResponse) {
+	r.Batches++
+	r.Entries += n
+	r.Accepted += resp.Accepted
+	r.Rejected += resp.Rejected
+	r.IDs = append(r.IDs, resp.IDs...)
+}
+
+type receiptCtxKey struct{}
+
+// withReceipt returns ctx collecting the batches sent with it into r.
+func withReceipt(ctx context.Context, r *FlushReceipt) context.Context {
+	return context.WithValue(ctx, receiptCtxKey{}, r)
+}
+
+// receiptFromContext returns the receipt collecting sends made with ctx,
+// or nil.
+func receiptFromContext(ctx context.Context) *FlushReceipt {
+	r, _ := ctx.Value(receiptCtxKey{}).(*FlushReceipt)
+	return r
+}
+
+// FlushWithReceipt is Flush, also reporting the batches this call sent:
+// the server's accepted and rejected counts and, when the server reports
+// them, the IDs assigned to the entries, so callers can correlate
+// application events with stored records.
+//
+// Only batches sent on the caller's goroutine are counted. Batches the
+// background sender already had in flight when the call began are
+// delivered (Flush waits for them) but not reported; use
+// WithOnBatchSentInfo to see every batch. On error the receipt covers the
+// batches sent before the failure.
+func (c *Client) FlushWithReceipt(ctx context.Context) (FlushReceipt, error) {
+	var receipt FlushReceipt
+	err := c.Flush(withReceipt(ctx, &receipt))
+	return receipt, err
+}
@@ -0,0 +1,105 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// newIDServer returns a server that accepts every entry and reports an ID
+// for each, numbered across requests.
+func newIDServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	next := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var batch []LogEntry
+		json.NewDecoder(r.Body).Decode(&batch)
+		mu.Lock()
+		ids := make([]string, len(batch))
+		for i := range ids {
+			next++
+			ids[i] = fmt.Sprintf("log-%d", next)
+		}
+		mu.Unlock()
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: len(batch), IDs: ids})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFlushWithReceipt(t *testing.T) {
+	server := newIDServer(t)
+	var infos []BatchInfo
+	client, err := New(server.URL, validAPIKey(), WithBatchSize(2),
+		WithOnBatchSentInfo(func(info BatchInfo) { infos = append(infos, info) }))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("one")
+	client.Info("two")
+	client.Info("three")
+	receipt, err := client.FlushWithReceipt(context.Background())
+	if err != nil {
+		t.Fatalf("FlushWithReceipt() error = %v", err)
+	}
+
+	// Background sends are delivered but not in the receipt, so the
+	// receipt holds the IDs of the entries it does report, in order.
+	all := []string{"log-1", "log-2", "log-3"}
+	if receipt.Accepted != receipt.Entries || len(receipt.IDs) != receipt.Entries || !slices.Equal(receipt.IDs, all[3-receipt.Entries:]) {
+		t.Errorf("receipt = %+v", receipt)
+	}
+	var reported []string
+	for _, info := range infos {
+		reported = append(reported, info.IDs...)
+	}
+	if !slices.Equal(reported, all) {
+		t.Errorf("OnBatchSentInfo IDs = %v, want %v", reported, all)
+	}
+
+	receipt, err = client.FlushWithReceipt(context.Background())
+	if err != nil || receipt.Batches != 0 || receipt.IDs != nil {
+		t.Errorf("empty FlushWithReceipt() = %+v, %v", receipt, err)
+	}
+}
+
+func TestFlushWithReceiptDeadLetter(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.setHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	})
+	client := createTestClient(t, ts, WithMaxRetries(0))
+	defer client.Shutdown(context.Background())
+
+	client.Info("huge")
+	receipt, err := client.FlushWithReceipt(context.Background())
+	if err != nil || receipt.DeadLettered != 1 || receipt.Batches != 0 {
+		t.Errorf("FlushWithReceipt() = %+v, %v; want one dead-lettered entry", receipt, err)
+	}
+}
+
+func TestLogSyncIDs(t *testing.T) {
+	server := newIDServer(t)
+	client, err := New(server.URL, validAPIKey())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	resp, err := client.LogSync(context.Background(), LevelInfo, "audit")
+	if err != nil {
+		t.Fatalf("LogSync() error = %v", err)
+	}
+	if !slices.Equal(resp.IDs, []string{"log-1"}) {
+		t.Errorf("LogSync() IDs = %v, want [log-1]", resp.IDs)
+	}
+}
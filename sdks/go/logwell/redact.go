@@ -0,0 +1,175 @@
+package logwell
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactReplacement replaces redacted values when a RedactRule sets
+// no Replacement.
+const DefaultRedactReplacement = "[REDACTED]"
+
+// RedactRule describes sensitive data to scrub from entries before they are
+// queued. A rule matches by metadata key, by value pattern, or both.
+type RedactRule struct {
+	// Keys lists metadata keys whose values are replaced outright, at any
+	// nesting depth. Keys match ignoring case, "-", and "_", so "apiKey"
+	// also matches "api_key" and "X-API-Key" matches "x_api_key".
+	Keys []string
+
+	// Pattern matches substrings of the message and of string metadata
+	// values; each match is replaced.
+	Pattern *regexp.Regexp
+
+	// Replacement is substituted for redacted data.
+	// Default: DefaultRedactReplacement.
+	Replacement string
+
+	// valid, if set, filters Pattern matches (e.g. a Luhn check).
+	valid func(match string) bool
+}
+
+// RedactKeys returns a rule replacing the values of the given metadata keys.
+func RedactKeys(keys ...string) RedactRule {
+	return RedactRule{Keys: keys}
+}
+
+// RedactPattern returns a rule replacing every match of pattern.
+func RedactPattern(pattern *regexp.Regexp) RedactRule {
+	return RedactRule{Pattern: pattern}
+}
+
+// Built-in redaction rules.
+var (
+	// RedactCommonKeys redacts credentials commonly found in metadata.
+	RedactCommonKeys = RedactKeys(
+		"password", "passwd", "secret", "token", "accessToken", "refreshToken",
+		"apiKey", "authorization", "cookie", "setCookie",
+	)
+
+	// RedactEmails replaces email addresses.
+	RedactEmails = RedactPattern(regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`))
+
+	// RedactCreditCards replaces 13-19 digit card numbers, optionally
+	// separated by spaces or dashes, that pass the Luhn check.
+	RedactCreditCards = RedactRule{
+		Pattern: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		valid:   luhnValid,
+	}
+
+	// RedactJWTs replaces JSON Web Tokens.
+	RedactJWTs = RedactPattern(regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`))
+)
+
+// redactor applies a set of rules to entries.
+type redactor struct {
+	keys     map[string]string // normalized key -> replacement
+	patterns []RedactRule
+}
+
+// newRedactor compiles rules into a redactor.
+func newRedactor(rules []RedactRule) *redactor {
+	r := &redactor{keys: make(map[string]string)}
+	for _, rule := range rules {
+		if rule.Replacement == "" {
+			rule.Replacement = DefaultRedactReplacement
+		}
+		for _, key := range rule.Keys {
+			r.keys[normalizeRedactKey(key)] = rule.Replacement
+		}
+		if rule.Pattern != nil {
+			r.patterns = append(r.patterns, rule)
+		}
+	}
+	return r
+}
+
+// keySeparators strips the separators ignored when matching keys.
+var keySeparators = strings.NewReplacer("-", "", "_", "")
+
+// normalizeRedactKey lowercases key and strips "-" and "_".
+func normalizeRedactKey(key string) string {
+	return strings.ToLower(keySeparators.Replace(key))
+}
+
+// redact scrubs the entry's message and metadata. Metadata maps are copied,
+// never modified in place, since nested maps may belong to the caller.
+func (r *redactor) redact(entry *LogEntry) {
+	entry.Message = r.redactString(entry.Message)
+	if entry.Metadata != nil {
+		entry.Metadata = r.redactMap(entry.Metadata)
+	}
+}
+
+func (r *redactor) redactMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if replacement, ok := r.keys[normalizeRedactKey(k)]; ok {
+			out[k] = replacement
+			continue
+		}
+		out[k] = r.redactValue(v)
+	}
+	return out
+}
+
+func (r *redactor) redactValue(v any) any {
+	switch v := v.(type) {
+	case string:
+		return r.redactString(v)
+	case M:
+		return r.redactMap(v)
+	case map[string]any:
+		return r.redactMap(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i] = r.redactValue(elem)
+		}
+		return out
+	case []string:
+		out := make([]string, len(v))
+		for i, elem := range v {
+			out[i] = r.redactString(elem)
+		}
+		return out
+	}
+	return v
+}
+
+func (r *redactor) redactString(s string) string {
+	for _, rule := range r.patterns {
+		if rule.valid == nil {
+			s = rule.Pattern.ReplaceAllLiteralString(s, rule.Replacement)
+			continue
+		}
+		s = rule.Pattern.ReplaceAllStringFunc(s, func(match string) string {
+			if rule.valid(match) {
+				return rule.Replacement
+			}
+			return match
+		})
+	}
+	return s
+}
+
+// luhnValid reports whether the digits in s pass the Luhn checksum.
+func luhnValid(s string) bool {
+	sum, n := 0, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if n%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		n++
+	}
+	return n > 0 && sum%10 == 0
+}
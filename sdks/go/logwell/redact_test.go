@@ -0,0 +1,108 @@
+package logwell
+
+import (
+	"context"
+	"regexp"
+	"testing"
+)
+
+func TestRedactKeys(t *testing.T) {
+	r := newRedactor([]RedactRule{RedactCommonKeys, {Keys: []string{"ssn"}, Replacement: "***"}})
+	nested := M{"password": "hunter2", "user": "ada"}
+	entry := LogEntry{Metadata: M{
+		"Authorization": "Bearer abc",
+		"api_key":       "lw_123",
+		"X-Set-Cookie":  "id=1", // not a listed key after normalization
+		"SSN":           "123-45-6789",
+		"request":       nested,
+		"attempts":      3,
+	}}
+
+	r.redact(&entry)
+
+	want := map[string]any{
+		"Authorization": DefaultRedactReplacement,
+		"api_key":       DefaultRedactReplacement,
+		"X-Set-Cookie":  "id=1",
+		"SSN":           "***",
+		"attempts":      3,
+	}
+	for k, v := range want {
+		if entry.Metadata[k] != v {
+			t.Errorf("Metadata[%s] = %v, want %v", k, entry.Metadata[k], v)
+		}
+	}
+	req := entry.Metadata["request"].(map[string]any)
+	if req["password"] != DefaultRedactReplacement || req["user"] != "ada" {
+		t.Errorf("nested = %v", req)
+	}
+	if nested["password"] != "hunter2" {
+		t.Error("caller's nested map was modified")
+	}
+}
+
+func TestRedactPatterns(t *testing.T) {
+	r := newRedactor([]RedactRule{RedactEmails, RedactCreditCards, RedactJWTs})
+	tests := []struct {
+		in, want string
+	}{
+		{"signup from ada@example.com", "signup from [REDACTED]"},
+		{"card 4111 1111 1111 1111 declined", "card [REDACTED] declined"},
+		{"card 4111-1111-1111-1111", "card [REDACTED]"},
+		{"order 1234567890123 shipped", "order 1234567890123 shipped"}, // fails Luhn
+		{"token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxIn0.sig-_x ok", "token [REDACTED] ok"},
+	}
+	for _, tt := range tests {
+		entry := LogEntry{Message: tt.in, Metadata: M{"detail": tt.in, "list": []any{tt.in}}}
+		r.redact(&entry)
+		if entry.Message != tt.want {
+			t.Errorf("Message = %q, want %q", entry.Message, tt.want)
+		}
+		if entry.Metadata["detail"] != tt.want || entry.Metadata["list"].([]any)[0] != tt.want {
+			t.Errorf("Metadata = %v, want values %q", entry.Metadata, tt.want)
+		}
+	}
+}
+
+func TestRedactCustomPattern(t *testing.T) {
+	r := newRedactor([]RedactRule{{Pattern: regexp.MustCompile(`acct-\d+`), Replacement: "acct-?"}})
+	entry := LogEntry{Message: "moved acct-42 to acct-7"}
+	r.redact(&entry)
+	if entry.Message != "moved acct-? to acct-?" {
+		t.Errorf("Message = %q", entry.Message)
+	}
+}
+
+func TestLuhnValid(t *testing.T) {
+	for s, want := range map[string]bool{
+		"4111111111111111":    true,
+		"5500 0000 0000 0004": true,
+		"4111111111111112":    false,
+	} {
+		if got := luhnValid(s); got != want {
+			t.Errorf("luhnValid(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestClientRedaction(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithRedaction(RedactCommonKeys), WithRedaction(RedactEmails))
+	defer client.Shutdown(context.Background())
+
+	client.With(M{"token": "t0k3n"}).InfoFields("login ada@example.com", String("password", "hunter2"))
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 1)
+	if len(logs) == 1 {
+		assertLogMetadata(t, logs[0], map[string]string{"token": DefaultRedactReplacement, "password": DefaultRedactReplacement})
+		if logs[0].Message != "login [REDACTED]" {
+			t.Errorf("Message = %q", logs[0].Message)
+		}
+	}
+}
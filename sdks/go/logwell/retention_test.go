@@ -0,0 +1,118 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAdminClientGetRetention(t *testing.T) {
+	tests := []struct {
+		body string
+		want Retention
+	}{
+		{`{"id":"p1","retentionDays":30}`, Retention{Days: 30}},
+		{`{"id":"p1","retentionDays":0}`, Retention{Days: 0}},
+		{`{"id":"p1","retentionDays":null}`, Retention{Default: true}},
+	}
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(tt.body))
+		}))
+		got, err := newAdminTestClient(t, server).GetRetention(context.Background(), "p1")
+		server.Close()
+		if err != nil {
+			t.Fatalf("GetRetention() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("GetRetention() with %s = %+v, want %+v", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestAdminClientSetRetention(t *testing.T) {
+	tests := []struct {
+		retention Retention
+		want      string
+	}{
+		{Retention{Days: 90}, `{"retentionDays":90}`},
+		{Retention{Days: 0}, `{"retentionDays":0}`},
+		{Retention{Default: true, Days: 7}, `{"retentionDays":null}`},
+	}
+	for _, tt := range tests {
+		var body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPatch || r.URL.Path != "/api/projects/p1" {
+				t.Errorf("request = %s %s", r.Method, r.URL.Path)
+			}
+			var raw json.RawMessage
+			json.NewDecoder(r.Body).Decode(&raw)
+			body = string(raw)
+			w.Write([]byte(`{"id":"p1"}`))
+		}))
+		err := newAdminTestClient(t, server).SetRetention(context.Background(), "p1", tt.retention)
+		server.Close()
+		if err != nil {
+			t.Fatalf("SetRetention() error = %v", err)
+		}
+		if body != tt.want {
+			t.Errorf("SetRetention(%+v) sent %s, want %s", tt.retention, body, tt.want)
+		}
+	}
+}
+
+func TestAdminClientSetRetentionValidation(t *testing.T) {
+	admin, err := NewAdminClient(validEndpoint(), WithSessionToken("s"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, days := range []int{-1, MaxRetentionDays + 1} {
+		err := admin.SetRetention(context.Background(), "p1", Retention{Days: days})
+		assertConfigError(t, err, ErrInvalidConfig)
+	}
+}
+
+func TestRetentionString(t *testing.T) {
+	for r, want := range map[Retention]string{
+		{Default: true}: "server default",
+		{Days: 0}:       "forever",
+		{Days: 1}:       "1 day",
+		{Days: 30}:      "30 days",
+	} {
+		if got := r.String(); got != want {
+			t.Errorf("%+v.String() = %q, want %q", r, got, want)
+		}
+	}
+}
+
+func TestAdminClientUsage(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/projects/p1":
+			w.Write([]byte(`{"id":"p1","retentionDays":14,"stats":{"totalLogs":900,"levelCounts":{"info":800,"error":100}}}`))
+		case "/api/projects/p1/stats":
+			if got := r.URL.Query().Get("from"); got != "2026-01-01T00:00:00Z" {
+				t.Errorf("from = %q", got)
+			}
+			w.Write([]byte(`{"totalLogs":120,"levelCounts":{"info":100,"error":20},"levelPercentages":{"info":83.33,"error":16.67}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	usage, err := newAdminTestClient(t, server).Usage(context.Background(), "p1", TimeRange{From: from})
+	if err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+	if usage.Logs != 120 || usage.LevelCounts[LevelError] != 20 || usage.StoredLogs != 900 || usage.Retention != (Retention{Days: 14}) {
+		t.Errorf("Usage() = %+v", usage)
+	}
+	if !usage.Range.From.Equal(from) {
+		t.Errorf("Range = %+v", usage.Range)
+	}
+}
@@ -0,0 +1,38 @@
+package logwell
+
+import (
+	"sync"
+	"time"
+)
+
+// retryBudget is a token bucket shared by every send of a client: each
+// retry spends a token, so during an outage the client makes a bounded
+// number of retries per second instead of MaxRetries for every batch.
+type retryBudget struct {
+	rate  float64 // tokens per second
+	burst float64
+	now   func() time.Time
+
+	mu     sync.Mutex
+	bucket rateBucket
+}
+
+// newRetryBudget creates a full budget refilling at rate tokens per second
+// up to burst, reading the time from now.
+func newRetryBudget(rate float64, burst int, now func() time.Time) *retryBudget {
+	b := &retryBudget{rate: rate, burst: float64(burst), now: now}
+	b.bucket = rateBucket{tokens: b.burst, last: b.now()}
+	return b
+}
+
+// take spends a token, reporting false if the budget is exhausted.
+func (b *retryBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bucket.refill(b.now(), b.rate, b.burst)
+	if b.bucket.tokens < 1 {
+		return false
+	}
+	b.bucket.tokens--
+	return true
+}
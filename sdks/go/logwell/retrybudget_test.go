@@ -0,0 +1,90 @@
+package logwell
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryBudget(t *testing.T) {
+	now := time.Unix(0, 0)
+	budget := newRetryBudget(1, 2, func() time.Time { return now })
+
+	if !budget.take() || !budget.take() {
+		t.Fatal("take() = false within burst, want true")
+	}
+	if budget.take() {
+		t.Fatal("take() = true with budget spent, want false")
+	}
+	now = now.Add(time.Second)
+	if !budget.take() {
+		t.Error("take() = false after refill, want true")
+	}
+}
+
+// TestTransport_RetryBudget tests that once the budget is spent, failed
+// sends return without retrying.
+func TestTransport_RetryBudget(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := newDefaultConfig(server.URL, validAPIKey())
+	WithRetryBudget(0.001, 1)(cfg)
+	transport := newHTTPTransportFromConfig(cfg)
+	logs := []LogEntry{{Level: LevelInfo, Message: "test"}}
+
+	if _, err := transport.sendWithRetry(context.Background(), logs); err == nil {
+		t.Fatal("sendWithRetry() error = nil, want 503")
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("first send made %d requests, want 2 (one retry from the budget)", got)
+	}
+
+	_, err := transport.sendWithRetry(context.Background(), logs)
+	if lwErr, ok := err.(*Error); !ok || lwErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("sendWithRetry() error = %v, want the 503", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %d, want 3 (no retry once the budget is spent)", got)
+	}
+	if got := transport.retries.Load(); got != 1 {
+		t.Errorf("retries = %d, want 1", got)
+	}
+}
+
+// TestTransport_RetryDeadline tests that retries stop once the next backoff
+// would pass the retry deadline.
+func TestTransport_RetryDeadline(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	cfg := newDefaultConfig(server.URL, validAPIKey())
+	WithMaxRetries(10)(cfg)
+	WithRetryDeadline(500 * time.Millisecond)(cfg)
+	transport := newHTTPTransportFromConfig(cfg)
+
+	start := time.Now()
+	_, err := transport.sendWithRetry(context.Background(), []LogEntry{{Level: LevelInfo, Message: "test"}})
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("sendWithRetry() error = nil, want 500")
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("sendWithRetry() took %v, want at most the 500ms retry deadline", elapsed)
+	}
+	if got := requests.Load(); got < 2 || got > 3 {
+		t.Errorf("requests = %d, want 2-3 within the deadline (of 11 allowed)", got)
+	}
+}
@@ -0,0 +1,95 @@
+package logwell
+
+import "sync/atomic"
+
+// ringSize is the number of entries the admission ring buffers between
+// logging goroutines and the queue. Must be a power of two.
+const ringSize = 1024
+
+// entryRing is a bounded lock-free multi-producer, single-consumer ring
+// (Vyukov's queue). Logging goroutines push without taking a lock; the
+// root client drains it into the batch queue in bulk, so the queue's
+// mutexes are taken once per drain instead of once per entry. Entries come
+// out in the order their pushes claimed a slot, so each producer's entries
+// stay in FIFO order.
+type entryRing struct {
+	// tail is the next position producers claim.
+	tail atomic.Uint64
+	_    [56]byte // keep tail and head on separate cache lines
+
+	// head is the next position the consumer reads. Only the consumer
+	// writes it; it is atomic so len can read it.
+	head  atomic.Uint64
+	cells [ringSize]ringCell
+
+	// wake has a pending signal once entries are waiting to be drained.
+	wake chan struct{}
+}
+
+// ringCell holds one entry. seq is the position the cell is ready to be
+// written at, plus one once the entry there is ready to be read.
+type ringCell struct {
+	seq   atomic.Uint64
+	entry LogEntry
+}
+
+func newEntryRing() *entryRing {
+	r := &entryRing{wake: make(chan struct{}, 1)}
+	for i := range r.cells {
+		r.cells[i].seq.Store(uint64(i))
+	}
+	return r
+}
+
+// push copies entry into the ring and signals the consumer. Reports false
+// if the ring is full.
+func (r *entryRing) push(entry *LogEntry) bool {
+	for {
+		pos := r.tail.Load()
+		cell := &r.cells[pos&(ringSize-1)]
+		switch seq := cell.seq.Load(); {
+		case seq == pos:
+			if !r.tail.CompareAndSwap(pos, pos+1) {
+				continue
+			}
+			cell.entry = *entry
+			cell.seq.Store(pos + 1)
+			select {
+			case r.wake <- struct{}{}:
+			default: // a drain is already pending
+			}
+			return true
+		case seq < pos:
+			return false // the consumer has not freed this cell yet
+		}
+		// Another producer claimed pos first; retry at the new tail.
+	}
+}
+
+// drain passes every ready entry, in order, to fn and returns how many it
+// passed. It stops at a slot claimed by a producer that has not finished
+// writing it; that producer signals wake once it has. Must not be called
+// concurrently.
+func (r *entryRing) drain(fn func(LogEntry)) int {
+	n := 0
+	for {
+		head := r.head.Load()
+		cell := &r.cells[head&(ringSize-1)]
+		if cell.seq.Load() != head+1 {
+			return n
+		}
+		entry := cell.entry
+		cell.entry = LogEntry{}
+		cell.seq.Store(head + ringSize)
+		r.head.Store(head + 1)
+		fn(entry)
+		n++
+	}
+}
+
+// len returns the number of entries pushed but not yet drained.
+func (r *entryRing) len() int {
+	// Load head first: it never passes tail, so the result cannot wrap.
+	head := r.head.Load()
+	return int(r.tail.Load() - head)
+}
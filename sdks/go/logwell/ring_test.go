@@ -0,0 +1,122 @@
+package logwell
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestEntryRing(t *testing.T) {
+	r := newEntryRing()
+	for i := range 3 {
+		if !r.push(&LogEntry{Message: fmt.Sprint(i)}) {
+			t.Fatalf("push(%d) = false, want true", i)
+		}
+	}
+	if got := r.len(); got != 3 {
+		t.Errorf("len() = %d, want 3", got)
+	}
+
+	var got []string
+	if n := r.drain(func(e LogEntry) { got = append(got, e.Message) }); n != 3 {
+		t.Errorf("drain() = %d, want 3", n)
+	}
+	if fmt.Sprint(got) != "[0 1 2]" {
+		t.Errorf("drained %v, want [0 1 2]", got)
+	}
+	if got := r.len(); got != 0 {
+		t.Errorf("len() after drain = %d, want 0", got)
+	}
+}
+
+func TestEntryRing_Full(t *testing.T) {
+	r := newEntryRing()
+	for i := range ringSize {
+		if !r.push(&LogEntry{Message: fmt.Sprint(i)}) {
+			t.Fatalf("push(%d) = false, want true", i)
+		}
+	}
+	if r.push(&LogEntry{}) {
+		t.Fatal("push() on a full ring = true, want false")
+	}
+
+	r.drain(func(LogEntry) {})
+	if !r.push(&LogEntry{Message: "after"}) {
+		t.Fatal("push() after drain = false, want true")
+	}
+}
+
+// TestEntryRing_ProducerOrder tests that concurrent producers' entries all
+// arrive and each producer's arrive in the order it pushed them.
+func TestEntryRing_ProducerOrder(t *testing.T) {
+	const producers, perProducer = 8, 5000
+	r := newEntryRing()
+
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Go(func() {
+			for i := range perProducer {
+				for !r.push(&LogEntry{Service: fmt.Sprint(p), LineNumber: i}) {
+					runtime.Gosched() // full; let the consumer run
+				}
+			}
+		})
+	}
+
+	next := make(map[string]int)
+	received := 0
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	drain := func() {
+		r.drain(func(e LogEntry) {
+			if e.LineNumber != next[e.Service] {
+				t.Errorf("producer %s: got entry %d, want %d", e.Service, e.LineNumber, next[e.Service])
+			}
+			next[e.Service] = e.LineNumber + 1
+			received++
+		})
+	}
+	for {
+		select {
+		case <-done:
+			drain()
+			if received != producers*perProducer {
+				t.Errorf("received %d entries, want %d", received, producers*perProducer)
+			}
+			return
+		case <-r.wake:
+			drain()
+		}
+	}
+}
+
+// TestClientRingDrainsOnFlush tests that entries still buffered in the ring
+// are sent by Flush.
+func TestClientRingDrainsOnFlush(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithBatchSize(100))
+	defer client.Shutdown(context.Background())
+
+	for i := range 10 {
+		client.Infof("m%d", i)
+	}
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	logs := ts.getLogs()
+	if len(logs) != 10 {
+		t.Fatalf("received %d logs, want 10", len(logs))
+	}
+	for i, log := range logs {
+		if want := fmt.Sprintf("m%d", i); log.Message != want {
+			t.Errorf("logs[%d] = %q, want %q", i, log.Message, want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package logwell
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"weak"
+)
+
+// defaultRedactParams lists query parameters whose values WrapRoundTripper
+// redacts by default, matched like RedactRule keys.
+var defaultRedactParams = []string{
+	"password", "passwd", "secret", "token", "accessToken", "refreshToken",
+	"apiKey", "key", "authorization", "signature", "sig", "code", "clientSecret",
+}
+
+// RoundTripperOption configures WrapRoundTripper.
+type RoundTripperOption func(*roundTripperConfig)
+
+type roundTripperConfig struct {
+	redactParams map[string]struct{}
+	skip         func(*http.Request) bool
+}
+
+// WithRoundTripRedactParams redacts the values of the named query
+// parameters in logged URLs, in addition to the defaults (password, token,
+// apiKey, key, signature, code, and similar). Names match ignoring case,
+// "-", and "_", like RedactRule keys.
+func WithRoundTripRedactParams(params ...string) RoundTripperOption {
+	return func(c *roundTripperConfig) {
+		for _, p := range params {
+			c.redactParams[normalizeRedactKey(p)] = struct{}{}
+		}
+	}
+}
+
+// WithRoundTripSkip sets a filter; requests for which skip returns true
+// are sent without being logged, such as health checks.
+func WithRoundTripSkip(skip func(*http.Request) bool) RoundTripperOption {
+	return func(c *roundTripperConfig) {
+		c.skip = skip
+	}
+}
+
+// loggingRoundTripper is the http.RoundTripper returned by
+// WrapRoundTripper.
+type loggingRoundTripper struct {
+	base     http.RoundTripper
+	client   *Client
+	cfg      roundTripperConfig
+	attempts attemptCounter
+	ownHost  string
+}
+
+// WrapRoundTripper returns an http.RoundTripper that sends each request
+// through base (http.DefaultTransport if nil) and logs it through client:
+// method, host, path, query with secret values redacted, status, duration,
+// and retries, the number of times the same *http.Request was sent before
+// (as retrying clients such as go-retryablehttp do). Calls failing with a
+// transport error or a 5xx status log at Error, 4xx at Warn, and the rest
+// at Info. If the request context carries a logger (see NewContext), that
+// logger is used, so entries carry its metadata.
+//
+// Requests to the host of client's own endpoint are never logged, so
+// wrapping the transport the client itself uses does not loop.
+//
+// Example:
+//
+//	httpClient := &http.Client{
+//	    Transport: logwell.WrapRoundTripper(http.DefaultTransport, client),
+//	}
+func WrapRoundTripper(base http.RoundTripper, client *Client, opts ...RoundTripperOption) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	cfg := roundTripperConfig{redactParams: make(map[string]struct{})}
+	for _, p := range defaultRedactParams {
+		cfg.redactParams[normalizeRedactKey(p)] = struct{}{}
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	rt := &loggingRoundTripper{base: base, client: client, cfg: cfg}
+	if u, err := url.Parse(client.root().config.Endpoint); err == nil {
+		rt.ownHost = u.Host
+	}
+	return rt
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *loggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host == rt.ownHost || (rt.cfg.skip != nil && rt.cfg.skip(req)) {
+		return rt.base.RoundTrip(req)
+	}
+
+	retries := rt.attempts.next(req)
+	start := time.Now()
+	resp, err := rt.base.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	logger := rt.client
+	if l := contextLogger(req.Context()); l != nil {
+		logger = l
+	}
+	meta := M{
+		"method":     req.Method,
+		"host":       req.URL.Host,
+		"path":       req.URL.Path,
+		"durationMs": float64(elapsed.Microseconds()) / 1000,
+		"retries":    retries,
+	}
+	if req.URL.RawQuery != "" {
+		meta["query"] = rt.redactQuery(req.URL.Query())
+	}
+	target := req.URL.Host + req.URL.Path
+
+	if err != nil {
+		meta["error"] = err.Error()
+		logger.Error(fmt.Sprintf("%s %s failed", req.Method, target), meta)
+		return resp, err
+	}
+	meta["status"] = resp.StatusCode
+	message := fmt.Sprintf("%s %s %d", req.Method, target, resp.StatusCode)
+	switch {
+	case resp.StatusCode >= 500:
+		logger.Error(message, meta)
+	case resp.StatusCode >= 400:
+		logger.Warn(message, meta)
+	default:
+		logger.Info(message, meta)
+	}
+	return resp, nil
+}
+
+// redactQuery encodes query with the values of sensitive parameters
+// replaced by DefaultRedactReplacement.
+func (rt *loggingRoundTripper) redactQuery(query url.Values) string {
+	for name, values := range query {
+		if _, ok := rt.cfg.redactParams[normalizeRedactKey(name)]; ok {
+			for i := range values {
+				values[i] = DefaultRedactReplacement
+			}
+		}
+	}
+	return strings.ReplaceAll(query.Encode(), url.QueryEscape(DefaultRedactReplacement), DefaultRedactReplacement)
+}
+
+// attemptCounter counts round trips per *http.Request without keeping
+// requests alive: entries are keyed by weak pointer and removed once the
+// request is garbage collected.
+type attemptCounter struct {
+	mu     sync.Mutex
+	counts map[weak.Pointer[http.Request]]int
+}
+
+// next records a round trip of req and returns how many came before it.
+func (a *attemptCounter) next(req *http.Request) int {
+	key := weak.Make(req)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.counts == nil {
+		a.counts = make(map[weak.Pointer[http.Request]]int)
+	}
+	n, seen := a.counts[key]
+	if !seen {
+		runtime.AddCleanup(req, a.forget, key)
+	}
+	a.counts[key] = n + 1
+	return n
+}
+
+// forget drops the count of a collected request.
+func (a *attemptCounter) forget(key weak.Pointer[http.Request]) {
+	a.mu.Lock()
+	delete(a.counts, key)
+	a.mu.Unlock()
+}
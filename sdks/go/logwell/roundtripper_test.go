@@ -0,0 +1,152 @@
+package logwell
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newRoundTripClient returns a client collecting its entries in got.
+func newRoundTripClient(t *testing.T, got *[]LogEntry) *Client {
+	t.Helper()
+	client, err := New(validEndpoint(), validAPIKey(),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			*got = append(*got, logs...)
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { client.Shutdown(context.Background()) })
+	return client
+}
+
+func TestWrapRoundTripper(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/broken":
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	var got []LogEntry
+	client := newRoundTripClient(t, &got)
+	httpClient := &http.Client{Transport: WrapRoundTripper(nil, client, WithRoundTripRedactParams("session"))}
+
+	for _, path := range []string{"/users?id=7&api_key=s3cret&Session=abc", "/missing", "/broken"} {
+		resp, err := httpClient.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("Get(%s) error = %v", path, err)
+		}
+		resp.Body.Close()
+	}
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("logged %d entries, want 3", len(got))
+	}
+
+	host := strings.TrimPrefix(srv.URL, "http://")
+	ok := got[0]
+	if ok.Level != LevelInfo || ok.Message != "GET "+host+"/users 200" {
+		t.Errorf("entry = %s %q", ok.Level, ok.Message)
+	}
+	if ok.Metadata["method"] != "GET" || ok.Metadata["host"] != host || ok.Metadata["path"] != "/users" ||
+		ok.Metadata["status"] != 200 || ok.Metadata["retries"] != 0 {
+		t.Errorf("metadata = %v", ok.Metadata)
+	}
+	if _, found := ok.Metadata["durationMs"]; !found {
+		t.Error("metadata lacks durationMs")
+	}
+	if q := ok.Metadata["query"]; q != "Session=[REDACTED]&api_key=[REDACTED]&id=7" {
+		t.Errorf("query = %v, want secrets redacted", q)
+	}
+	if got[1].Level != LevelWarn || got[2].Level != LevelError {
+		t.Errorf("levels = %s, %s, want warn, error", got[1].Level, got[2].Level)
+	}
+}
+
+func TestWrapRoundTripperErrorsAndRetries(t *testing.T) {
+	var got []LogEntry
+	client := newRoundTripClient(t, &got)
+	failing := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+	rt := WrapRoundTripper(failing, client)
+
+	req, _ := http.NewRequest(http.MethodPost, "http://api.example.com/orders", nil)
+	for range 3 {
+		if _, err := rt.RoundTrip(req); err == nil {
+			t.Fatal("RoundTrip() error = nil")
+		}
+	}
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("logged %d entries, want 3", len(got))
+	}
+	for i, e := range got {
+		if e.Level != LevelError || e.Message != "POST api.example.com/orders failed" ||
+			e.Metadata["error"] != "connection refused" || e.Metadata["retries"] != i {
+			t.Errorf("entry %d = %s %q %v", i, e.Level, e.Message, e.Metadata)
+		}
+	}
+}
+
+func TestWrapRoundTripperSkips(t *testing.T) {
+	var got []LogEntry
+	client := newRoundTripClient(t, &got)
+	ok := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+	rt := WrapRoundTripper(ok, client, WithRoundTripSkip(func(r *http.Request) bool {
+		return r.URL.Path == "/healthz"
+	}))
+
+	for _, target := range []string{validEndpoint() + "/v1/ingest", "http://svc.internal/healthz"} {
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		if _, err := rt.RoundTrip(req); err != nil {
+			t.Fatalf("RoundTrip(%s) error = %v", target, err)
+		}
+	}
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("logged %v, want own endpoint and skipped requests unlogged", got)
+	}
+}
+
+func TestWrapRoundTripperContextLogger(t *testing.T) {
+	var got []LogEntry
+	client := newRoundTripClient(t, &got)
+	ok := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+	})
+	rt := WrapRoundTripper(ok, client)
+
+	ctx := NewContext(context.Background(), client.With(M{"requestId": "req-1"}))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodDelete, "http://api.example.com/carts/1", nil)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(got) != 1 || got[0].Metadata["requestId"] != "req-1" {
+		t.Errorf("logged %v, want the context logger's metadata", got)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
@@ -0,0 +1,70 @@
+package logwell
+
+import "slices"
+
+// RouteConfig is the destination for entries routed by WithLevelRouting.
+type RouteConfig struct {
+	// Endpoint is the Logwell server URL. Default: the client's Endpoint.
+	Endpoint string
+
+	// APIKey is the API key of the project the entries are sent to.
+	APIKey string
+}
+
+// newRoutes creates one client per distinct route in cfg.LevelRouting, so
+// levels routed to the same project share a queue and transport.
+//
+// A route client inherits cfg's batching, retry, and transport settings.
+// Entries reach it after the root client's processors, redaction, sampling,
+// rate limiting, and local sink, so none of those are repeated. Options tied
+// to the client's own project are not inherited: the persistent queue,
+// fallback file, fallback endpoints, and custom Transport.
+func newRoutes(cfg *Config) map[LogLevel]*Client {
+	clients := make(map[RouteConfig]*Client, len(cfg.LevelRouting))
+	routes := make(map[LogLevel]*Client, len(cfg.LevelRouting))
+	for level, route := range cfg.LevelRouting {
+		if route.Endpoint == "" {
+			route.Endpoint = cfg.Endpoint
+		}
+		client, ok := clients[route]
+		if !ok {
+			client = newRouteClient(cfg, route)
+			clients[route] = client
+		}
+		routes[level] = client
+	}
+	return routes
+}
+
+// newRouteClient creates the client for one route.
+func newRouteClient(cfg *Config, route RouteConfig) *Client {
+	routeCfg := *cfg
+	routeCfg.Endpoint = route.Endpoint
+	routeCfg.APIKey = route.APIKey
+	routeCfg.LevelRouting = nil
+	routeCfg.Processors = nil
+	routeCfg.Redaction = nil
+	routeCfg.Sampler = nil
+	routeCfg.RateLimit = 0
+	routeCfg.DedupeWindow = 0
+	routeCfg.LocalSink = nil
+	routeCfg.PersistentQueueDir = ""
+	routeCfg.FallbackFile = ""
+	routeCfg.FallbackEndpoints = nil
+	routeCfg.Transport = nil
+
+	// newClient only fails opening a persistent queue, which routes lack.
+	client, _ := newClient(&routeCfg)
+	return client
+}
+
+// routeClients returns the distinct route clients of a root client.
+func (c *Client) routeClients() []*Client {
+	var clients []*Client
+	for _, route := range c.routes {
+		if !slices.Contains(clients, route) {
+			clients = append(clients, route)
+		}
+	}
+	return clients
+}
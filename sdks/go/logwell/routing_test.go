@@ -0,0 +1,111 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// projectServer records the entries it receives per API key.
+type projectServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	entries map[string][]LogEntry
+}
+
+func newProjectServer(t *testing.T) *projectServer {
+	t.Helper()
+	s := &projectServer{entries: make(map[string][]LogEntry)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		entries := decodeRequestBody(t, r)
+		s.mu.Lock()
+		s.entries[key] = append(s.entries[key], entries...)
+		s.mu.Unlock()
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: len(entries)})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// received returns the messages received for apiKey, in order.
+func (s *projectServer) received(apiKey string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var messages []string
+	for _, e := range s.entries[apiKey] {
+		messages = append(messages, e.Message)
+	}
+	return messages
+}
+
+const alertsAPIKey = "lw_" + "alertsalertsalertsalertsalerts00"
+
+func TestLevelRouting(t *testing.T) {
+	srv := newProjectServer(t)
+	alerts := RouteConfig{APIKey: alertsAPIKey}
+	client, err := New(srv.URL, validAPIKey(),
+		WithFatalBehavior(LogOnly),
+		WithLevelRouting(map[LogLevel]RouteConfig{LevelError: alerts, LevelFatal: alerts}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Debug("debug")
+	client.Info("info")
+	client.Error("error")
+	client.Child(ChildWithService("worker")).Fatal("fatal")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if got, want := srv.received(validAPIKey()), []string{"debug", "info"}; !slices.Equal(got, want) {
+		t.Errorf("default project received %v, want %v", got, want)
+	}
+	if got, want := srv.received(alertsAPIKey), []string{"error", "fatal"}; !slices.Equal(got, want) {
+		t.Errorf("alerts project received %v, want %v", got, want)
+	}
+	if stats := client.Stats(); stats.BatchesSent != 2 || stats.Queued != 0 {
+		t.Errorf("BatchesSent/Queued = %d/%d, want 2/0 (one batch per project)", stats.BatchesSent, stats.Queued)
+	}
+	if routes := client.routeClients(); len(routes) != 1 {
+		t.Errorf("route clients = %d, want 1 shared by error and fatal", len(routes))
+	}
+}
+
+func TestLevelRoutingEndpoint(t *testing.T) {
+	main := newProjectServer(t)
+	alerts := newProjectServer(t)
+	client, err := New(main.URL, validAPIKey(),
+		WithLevelRouting(map[LogLevel]RouteConfig{
+			LevelError: {Endpoint: alerts.URL, APIKey: alertsAPIKey},
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	client.Info("info")
+	client.Error("error")
+	// Shutdown drains the routes too.
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	if got := main.received(validAPIKey()); !slices.Equal(got, []string{"info"}) {
+		t.Errorf("main server received %v, want [info]", got)
+	}
+	if got := alerts.received(alertsAPIKey); !slices.Equal(got, []string{"error"}) {
+		t.Errorf("alerts server received %v, want [error]", got)
+	}
+	if got := main.received(alertsAPIKey); len(got) != 0 {
+		t.Errorf("main server received alerts %v, want none", got)
+	}
+}
@@ -0,0 +1,79 @@
+package logwell
+
+import (
+	"os"
+	"regexp"
+	"runtime"
+)
+
+// Runtime metadata keys added by WithRuntimeMetadata.
+const (
+	MetaHost         = "host"
+	MetaPID          = "pid"
+	MetaGoVersion    = "goVersion"
+	MetaOS           = "os"
+	MetaArch         = "arch"
+	MetaContainerID  = "containerId"
+	MetaK8sPod       = "k8sPod"
+	MetaK8sNamespace = "k8sNamespace"
+	MetaK8sNode      = "k8sNode"
+)
+
+// k8sEnv maps metadata keys to the environment variables conventionally
+// populated from the Kubernetes downward API.
+var k8sEnv = []struct{ key, env string }{
+	{MetaK8sPod, "POD_NAME"},
+	{MetaK8sNamespace, "POD_NAMESPACE"},
+	{MetaK8sNode, "NODE_NAME"},
+}
+
+// readFile reads process information files; overridable in tests.
+var readFile = os.ReadFile
+
+// runtimeMetadata describes the host and process. Values that cannot be
+// determined are omitted.
+func runtimeMetadata() M {
+	m := M{
+		MetaPID:       os.Getpid(),
+		MetaGoVersion: runtime.Version(),
+		MetaOS:        runtime.GOOS,
+		MetaArch:      runtime.GOARCH,
+	}
+	if host, err := os.Hostname(); err == nil {
+		m[MetaHost] = host
+	}
+	if id := containerID(); id != "" {
+		m[MetaContainerID] = id
+	}
+	for _, kv := range k8sEnv {
+		if v := os.Getenv(kv.env); v != "" {
+			m[kv.key] = v
+		}
+	}
+	return m
+}
+
+var (
+	// cgroupIDRegex matches a container ID in /proc/self/cgroup (cgroup v1).
+	cgroupIDRegex = regexp.MustCompile(`[0-9a-f]{64}`)
+
+	// mountIDRegex matches a container ID in /proc/self/mountinfo, where
+	// runtimes bind-mount /etc/hostname and friends (cgroup v2).
+	mountIDRegex = regexp.MustCompile(`/containers/([0-9a-f]{64})/`)
+)
+
+// containerID returns the ID of the container the process runs in, or ""
+// outside a container or on platforms without /proc.
+func containerID() string {
+	if data, err := readFile("/proc/self/cgroup"); err == nil {
+		if id := cgroupIDRegex.Find(data); id != nil {
+			return string(id)
+		}
+	}
+	if data, err := readFile("/proc/self/mountinfo"); err == nil {
+		if m := mountIDRegex.FindSubmatch(data); m != nil {
+			return string(m[1])
+		}
+	}
+	return ""
+}
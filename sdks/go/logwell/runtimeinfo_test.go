@@ -0,0 +1,111 @@
+package logwell
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+)
+
+const testContainerID = "3f4c1b2a9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d0e9f8a7b6c5d4e3f2a"
+
+// stubProcFiles replaces readFile with an in-memory /proc for the test.
+func stubProcFiles(t *testing.T, files map[string]string) {
+	t.Helper()
+	orig := readFile
+	readFile = func(name string) ([]byte, error) {
+		if data, ok := files[name]; ok {
+			return []byte(data), nil
+		}
+		return nil, os.ErrNotExist
+	}
+	t.Cleanup(func() { readFile = orig })
+}
+
+func TestContainerID(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "cgroup v1",
+			files: map[string]string{"/proc/self/cgroup": "12:memory:/docker/" + testContainerID + "\n"},
+			want:  testContainerID,
+		},
+		{
+			name: "cgroup v2 mountinfo",
+			files: map[string]string{
+				"/proc/self/cgroup":    "0::/\n",
+				"/proc/self/mountinfo": "1 2 0:1 /var/lib/docker/containers/" + testContainerID + "/hostname /etc/hostname rw\n",
+			},
+			want: testContainerID,
+		},
+		{
+			name:  "not in a container",
+			files: map[string]string{"/proc/self/cgroup": "0::/user.slice\n"},
+			want:  "",
+		},
+		{
+			name:  "no proc",
+			files: nil,
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubProcFiles(t, tt.files)
+			if got := containerID(); got != tt.want {
+				t.Errorf("containerID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuntimeMetadata(t *testing.T) {
+	stubProcFiles(t, map[string]string{"/proc/self/cgroup": "1:cpu:/kubepods/pod1/" + testContainerID})
+	t.Setenv("POD_NAME", "api-7d9f")
+	t.Setenv("POD_NAMESPACE", "prod")
+	t.Setenv("NODE_NAME", "")
+
+	m := runtimeMetadata()
+	if m[MetaPID] != os.Getpid() || m[MetaGoVersion] != runtime.Version() || m[MetaOS] != runtime.GOOS || m[MetaArch] != runtime.GOARCH {
+		t.Errorf("process metadata = %v", m)
+	}
+	if host, _ := os.Hostname(); m[MetaHost] != host {
+		t.Errorf("host = %v, want %q", m[MetaHost], host)
+	}
+	if m[MetaContainerID] != testContainerID || m[MetaK8sPod] != "api-7d9f" || m[MetaK8sNamespace] != "prod" {
+		t.Errorf("container metadata = %v", m)
+	}
+	if _, ok := m[MetaK8sNode]; ok {
+		t.Error("k8sNode set from an empty variable")
+	}
+}
+
+func TestClientRuntimeMetadata(t *testing.T) {
+	stubProcFiles(t, nil)
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithRuntimeMetadata(), WithMetadata(M{MetaHost: "override"}))
+	defer client.Shutdown(context.Background())
+
+	client.Child(ChildWithService("worker")).Info("hello")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 1)
+	if len(logs) == 1 {
+		md := logs[0].Metadata
+		if md[MetaHost] != "override" || md[MetaOS] != runtime.GOOS || md[MetaPID] == nil {
+			t.Errorf("Metadata = %v", md)
+		}
+		if _, ok := md[MetaContainerID]; ok {
+			t.Error("containerId set outside a container")
+		}
+	}
+}
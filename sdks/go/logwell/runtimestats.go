@@ -0,0 +1,104 @@
+package logwell
+
+import (
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// RuntimeStatsMessage is the message of the entries sent by
+// WithRuntimeStats.
+const RuntimeStatsMessage = "runtime stats"
+
+// Runtime stats metadata keys added by WithRuntimeStats.
+const (
+	MetaGoroutines     = "goroutines"
+	MetaHeapInuse      = "heapInuseBytes"
+	MetaHeapObjects    = "heapObjects"
+	MetaGCCount        = "gcCount"
+	MetaGCPauseTotalMs = "gcPauseTotalMs"
+	MetaOpenFDs        = "openFds"
+)
+
+// openFDs counts the open file descriptors of the process, or returns -1
+// where that is unknown; overridable in tests.
+var openFDs = func() int {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return -1
+	}
+	return len(entries) - 1 // the descriptor reading the directory
+}
+
+// runtimeReporter logs a RuntimeStatsMessage entry every interval until
+// stopped.
+type runtimeReporter struct {
+	client   *Client
+	interval time.Duration
+
+	mu      sync.Mutex
+	timer   Timer
+	stopped bool
+}
+
+// startRuntimeReporter starts reporting on c's clock. Must be called on the
+// root client.
+func startRuntimeReporter(c *Client, interval time.Duration) *runtimeReporter {
+	r := &runtimeReporter{client: c, interval: interval}
+	r.mu.Lock()
+	r.timer = c.clock.AfterFunc(interval, r.tick)
+	r.mu.Unlock()
+	return r
+}
+
+// tick logs one entry and re-arms the timer.
+func (r *runtimeReporter) tick() {
+	r.client.logRuntimeStats()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.stopped {
+		r.timer.Reset(r.interval)
+	}
+}
+
+// stop halts reporting. Subsequent calls do nothing.
+func (r *runtimeReporter) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopped = true
+	r.timer.Stop()
+}
+
+// logRuntimeStats admits a RuntimeStatsMessage entry. Like the startup
+// event, it is logged at INFO regardless of the minimum level and
+// otherwise goes through the pipeline like any entry. Must be called on
+// the root client.
+func (c *Client) logRuntimeStats() {
+	entry := LogEntry{
+		Level:    LevelInfo,
+		Message:  RuntimeStatsMessage,
+		Service:  c.config.Service,
+		Metadata: mergeMetadata(c.config.Metadata, runtimeStats()),
+	}
+	c.enqueue(&entry)
+}
+
+// runtimeStats samples the Go runtime. The open descriptor count is
+// omitted where it is unknown.
+func runtimeStats() M {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	stats := M{
+		MetaGoroutines:     runtime.NumGoroutine(),
+		MetaHeapInuse:      mem.HeapInuse,
+		MetaHeapObjects:    mem.HeapObjects,
+		MetaGCCount:        mem.NumGC,
+		MetaGCPauseTotalMs: float64(mem.PauseTotalNs) / float64(time.Millisecond),
+	}
+	if fds := openFDs(); fds >= 0 {
+		stats[MetaOpenFDs] = fds
+	}
+	return stats
+}
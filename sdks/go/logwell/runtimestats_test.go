@@ -0,0 +1,94 @@
+package logwell
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRuntimeStats(t *testing.T) {
+	defer func(orig func() int) { openFDs = orig }(openFDs)
+	openFDs = func() int { return 7 }
+
+	var got []LogEntry
+	client, err := New(validEndpoint(), validAPIKey(),
+		WithClock(stubClock{}),
+		WithMinLevel(LevelError),
+		WithMetadata(M{"env": "test"}),
+		WithRuntimeStats(time.Minute),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			got = append(got, logs...)
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	client.runtimeStats.tick()
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("sent %d entries, want 1", len(got))
+	}
+	e := got[0]
+	if e.Level != LevelInfo || e.Message != RuntimeStatsMessage || e.Metadata["env"] != "test" {
+		t.Errorf("entry = %+v, want an INFO runtime stats entry despite minLevel", e)
+	}
+	for _, key := range []string{MetaGoroutines, MetaHeapInuse, MetaHeapObjects, MetaGCCount, MetaGCPauseTotalMs} {
+		if _, ok := e.Metadata[key]; !ok {
+			t.Errorf("metadata lacks %q: %v", key, e.Metadata)
+		}
+	}
+	if e.Metadata[MetaOpenFDs] != 7 {
+		t.Errorf("%s = %v, want 7", MetaOpenFDs, e.Metadata[MetaOpenFDs])
+	}
+
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if !client.runtimeStats.stopped {
+		t.Error("Shutdown did not stop the reporter")
+	}
+}
+
+func TestRuntimeStatsOmitsUnknownFDs(t *testing.T) {
+	defer func(orig func() int) { openFDs = orig }(openFDs)
+	openFDs = func() int { return -1 }
+
+	if _, ok := runtimeStats()[MetaOpenFDs]; ok {
+		t.Errorf("runtimeStats() has %s when the count is unknown", MetaOpenFDs)
+	}
+}
+
+func TestRuntimeStatsReporterInterval(t *testing.T) {
+	entries := make(chan LogEntry, 4)
+	client, err := New(validEndpoint(), validAPIKey(), WithBatchSize(1),
+		WithRuntimeStats(MinRuntimeStatsInterval),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			for _, e := range logs {
+				entries <- e
+			}
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	select {
+	case e := <-entries:
+		if e.Message != RuntimeStatsMessage {
+			t.Errorf("Message = %q, want %q", e.Message, RuntimeStatsMessage)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no runtime stats entry after the interval")
+	}
+}
+
+func TestRuntimeStatsValidation(t *testing.T) {
+	for _, interval := range []time.Duration{-time.Second, time.Millisecond, 48 * time.Hour} {
+		_, err := New(validEndpoint(), validAPIKey(), WithRuntimeStats(interval))
+		assertConfigError(t, err, ErrInvalidConfig)
+	}
+}
@@ -0,0 +1,103 @@
+package logwell
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Sampler decides which entries are sent. Sample is called for every entry
+// at or above the minimum level and must be safe for concurrent use.
+type Sampler interface {
+	Sample(entry LogEntry) bool
+}
+
+// SamplerFunc adapts a function to the Sampler interface.
+type SamplerFunc func(entry LogEntry) bool
+
+// Sample calls f(entry).
+func (f SamplerFunc) Sample(entry LogEntry) bool {
+	return f(entry)
+}
+
+// FractionSampler keeps each entry with probability fraction (0-1).
+// A fraction of 1 or more keeps everything; 0 or less drops everything.
+func FractionSampler(fraction float64) Sampler {
+	return &fractionSampler{fraction: fraction, random: rand.Float64}
+}
+
+type fractionSampler struct {
+	fraction float64
+
+	// random returns a value in [0, 1); overridable in tests.
+	random func() float64
+}
+
+func (s *fractionSampler) Sample(LogEntry) bool {
+	return s.random() < s.fraction
+}
+
+// CountSampler keeps the first entries with a given level and message in
+// each tick, then every thereafter-th one (none if thereafter is 0).
+// Counts reset at the start of every tick; a zero tick never resets them.
+//
+// Example (at most 10 identical lines per second, then 1 in 100):
+//
+//	logwell.CountSampler(10, 100, time.Second)
+func CountSampler(first, thereafter int, tick time.Duration) Sampler {
+	return &countSampler{
+		first:      first,
+		thereafter: thereafter,
+		tick:       tick,
+		now:        time.Now,
+		counts:     make(map[string]int),
+	}
+}
+
+type countSampler struct {
+	first      int
+	thereafter int
+	tick       time.Duration
+	now        func() time.Time
+
+	mu        sync.Mutex
+	tickStart time.Time
+	counts    map[string]int
+}
+
+func (s *countSampler) Sample(entry LogEntry) bool {
+	key := RateLimitKey(entry)
+
+	s.mu.Lock()
+	if s.tick > 0 {
+		if now := s.now(); now.Sub(s.tickStart) >= s.tick {
+			clear(s.counts)
+			s.tickStart = now
+		}
+	}
+	s.counts[key]++
+	n := s.counts[key]
+	s.mu.Unlock()
+
+	if n <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.first)%s.thereafter == 0
+}
+
+// LevelSampler keeps every entry at or above level and passes the rest to
+// sampler, so warnings and errors always ship while high-volume debug and
+// info traffic is downsampled.
+//
+// Example:
+//
+//	logwell.WithSampler(logwell.LevelSampler(logwell.LevelWarn, logwell.FractionSampler(0.1)))
+func LevelSampler(level LogLevel, sampler Sampler) Sampler {
+	floor := max(level.severity(), 0)
+	return SamplerFunc(func(entry LogEntry) bool {
+		if entry.Level.severity() >= floor {
+			return true
+		}
+		return sampler.Sample(entry)
+	})
+}
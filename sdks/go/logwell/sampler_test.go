@@ -0,0 +1,117 @@
+package logwell
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFractionSampler(t *testing.T) {
+	s := FractionSampler(0.25).(*fractionSampler)
+	values := []float64{0.1, 0.24, 0.25, 0.9}
+	s.random = func() float64 {
+		v := values[0]
+		values = values[1:]
+		return v
+	}
+
+	var kept int
+	for range 4 {
+		if s.Sample(LogEntry{}) {
+			kept++
+		}
+	}
+	if kept != 2 {
+		t.Errorf("kept = %d, want 2", kept)
+	}
+
+	if FractionSampler(0).Sample(LogEntry{}) {
+		t.Error("FractionSampler(0) kept an entry")
+	}
+	if !FractionSampler(1).Sample(LogEntry{}) {
+		t.Error("FractionSampler(1) dropped an entry")
+	}
+}
+
+func TestCountSampler(t *testing.T) {
+	clock := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := CountSampler(2, 3, time.Second).(*countSampler)
+	s.now = func() time.Time { return clock }
+
+	entry := LogEntry{Level: LevelDebug, Message: "poll"}
+	var got []bool
+	for range 8 {
+		got = append(got, s.Sample(entry))
+	}
+	want := []bool{true, true, false, false, true, false, false, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("samples = %v, want %v", got, want)
+		}
+	}
+
+	if !s.Sample(LogEntry{Level: LevelInfo, Message: "poll"}) {
+		t.Error("different level shares the count")
+	}
+
+	clock = clock.Add(time.Second)
+	if !s.Sample(entry) {
+		t.Error("count not reset after tick")
+	}
+}
+
+func TestCountSamplerThereafterZero(t *testing.T) {
+	s := CountSampler(1, 0, 0)
+	entry := LogEntry{Level: LevelInfo, Message: "once"}
+	if !s.Sample(entry) || s.Sample(entry) || s.Sample(entry) {
+		t.Error("want only the first entry kept")
+	}
+}
+
+func TestLevelSampler(t *testing.T) {
+	s := LevelSampler(LevelWarn, FractionSampler(0))
+	for _, tt := range []struct {
+		level LogLevel
+		want  bool
+	}{
+		{LevelDebug, false},
+		{LevelInfo, false},
+		{LevelWarn, true},
+		{LevelError, true},
+		{LevelFatal, true},
+	} {
+		if got := s.Sample(LogEntry{Level: tt.level}); got != tt.want {
+			t.Errorf("Sample(%s) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestClientSampler(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithSampler(LevelSampler(LevelWarn, CountSampler(1, 0, 0))))
+	defer client.Shutdown(context.Background())
+
+	child := client.With(M{"k": "v"})
+	for range 3 {
+		child.Info("heartbeat")
+		child.Error("failed")
+	}
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	var info, errs int
+	for _, log := range ts.getLogs() {
+		switch log.Level {
+		case LevelInfo:
+			info++
+		case LevelError:
+			errs++
+		}
+	}
+	if info != 1 || errs != 3 {
+		t.Errorf("info/error = %d/%d, want 1/3", info, errs)
+	}
+}
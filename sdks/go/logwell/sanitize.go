@@ -0,0 +1,122 @@
+package logwell
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxSanitizeDepth bounds how deeply nested metadata is walked, so a map
+// that contains itself cannot recurse forever.
+const maxSanitizeDepth = 32
+
+// sanitizeMetadata replaces metadata values that encoding/json cannot
+// encode (channels, funcs, complex numbers, NaN and infinite floats, and
+// values containing them) so one bad value cannot fail a whole batch.
+// It returns the sanitized map, copied where changed since nested values
+// may belong to the caller, and the sorted keys that needed replacing.
+func sanitizeMetadata(metadata M) (M, []string) {
+	var replaced []string
+	var out M
+	for k, v := range metadata {
+		clean, ok := sanitizeValue(v, 0)
+		if ok {
+			continue
+		}
+		if out == nil {
+			out = maps.Clone(metadata)
+		}
+		out[k] = clean
+		replaced = append(replaced, k)
+	}
+	if out == nil {
+		return metadata, nil
+	}
+	slices.Sort(replaced)
+	return out, replaced
+}
+
+// sanitizeValue returns v, or a JSON-safe replacement and false if v (or
+// something nested in it) cannot be encoded.
+func sanitizeValue(v any, depth int) (any, bool) {
+	switch v := v.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64, json.Number, time.Time, time.Duration:
+		return v, true
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Sprint(v), false
+		}
+		return v, true
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return fmt.Sprint(v), false
+		}
+		return v, true
+	case M:
+		return sanitizeMap(v, depth)
+	case map[string]any:
+		return sanitizeMap(v, depth)
+	case []any:
+		if depth >= maxSanitizeDepth {
+			return "[max depth exceeded]", false
+		}
+		var out []any
+		for i, elem := range v {
+			clean, ok := sanitizeValue(elem, depth+1)
+			if ok {
+				continue
+			}
+			if out == nil {
+				out = slices.Clone(v)
+			}
+			out[i] = clean
+		}
+		if out == nil {
+			return v, true
+		}
+		return out, false
+	}
+
+	if _, err := json.Marshal(v); err != nil {
+		switch reflect.TypeOf(v).Kind() {
+		case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+			return "[unserializable " + reflect.TypeOf(v).String() + "]", false
+		}
+		return fmt.Sprintf("%v", v), false
+	}
+	return v, true
+}
+
+// sanitizeMap sanitizes the values of m, copying it if any change.
+func sanitizeMap(m map[string]any, depth int) (any, bool) {
+	if depth >= maxSanitizeDepth {
+		return "[max depth exceeded]", false
+	}
+	var out map[string]any
+	for k, v := range m {
+		clean, ok := sanitizeValue(v, depth+1)
+		if ok {
+			continue
+		}
+		if out == nil {
+			out = maps.Clone(m)
+		}
+		out[k] = clean
+	}
+	if out == nil {
+		return m, true
+	}
+	return out, false
+}
+
+// unserializableError describes the metadata keys sanitizeMetadata replaced.
+func unserializableError(message string, keys []string) *Error {
+	return NewError(ErrValidationError, fmt.Sprintf(
+		"metadata [%s] of %q not JSON-serializable; replaced with strings", strings.Join(keys, ", "), message))
+}
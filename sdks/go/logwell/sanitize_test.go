@@ -0,0 +1,97 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSanitizeMetadata(t *testing.T) {
+	type point struct{ X, Y int }
+	nested := M{"ch": make(chan int)}
+	metadata := M{
+		"ok":      "value",
+		"count":   3,
+		"when":    time.Unix(0, 0).UTC(),
+		"point":   point{1, 2},
+		"ch":      make(chan int),
+		"fn":      func() {},
+		"nan":     math.NaN(),
+		"complex": complex(1, 2),
+		"nested":  nested,
+		"list":    []any{1, math.Inf(1)},
+	}
+
+	out, replaced := sanitizeMetadata(metadata)
+
+	if want := []string{"ch", "complex", "fn", "list", "nan", "nested"}; strings.Join(replaced, ",") != strings.Join(want, ",") {
+		t.Errorf("replaced = %v, want %v", replaced, want)
+	}
+	if _, err := json.Marshal(out); err != nil {
+		t.Fatalf("json.Marshal(sanitized) error = %v", err)
+	}
+	if out["ch"] != "[unserializable chan int]" || out["fn"] != "[unserializable func()]" {
+		t.Errorf("ch, fn = %v, %v, want placeholders", out["ch"], out["fn"])
+	}
+	if out["nan"] != "NaN" || out["complex"] != "(1+2i)" {
+		t.Errorf("nan, complex = %v, %v, want their %%v form", out["nan"], out["complex"])
+	}
+	if list := out["list"].([]any); list[0] != 1 || list[1] != "+Inf" {
+		t.Errorf("list = %v, want [1 +Inf]", list)
+	}
+	if out["ok"] != "value" || out["point"] != (point{1, 2}) {
+		t.Errorf("serializable values changed: %v", out)
+	}
+	if _, ok := nested["ch"].(chan int); !ok {
+		t.Error("caller's nested map was modified, want it copied")
+	}
+}
+
+func TestSanitizeMetadata_Clean(t *testing.T) {
+	metadata := M{"a": 1, "b": M{"c": []any{"d"}}}
+	out, replaced := sanitizeMetadata(metadata)
+	if replaced != nil {
+		t.Errorf("replaced = %v, want nil", replaced)
+	}
+	if out["b"].(M)["c"].([]any)[0] != "d" {
+		t.Errorf("out = %v, want unchanged", out)
+	}
+}
+
+func TestSanitizeMetadata_Cycle(t *testing.T) {
+	cyclic := map[string]any{}
+	cyclic["self"] = cyclic
+	out, replaced := sanitizeMetadata(M{"cyclic": cyclic})
+	if len(replaced) != 1 {
+		t.Errorf("replaced = %v, want [cyclic]", replaced)
+	}
+	if _, err := json.Marshal(out); err != nil {
+		t.Errorf("json.Marshal(sanitized) error = %v", err)
+	}
+}
+
+// TestClientSanitizesMetadata tests that an unserializable value no longer
+// fails the batch and is reported to OnError.
+func TestClientSanitizesMetadata(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var reported []*Error
+	client := createTestClient(t, ts,
+		WithBatchSize(1),
+		WithOnError(func(err *Error) { reported = append(reported, err) }),
+	)
+	defer client.Shutdown(context.Background())
+
+	log := logAndWait(client, ts, client.Info, "job queued", M{"jobId": "j-1", "done": make(chan struct{})})
+	if log.Message != "job queued" {
+		t.Fatalf("logged %+v, want the entry delivered", log)
+	}
+	assertLogMetadata(t, log, map[string]string{"jobId": "j-1", "done": "[unserializable chan struct {}]"})
+	if len(reported) != 1 || reported[0].Code != ErrValidationError || !strings.Contains(reported[0].Message, "done") {
+		t.Errorf("OnError = %v, want one validation error naming the key", reported)
+	}
+}
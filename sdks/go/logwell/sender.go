@@ -0,0 +1,97 @@
+package logwell
+
+import (
+	"context"
+	"sync"
+)
+
+// sender is a fixed pool of background goroutines that ship batches handed
+// to it by the client. Submission never blocks: the client checks hasCapacity
+// before submitting and leaves entries in the queue when every worker is busy.
+type sender struct {
+	batches chan []LogEntry
+	send    func(batch []LogEntry)
+	workers sync.WaitGroup
+
+	// inflight counts submitted batches that have not finished sending;
+	// idle is closed whenever inflight drops to zero.
+	mu       sync.Mutex
+	inflight int
+	idle     chan struct{}
+}
+
+// newSender starts concurrency workers, each calling send for every batch
+// they receive.
+func newSender(concurrency int, send func(batch []LogEntry)) *sender {
+	s := &sender{
+		batches: make(chan []LogEntry, concurrency),
+		send:    send,
+		idle:    make(chan struct{}),
+	}
+	close(s.idle)
+
+	s.workers.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go s.run()
+	}
+	return s
+}
+
+// run is the worker loop.
+func (s *sender) run() {
+	defer s.workers.Done()
+	for batch := range s.batches {
+		s.send(batch)
+		s.finish()
+	}
+}
+
+// hasCapacity reports whether submit would not block.
+// Only meaningful while the caller serializes submissions.
+func (s *sender) hasCapacity() bool {
+	return len(s.batches) < cap(s.batches)
+}
+
+// submit hands batch to the pool. Callers must check hasCapacity first
+// (under the same lock that serializes submissions) to avoid blocking.
+func (s *sender) submit(batch []LogEntry) {
+	s.mu.Lock()
+	if s.inflight == 0 {
+		s.idle = make(chan struct{})
+	}
+	s.inflight++
+	s.mu.Unlock()
+
+	s.batches <- batch
+}
+
+// finish marks one submitted batch as done.
+func (s *sender) finish() {
+	s.mu.Lock()
+	s.inflight--
+	if s.inflight == 0 {
+		close(s.idle)
+	}
+	s.mu.Unlock()
+}
+
+// waitIdle blocks until no submitted batch is in flight or ctx is done.
+func (s *sender) waitIdle(ctx context.Context) error {
+	s.mu.Lock()
+	idle := s.idle
+	s.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close stops accepting batches and waits for the workers to exit.
+// Callers must guarantee no further submit calls.
+func (s *sender) close() {
+	close(s.batches)
+	s.workers.Wait()
+}
@@ -0,0 +1,93 @@
+package logwell
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSenderRunsBatchesConcurrently(t *testing.T) {
+	release := make(chan struct{})
+	var running int32
+
+	s := newSender(3, func([]LogEntry) {
+		atomic.AddInt32(&running, 1)
+		<-release
+	})
+
+	for i := 0; i < 3; i++ {
+		s.submit([]LogEntry{{Message: "x"}})
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&running) < 3 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := atomic.LoadInt32(&running); got != 3 {
+		t.Errorf("running workers = %d, want 3", got)
+	}
+
+	close(release)
+	s.close()
+}
+
+func TestSenderHasCapacity(t *testing.T) {
+	release := make(chan struct{})
+	s := newSender(1, func([]LogEntry) { <-release })
+	defer s.close()
+	defer close(release)
+
+	if !s.hasCapacity() {
+		t.Fatal("new sender should have capacity")
+	}
+
+	s.submit([]LogEntry{{Message: "taken by worker"}})
+	time.Sleep(10 * time.Millisecond)
+	s.submit([]LogEntry{{Message: "buffered"}})
+
+	if s.hasCapacity() {
+		t.Error("sender with busy worker and full buffer should have no capacity")
+	}
+}
+
+func TestSenderWaitIdle(t *testing.T) {
+	var mu sync.Mutex
+	var sent int
+	s := newSender(2, func(batch []LogEntry) {
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		sent += len(batch)
+		mu.Unlock()
+	})
+	defer s.close()
+
+	t.Run("idle sender returns immediately", func(t *testing.T) {
+		if err := s.waitIdle(context.Background()); err != nil {
+			t.Fatalf("waitIdle() error = %v", err)
+		}
+	})
+
+	t.Run("waits for in-flight batches", func(t *testing.T) {
+		s.submit([]LogEntry{{}, {}})
+		s.submit([]LogEntry{{}})
+		if err := s.waitIdle(context.Background()); err != nil {
+			t.Fatalf("waitIdle() error = %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if sent != 3 {
+			t.Errorf("sent = %d after waitIdle, want 3", sent)
+		}
+	})
+
+	t.Run("respects context", func(t *testing.T) {
+		s.submit([]LogEntry{{}})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := s.waitIdle(ctx); err == nil {
+			t.Error("waitIdle() expected context error")
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package logwell
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// sessionCookieName is the cookie better-auth uses for dashboard sessions.
+// Over HTTPS it carries the __Secure- prefix.
+const sessionCookieName = "better-auth.session_token"
+
+// sessionClient performs requests against the dashboard API
+// (/api/projects/...), which is authenticated with a browser session
+// rather than an API key. It backs QueryClient and AdminClient.
+type sessionClient struct {
+	endpoint     string
+	origin       string
+	sessionToken string
+	cookieName   string
+	httpClient   *http.Client
+}
+
+// SessionOption configures a client of the session-authenticated dashboard
+// API (QueryClient, AdminClient).
+type SessionOption func(*sessionClient)
+
+// WithSessionToken sets the dashboard session token used to authenticate
+// requests (the value of the better-auth.session_token cookie).
+func WithSessionToken(token string) SessionOption {
+	return func(s *sessionClient) {
+		s.sessionToken = token
+	}
+}
+
+// WithSessionHTTPClient sets the HTTP client used for dashboard API requests.
+// Default: a client with a 30s timeout.
+func WithSessionHTTPClient(client *http.Client) SessionOption {
+	return func(s *sessionClient) {
+		s.httpClient = client
+	}
+}
+
+// newSessionClient applies opts and validates the endpoint and session token.
+func newSessionClient(endpoint string, opts []SessionOption) (*sessionClient, error) {
+	s := &sessionClient{
+		endpoint:   strings.TrimRight(endpoint, "/"),
+		cookieName: sessionCookieName,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := validateEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+	if s.sessionToken == "" {
+		return nil, NewError(ErrInvalidConfig, "sessionToken is required")
+	}
+
+	u, _ := url.Parse(s.endpoint)
+	s.origin = u.Scheme + "://" + u.Host
+	if u.Scheme == "https" {
+		s.cookieName = "__Secure-" + sessionCookieName
+	}
+	if s.httpClient == nil {
+		s.httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return s, nil
+}
+
+// doJSON sends body (if non-nil) as JSON to path and decodes the JSON
+// response into out (if non-nil).
+func (s *sessionClient) doJSON(ctx context.Context, method, path string, params url.Values, body, out any) error {
+	resp, err := s.do(ctx, s.httpClient, method, path, params, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return NewErrorWithCause(ErrServerError, "failed to parse response", err)
+	}
+	return nil
+}
+
+// do performs an authenticated request for path (relative to /api) using
+// client. Non-2xx responses are converted to an *Error; on success the
+// caller owns the response body.
+func (s *sessionClient) do(ctx context.Context, client *http.Client, method, path string, params url.Values, body any) (*http.Response, error) {
+	reqURL := s.endpoint + "/api" + path
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, NewErrorWithCause(ErrValidationError, "failed to marshal request", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return nil, NewErrorWithCause(ErrNetworkError, "failed to create request", err)
+	}
+	req.AddCookie(&http.Cookie{Name: s.cookieName, Value: s.sessionToken})
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if method != http.MethodGet {
+		// Session-authenticated writes are CSRF-checked against the Origin header.
+		req.Header.Set("Origin", s.origin)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, NewErrorWithCause(ErrNetworkError, "request failed", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer func() { _ = resp.Body.Close() }()
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return nil, createError(resp.StatusCode, parseErrorMessage(respBody, resp.StatusCode))
+	}
+	return resp, nil
+}
@@ -0,0 +1,73 @@
+package logwell
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// DefaultSignalGrace bounds the shutdown started by HandleSignals when no
+// grace period is given.
+const DefaultSignalGrace = 5 * time.Second
+
+// HandleSignals shuts the client down when the process receives one of
+// sigs (default: os.Interrupt and SIGTERM), draining the queue within
+// grace, then re-raises the signal so the process terminates as it would
+// have without the handler. Keep grace below the platform's kill deadline,
+// such as the Kubernetes terminationGracePeriodSeconds, so the drain
+// finishes before the process is SIGKILLed. grace <= 0 uses
+// DefaultSignalGrace.
+//
+// Use it in programs that do not handle these signals themselves; a
+// program that does would receive the re-raised signal again, and should
+// call Shutdown from its own handler instead. On a child logger it shuts
+// down the root client. stop uninstalls the handler.
+//
+// Example:
+//
+//	stop := client.HandleSignals(10*time.Second, os.Interrupt, syscall.SIGTERM)
+//	defer stop()
+func (c *Client) HandleSignals(grace time.Duration, sigs ...os.Signal) (stop func()) {
+	if grace <= 0 {
+		grace = DefaultSignalGrace
+	}
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, sigs...)
+	done := make(chan struct{})
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(done)
+		})
+	}
+
+	go func() {
+		select {
+		case sig := <-received:
+			ctx, cancel := context.WithTimeout(context.Background(), grace)
+			_ = c.root().Shutdown(ctx) // send failures already reach OnError
+			cancel()
+			stop()
+			raise(sig)
+		case <-done:
+		}
+	}()
+	return stop
+}
+
+// raise delivers sig to the current process, exiting with status 1 where
+// the signal cannot be sent (os.Interrupt on Windows).
+func raise(sig os.Signal) {
+	if p, err := os.FindProcess(os.Getpid()); err == nil && p.Signal(sig) == nil {
+		return
+	}
+	os.Exit(1)
+}
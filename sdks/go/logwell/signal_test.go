@@ -0,0 +1,78 @@
+//go:build unix
+
+package logwell
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestHandleSignals tests that a caught signal drains the client and is
+// re-raised once the handler is uninstalled.
+func TestHandleSignals(t *testing.T) {
+	// Catching SIGUSR1 here keeps the re-raised signal from killing the
+	// test binary, and observes it.
+	observed := make(chan os.Signal, 2)
+	signal.Notify(observed, syscall.SIGUSR1)
+	defer signal.Stop(observed)
+
+	sent := make(chan string, 1)
+	client, err := New(validEndpoint(), validAPIKey(),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			sent <- logs[0].Message
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.HandleSignals(time.Second, syscall.SIGUSR1)()
+
+	client.Info("before exit")
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Kill() error = %v", err)
+	}
+
+	select {
+	case msg := <-sent:
+		if msg != "before exit" {
+			t.Errorf("sent %q, want the queued entry", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("queued entry not sent after the signal")
+	}
+	for i := range 2 {
+		select {
+		case <-observed:
+		case <-time.After(2 * time.Second):
+			t.Fatalf("observed %d signals, want the original and the re-raised one", i)
+		}
+	}
+	if err := client.Flush(context.Background()); err != ErrClientClosed {
+		t.Errorf("Flush() after the signal error = %v, want ErrClientClosed", err)
+	}
+}
+
+func TestHandleSignalsStop(t *testing.T) {
+	observed := make(chan os.Signal, 1)
+	signal.Notify(observed, syscall.SIGUSR2)
+	defer signal.Stop(observed)
+
+	client, err := New(validEndpoint(), validAPIKey())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.HandleSignals(time.Second, syscall.SIGUSR2)()
+	if err := syscall.Kill(os.Getpid(), syscall.SIGUSR2); err != nil {
+		t.Fatalf("Kill() error = %v", err)
+	}
+	<-observed
+	if err := client.Flush(context.Background()); err != nil {
+		t.Errorf("Flush() error = %v, want nil: a stopped handler must not shut down", err)
+	}
+}
@@ -0,0 +1,112 @@
+package logwell
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignRequest(t *testing.T) {
+	got := signRequest([]byte("0123456789abcdef"), time.Unix(1700000000, 0), []byte(`[{"level":"info"}]`))
+
+	mac := hmac.New(sha256.New, []byte("0123456789abcdef"))
+	mac.Write([]byte(`1700000000.[{"level":"info"}]`))
+	want := "t=1700000000,v1=" + hex.EncodeToString(mac.Sum(nil))
+	if got != want {
+		t.Errorf("signRequest() = %q, want %q", got, want)
+	}
+}
+
+func TestTransportSignsRequests(t *testing.T) {
+	const secret = "s3cr3t-s3cr3t-s3cr3t"
+	var verified, unsigned bool
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		header := r.Header.Get(SignatureHeader)
+		if header == "" {
+			unsigned = true
+		}
+		ts, sig, _ := strings.Cut(strings.TrimPrefix(header, "t="), ",v1=")
+		sec, _ := strconv.ParseInt(ts, 10, 64)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(ts + "." + string(body)))
+		if hmac.Equal([]byte(sig), []byte(hex.EncodeToString(mac.Sum(nil)))) && time.Since(time.Unix(sec, 0)) < time.Minute {
+			verified = true
+		}
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: 1})
+	}))
+	defer server.Close()
+
+	cfg := newDefaultConfig(server.URL, validAPIKey())
+	WithRequestSigning(secret)(cfg)
+	transport := newHTTPTransportFromConfig(cfg)
+	if _, err := transport.send(context.Background(), []LogEntry{{Level: LevelInfo, Message: "signed"}}); err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	if !verified {
+		t.Error("server could not verify the request signature")
+	}
+
+	transport = newHTTPTransportFromConfig(newDefaultConfig(server.URL, validAPIKey()))
+	if _, err := transport.send(context.Background(), []LogEntry{{Level: LevelInfo, Message: "plain"}}); err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	if !unsigned {
+		t.Error("request signed without WithRequestSigning")
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	const secret = "s3cr3t-s3cr3t-s3cr3t"
+	body := []byte(`[{"level":"info","message":"signed"}]`)
+	header := signRequest([]byte(secret), time.Now(), body)
+
+	if err := VerifyWebhookSignature(header, body, secret); err != nil {
+		t.Errorf("VerifyWebhookSignature() error = %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	valid := signRequest([]byte(secret), now, body)
+	_, validSig, _ := strings.Cut(valid, ",v1=")
+	tests := []struct {
+		name   string
+		header string
+		body   []byte
+		secret string
+		now    time.Time
+		ok     bool
+	}{
+		{"valid", valid, body, secret, now, true},
+		{"within tolerance", valid, body, secret, now.Add(SignatureTolerance), true},
+		{"rotated secret", "t=1700000000,v1=00ff," + "v1=" + validSig, body, secret, now, true},
+		{"missing", "", body, secret, now, false},
+		{"malformed", "v1=" + validSig, body, secret, now, false},
+		{"not hex", "t=1700000000,v1=zz", body, secret, now, false},
+		{"expired", valid, body, secret, now.Add(SignatureTolerance + time.Second), false},
+		{"from the future", valid, body, secret, now.Add(-SignatureTolerance - time.Second), false},
+		{"tampered body", valid, []byte(`[{"level":"fatal"}]`), secret, now, false},
+		{"wrong secret", valid, body, "another-secret-value", now, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifySignature(tt.header, tt.body, []byte(tt.secret), tt.now)
+			if tt.ok {
+				if err != nil {
+					t.Errorf("verifySignature() error = %v", err)
+				}
+				return
+			}
+			assertConfigError(t, err, ErrUnauthorized)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package logwell
+
+import "context"
+
+// Sink is the delivery surface of a Client for producers that already
+// have complete entries, such as log shippers, pipeline plugins, and
+// HTTP receivers for tools like Vector or Benthos. Log hands an entry to
+// the client's batching queue, which compresses, retries, and re-queues
+// on failure; Flush and Shutdown wait for delivery.
+//
+// *Client, its child loggers, MultiLogger, and logwelltest.Recorder
+// implement Sink, so code built on it can deliver to one or several
+// Logwell instances, or be tested in memory.
+type Sink interface {
+	// Log queues an entry; see Client.Log.
+	Log(entry LogEntry)
+
+	// Flush sends every pending entry; see Client.Flush.
+	Flush(ctx context.Context) error
+
+	// Shutdown flushes and stops the sink; see Client.Shutdown.
+	Shutdown(ctx context.Context) error
+}
+
+var (
+	_ Sink = (*Client)(nil)
+	_ Sink = (*MultiLogger)(nil)
+)
@@ -16,3 +16,26 @@ func captureSource(skip int) (file string, line int) {
 	// Return the full path (aligned with TS/Python SDKs)
 	return file, line
 }
+
+// maxStackDepth bounds the number of frames captured by captureStack.
+const maxStackDepth = 64
+
+// captureStack returns the formatted stack of the calling goroutine. The
+// skip parameter counts frames as for captureSource.
+func captureStack(skip int) string {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+1, pcs)
+	return formatStack(pcs[:n])
+}
+
+// withStack sets the "stack" key of metadata to stack, allocating the map
+// if needed. An existing "stack" value (e.g. from RecoverAndLog) is kept.
+func withStack(metadata map[string]any, stack string) map[string]any {
+	if metadata == nil {
+		return map[string]any{"stack": stack}
+	}
+	if _, ok := metadata["stack"]; !ok {
+		metadata["stack"] = stack
+	}
+	return metadata
+}
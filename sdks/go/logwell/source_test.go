@@ -1,7 +1,9 @@
 package logwell
 
 import (
+	"context"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -36,3 +38,99 @@ func TestCaptureSource(t *testing.T) {
 		}
 	})
 }
+
+func TestClientStackTrace(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	client := createTestClient(t, ts, WithStackTrace(LevelError), WithFatalBehavior(LogOnly))
+	defer client.Shutdown(context.Background())
+
+	child := client.With(M{"k": "v"})
+	child.Warn("no stack")
+	child.Error("with stack")
+	child.ErrorFields("fields stack")
+	child.Fatalf("formatted %s", "stack")
+	child.Log(LogEntry{Level: LevelError, Message: "entry stack"})
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	logs := ts.getLogs()
+	assertLogCount(t, logs, 5)
+	for _, log := range logs {
+		stack, ok := log.Metadata["stack"].(string)
+		if log.Level == LevelWarn {
+			if ok {
+				t.Errorf("%q has a stack below the threshold", log.Message)
+			}
+			continue
+		}
+		// The first frame is the logging call site, not SDK internals.
+		if !strings.HasPrefix(stack, "github.com/Divkix/Logwell/sdks/go/logwell.TestClientStackTrace\n") {
+			t.Errorf("%q stack does not start at the caller:\n%s", log.Message, stack)
+		}
+	}
+}
+
+func TestWithStackKeepsExisting(t *testing.T) {
+	m := withStack(map[string]any{"stack": "from panic"}, "captured")
+	if m["stack"] != "from panic" {
+		t.Errorf("stack = %v, want existing value kept", m["stack"])
+	}
+	if m := withStack(nil, "captured"); m["stack"] != "captured" {
+		t.Errorf("stack = %v, want captured", m["stack"])
+	}
+}
+
+// facade mimics a team's logging wrapper around the client.
+type facade struct{ client *Client }
+
+func (f facade) Info(message string)  { f.client.Info(message) }
+func (f facade) Warn(message string)  { f.client.WarnFields(message, String("k", "v")) }
+func (f facade) Error(message string) { f.client.Log(LogEntry{Level: LevelError, Message: message}) }
+
+// Notice adds a second wrapper frame on top of Info.
+func (f facade) Notice(message string) { f.Info(message) }
+
+func TestCallerSkip(t *testing.T) {
+	var got []LogEntry
+	client, err := New(validEndpoint(), validAPIKey(),
+		WithCaptureSourceLocation(true),
+		WithCallerSkip(1),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			got = append(got, logs...)
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	log := facade{client}
+	_, _, line, _ := runtime.Caller(0)
+	log.Info("info")
+	log.Warn("warn")
+	log.Error("error")
+	// A child used behind one more wrapper frame skips it too.
+	nested := facade{client.Child(ChildWithCallerSkip(1))}
+	nested.Notice("nested")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := []int{line + 1, line + 2, line + 3, line + 6}
+	if len(got) != len(want) {
+		t.Fatalf("sent %d entries, want %d", len(got), len(want))
+	}
+	for i, e := range got {
+		if !strings.HasSuffix(e.SourceFile, "source_test.go") || e.LineNumber != want[i] {
+			t.Errorf("%s: source = %s:%d, want source_test.go:%d", e.Message, e.SourceFile, e.LineNumber, want[i])
+		}
+	}
+}
+
+func TestCallerSkipValidation(t *testing.T) {
+	_, err := New(validEndpoint(), validAPIKey(), WithCallerSkip(-1))
+	assertConfigError(t, err, ErrInvalidConfig)
+}
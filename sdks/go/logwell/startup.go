@@ -0,0 +1,81 @@
+package logwell
+
+import (
+	"maps"
+	"slices"
+)
+
+// StartupMessage is the message of the entry sent by WithStartupEvent.
+const StartupMessage = "sdk started"
+
+// logStartup admits the WithStartupEvent entry. It is logged at INFO
+// regardless of the minimum level, and otherwise goes through the pipeline
+// like any entry. Must be called on the root client.
+func (c *Client) logStartup() {
+	metadata := M{
+		"sdk":        "logwell-go",
+		"sdkVersion": Version,
+		"sdkConfig":  configSummary(c.config),
+	}
+	if !c.config.RuntimeMetadata {
+		maps.Copy(metadata, runtimeMetadata())
+	}
+	entry := LogEntry{
+		Level:    LevelInfo,
+		Message:  StartupMessage,
+		Service:  c.config.Service,
+		Metadata: mergeMetadata(c.config.Metadata, metadata),
+	}
+	c.enqueue(&entry)
+}
+
+// configSummary describes the settings of cfg that shape delivery. Secrets
+// (the API key, signing secret, and header values) are left out; only
+// whether they are set is reported.
+func configSummary(cfg *Config) M {
+	summary := M{
+		"endpoint":          cfg.Endpoint,
+		"batchSize":         cfg.BatchSize,
+		"flushInterval":     cfg.FlushInterval.String(),
+		"maxQueueSize":      cfg.MaxQueueSize,
+		"maxRetries":        cfg.MaxRetries,
+		"requestTimeout":    cfg.RequestTimeout.String(),
+		"senderConcurrency": cfg.SenderConcurrency,
+		"overflowStrategy":  cfg.OverflowStrategy.String(),
+		"syncMode":          cfg.SyncMode,
+		"sampling":          cfg.Sampler != nil,
+		"redactionRules":    len(cfg.Redaction),
+		"processors":        len(cfg.Processors),
+		"persistentQueue":   cfg.PersistentQueueDir != "",
+		"fallbackFile":      cfg.FallbackFile != "",
+		"requestSigning":    cfg.SigningSecret != "",
+		"customTransport":   cfg.Transport != nil,
+	}
+	if cfg.MinLevel != "" {
+		summary["minLevel"] = string(cfg.MinLevel)
+	}
+	if len(cfg.FallbackEndpoints) > 0 {
+		summary["fallbackEndpoints"] = slices.Clone(cfg.FallbackEndpoints)
+	}
+	if cfg.CircuitBreakerThreshold > 0 {
+		summary["circuitBreaker"] = M{
+			"threshold": cfg.CircuitBreakerThreshold,
+			"cooldown":  cfg.CircuitBreakerCooldown.String(),
+		}
+	}
+	if cfg.RateLimit > 0 {
+		summary["rateLimit"] = M{"perSecond": cfg.RateLimit, "burst": cfg.RateLimitBurst}
+	}
+	if len(cfg.LevelRouting) > 0 {
+		levels := make([]string, 0, len(cfg.LevelRouting))
+		for level := range cfg.LevelRouting {
+			levels = append(levels, string(level))
+		}
+		slices.Sort(levels)
+		summary["routedLevels"] = levels
+	}
+	if len(cfg.Headers) > 0 {
+		summary["headers"] = slices.Sorted(maps.Keys(cfg.Headers))
+	}
+	return summary
+}
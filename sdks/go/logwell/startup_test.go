@@ -0,0 +1,77 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStartupEvent(t *testing.T) {
+	var got []LogEntry
+	client, err := New(validEndpoint(), validAPIKey(),
+		WithStartupEvent(),
+		WithService("billing"),
+		WithMinLevel(LevelError),
+		WithRequestSigning("0123456789abcdef-secret"),
+		WithHeaders(map[string]string{"X-Tenant": "acme-tenant-secret"}),
+		WithCircuitBreaker(5, time.Second),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			got = append(got, logs...)
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("sent %d entries, want the startup event despite MinLevel", len(got))
+	}
+	entry := got[0]
+	if entry.Level != LevelInfo || entry.Message != StartupMessage || entry.Service != "billing" {
+		t.Errorf("entry = %s %q service %q, want info %q service billing", entry.Level, entry.Message, entry.Service, StartupMessage)
+	}
+	if entry.Metadata["sdkVersion"] != Version || entry.Metadata[MetaGoVersion] == nil {
+		t.Errorf("metadata = %v, want the SDK version and runtime metadata", entry.Metadata)
+	}
+	summary, ok := entry.Metadata["sdkConfig"].(M)
+	if !ok {
+		t.Fatalf("sdkConfig = %T, want M", entry.Metadata["sdkConfig"])
+	}
+	if summary["batchSize"] != DefaultBatchSize || summary["minLevel"] != "error" || summary["requestSigning"] != true {
+		t.Errorf("sdkConfig = %v", summary)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, secret := range []string{validAPIKey(), "0123456789abcdef-secret", "acme-tenant-secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("startup event contains secret %q: %s", secret, data)
+		}
+	}
+}
+
+func TestStartupEventDisabled(t *testing.T) {
+	sent := 0
+	client, err := New(validEndpoint(), validAPIKey(),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			sent += len(logs)
+			return nil, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if sent != 0 {
+		t.Errorf("sent %d entries, want none without WithStartupEvent", sent)
+	}
+}
@@ -0,0 +1,153 @@
+package logwell
+
+import (
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// ClientStats is a point-in-time snapshot of a client's delivery pipeline,
+// for monitoring the logging path itself.
+type ClientStats struct {
+	// Queued is the number of entries waiting to be sent.
+	Queued int
+
+	// Dropped is the number of entries discarded because the queue was full.
+	Dropped uint64
+
+	// Throttled is the number of entries suppressed by WithRateLimit.
+	Throttled uint64
+
+	// Deduplicated is the number of entries collapsed into an earlier
+	// identical one by WithDedupe.
+	Deduplicated uint64
+
+	// BatchesSent is the number of batches accepted by the server.
+	BatchesSent uint64
+
+	// BatchesFailed is the number of batches that failed after all retries
+	// and were re-queued.
+	BatchesFailed uint64
+
+	// Retries is the number of retry attempts made by the transport.
+	Retries uint64
+
+	// LastFlushLatency is how long the most recent successful batch took to
+	// send, including retries. Zero until the first batch is sent.
+	LastFlushLatency time.Duration
+
+	// SendLatency is the distribution of successful batch send times.
+	SendLatency LatencyHistogram
+
+	// ActiveEndpoint is the endpoint sends currently go to; it differs from
+	// the primary while failed over to a fallback (see WithEndpoints).
+	ActiveEndpoint string
+}
+
+// latencyBuckets are the upper bounds of the SendLatency histogram buckets.
+var latencyBuckets = [...]time.Duration{
+	5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond,
+	50 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond,
+	500 * time.Millisecond, time.Second, 2500 * time.Millisecond,
+	5 * time.Second, 10 * time.Second, 30 * time.Second,
+}
+
+// LatencyBuckets returns the upper bounds of the SendLatency histogram
+// buckets. The slice is a copy.
+func LatencyBuckets() []time.Duration {
+	return slices.Clone(latencyBuckets[:])
+}
+
+// LatencyHistogram is a snapshot of a latency distribution.
+type LatencyHistogram struct {
+	// Bounds are the inclusive upper bounds of the buckets (LatencyBuckets()).
+	Bounds []time.Duration
+
+	// Counts holds the observations per bucket: Counts[i] counts values in
+	// (Bounds[i-1], Bounds[i]], and the final extra element counts values
+	// above the last bound. Counts are not cumulative.
+	Counts []uint64
+
+	// Count is the total number of observations.
+	Count uint64
+
+	// Sum is the total of all observed values.
+	Sum time.Duration
+}
+
+// clientStats holds the counters behind ClientStats. Dropped entries are
+// counted by the queue and retries by the transport.
+type clientStats struct {
+	batchesSent      atomic.Uint64
+	batchesFailed    atomic.Uint64
+	lastFlushLatency atomic.Int64
+	sendLatency      latencyHistogram
+}
+
+// latencyHistogram accumulates observations into latencyBuckets.
+type latencyHistogram struct {
+	counts [len(latencyBuckets) + 1]atomic.Uint64 // one per bucket + overflow
+	sum    atomic.Int64
+}
+
+// observe records one duration.
+func (h *latencyHistogram) observe(d time.Duration) {
+	i, _ := slices.BinarySearch(latencyBuckets[:], d)
+	h.counts[i].Add(1)
+	h.sum.Add(int64(d))
+}
+
+// snapshot returns the current distribution. Concurrent observations may
+// be partially reflected.
+func (h *latencyHistogram) snapshot() LatencyHistogram {
+	snap := LatencyHistogram{
+		Bounds: LatencyBuckets(),
+		Counts: make([]uint64, len(h.counts)),
+		Sum:    time.Duration(h.sum.Load()),
+	}
+	for i := range h.counts {
+		snap.Counts[i] = h.counts[i].Load()
+		snap.Count += snap.Counts[i]
+	}
+	return snap
+}
+
+// Stats returns a snapshot of the pipeline counters. Child loggers report
+// the counters of the root client they share a queue with. The entry and
+// batch counters include level routes (see WithLevelRouting); the latency
+// and endpoint fields describe the client's own project only.
+func (c *Client) Stats() ClientStats {
+	root := c.root()
+	var throttled uint64
+	if root.limiter != nil {
+		throttled = root.limiter.suppressed.Load()
+	}
+	var deduplicated uint64
+	if root.deduper != nil {
+		deduplicated = root.deduper.collapsed.Load()
+	}
+	queued := root.queue.size()
+	if root.ring != nil {
+		queued += root.ring.len()
+	}
+	s := ClientStats{
+		Queued:           queued,
+		Dropped:          root.queue.dropped.Load(),
+		Throttled:        throttled,
+		Deduplicated:     deduplicated,
+		BatchesSent:      root.stats.batchesSent.Load(),
+		BatchesFailed:    root.stats.batchesFailed.Load(),
+		Retries:          root.transport.retries.Load(),
+		LastFlushLatency: time.Duration(root.stats.lastFlushLatency.Load()),
+		SendLatency:      root.stats.sendLatency.snapshot(),
+		ActiveEndpoint:   root.transport.activeEndpoint(),
+	}
+	for _, route := range root.routeClients() {
+		s.Queued += route.queue.size()
+		s.Dropped += route.queue.dropped.Load()
+		s.BatchesSent += route.stats.batchesSent.Load()
+		s.BatchesFailed += route.stats.batchesFailed.Load()
+		s.Retries += route.transport.retries.Load()
+	}
+	return s
+}
@@ -0,0 +1,112 @@
+package logwell
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientStatsCountsSendsAndRetries(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+
+	var requests atomic.Int32
+	ts.handler = func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"accepted":1}`))
+	}
+
+	client := createTestClient(t, ts, WithMaxRetries(2))
+	defer client.Shutdown(context.Background())
+
+	client.Info("hello")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	stats := client.Stats()
+	if stats.BatchesSent != 1 || stats.BatchesFailed != 0 {
+		t.Errorf("BatchesSent/Failed = %d/%d, want 1/0", stats.BatchesSent, stats.BatchesFailed)
+	}
+	if stats.Retries != 1 {
+		t.Errorf("Retries = %d, want 1", stats.Retries)
+	}
+	if stats.LastFlushLatency <= 0 {
+		t.Errorf("LastFlushLatency = %v, want > 0", stats.LastFlushLatency)
+	}
+	if stats.Queued != 0 {
+		t.Errorf("Queued = %d, want 0", stats.Queued)
+	}
+	if h := stats.SendLatency; h.Count != 1 || h.Sum != stats.LastFlushLatency || len(h.Counts) != len(h.Bounds)+1 {
+		t.Errorf("SendLatency = %+v, want one observation", h)
+	}
+}
+
+func TestLatencyHistogramBuckets(t *testing.T) {
+	var h latencyHistogram
+	h.observe(time.Millisecond)
+	h.observe(5 * time.Millisecond) // bounds are inclusive
+	h.observe(6 * time.Millisecond)
+	h.observe(time.Minute)
+
+	snap := h.snapshot()
+	if snap.Count != 4 || snap.Sum != time.Minute+12*time.Millisecond {
+		t.Errorf("Count/Sum = %d/%v", snap.Count, snap.Sum)
+	}
+	if snap.Counts[0] != 2 || snap.Counts[1] != 1 || snap.Counts[len(snap.Counts)-1] != 1 {
+		t.Errorf("Counts = %v", snap.Counts)
+	}
+	if len(snap.Counts) != len(LatencyBuckets())+1 {
+		t.Errorf("len(Counts) = %d, want one per bucket plus overflow", len(snap.Counts))
+	}
+
+	// Callers get a copy of the bounds.
+	LatencyBuckets()[0] = time.Hour
+	snap.Bounds[0] = time.Hour
+	if LatencyBuckets()[0] != 5*time.Millisecond {
+		t.Error("LatencyBuckets() exposed the histogram bounds")
+	}
+}
+
+func TestClientStatsCountsFailuresAndDrops(t *testing.T) {
+	ts := newTestServer()
+	defer ts.Close()
+	ts.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	client := createTestClient(t, ts,
+		WithMaxRetries(0),
+		WithBatchSize(10),
+		WithMaxQueueSize(2),
+		WithOverflowStrategy(DropNewest),
+	)
+	defer client.Shutdown(context.Background())
+
+	child := client.With(M{"k": "v"})
+	for range 5 {
+		child.Info("entry")
+	}
+	if err := client.Flush(context.Background()); err == nil {
+		t.Fatal("Flush() error = nil, want server error")
+	}
+
+	stats := child.Stats()
+	if stats.Dropped != 3 {
+		t.Errorf("Dropped = %d, want 3", stats.Dropped)
+	}
+	if stats.BatchesFailed != 1 || stats.BatchesSent != 0 {
+		t.Errorf("BatchesSent/Failed = %d/%d, want 0/1", stats.BatchesSent, stats.BatchesFailed)
+	}
+	if stats.Queued != 2 {
+		t.Errorf("Queued = %d, want the failed batch re-queued", stats.Queued)
+	}
+	if stats.LastFlushLatency != 0 {
+		t.Errorf("LastFlushLatency = %v, want 0 before any success", stats.LastFlushLatency)
+	}
+}
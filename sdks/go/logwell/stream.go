@@ -0,0 +1,330 @@
+package logwell
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Stream tuning. The server sends a heartbeat every 30s, so a connection
+// silent for streamIdleTimeout is treated as dead and reconnected.
+const (
+	streamBufferSize       = 256
+	streamIdleTimeout      = 90 * time.Second
+	streamMinReconnect     = 500 * time.Millisecond
+	streamMaxReconnect     = 30 * time.Second
+	streamMaxBackfill      = 1000
+	streamDedupeWindow     = 4096
+	streamMaxEventDataSize = 4 << 20
+)
+
+// StreamFilter selects which live logs Stream delivers. The server streams
+// every log in the project; the filter is applied on the client.
+type StreamFilter struct {
+	// Levels restricts delivery to these levels. Empty matches all levels.
+	Levels []LogLevel
+
+	// Service restricts delivery to one service name.
+	Service string
+
+	// Text restricts delivery to logs whose message contains Text
+	// (case-insensitive).
+	Text string
+}
+
+// matches reports whether rec passes the filter.
+func (f *StreamFilter) matches(rec *LogRecord) bool {
+	if len(f.Levels) > 0 && !slices.Contains(f.Levels, rec.Level) {
+		return false
+	}
+	if f.Service != "" && rec.Service != f.Service {
+		return false
+	}
+	if f.Text != "" && !strings.Contains(strings.ToLower(rec.Message), strings.ToLower(f.Text)) {
+		return false
+	}
+	return true
+}
+
+// StreamOption configures a Stream call.
+type StreamOption func(*streamConfig)
+
+type streamConfig struct {
+	onError func(error)
+}
+
+// WithStreamOnError sets a callback for errors that interrupt the stream.
+// Transient errors are followed by a reconnect; the final error, if the
+// stream gives up, is reported before the channel is closed.
+func WithStreamOnError(fn func(error)) StreamOption {
+	return func(c *streamConfig) {
+		c.onError = fn
+	}
+}
+
+// Stream tails the project's logs in real time, delivering each new log
+// that matches filter on the returned channel. The channel is closed when
+// ctx is done or the stream fails with a non-retryable error.
+//
+// Dropped connections are reconnected with exponential backoff. On
+// reconnect the logs received by the server while disconnected are
+// backfilled through Search (up to 1000, starting at the timestamp of the
+// last delivered log), and logs already delivered are skipped, so a brief
+// outage does not leave a gap. Delivery is best-effort: logs ingested with
+// timestamps older than the last delivered log are not backfilled.
+//
+// Stream returns an error if the first connection fails.
+//
+// Example:
+//
+//	logs, err := qc.Stream(ctx, logwell.StreamFilter{Levels: []logwell.LogLevel{logwell.LevelError}})
+//	if err != nil {
+//	    return err
+//	}
+//	for rec := range logs {
+//	    fmt.Println(rec.Timestamp.Format(time.TimeOnly), rec.Level, rec.Message)
+//	}
+func (q *QueryClient) Stream(ctx context.Context, filter StreamFilter, opts ...StreamOption) (<-chan LogRecord, error) {
+	var cfg streamConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := &logStream{
+		q:      q,
+		filter: filter,
+		cfg:    cfg,
+		out:    make(chan LogRecord, streamBufferSize),
+		seen:   make(map[string]struct{}, streamDedupeWindow),
+	}
+
+	// The stream is long-lived, so it must not inherit the client timeout;
+	// liveness is tracked with streamIdleTimeout instead.
+	hc := *q.session.httpClient
+	hc.Timeout = 0
+	s.httpClient = &hc
+
+	connCtx, cancel := context.WithCancel(ctx)
+	resp, err := s.connect(connCtx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go s.run(ctx, connCtx, cancel, resp)
+	return s.out, nil
+}
+
+// logStream holds the state of one Stream call.
+type logStream struct {
+	q          *QueryClient
+	httpClient *http.Client
+	filter     StreamFilter
+	cfg        streamConfig
+	out        chan LogRecord
+
+	// Resume state: the timestamp of the newest delivered log, and the IDs
+	// of recently delivered logs (a bounded window, oldest first) used to
+	// skip duplicates between backfill and the live stream.
+	last     time.Time
+	seen     map[string]struct{}
+	seenRing []string
+}
+
+// connect opens the SSE connection.
+func (s *logStream) connect(ctx context.Context) (*http.Response, error) {
+	return s.q.session.do(ctx, s.httpClient, http.MethodPost, projectsPath(s.q.projectID, "/logs/stream"), nil, nil)
+}
+
+// run reads the stream until ctx is done, reconnecting as needed.
+func (s *logStream) run(ctx, connCtx context.Context, cancel context.CancelFunc, resp *http.Response) {
+	defer close(s.out)
+
+	delay := streamMinReconnect
+	for {
+		received := s.read(connCtx, cancel, resp)
+		if ctx.Err() != nil {
+			return
+		}
+		if received {
+			delay = streamMinReconnect
+		}
+		s.reportError(NewError(ErrNetworkError, "stream disconnected"))
+
+		for {
+			if !sleepCtx(ctx, delay) {
+				return
+			}
+			delay = min(delay*2, streamMaxReconnect)
+
+			connCtx, cancel = context.WithCancel(ctx)
+			var err error
+			resp, err = s.connect(connCtx)
+			if err == nil {
+				break
+			}
+			cancel()
+			s.reportError(err)
+			if !streamRetryable(err) {
+				return
+			}
+		}
+
+		if !s.backfill(ctx) {
+			cancel()
+			return
+		}
+	}
+}
+
+// read consumes SSE events from resp until the connection ends. It reports
+// whether any event arrived, and always cancels the connection context.
+func (s *logStream) read(ctx context.Context, cancel context.CancelFunc, resp *http.Response) bool {
+	defer cancel()
+	defer func() { _ = resp.Body.Close() }()
+
+	idle := time.AfterFunc(streamIdleTimeout, cancel)
+	defer idle.Stop()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64<<10), streamMaxEventDataSize)
+
+	received := false
+	var event string
+	var data strings.Builder
+	for scanner.Scan() {
+		idle.Reset(streamIdleTimeout)
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if event == "logs" {
+				received = true
+				var batch []LogRecord
+				if err := json.Unmarshal([]byte(data.String()), &batch); err != nil {
+					s.reportError(NewErrorWithCause(ErrServerError, "failed to parse stream event", err))
+				} else if !s.deliver(ctx, batch) {
+					return received
+				}
+			} else if event != "" {
+				received = true
+			}
+			event = ""
+			data.Reset()
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	return received
+}
+
+// backfill delivers logs stored since the last delivered log. It returns
+// false if ctx was cancelled.
+func (s *logStream) backfill(ctx context.Context) bool {
+	if s.last.IsZero() {
+		return true
+	}
+
+	query := Query{
+		Levels:    s.filter.Levels,
+		TimeRange: TimeRange{From: s.last},
+		Limit:     MaxSearchLimit,
+	}
+	var missed []LogRecord
+	for rec, err := range s.q.SearchAll(ctx, query) {
+		if err != nil {
+			s.reportError(err)
+			break
+		}
+		missed = append(missed, rec)
+		if len(missed) >= streamMaxBackfill {
+			break
+		}
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+
+	// Search returns newest first; deliver in arrival order.
+	slices.Reverse(missed)
+	return s.deliver(ctx, missed)
+}
+
+// deliver sends the matching, not yet delivered records in batch to the
+// output channel. It returns false if ctx was cancelled.
+func (s *logStream) deliver(ctx context.Context, batch []LogRecord) bool {
+	for i := range batch {
+		rec := &batch[i]
+		if _, dup := s.seen[rec.ID]; dup {
+			continue
+		}
+		s.markSeen(rec)
+		if !s.filter.matches(rec) {
+			continue
+		}
+		select {
+		case s.out <- *rec:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// markSeen records rec in the resume state.
+func (s *logStream) markSeen(rec *LogRecord) {
+	if rec.Timestamp.After(s.last) {
+		s.last = rec.Timestamp
+	}
+	if rec.ID == "" {
+		return
+	}
+	if len(s.seenRing) >= streamDedupeWindow {
+		delete(s.seen, s.seenRing[0])
+		s.seenRing = s.seenRing[1:]
+	}
+	s.seen[rec.ID] = struct{}{}
+	s.seenRing = append(s.seenRing, rec.ID)
+}
+
+// reportError passes err to the OnError callback, if set.
+func (s *logStream) reportError(err error) {
+	if s.cfg.onError != nil {
+		s.cfg.onError(err)
+	}
+}
+
+// streamRetryable reports whether a connection error is worth retrying.
+// Client errors other than 429 (bad session, missing project) are final.
+func streamRetryable(err error) bool {
+	lwErr, ok := err.(*Error)
+	if !ok {
+		return true
+	}
+	if lwErr.StatusCode >= 400 && lwErr.StatusCode < 500 && lwErr.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	return lwErr.Retryable
+}
+
+// sleepCtx waits for d or until ctx is done, reporting whether the full
+// duration elapsed.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
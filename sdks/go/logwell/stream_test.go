@@ -0,0 +1,230 @@
+package logwell
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// sseLog renders a log object as it appears in a stream or search response.
+func sseLog(id, level, service, message, ts string) string {
+	return fmt.Sprintf(`{"id":%q,"projectId":"proj_123","level":%q,"serviceName":%q,"message":%q,"timestamp":%q}`,
+		id, level, service, message, ts)
+}
+
+// writeSSE writes one SSE event and flushes it.
+func writeSSE(w http.ResponseWriter, event, data string) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	w.(http.Flusher).Flush()
+}
+
+// collect reads n records from ch, failing the test on timeout.
+func collect(t *testing.T, ch <-chan LogRecord, n int) []LogRecord {
+	t.Helper()
+	var got []LogRecord
+	timeout := time.After(5 * time.Second)
+	for len(got) < n {
+		select {
+		case rec, ok := <-ch:
+			if !ok {
+				t.Fatalf("channel closed after %d records, want %d", len(got), n)
+			}
+			got = append(got, rec)
+		case <-timeout:
+			t.Fatalf("timed out after %d records, want %d", len(got), n)
+		}
+	}
+	return got
+}
+
+func recordIDs(recs []LogRecord) string {
+	ids := make([]string, len(recs))
+	for i, r := range recs {
+		ids[i] = r.ID
+	}
+	return strings.Join(ids, ",")
+}
+
+func TestStreamDeliversFilteredLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/projects/proj_123/logs/stream" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if origin := r.Header.Get("Origin"); !strings.HasPrefix(origin, "http://127.0.0.1") {
+			t.Errorf("Origin = %q, want the endpoint origin", origin)
+		}
+		if c, err := r.Cookie("better-auth.session_token"); err != nil || c.Value != "session-abc" {
+			t.Errorf("session cookie = %v, %v", c, err)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "heartbeat", `{"ts":1}`)
+		writeSSE(w, "logs", "["+
+			sseLog("1", "error", "api", "Payment FAILED", "2025-01-01T00:00:01Z")+","+
+			sseLog("2", "info", "api", "payment ok", "2025-01-01T00:00:02Z")+","+
+			sseLog("3", "error", "worker", "payment failed", "2025-01-01T00:00:03Z")+"]")
+		writeSSE(w, "logs", "["+sseLog("4", "error", "api", "payment failed again", "2025-01-01T00:00:04Z")+"]")
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logs, err := newQueryTestClient(t, server).Stream(ctx, StreamFilter{
+		Levels:  []LogLevel{LevelError},
+		Service: "api",
+		Text:    "payment failed",
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	got := collect(t, logs, 2)
+	if ids := recordIDs(got); ids != "1,4" {
+		t.Errorf("ids = %s, want 1,4", ids)
+	}
+
+	cancel()
+	for range logs {
+	}
+}
+
+func TestStreamReconnectsAndBackfills(t *testing.T) {
+	var connects atomic.Int32
+	var mu sync.Mutex
+	var searchFrom string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/projects/proj_123/logs/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+			if connects.Add(1) == 1 {
+				// First connection delivers one log, then drops.
+				writeSSE(w, "logs", "["+sseLog("1", "info", "api", "one", "2025-01-01T00:00:01Z")+"]")
+				return
+			}
+			// Log 3 was also returned by the backfill and must not repeat.
+			writeSSE(w, "logs", "["+
+				sseLog("3", "info", "api", "three", "2025-01-01T00:00:03Z")+","+
+				sseLog("4", "info", "api", "four", "2025-01-01T00:00:04Z")+"]")
+			<-r.Context().Done()
+		case "/api/projects/proj_123/logs":
+			mu.Lock()
+			searchFrom = r.URL.Query().Get("from")
+			mu.Unlock()
+			w.Write([]byte(`{"logs":[` +
+				sseLog("3", "info", "api", "three", "2025-01-01T00:00:03Z") + "," +
+				sseLog("2", "info", "api", "two", "2025-01-01T00:00:02Z") + "," +
+				sseLog("1", "info", "api", "one", "2025-01-01T00:00:01Z") +
+				`],"has_more":false,"nextCursor":null}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var streamErrs atomic.Int32
+	logs, err := newQueryTestClient(t, server).Stream(ctx, StreamFilter{},
+		WithStreamOnError(func(error) { streamErrs.Add(1) }))
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	got := collect(t, logs, 4)
+	if ids := recordIDs(got); ids != "1,2,3,4" {
+		t.Errorf("ids = %s, want 1,2,3,4", ids)
+	}
+
+	mu.Lock()
+	if searchFrom != "2025-01-01T00:00:01Z" {
+		t.Errorf("backfill from = %q, want the last delivered timestamp", searchFrom)
+	}
+	mu.Unlock()
+	if connects.Load() != 2 {
+		t.Errorf("connects = %d, want 2", connects.Load())
+	}
+	if streamErrs.Load() == 0 {
+		t.Error("OnError was not told about the disconnect")
+	}
+}
+
+func TestStreamInitialConnectError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message":"Unauthorized"}`))
+	}))
+	defer server.Close()
+
+	_, err := newQueryTestClient(t, server).Stream(context.Background(), StreamFilter{})
+	lwErr, ok := err.(*Error)
+	if !ok || lwErr.Code != ErrUnauthorized {
+		t.Fatalf("error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestStreamStopsOnPermanentReconnectError(t *testing.T) {
+	var connects atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if connects.Add(1) > 1 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "heartbeat", `{"ts":1}`)
+	}))
+	defer server.Close()
+
+	var lastErr atomic.Value
+	logs, err := newQueryTestClient(t, server).Stream(context.Background(), StreamFilter{},
+		WithStreamOnError(func(err error) { lastErr.Store(err) }))
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	select {
+	case _, ok := <-logs:
+		if ok {
+			t.Fatal("received a record, want the channel closed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("channel not closed after a 403 on reconnect")
+	}
+
+	lwErr, ok := lastErr.Load().(*Error)
+	if !ok || lwErr.StatusCode != http.StatusForbidden {
+		t.Errorf("last error = %v, want the 403", lastErr.Load())
+	}
+}
+
+func TestStreamFilterMatches(t *testing.T) {
+	rec := LogRecord{Level: LevelWarn, Service: "api", Message: "Disk Almost Full"}
+	tests := []struct {
+		name   string
+		filter StreamFilter
+		want   bool
+	}{
+		{"empty", StreamFilter{}, true},
+		{"level match", StreamFilter{Levels: []LogLevel{LevelWarn, LevelError}}, true},
+		{"level mismatch", StreamFilter{Levels: []LogLevel{LevelError}}, false},
+		{"service mismatch", StreamFilter{Service: "worker"}, false},
+		{"text case-insensitive", StreamFilter{Text: "almost full"}, true},
+		{"text mismatch", StreamFilter{Text: "memory"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(&rec); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
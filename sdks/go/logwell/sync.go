@@ -0,0 +1,116 @@
+package logwell
+
+import (
+	"context"
+	"fmt"
+)
+
+// admitSync sends entry on the caller's goroutine (see WithSyncMode),
+// bounded by SyncTimeout. Entries queued earlier, such as ones a previous
+// sync send failed to deliver, are sent first in the same batch so order
+// is kept. On failure sendBatch re-queues the batch for the next Flush or
+// Shutdown and reports the error to OnError. Must be called on the root
+// client.
+func (c *Client) admitSync(entry *LogEntry) {
+	if c.closed.Load() {
+		return
+	}
+	batch := c.queue.take(c.config.BatchSize - 1)
+	batch = append(batch, *entry)
+
+	ctx, cancel := context.WithTimeout(c.inflightCtx, c.config.SyncTimeout)
+	defer cancel()
+	_ = c.sendBatch(ctx, batch)
+	putBatch(batch)
+}
+
+// LogSync sends one entry and blocks until the server accepts it or ctx is
+// done, for audit and compliance logs that must never be dropped. It
+// bypasses the queue: the entry is not subject to the minimum level, the
+// sampler, the dedupe window, the rate limit, queue overflow, or the
+// circuit breaker, and is sent on its own rather than batched. Processors,
+// redaction, size limits, level routing, and the local sink still apply.
+//
+// Failed attempts are retried with backoff, under the same idempotency
+// key, until the entry is accepted or ctx is done, so pass a context with
+// a deadline. Errors that retrying cannot fix, such as ErrUnauthorized or
+// ErrValidationError, are returned at once. The returned response is the
+// server's, including the entry's ID in IngestResponse.IDs when the server
+// reports it. If a processor drops the entry, LogSync returns a nil
+// response and a nil error without sending anything.
+//
+// Logging at LevelFatal does not run the fatal behavior. Returns
+// ErrClientClosed after Shutdown.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+//	defer cancel()
+//	if _, err := client.LogSync(ctx, logwell.LevelInfo, "role granted", logwell.M{"user": id}); err != nil {
+//		return fmt.Errorf("audit log: %w", err)
+//	}
+func (c *Client) LogSync(ctx context.Context, level LogLevel, message string, metadata ...map[string]any) (*IngestResponse, error) {
+	if c.isClosed() {
+		return nil, ErrClientClosed
+	}
+	if level.severity() < 0 {
+		return nil, NewError(ErrValidationError, fmt.Sprintf("invalid level %q", level))
+	}
+
+	entry := LogEntry{
+		Level:    level,
+		Message:  message,
+		Service:  c.config.Service,
+		Metadata: buildMetadata(c.config.Metadata, metadata),
+	}
+	root := c.root()
+	// Skip 2 frames: captureSource -> LogSync.
+	skip := 2 + c.config.CallerSkip
+	if c.config.CaptureSourceLocation {
+		entry.SourceFile, entry.LineNumber = captureSource(skip)
+	}
+	if c.wantsStack(level) {
+		entry.Metadata = withStack(entry.Metadata, captureStack(skip))
+	}
+
+	if !c.prepare(&entry) {
+		return nil, nil
+	}
+	if root.config.SequenceNumbers {
+		entry.Seq = root.seq.Add(1)
+	}
+	root.writeSink(&entry)
+
+	target := c
+	if route := root.routes[level]; route != nil {
+		target = route
+	}
+	return target.sendUntilAccepted(ctx, []LogEntry{entry})
+}
+
+// sendUntilAccepted sends batch with retry, and retries again after each
+// retryable failure, until the server accepts it or ctx is done.
+func (c *Client) sendUntilAccepted(ctx context.Context, batch []LogEntry) (*IngestResponse, error) {
+	key := newIdempotencyKey()
+	ctx = withIdempotencyKey(ctx, key)
+	transport, clock := c.root().transport, c.root().clock
+	start := clock.Now()
+	for {
+		resp, err := transport.sendWithRetry(ctx, batch)
+		if err == nil {
+			c.batchSent(batch, key, resp, clock.Now().Sub(start))
+			return resp, nil
+		}
+		retry := transport.isRetryableError(err) && ctx.Err() == nil
+		if retry {
+			delay := max(transport.calculateBackoff(transport.maxRetries+1), transport.retryAfterFloor(err))
+			c.debug("logwell: sync send failed, retrying", "delay", delay, "error", err)
+			retry = sleep(ctx, transport.clock, delay) == nil
+		}
+		if !retry {
+			c.root().stats.batchesFailed.Add(1)
+			c.reportError(err)
+			return nil, err
+		}
+	}
+}
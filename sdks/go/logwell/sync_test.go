@@ -0,0 +1,209 @@
+package logwell
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSyncMode tests that entries are delivered before the log call
+// returns.
+func TestSyncMode(t *testing.T) {
+	var batches [][]string
+	client, err := New(validEndpoint(), validAPIKey(), WithSyncMode(),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			var messages []string
+			for _, l := range logs {
+				messages = append(messages, l.Message)
+			}
+			batches = append(batches, messages)
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("first")
+	if len(batches) != 1 {
+		t.Fatalf("batches after Info = %d, want 1 sent synchronously", len(batches))
+	}
+	client.With(M{"k": "v"}).Warn("second")
+	if want := [][]string{{"first"}, {"second"}}; !slices.EqualFunc(batches, want, slices.Equal) {
+		t.Errorf("batches = %v, want %v", batches, want)
+	}
+	if stats := client.Stats(); stats.BatchesSent != 2 || stats.Queued != 0 {
+		t.Errorf("BatchesSent/Queued = %d/%d, want 2/0", stats.BatchesSent, stats.Queued)
+	}
+}
+
+// TestSyncModeFailure tests that an entry whose send fails stays queued
+// and goes out, ahead of later entries, with the next send.
+func TestSyncModeFailure(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		fail     = true
+		received []string
+		reported []*Error
+	)
+	client, err := New(validEndpoint(), validAPIKey(), WithSyncMode(), WithMaxRetries(0),
+		WithOnError(func(err *Error) { reported = append(reported, err) }),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if fail {
+				return nil, NewError(ErrServerError, "unavailable")
+			}
+			for _, l := range logs {
+				received = append(received, l.Message)
+			}
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("lost")
+	if len(reported) != 1 || !errors.Is(reported[0], ErrServerErr) {
+		t.Fatalf("reported = %v, want one ErrServerError", reported)
+	}
+	if queued := client.Stats().Queued; queued != 1 {
+		t.Fatalf("Queued = %d, want 1 after a failed send", queued)
+	}
+
+	mu.Lock()
+	fail = false
+	mu.Unlock()
+	client.Info("next")
+	if want := []string{"lost", "next"}; !slices.Equal(received, want) {
+		t.Errorf("received = %v, want %v", received, want)
+	}
+}
+
+// TestLogSync tests that LogSync sends one entry immediately, skipping the
+// lossy parts of the pipeline, and returns the server's response.
+func TestLogSync(t *testing.T) {
+	var sent []LogEntry
+	client, err := New(validEndpoint(), validAPIKey(), WithService("api"),
+		WithMinLevel(LevelError), WithSampler(FractionSampler(0)),
+		WithProcessor(func(e *LogEntry) bool { return e.Message != "skip" }),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			sent = append(sent, logs...)
+			return &IngestResponse{Accepted: len(logs), Errors: []string{"note"}}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("queued")
+	resp, err := client.With(M{"k": "v"}).LogSync(context.Background(), LevelInfo, "audit", M{"user": "u1"})
+	if err != nil {
+		t.Fatalf("LogSync() error = %v", err)
+	}
+	if resp.Accepted != 1 || len(resp.Errors) != 1 {
+		t.Errorf("LogSync() response = %+v, want the server's", resp)
+	}
+	if len(sent) != 1 {
+		t.Fatalf("sent %d entries, want 1", len(sent))
+	}
+	got := sent[0]
+	if got.Message != "audit" || got.Service != "api" || got.Metadata["k"] != "v" || got.Metadata["user"] != "u1" || got.Timestamp == "" {
+		t.Errorf("sent entry = %+v", got)
+	}
+
+	if resp, err := client.LogSync(context.Background(), LevelInfo, "skip"); resp != nil || err != nil || len(sent) != 1 {
+		t.Errorf("LogSync() of a processor-dropped entry = %v, %v; sent %d", resp, err, len(sent))
+	}
+	if _, err := client.LogSync(context.Background(), "trace", "bad level"); err == nil {
+		t.Error("LogSync() with an invalid level error = nil")
+	}
+	if stats := client.Stats(); stats.BatchesSent != 1 {
+		t.Errorf("BatchesSent = %d, want 1", stats.BatchesSent)
+	}
+}
+
+// TestLogSyncRetriesUntilAccepted tests that LogSync keeps retrying past
+// MaxRetries, under one idempotency key.
+func TestLogSyncRetriesUntilAccepted(t *testing.T) {
+	var keys []string
+	client, err := New(validEndpoint(), validAPIKey(), WithMaxRetries(1),
+		WithBackoff(ConstantBackoff(time.Millisecond)),
+		WithTransport(transportFunc(func(ctx context.Context, logs []LogEntry) (*IngestResponse, error) {
+			key, _ := IdempotencyKeyFromContext(ctx)
+			keys = append(keys, key)
+			if len(keys) < 5 {
+				return nil, NewError(ErrServerError, "unavailable")
+			}
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	if _, err := client.LogSync(context.Background(), LevelWarn, "must arrive"); err != nil {
+		t.Fatalf("LogSync() error = %v", err)
+	}
+	if len(keys) != 5 || keys[0] == "" || slices.IndexFunc(keys, func(k string) bool { return k != keys[0] }) >= 0 {
+		t.Errorf("attempt keys = %v, want 5 attempts sharing one key", keys)
+	}
+}
+
+// TestLogSyncFailure tests that LogSync gives up on errors retrying cannot
+// fix, when its context is done, and after Shutdown.
+func TestLogSyncFailure(t *testing.T) {
+	var code ErrorCode
+	var attempts int
+	client, err := New(validEndpoint(), validAPIKey(), WithMaxRetries(0),
+		WithBackoff(ConstantBackoff(time.Millisecond)),
+		WithTransport(transportFunc(func(context.Context, []LogEntry) (*IngestResponse, error) {
+			attempts++
+			return nil, NewError(code, "failed")
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	code = ErrUnauthorized
+	_, err = client.LogSync(context.Background(), LevelInfo, "denied")
+	assertConfigError(t, err, ErrUnauthorized)
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1 for a non-retryable error", attempts)
+	}
+
+	code = ErrServerError
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.LogSync(ctx, LevelInfo, "outage"); err == nil {
+		t.Error("LogSync() error = nil, want failure once the context expires")
+	}
+	if stats := client.Stats(); stats.BatchesFailed != 2 || stats.Queued != 0 {
+		t.Errorf("BatchesFailed/Queued = %d/%d, want 2/0", stats.BatchesFailed, stats.Queued)
+	}
+
+	client.Shutdown(context.Background())
+	if _, err := client.LogSync(context.Background(), LevelInfo, "late"); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("LogSync() after Shutdown error = %v, want ErrClientClosed", err)
+	}
+}
+
+func TestConfigValidateSyncMode(t *testing.T) {
+	cfg := newDefaultConfig(validEndpoint(), validAPIKey())
+	WithSyncMode()(cfg)
+	if err := validateConfig(cfg); err != nil {
+		t.Errorf("validateConfig() error = %v, want nil", err)
+	}
+
+	WithSyncTimeout(0)(cfg)
+	assertConfigError(t, validateConfig(cfg), ErrInvalidConfig)
+
+	cfg = newDefaultConfig(validEndpoint(), validAPIKey())
+	WithSyncMode()(cfg)
+	WithPersistentQueue(t.TempDir(), MinPersistentQueueBytes)(cfg)
+	assertConfigError(t, validateConfig(cfg), ErrInvalidConfig)
+}
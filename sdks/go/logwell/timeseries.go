@@ -0,0 +1,184 @@
+package logwell
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MaxTimeseriesBuckets caps the number of buckets in one Timeseries call.
+const MaxTimeseriesBuckets = 500
+
+// timeseriesConcurrency bounds the bucket requests in flight at once.
+const timeseriesConcurrency = 4
+
+// LogStats summarizes the logs in a time range.
+type LogStats struct {
+	// TotalLogs is the number of logs in the range.
+	TotalLogs int `json:"totalLogs"`
+
+	// LevelCounts is the number of logs per level. Levels with no logs
+	// are absent.
+	LevelCounts map[LogLevel]int `json:"levelCounts"`
+
+	// LevelPercentages is each level's share of TotalLogs, in percent
+	// rounded to two decimals.
+	LevelPercentages map[LogLevel]float64 `json:"levelPercentages"`
+}
+
+// Stats returns the level distribution of the logs in r. A zero r covers
+// all stored logs.
+func (q *QueryClient) Stats(ctx context.Context, r TimeRange) (*LogStats, error) {
+	return fetchStats(ctx, q.session, q.projectID, r)
+}
+
+// fetchStats requests the level distribution of projectID's logs in r.
+func fetchStats(ctx context.Context, session *sessionClient, projectID string, r TimeRange) (*LogStats, error) {
+	params := url.Values{}
+	if !r.From.IsZero() {
+		params.Set("from", r.From.UTC().Format(time.RFC3339Nano))
+	}
+	if !r.To.IsZero() {
+		params.Set("to", r.To.UTC().Format(time.RFC3339Nano))
+	}
+
+	var stats LogStats
+	if err := session.doJSON(ctx, http.MethodGet, projectsPath(projectID, "/stats"), params, nil, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// TimeseriesFilter selects the logs counted by Timeseries.
+type TimeseriesFilter struct {
+	// TimeRange is the window to chart. A zero To means now; a zero From
+	// means 24 hours before To.
+	TimeRange TimeRange
+
+	// Levels restricts the counts to these levels. Empty counts all levels.
+	Levels []LogLevel
+}
+
+// TimeseriesBucket holds the log counts of one interval.
+type TimeseriesBucket struct {
+	// Start is the beginning of the interval; it spans [Start, Start+bucket).
+	Start time.Time
+
+	// Count is the number of logs in the interval.
+	Count int
+
+	// Levels is the number of logs per level. Levels with no logs are absent.
+	Levels map[LogLevel]int
+}
+
+// Timeseries is log volume over time.
+type Timeseries struct {
+	// Bucket is the interval width.
+	Bucket time.Duration
+
+	// Buckets holds one entry per interval, oldest first. Intervals with
+	// no logs are included with zero counts.
+	Buckets []TimeseriesBucket
+
+	// Total is the sum of all bucket counts.
+	Total int
+}
+
+// Timeseries returns log counts per level for each bucket-sized interval of
+// filter.TimeRange, for charting log volume or spotting anomalies.
+//
+// Each bucket is fetched from the stats endpoint, up to four at a time, so
+// keep the number of buckets modest; more than MaxTimeseriesBuckets is
+// rejected. The last bucket is truncated at the end of the range.
+//
+// Example:
+//
+//	ts, err := qc.Timeseries(ctx, 5*time.Minute, logwell.TimeseriesFilter{
+//	    TimeRange: logwell.TimeRange{From: time.Now().Add(-time.Hour)},
+//	    Levels:    []logwell.LogLevel{logwell.LevelError, logwell.LevelFatal},
+//	})
+func (q *QueryClient) Timeseries(ctx context.Context, bucket time.Duration, filter TimeseriesFilter) (*Timeseries, error) {
+	if bucket < time.Millisecond {
+		return nil, NewError(ErrInvalidConfig, "timeseries bucket must be at least 1ms")
+	}
+
+	end := filter.TimeRange.To
+	if end.IsZero() {
+		end = time.Now()
+	}
+	start := filter.TimeRange.From
+	if start.IsZero() {
+		start = end.Add(-24 * time.Hour)
+	}
+	if !start.Before(end) {
+		return nil, NewError(ErrInvalidConfig, "timeseries range start must be before its end")
+	}
+
+	n := int((end.Sub(start) + bucket - 1) / bucket)
+	if n > MaxTimeseriesBuckets {
+		return nil, NewError(ErrInvalidConfig, "timeseries range spans too many buckets (max 500)")
+	}
+
+	ts := &Timeseries{Bucket: bucket, Buckets: make([]TimeseriesBucket, n)}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		sem      = make(chan struct{}, timeseriesConcurrency)
+	)
+	for i := range n {
+		from := start.Add(time.Duration(i) * bucket)
+		// The stats endpoint's upper bound is inclusive and timestamps are
+		// stored with millisecond precision.
+		to := from.Add(bucket)
+		if to.After(end) {
+			to = end
+		}
+		to = to.Add(-time.Millisecond)
+		ts.Buckets[i].Start = from
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			stats, err := q.Stats(ctx, TimeRange{From: from, To: to})
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+				return
+			}
+
+			b := &ts.Buckets[i]
+			b.Levels = make(map[LogLevel]int, len(stats.LevelCounts))
+			for level, count := range stats.LevelCounts {
+				if len(filter.Levels) > 0 && !slices.Contains(filter.Levels, level) {
+					continue
+				}
+				b.Levels[level] = count
+				b.Count += count
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	for _, b := range ts.Buckets {
+		ts.Total += b.Count
+	}
+	return ts, nil
+}
@@ -0,0 +1,138 @@
+package logwell
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestQueryClientStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/projects/proj_123/stats" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("from"); got != "2025-01-01T00:00:00Z" {
+			t.Errorf("from = %q", got)
+		}
+		if r.URL.Query().Has("to") {
+			t.Error("to should be omitted for an open range")
+		}
+		w.Write([]byte(`{"totalLogs":4,"levelCounts":{"info":3,"error":1},"levelPercentages":{"info":75,"error":25}}`))
+	}))
+	defer server.Close()
+
+	stats, err := newQueryTestClient(t, server).Stats(context.Background(), TimeRange{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.TotalLogs != 4 || stats.LevelCounts[LevelInfo] != 3 || stats.LevelPercentages[LevelError] != 25 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestQueryClientTimeseries(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		from, _ := time.Parse(time.RFC3339Nano, r.URL.Query().Get("from"))
+		to, _ := time.Parse(time.RFC3339Nano, r.URL.Query().Get("to"))
+		if want := from.Add(10*time.Minute - time.Millisecond); !to.Equal(want) && !to.Equal(start.Add(25*time.Minute-time.Millisecond)) {
+			t.Errorf("bucket %v..%v does not end 1ms before the next bucket", from, to)
+		}
+		// Bucket i has i+1 info logs and, from the second bucket on, one error.
+		i := int(from.Sub(start) / (10 * time.Minute))
+		body := fmt.Sprintf(`{"totalLogs":%d,"levelCounts":{"info":%d`, i+1, i+1)
+		if i > 0 {
+			body += `,"error":1`
+		}
+		w.Write([]byte(body + "}}"))
+	}))
+	defer server.Close()
+
+	ts, err := newQueryTestClient(t, server).Timeseries(context.Background(), 10*time.Minute, TimeseriesFilter{
+		TimeRange: TimeRange{From: start, To: start.Add(25 * time.Minute)},
+	})
+	if err != nil {
+		t.Fatalf("Timeseries() error = %v", err)
+	}
+
+	if len(ts.Buckets) != 3 {
+		t.Fatalf("len(Buckets) = %d, want 3 (last one truncated)", len(ts.Buckets))
+	}
+	for i, b := range ts.Buckets {
+		if !b.Start.Equal(start.Add(time.Duration(i) * 10 * time.Minute)) {
+			t.Errorf("Buckets[%d].Start = %v", i, b.Start)
+		}
+	}
+	if b := ts.Buckets[1]; b.Count != 3 || b.Levels[LevelInfo] != 2 || b.Levels[LevelError] != 1 {
+		t.Errorf("Buckets[1] = %+v, want 2 info + 1 error", b)
+	}
+	if ts.Total != 1+3+4 {
+		t.Errorf("Total = %d, want 8", ts.Total)
+	}
+}
+
+func TestQueryClientTimeseriesLevelFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"totalLogs":6,"levelCounts":{"info":5,"error":1}}`))
+	}))
+	defer server.Close()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts, err := newQueryTestClient(t, server).Timeseries(context.Background(), time.Hour, TimeseriesFilter{
+		TimeRange: TimeRange{From: start, To: start.Add(2 * time.Hour)},
+		Levels:    []LogLevel{LevelError},
+	})
+	if err != nil {
+		t.Fatalf("Timeseries() error = %v", err)
+	}
+	if ts.Total != 2 || ts.Buckets[0].Levels[LevelInfo] != 0 {
+		t.Errorf("Timeseries = %+v, want only error counts", ts)
+	}
+}
+
+func TestQueryClientTimeseriesValidation(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		bucket time.Duration
+		r      TimeRange
+	}{
+		{"zero bucket", 0, TimeRange{From: start, To: start.Add(time.Hour)}},
+		{"inverted range", time.Minute, TimeRange{From: start.Add(time.Hour), To: start}},
+		{"too many buckets", time.Second, TimeRange{From: start, To: start.Add(time.Hour)}},
+	}
+	qc, _ := NewQueryClient(validEndpoint(), testProjectID, WithSessionToken("s"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := qc.Timeseries(context.Background(), tt.bucket, TimeseriesFilter{TimeRange: tt.r})
+			assertConfigError(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestQueryClientTimeseriesError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"totalLogs":0,"levelCounts":{}}`))
+	}))
+	defer server.Close()
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := newQueryTestClient(t, server).Timeseries(context.Background(), time.Minute, TimeseriesFilter{
+		TimeRange: TimeRange{From: start, To: start.Add(10 * time.Minute)},
+	})
+	if lwErr, ok := err.(*Error); !ok || lwErr.Code != ErrServerError {
+		t.Fatalf("error = %v, want ErrServerError", err)
+	}
+}
@@ -0,0 +1,109 @@
+package logwell
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// TimestampFormat selects how generated entry timestamps are written; see
+// WithTimestampFormat. Timestamps set by the caller are sent unchanged.
+type TimestampFormat int
+
+const (
+	// TimestampRFC3339Nano writes UTC RFC 3339 with nanoseconds, the
+	// default: "2024-01-15T10:30:00.123456789Z".
+	TimestampRFC3339Nano TimestampFormat = iota
+
+	// TimestampRFC3339Millis writes UTC RFC 3339 with milliseconds, the
+	// precision the Logwell server stores: "2024-01-15T10:30:00.123Z".
+	// Timestamps are formatted once per millisecond, which keeps the log
+	// path free of allocations.
+	TimestampRFC3339Millis
+
+	// TimestampUnixMillis writes milliseconds since the Unix epoch:
+	// "1705314600123". The Logwell ingest API does not parse it; use it
+	// with a custom Transport.
+	TimestampUnixMillis
+
+	// TimestampUnixNanos writes nanoseconds since the Unix epoch:
+	// "1705314600123456789". Like TimestampUnixMillis, it requires a
+	// custom Transport.
+	TimestampUnixNanos
+)
+
+// rfc3339Millis is the layout of TimestampRFC3339Millis.
+const rfc3339Millis = "2006-01-02T15:04:05.000Z07:00"
+
+// String returns the format's name, such as "rfc3339millis".
+func (f TimestampFormat) String() string {
+	switch f {
+	case TimestampRFC3339Nano:
+		return "rfc3339nano"
+	case TimestampRFC3339Millis:
+		return "rfc3339millis"
+	case TimestampUnixMillis:
+		return "unixmillis"
+	case TimestampUnixNanos:
+		return "unixnanos"
+	default:
+		return "TimestampFormat(" + strconv.Itoa(int(f)) + ")"
+	}
+}
+
+// valid reports whether f is one of the defined formats.
+func (f TimestampFormat) valid() bool {
+	return f >= TimestampRFC3339Nano && f <= TimestampUnixNanos
+}
+
+// timestamper generates entry timestamps in one format. Millisecond
+// formats cache the last timestamp, so entries logged within the same
+// millisecond share one string instead of each formatting their own.
+type timestamper struct {
+	format TimestampFormat
+	now    func() time.Time
+	last   atomic.Pointer[cachedTimestamp]
+}
+
+// cachedTimestamp is a formatted timestamp and the millisecond it names.
+type cachedTimestamp struct {
+	unixMilli int64
+	formatted string
+}
+
+// at returns t in the timestamper's format, bypassing the cache.
+func (ts *timestamper) at(t time.Time) string {
+	switch ts.format {
+	case TimestampRFC3339Millis:
+		return t.UTC().Format(rfc3339Millis)
+	case TimestampUnixNanos:
+		return strconv.FormatInt(t.UnixNano(), 10)
+	case TimestampUnixMillis:
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// timestamp returns the current time in the timestamper's format.
+func (ts *timestamper) timestamp() string {
+	t := ts.now()
+	switch ts.format {
+	case TimestampRFC3339Nano:
+		return t.UTC().Format(time.RFC3339Nano)
+	case TimestampUnixNanos:
+		return strconv.FormatInt(t.UnixNano(), 10)
+	}
+
+	ms := t.UnixMilli()
+	if cached := ts.last.Load(); cached != nil && cached.unixMilli == ms {
+		return cached.formatted
+	}
+	var formatted string
+	if ts.format == TimestampUnixMillis {
+		formatted = strconv.FormatInt(ms, 10)
+	} else {
+		formatted = time.UnixMilli(ms).UTC().Format(rfc3339Millis)
+	}
+	ts.last.Store(&cachedTimestamp{unixMilli: ms, formatted: formatted})
+	return formatted
+}
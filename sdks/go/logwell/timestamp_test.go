@@ -0,0 +1,81 @@
+package logwell
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTimestamper(t *testing.T) {
+	tests := []struct {
+		format TimestampFormat
+		want   *regexp.Regexp
+	}{
+		{TimestampRFC3339Millis, regexp.MustCompile(`^\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{3}Z$`)},
+		{TimestampRFC3339Nano, regexp.MustCompile(`^\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d(\.\d{1,9})?Z$`)},
+		{TimestampUnixMillis, regexp.MustCompile(`^\d{13}$`)},
+		{TimestampUnixNanos, regexp.MustCompile(`^\d{19}$`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			ts := &timestamper{format: tt.format, now: time.Now}
+			if got := ts.timestamp(); !tt.want.MatchString(got) {
+				t.Errorf("now() = %q, want match for %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimestamperMillisIsCurrent(t *testing.T) {
+	ts := &timestamper{format: TimestampUnixMillis, now: time.Now}
+	before := time.Now().UnixMilli()
+	got, err := strconv.ParseInt(ts.timestamp(), 10, 64)
+	after := time.Now().UnixMilli()
+	if err != nil || got < before || got > after {
+		t.Errorf("now() = %d, want between %d and %d", got, before, after)
+	}
+}
+
+func TestTimestampFormatString(t *testing.T) {
+	if got := TimestampUnixNanos.String(); got != "unixnanos" {
+		t.Errorf("String() = %q, want unixnanos", got)
+	}
+	if got := TimestampFormat(9).String(); got != "TimestampFormat(9)" {
+		t.Errorf("String() = %q, want TimestampFormat(9)", got)
+	}
+}
+
+// TestClientTimestampFormat tests that generated timestamps use the
+// configured format and caller-set timestamps are kept.
+func TestClientTimestampFormat(t *testing.T) {
+	var got []LogEntry
+	client, err := New("http://unused.invalid", validAPIKey(),
+		WithTransport(transportFunc(func(_ context.Context, entries []LogEntry) (*IngestResponse, error) {
+			got = append(got, entries...)
+			return nil, nil
+		})),
+		WithTimestampFormat(TimestampUnixMillis),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("generated")
+	client.Log(LogEntry{Level: LevelInfo, Message: "explicit", Timestamp: "2024-01-01T00:00:00Z"})
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("got %d entries, want 2", len(got))
+	}
+	if _, err := strconv.ParseInt(got[0].Timestamp, 10, 64); err != nil {
+		t.Errorf("generated Timestamp = %q, want Unix milliseconds", got[0].Timestamp)
+	}
+	if got[1].Timestamp != "2024-01-01T00:00:00Z" {
+		t.Errorf("explicit Timestamp = %q, want it unchanged", got[1].Timestamp)
+	}
+}
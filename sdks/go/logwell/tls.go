@@ -0,0 +1,41 @@
+package logwell
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+)
+
+// LoadTLSConfig builds a TLS configuration for WithTLSConfig from PEM
+// files. caFile, if set, replaces the system roots with the certificates it
+// contains (for servers behind internal PKI). certFile and keyFile, if set,
+// provide the client certificate presented for mTLS; both or neither must
+// be given.
+func LoadTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, NewErrorWithCause(ErrInvalidConfig, "failed to read CA file", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, NewError(ErrInvalidConfig, "CA file contains no PEM certificates")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if (certFile == "") != (keyFile == "") {
+		return nil, NewError(ErrInvalidConfig, "certFile and keyFile must be set together")
+	}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, NewErrorWithCause(ErrInvalidConfig, "failed to load client certificate", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,134 @@
+package logwell
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeClientCert generates a self-signed client certificate and writes it
+// and its key as PEM files, returning their paths and the certificate.
+func writeClientCert(t *testing.T, dir string) (certFile, keyFile string, cert *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "logwell-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(dir, "client.pem")
+	keyFile = filepath.Join(dir, "client-key.pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile, cert
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadTLSConfigMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, clientCert := writeClientCert(t, dir)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: 1})
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	writePEM(t, caFile, "CERTIFICATE", server.Certificate().Raw)
+
+	tlsConfig, err := LoadTLSConfig(caFile, certFile, keyFile)
+	if err != nil {
+		t.Fatalf("LoadTLSConfig() error = %v", err)
+	}
+
+	cfg := newDefaultConfig(server.URL, validAPIKey())
+	WithTLSConfig(tlsConfig)(cfg)
+	if err := validateConfig(cfg); err != nil {
+		t.Fatalf("validateConfig() error = %v", err)
+	}
+	transport := newHTTPTransportFromConfig(cfg)
+	if _, err := transport.send(context.Background(), []LogEntry{{Level: LevelInfo, Message: "test"}}); err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+
+	// Without the client certificate the handshake is rejected.
+	tlsConfig, err = LoadTLSConfig(caFile, "", "")
+	if err != nil {
+		t.Fatalf("LoadTLSConfig() error = %v", err)
+	}
+	cfg.TLSConfig = tlsConfig
+	transport = newHTTPTransportFromConfig(cfg)
+	if _, err := transport.send(context.Background(), []LogEntry{{Level: LevelInfo, Message: "test"}}); err == nil {
+		t.Error("send() without client certificate error = nil, want handshake failure")
+	}
+}
+
+func TestLoadTLSConfigErrors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, _ := writeClientCert(t, dir)
+	notPEM := filepath.Join(dir, "not.pem")
+	if err := os.WriteFile(notPEM, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                      string
+		caFile, certFile, keyFile string
+	}{
+		{"missing CA file", filepath.Join(dir, "missing.pem"), "", ""},
+		{"CA file without certificates", notPEM, "", ""},
+		{"cert without key", "", certFile, ""},
+		{"key without cert", "", "", keyFile},
+		{"mismatched pair", "", certFile, notPEM},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTLSConfig(tt.caFile, tt.certFile, tt.keyFile)
+			assertConfigError(t, err, ErrInvalidConfig)
+		})
+	}
+}
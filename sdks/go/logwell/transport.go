@@ -1,31 +1,91 @@
 package logwell
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"math/rand"
+	"log/slog"
+	"maps"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
-const (
-	defaultMaxRetries = 3
-	baseRetryDelay    = 100 * time.Millisecond
-	maxRetryDelay     = 10 * time.Second
-	jitterFactor      = 0.3 // 30% jitter
-)
+const defaultMaxRetries = 3
+
+// Transport delivers batches in place of the built-in HTTP sender; see
+// WithTransport. Send makes a single attempt: the client still batches,
+// retries with backoff, honors the circuit breaker, and re-queues on failure.
+//
+// Return an *Error to control retries: ErrNetworkError, ErrServerError, and
+// ErrRateLimited are retried, other codes are not. Any other error is treated
+// as a retryable network failure. A nil response with a nil error counts the
+// whole batch as accepted. Send must be safe for concurrent use and must not
+// retain entries after it returns: the slice is reused. If the Transport
+// also implements io.Closer, Shutdown closes it after draining.
+type Transport interface {
+	Send(ctx context.Context, entries []LogEntry) (*IngestResponse, error)
+}
 
-// httpTransport sends log batches to the Logwell server.
+// httpTransport sends log batches to the Logwell server, or through a
+// custom Transport when one is configured.
 type httpTransport struct {
 	endpoint   string
 	apiKey     string
 	httpClient *http.Client
 	ingestURL  string
 	maxRetries int
+
+	// tuned is the SDK's own HTTP transport, whose idle connections
+	// Shutdown closes; nil when the caller's client brings a Transport.
+	tuned *http.Transport
+
+	// maxRetryAfter caps the server-requested Retry-After delay; 0 ignores it.
+	maxRetryAfter time.Duration
+
+	// requestTimeout bounds each attempt; 0 leaves it to the caller's context.
+	requestTimeout time.Duration
+
+	// backoff computes retry delays; nil uses defaultBackoff.
+	backoff BackoffPolicy
+
+	// retryDeadline bounds the retries of one send; 0 leaves it unbounded.
+	retryDeadline time.Duration
+
+	// budget, if set, limits retries across all sends (WithRetryBudget).
+	budget *retryBudget
+
+	// upload, if set, limits the body bytes sent per second across all
+	// attempts (WithMaxUploadRate).
+	upload *uploadLimiter
+
+	// headers are extra request headers from Config.Headers.
+	headers map[string]string
+
+	// signingSecret, if set, signs each request body (WithRequestSigning).
+	signingSecret []byte
+
+	// custom replaces the HTTP request of each attempt when set.
+	custom Transport
+
+	// endpoints fails over between Config.Endpoint and its fallbacks;
+	// nil when there are no fallbacks and every send goes to ingestURL.
+	endpoints *endpointPool
+
+	// clock times retry waits and the retry deadline.
+	clock Clock
+
+	// retries counts retry attempts, for Client.Stats.
+	retries atomic.Uint64
+
+	// onRetry, if set, is called before each retry.
+	onRetry func(attempt int, err error)
+
+	// debugLog, if set, is told of each scheduled retry (WithDebugLogger).
+	debugLog *slog.Logger
 }
 
 // newHTTPTransport creates a new HTTP transport with the given endpoint and API key.
@@ -34,57 +94,161 @@ func newHTTPTransport(endpoint, apiKey string) *httpTransport {
 	return &httpTransport{
 		endpoint:   endpoint,
 		apiKey:     apiKey,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: newTunedTransport(&Config{})},
 		ingestURL:  strings.TrimRight(endpoint, "/") + "/v1/ingest",
 		maxRetries: defaultMaxRetries,
+		clock:      systemClock{},
+
+		maxRetryAfter:  DefaultMaxRetryAfter,
+		requestTimeout: DefaultRequestTimeout,
 	}
 }
 
 // newHTTPTransportFromConfig creates a new HTTP transport from the given config.
 // Wires MaxRetries and HTTPClient from the config; applies a 30s default timeout
-// when no custom HTTP client is provided.
+// when no custom HTTP client is provided, and installs the tuned transport
+// (with the proxy and TLS settings) when the client has no Transport.
 func newHTTPTransportFromConfig(cfg *Config) *httpTransport {
 	httpClient := cfg.HTTPClient
 	if httpClient == nil {
 		httpClient = &http.Client{Timeout: 30 * time.Second}
-	} else if httpClient == http.DefaultClient || httpClient.Timeout == 0 {
+	} else if httpClient == http.DefaultClient || httpClient.Timeout == 0 || httpClient.Transport == nil {
 		// http.DefaultClient (and any client without a timeout) has no request
 		// timeout, which can hang flushes indefinitely. Apply a 30s default
-		// without mutating the caller's client; carry over its Transport.
+		// without mutating the caller's client; carry over its Transport. A
+		// client without a Transport also needs a copy to install one on.
+		timeout := httpClient.Timeout
+		if httpClient == http.DefaultClient || timeout == 0 {
+			timeout = 30 * time.Second
+		}
 		httpClient = &http.Client{
 			Transport:     httpClient.Transport,
 			CheckRedirect: httpClient.CheckRedirect,
 			Jar:           httpClient.Jar,
-			Timeout:       30 * time.Second,
+			Timeout:       timeout,
 		}
 	}
-	return &httpTransport{
+	var tuned *http.Transport
+	if httpClient.Transport == nil {
+		// validateTransportOptions guarantees proxy, TLS, and tuning options
+		// are only set when there is no custom Transport.
+		tuned = newTunedTransport(cfg)
+		httpClient.Transport = tuned
+	}
+	t := &httpTransport{
 		endpoint:   cfg.Endpoint,
 		apiKey:     cfg.APIKey,
 		httpClient: httpClient,
+		tuned:      tuned,
 		ingestURL:  strings.TrimRight(cfg.Endpoint, "/") + "/v1/ingest",
 		maxRetries: cfg.MaxRetries,
+		clock:      clockOrSystem(cfg.Clock),
+
+		maxRetryAfter:  cfg.MaxRetryAfter,
+		requestTimeout: cfg.RequestTimeout,
+		retryDeadline:  cfg.RetryDeadline,
+		backoff:        cfg.Backoff,
+		headers:        maps.Clone(cfg.Headers),
+		onRetry:        cfg.OnRetry,
+		debugLog:       cfg.DebugLogger,
+		custom:         cfg.Transport,
 	}
+	if cfg.RetryBudget > 0 {
+		t.budget = newRetryBudget(cfg.RetryBudget, cfg.RetryBudgetBurst, t.clock.Now)
+	}
+	if cfg.MaxUploadRate > 0 {
+		t.upload = newUploadLimiter(cfg.MaxUploadRate, t.clock)
+	}
+	if cfg.SigningSecret != "" {
+		t.signingSecret = []byte(cfg.SigningSecret)
+	}
+	if len(cfg.FallbackEndpoints) > 0 {
+		t.endpoints = newEndpointPool(append([]string{cfg.Endpoint}, cfg.FallbackEndpoints...))
+		t.endpoints.now = t.clock.Now
+	}
+	return t
+}
+
+// closeIdleConnections releases the idle connections of the SDK's own
+// HTTP transport, leaving a caller's Transport alone.
+func (t *httpTransport) closeIdleConnections() {
+	if t.tuned != nil {
+		t.tuned.CloseIdleConnections()
+	}
+}
+
+// activeEndpoint returns the base URL sends currently go to.
+func (t *httpTransport) activeEndpoint() string {
+	if t.endpoints == nil {
+		return t.endpoint
+	}
+	return t.endpoints.current()
 }
 
 // sendWithRetry sends a batch with exponential backoff retry for transient errors.
+// Every attempt carries the same idempotency key: the one on ctx, or a new
+// one if ctx has none.
 // Network errors, 5xx, and 429 are retried. 400, 401, 403 are not.
+// A Retry-After header on 429/503 responses (capped at maxRetryAfter) is
+// used as the floor for the next delay; if honoring it would overrun the
+// context deadline, the error is returned without retrying.
+// The last error is also returned, without retrying, once the retry
+// deadline would be overrun or the retry budget is spent.
 func (t *httpTransport) sendWithRetry(ctx context.Context, logs []LogEntry) (*IngestResponse, error) {
+	if _, ok := IdempotencyKeyFromContext(ctx); !ok {
+		ctx = withIdempotencyKey(ctx, newIdempotencyKey())
+	}
+	// Encode once; every attempt posts the same body.
+	var body *bodyBuffer
+	if t.custom == nil {
+		var err error
+		if body, err = t.encode(ctx, logs); err != nil {
+			return nil, err
+		}
+		defer body.release()
+	}
+
 	var lastErr error
+	var retryDeadline time.Time
+	if t.retryDeadline > 0 {
+		retryDeadline = t.clock.Now().Add(t.retryDeadline)
+	}
 
 	for attempt := 0; attempt <= t.maxRetries; attempt++ {
 		// Wait before retry (skip on first attempt)
 		if attempt > 0 {
 			delay := t.calculateBackoff(attempt)
-			select {
-			case <-ctx.Done():
-				return nil, NewErrorWithCause(ErrNetworkError, "context canceled during retry", ctx.Err())
-			case <-time.After(delay):
-				// Continue with retry
+			if floor := t.retryAfterFloor(lastErr); floor > delay {
+				delay = floor
+				if deadline, ok := ctx.Deadline(); ok && deadline.Sub(t.clock.Now()) < delay {
+					return nil, lastErr
+				}
+			}
+			if !retryDeadline.IsZero() && retryDeadline.Sub(t.clock.Now()) < delay {
+				return nil, lastErr
+			}
+			if t.budget != nil && !t.budget.take() {
+				return nil, lastErr
+			}
+			if t.debugLog != nil {
+				t.debugLog.Debug("logwell: retry scheduled", "attempt", attempt, "delay", delay, "error", lastErr)
+			}
+			if err := sleep(ctx, t.clock, delay); err != nil {
+				return nil, NewErrorWithCause(ErrNetworkError, "context canceled during retry", err)
+			}
+			t.retries.Add(1)
+			if t.onRetry != nil {
+				t.onRetry(attempt, lastErr)
 			}
 		}
 
-		resp, err := t.send(ctx, logs)
+		var resp *IngestResponse
+		var err error
+		if body != nil {
+			resp, err = t.sendEncoded(ctx, body)
+		} else {
+			resp, err = t.sendCustom(ctx, logs)
+		}
 		if err == nil {
 			return resp, nil
 		}
@@ -106,22 +270,46 @@ func (t *httpTransport) sendWithRetry(ctx context.Context, logs []LogEntry) (*In
 	return nil, lastErr
 }
 
-// calculateBackoff computes delay with exponential backoff + jitter.
-// Formula: min(baseDelay * 2^attempt, maxDelay) + 0-30% jitter
+// calculateBackoff returns the delay before retry number attempt from the
+// configured BackoffPolicy, or defaultBackoff.
 func (t *httpTransport) calculateBackoff(attempt int) time.Duration {
-	// Exponential: baseDelay * 2^attempt
-	delay := baseRetryDelay * (1 << attempt)
-
-	// Cap at max delay
-	if delay > maxRetryDelay {
-		delay = maxRetryDelay
+	backoff := t.backoff
+	if backoff == nil {
+		backoff = defaultBackoff
 	}
+	return max(backoff.Delay(attempt), 0)
+}
 
-	// Add jitter: 0-30% positive-only (aligned with TS/Python SDKs)
-	jitter := time.Duration(float64(delay) * jitterFactor * rand.Float64())
-	delay += jitter
+// retryAfterFloor returns the server-requested delay carried by err,
+// capped at maxRetryAfter, or 0 if there is none.
+func (t *httpTransport) retryAfterFloor(err error) time.Duration {
+	logwellErr, ok := err.(*Error)
+	if !ok || logwellErr.RetryAfter <= 0 || t.maxRetryAfter <= 0 {
+		return 0
+	}
+	return min(logwellErr.RetryAfter, t.maxRetryAfter)
+}
 
-	return delay
+// parseRetryAfter parses a Retry-After header value, given either as a
+// number of seconds or as an HTTP date. Returns 0 if the value is empty,
+// invalid, or in the past.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs <= 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if d := when.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
 }
 
 // isRetryableError returns true if the error is transient and should be retried.
@@ -148,7 +336,7 @@ func (t *httpTransport) isRetryableError(err error) bool {
 		return true
 	case ErrRateLimited:
 		return true
-	case ErrUnauthorized, ErrValidationError:
+	case ErrUnauthorized, ErrValidationError, ErrPayloadTooLarge:
 		return false
 	default:
 		// Unknown code - don't retry to be safe
@@ -156,41 +344,125 @@ func (t *httpTransport) isRetryableError(err error) bool {
 	}
 }
 
-// send sends a batch of log entries to the Logwell server.
+// send sends a batch of log entries to the Logwell server, at the endpoint
+// chosen by the failover pool when fallbacks are configured.
 // Returns IngestResponse on success, or an Error on failure.
 func (t *httpTransport) send(ctx context.Context, logs []LogEntry) (*IngestResponse, error) {
-	// Build request body
-	bodyBytes, err := json.Marshal(logs)
+	if t.custom != nil {
+		return t.sendCustom(ctx, logs)
+	}
+	body, err := t.encode(ctx, logs)
+	if err != nil {
+		return nil, err
+	}
+	defer body.release()
+	return t.sendEncoded(ctx, body)
+}
+
+// encode encodes logs as a request body tagged with ctx's idempotency key.
+func (t *httpTransport) encode(ctx context.Context, logs []LogEntry) (*bodyBuffer, error) {
+	key, _ := IdempotencyKeyFromContext(ctx)
+	body, err := encodeBatch(logs, key)
 	if err != nil {
 		return nil, NewErrorWithCause(ErrValidationError, "failed to marshal logs", err)
 	}
+	return body, nil
+}
+
+// sendEncoded makes one attempt to post an encoded batch, to the active
+// endpoint when failing over. With an upload rate limit it first waits
+// until the body's bytes may be sent.
+func (t *httpTransport) sendEncoded(ctx context.Context, body *bodyBuffer) (*IngestResponse, error) {
+	if err := t.throttle(ctx, body.Len()); err != nil {
+		return nil, err
+	}
+	if t.endpoints == nil {
+		return t.sendTo(ctx, t.ingestURL, body)
+	}
+	i, ingestURL := t.endpoints.pick()
+	resp, err := t.sendTo(ctx, ingestURL, body)
+	t.endpoints.report(i, err != nil && ctx.Err() == nil && isEndpointFailure(err))
+	return resp, err
+}
+
+// throttle waits until n body bytes fit the upload rate limit, if any.
+func (t *httpTransport) throttle(ctx context.Context, n int) error {
+	if t.upload == nil {
+		return nil
+	}
+	delay := t.upload.reserve(n)
+	if delay <= 0 {
+		return nil
+	}
+	if t.debugLog != nil {
+		t.debugLog.Debug("logwell: upload throttled", "bytes", n, "delay", delay)
+	}
+	if err := sleep(ctx, t.clock, delay); err != nil {
+		t.upload.cancel(n)
+		return NewErrorWithCause(ErrNetworkError, "context canceled while throttling upload", err)
+	}
+	return nil
+}
+
+// sendCustom makes one attempt through the custom Transport, bounded by
+// the request timeout.
+func (t *httpTransport) sendCustom(ctx context.Context, logs []LogEntry) (*IngestResponse, error) {
+	if t.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.requestTimeout)
+		defer cancel()
+	}
+	resp, err := t.custom.Send(ctx, logs)
+	if err == nil && resp == nil {
+		resp = &IngestResponse{Accepted: len(logs)}
+	}
+	return resp, err
+}
+
+// sendTo posts an encoded batch to ingestURL.
+func (t *httpTransport) sendTo(ctx context.Context, ingestURL string, body *bodyBuffer) (*IngestResponse, error) {
+	key, _ := IdempotencyKeyFromContext(ctx)
+	parent := ctx
+	if t.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.requestTimeout)
+		defer cancel()
+	}
 
 	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.ingestURL, bytes.NewReader(bodyBytes))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ingestURL, body.reader())
 	if err != nil {
 		return nil, NewErrorWithCause(ErrNetworkError, "failed to create request", err)
 	}
+	req.ContentLength = int64(body.Len())
+	req.GetBody = func() (io.ReadCloser, error) { return body.reader(), nil }
 
-	req.Header.Set("Authorization", "Bearer "+t.apiKey)
-	req.Header.Set("Content-Type", "application/json")
+	t.setHeaders(req, body.Bytes())
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
 
 	// Execute request
 	resp, err := t.httpClient.Do(req)
 	if err != nil {
-		return nil, NewErrorWithCause(ErrNetworkError, "request failed", err)
+		return nil, t.attemptError(ctx, parent, "request failed", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, NewErrorWithCause(ErrNetworkError, "failed to read response", err)
+		return nil, t.attemptError(ctx, parent, "failed to read response", err)
 	}
 
 	// Handle error responses
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		errorMsg := t.parseErrorMessage(respBody, resp.StatusCode)
-		return nil, t.createError(resp.StatusCode, errorMsg)
+		errorMsg := parseErrorMessage(respBody, resp.StatusCode)
+		logwellErr := createError(resp.StatusCode, errorMsg)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			logwellErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), t.clock.Now())
+		}
+		return nil, logwellErr
 	}
 
 	// Parse successful response
@@ -202,8 +474,32 @@ func (t *httpTransport) send(ctx context.Context, logs []LogEntry) (*IngestRespo
 	return &ingestResp, nil
 }
 
+// setHeaders sets the headers of an ingest request carrying body: the
+// User-Agent, Config.Headers, authorization, and the signature, if enabled.
+func (t *httpTransport) setHeaders(req *http.Request, body []byte) {
+	req.Header.Set("User-Agent", userAgent)
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	if t.signingSecret != nil {
+		// Signed per attempt, so retries carry a fresh timestamp.
+		req.Header.Set(SignatureHeader, signRequest(t.signingSecret, time.Now(), body))
+	}
+}
+
+// attemptError wraps a failed attempt as a network error, naming the
+// request timeout when the attempt's own deadline (not the caller's) expired.
+func (t *httpTransport) attemptError(attempt, parent context.Context, message string, err error) *Error {
+	if attempt.Err() == context.DeadlineExceeded && parent.Err() == nil {
+		message = "request timed out after " + t.requestTimeout.String()
+	}
+	return NewErrorWithCause(ErrNetworkError, message, err)
+}
+
 // parseErrorMessage tries to extract an error message from the response body.
-func (t *httpTransport) parseErrorMessage(body []byte, statusCode int) string {
+func parseErrorMessage(body []byte, statusCode int) string {
 	var errResp struct {
 		Message string `json:"message"`
 		Error   string `json:"error"`
@@ -222,7 +518,7 @@ func (t *httpTransport) parseErrorMessage(body []byte, statusCode int) string {
 }
 
 // createError creates an appropriate Error based on HTTP status code.
-func (t *httpTransport) createError(status int, message string) *Error {
+func createError(status int, message string) *Error {
 	switch status {
 	case 401:
 		return NewErrorWithStatus(ErrUnauthorized, "unauthorized: "+message, status)
@@ -230,6 +526,8 @@ func (t *httpTransport) createError(status int, message string) *Error {
 		return NewErrorWithStatus(ErrValidationError, "validation error: "+message, status)
 	case 429:
 		return NewErrorWithStatus(ErrRateLimited, "rate limited: "+message, status)
+	case 413:
+		return NewErrorWithStatus(ErrPayloadTooLarge, "payload too large: "+message, status)
 	default:
 		if status >= 500 {
 			return NewErrorWithStatus(ErrServerError, "server error: "+message, status)
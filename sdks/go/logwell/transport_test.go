@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"runtime"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -152,6 +153,145 @@ func TestTransport_RetryOn429(t *testing.T) {
 	}
 }
 
+// TestTransport_RetryAfterFloor tests that a Retry-After header on 429/503
+// sets the minimum delay before the next attempt.
+func TestTransport_RetryAfterFloor(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var requestCount int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&requestCount, 1) == 1 {
+					w.Header().Set("Retry-After", "1")
+					w.WriteHeader(status)
+					return
+				}
+				json.NewEncoder(w).Encode(IngestResponse{Accepted: 1})
+			}))
+			defer server.Close()
+
+			transport := newHTTPTransport(server.URL, "test-api-key")
+
+			start := time.Now()
+			if _, err := transport.sendWithRetry(context.Background(), []LogEntry{{Level: LevelInfo, Message: "test"}}); err != nil {
+				t.Fatalf("sendWithRetry() error = %v", err)
+			}
+			if elapsed := time.Since(start); elapsed < time.Second {
+				t.Errorf("elapsed = %v, want >= 1s (Retry-After floor)", elapsed)
+			}
+		})
+	}
+}
+
+// TestTransport_RetryAfterCapped tests that the Retry-After delay is capped
+// at maxRetryAfter.
+func TestTransport_RetryAfterCapped(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requestCount, 1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: 1})
+	}))
+	defer server.Close()
+
+	transport := newHTTPTransport(server.URL, "test-api-key")
+	transport.maxRetryAfter = 300 * time.Millisecond
+
+	start := time.Now()
+	if _, err := transport.sendWithRetry(context.Background(), []LogEntry{{Level: LevelInfo, Message: "test"}}); err != nil {
+		t.Fatalf("sendWithRetry() error = %v", err)
+	}
+	elapsed := time.Since(start)
+	if elapsed < 300*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("elapsed = %v, want about the 300ms cap", elapsed)
+	}
+}
+
+// TestTransport_RetryAfterBeyondDeadline tests that a Retry-After delay
+// exceeding the context deadline returns the error instead of waiting.
+func TestTransport_RetryAfterBeyondDeadline(t *testing.T) {
+	var requestCount int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.Header().Set("Retry-After", "20")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	transport := newHTTPTransport(server.URL, "test-api-key")
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := transport.sendWithRetry(ctx, []LogEntry{{Level: LevelInfo, Message: "test"}})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("elapsed = %v, want an immediate return", elapsed)
+	}
+
+	logwellErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("error type = %T, want *Error", err)
+	}
+	if logwellErr.Code != ErrRateLimited {
+		t.Errorf("Code = %v, want %v", logwellErr.Code, ErrRateLimited)
+	}
+	if logwellErr.RetryAfter != 20*time.Second {
+		t.Errorf("RetryAfter = %v, want 20s", logwellErr.RetryAfter)
+	}
+	if got := atomic.LoadInt32(&requestCount); got != 1 {
+		t.Errorf("requestCount = %d, want 1", got)
+	}
+}
+
+// TestTransport_RetryAfterDateUsesClock tests that an HTTP-date
+// Retry-After is measured from the client's Clock.
+func TestTransport_RetryAfterDateUsesClock(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", now.Add(2*time.Minute).Format(http.TimeFormat))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	cfg := newDefaultConfig(server.URL, validAPIKey())
+	WithClock(&skipClock{now: now})(cfg)
+	WithMaxRetries(0)(cfg)
+	transport := newHTTPTransportFromConfig(cfg)
+
+	_, err := transport.sendWithRetry(context.Background(), []LogEntry{{Level: LevelInfo, Message: "test"}})
+	logwellErr, ok := err.(*Error)
+	if !ok || logwellErr.RetryAfter != 2*time.Minute {
+		t.Errorf("sendWithRetry() error = %v, want RetryAfter 2m from the client clock", err)
+	}
+}
+
+// TestParseRetryAfter tests parsing of delta-seconds and HTTP-date values.
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"60", 60 * time.Second},
+		{" 5 ", 5 * time.Second},
+		{"0", 0},
+		{"-1", 0},
+		{"soon", 0},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
 // TestTransport_NoRetryOn401 tests that 401 errors do NOT retry.
 func TestTransport_NoRetryOn401(t *testing.T) {
 	var requestCount int32
@@ -296,6 +436,80 @@ func TestTransport_ContextCancellation(t *testing.T) {
 	}
 }
 
+// TestTransport_RequestTimeout tests that a hung attempt times out and is retried.
+func TestTransport_RequestTimeout(t *testing.T) {
+	var requestCount int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requestCount, 1) == 1 {
+			// Hang the first attempt past the request timeout
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: 1})
+	}))
+	defer server.Close()
+
+	transport := newHTTPTransport(server.URL, "test-api-key")
+	transport.requestTimeout = 50 * time.Millisecond
+	logs := []LogEntry{{Level: LevelInfo, Message: "test"}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := transport.send(ctx, logs)
+	logwellErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("send() error = %v (%T), want *Error", err, err)
+	}
+	if logwellErr.Code != ErrNetworkError {
+		t.Errorf("error code = %q, want %q", logwellErr.Code, ErrNetworkError)
+	}
+	if logwellErr.Message != "request timed out after 50ms" {
+		t.Errorf("error message = %q, want request timeout", logwellErr.Message)
+	}
+
+	resp, err := transport.sendWithRetry(ctx, logs)
+	if err != nil {
+		t.Fatalf("sendWithRetry() error = %v", err)
+	}
+	if resp.Accepted != 1 {
+		t.Errorf("resp.Accepted = %d, want 1", resp.Accepted)
+	}
+	if count := atomic.LoadInt32(&requestCount); count != 2 {
+		t.Errorf("requestCount = %d, want 2", count)
+	}
+}
+
+// TestTransport_RequestTimeoutCallerDeadline tests that a caller deadline
+// shorter than the request timeout is not reported as a request timeout.
+func TestTransport_RequestTimeoutCallerDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	transport := newHTTPTransport(server.URL, "test-api-key")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := transport.send(ctx, []LogEntry{{Level: LevelInfo, Message: "test"}})
+	logwellErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("send() error = %v (%T), want *Error", err, err)
+	}
+	if logwellErr.Message != "request failed" {
+		t.Errorf("error message = %q, want %q", logwellErr.Message, "request failed")
+	}
+}
+
 // TestTransport_MaxRetriesExhausted tests that errors are returned after max retries.
 func TestTransport_MaxRetriesExhausted(t *testing.T) {
 	var requestCount int32
@@ -331,7 +545,7 @@ func TestTransport_MaxRetriesExhausted(t *testing.T) {
 	}
 }
 
-// TestTransport_BackoffCalculation tests the exponential backoff formula.
+// TestTransport_BackoffCalculation tests the default exponential backoff.
 func TestTransport_BackoffCalculation(t *testing.T) {
 	transport := newHTTPTransport("http://example.com", "test-api-key")
 
@@ -341,11 +555,11 @@ func TestTransport_BackoffCalculation(t *testing.T) {
 		minExpected  time.Duration
 		maxExpected  time.Duration
 	}{
-		// Attempt 1: 100ms * 2^1 = 200ms, +0-30% jitter = [200ms, 260ms]
+		// Attempt 1: 200ms, +0-30% jitter = [200ms, 260ms]
 		{1, 200 * time.Millisecond, 200 * time.Millisecond, 260 * time.Millisecond},
-		// Attempt 2: 100ms * 2^2 = 400ms, +0-30% jitter = [400ms, 520ms]
+		// Attempt 2: 200ms * 2 = 400ms, +0-30% jitter = [400ms, 520ms]
 		{2, 400 * time.Millisecond, 400 * time.Millisecond, 520 * time.Millisecond},
-		// Attempt 3: 100ms * 2^3 = 800ms, +0-30% jitter = [800ms, 1040ms]
+		// Attempt 3: 200ms * 4 = 800ms, +0-30% jitter = [800ms, 1040ms]
 		{3, 800 * time.Millisecond, 800 * time.Millisecond, 1040 * time.Millisecond},
 		// Attempt 10: capped at 10s, +0-30% jitter = [10s, 13s]
 		{10, 10 * time.Second, 10 * time.Second, 13 * time.Second},
@@ -400,6 +614,11 @@ func TestTransport_IsRetryableError(t *testing.T) {
 			err:       NewErrorWithStatus(ErrValidationError, "invalid format", 400),
 			retryable: false,
 		},
+		{
+			name:      "payload too large (413) is NOT retryable",
+			err:       NewErrorWithStatus(ErrPayloadTooLarge, "too large", 413),
+			retryable: false,
+		},
 		{
 			name:      "403 forbidden is NOT retryable",
 			err:       NewErrorWithStatus(ErrServerError, "forbidden", 403),
@@ -535,3 +754,164 @@ func TestTransport_RequestBody(t *testing.T) {
 		t.Errorf("receivedBody[1][\"metadata\"][\"key\"] = %v, want %v", meta["key"], "value")
 	}
 }
+
+// TestTransport_Headers tests the User-Agent and custom request headers.
+func TestTransport_Headers(t *testing.T) {
+	var received http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: 1})
+	}))
+	defer server.Close()
+
+	cfg := newDefaultConfig(server.URL, "test-api-key")
+	WithHeaders(map[string]string{"X-Tenant": "acme"})(cfg)
+	transport := newHTTPTransportFromConfig(cfg)
+
+	if _, err := transport.send(context.Background(), []LogEntry{{Level: LevelInfo, Message: "test"}}); err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+
+	wantUA := fmt.Sprintf("logwell-go/%s (%s; %s/%s)", Version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if got := received.Get("User-Agent"); got != wantUA {
+		t.Errorf("User-Agent = %q, want %q", got, wantUA)
+	}
+	if got := received.Get("X-Tenant"); got != "acme" {
+		t.Errorf("X-Tenant = %q, want %q", got, "acme")
+	}
+	if got := received.Get("Authorization"); got != "Bearer test-api-key" {
+		t.Errorf("Authorization = %q, want %q", got, "Bearer test-api-key")
+	}
+
+	// A custom User-Agent replaces the default.
+	WithHeaders(map[string]string{"user-agent": "my-app/2.0"})(cfg)
+	transport = newHTTPTransportFromConfig(cfg)
+	if _, err := transport.send(context.Background(), []LogEntry{{Level: LevelInfo, Message: "test"}}); err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	if got := received.Get("User-Agent"); got != "my-app/2.0" {
+		t.Errorf("User-Agent = %q, want %q", got, "my-app/2.0")
+	}
+}
+
+// TestTransport_Proxy tests that WithProxy routes requests through the proxy.
+func TestTransport_Proxy(t *testing.T) {
+	var proxiedHost atomic.Value
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost.Store(r.URL.Host)
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: 1})
+	}))
+	defer proxy.Close()
+
+	cfg := newDefaultConfig("http://logwell.internal:3000", "test-api-key")
+	WithProxy(proxy.URL)(cfg)
+	transport := newHTTPTransportFromConfig(cfg)
+
+	if _, err := transport.send(context.Background(), []LogEntry{{Level: LevelInfo, Message: "test"}}); err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+	if got := proxiedHost.Load(); got != "logwell.internal:3000" {
+		t.Errorf("proxied host = %v, want %q", got, "logwell.internal:3000")
+	}
+}
+
+// transportFunc adapts a function to the Transport interface.
+type transportFunc func(ctx context.Context, entries []LogEntry) (*IngestResponse, error)
+
+func (f transportFunc) Send(ctx context.Context, entries []LogEntry) (*IngestResponse, error) {
+	return f(ctx, entries)
+}
+
+// TestTransport_Custom tests that WithTransport replaces the HTTP sender
+// while keeping retries.
+func TestTransport_Custom(t *testing.T) {
+	var calls atomic.Int32
+	var delivered atomic.Int32
+	custom := transportFunc(func(ctx context.Context, entries []LogEntry) (*IngestResponse, error) {
+		if calls.Add(1) == 1 {
+			return nil, NewError(ErrNetworkError, "broker unavailable")
+		}
+		delivered.Add(int32(len(entries)))
+		return nil, nil
+	})
+
+	client, err := New("http://unused.invalid", validAPIKey(),
+		WithTransport(custom),
+		WithMaxRetries(2),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("one")
+	client.Info("two")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if got := delivered.Load(); got != 2 {
+		t.Errorf("delivered = %d, want 2", got)
+	}
+	if stats := client.Stats(); stats.Retries != 1 || stats.BatchesSent != 1 {
+		t.Errorf("Retries/BatchesSent = %d/%d, want 1/1", stats.Retries, stats.BatchesSent)
+	}
+}
+
+// TestTransport_CustomNonRetryable tests that a non-retryable *Error from a
+// custom transport is not retried and the batch is re-queued.
+func TestTransport_CustomNonRetryable(t *testing.T) {
+	var calls atomic.Int32
+	custom := transportFunc(func(ctx context.Context, entries []LogEntry) (*IngestResponse, error) {
+		calls.Add(1)
+		return nil, NewError(ErrValidationError, "rejected")
+	})
+
+	client, err := New("http://unused.invalid", validAPIKey(), WithTransport(custom))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer client.Shutdown(context.Background())
+
+	client.Info("bad")
+	if err := client.Flush(context.Background()); err == nil {
+		t.Fatal("Flush() error = nil, want validation error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calls = %d, want 1", got)
+	}
+	if q := client.Stats().Queued; q != 1 {
+		t.Errorf("Queued = %d, want 1", q)
+	}
+}
+
+// closingTransport records whether Close was called.
+type closingTransport struct {
+	transportFunc
+	closed atomic.Bool
+}
+
+func (c *closingTransport) Close() error {
+	c.closed.Store(true)
+	return nil
+}
+
+// TestTransport_CustomClosedOnShutdown tests that Shutdown closes a custom
+// transport implementing io.Closer.
+func TestTransport_CustomClosedOnShutdown(t *testing.T) {
+	custom := &closingTransport{transportFunc: func(context.Context, []LogEntry) (*IngestResponse, error) {
+		return nil, nil
+	}}
+	client, err := New("http://unused.invalid", validAPIKey(), WithTransport(custom))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := client.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if !custom.closed.Load() {
+		t.Error("Shutdown() did not close the transport")
+	}
+}
@@ -0,0 +1,102 @@
+package logwell
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// TransportTuning configures connection reuse and timeouts of the SDK's
+// default HTTP transport; see WithTransportTuning. Zero fields use the
+// defaults of DefaultTransportTuning.
+type TransportTuning struct {
+	// MaxIdleConns bounds idle connections across all hosts.
+	MaxIdleConns int
+
+	// MaxIdleConnsPerHost bounds idle connections kept per host, so
+	// concurrent flushes reuse connections instead of redialing.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept open.
+	IdleConnTimeout time.Duration
+
+	// DialTimeout bounds establishing a TCP connection.
+	DialTimeout time.Duration
+
+	// KeepAlive is the TCP keep-alive interval of open connections.
+	KeepAlive time.Duration
+
+	// TLSHandshakeTimeout bounds the TLS handshake.
+	TLSHandshakeTimeout time.Duration
+
+	// DisableHTTP2 keeps requests on HTTP/1.1.
+	DisableHTTP2 bool
+}
+
+// DefaultTransportTuning returns the connection settings used when
+// WithTransportTuning is not set.
+func DefaultTransportTuning() TransportTuning {
+	return TransportTuning{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     90 * time.Second,
+		DialTimeout:         10 * time.Second,
+		KeepAlive:           30 * time.Second,
+		TLSHandshakeTimeout: 10 * time.Second,
+	}
+}
+
+// withDefaults returns t with zero fields replaced by their defaults.
+func (t TransportTuning) withDefaults() TransportTuning {
+	d := DefaultTransportTuning()
+	if t.MaxIdleConns == 0 {
+		t.MaxIdleConns = d.MaxIdleConns
+	}
+	if t.MaxIdleConnsPerHost == 0 {
+		t.MaxIdleConnsPerHost = d.MaxIdleConnsPerHost
+	}
+	if t.IdleConnTimeout == 0 {
+		t.IdleConnTimeout = d.IdleConnTimeout
+	}
+	if t.DialTimeout == 0 {
+		t.DialTimeout = d.DialTimeout
+	}
+	if t.KeepAlive == 0 {
+		t.KeepAlive = d.KeepAlive
+	}
+	if t.TLSHandshakeTimeout == 0 {
+		t.TLSHandshakeTimeout = d.TLSHandshakeTimeout
+	}
+	return t
+}
+
+// newTunedTransport builds the default HTTP transport from cfg's tuning,
+// proxy, and TLS settings.
+func newTunedTransport(cfg *Config) *http.Transport {
+	var tuning TransportTuning
+	if cfg.TransportTuning != nil {
+		tuning = *cfg.TransportTuning
+	}
+	tuning = tuning.withDefaults()
+
+	dialer := &net.Dialer{Timeout: tuning.DialTimeout, KeepAlive: tuning.KeepAlive}
+	rt := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     !tuning.DisableHTTP2,
+		MaxIdleConns:          tuning.MaxIdleConns,
+		MaxIdleConnsPerHost:   tuning.MaxIdleConnsPerHost,
+		IdleConnTimeout:       tuning.IdleConnTimeout,
+		TLSHandshakeTimeout:   tuning.TLSHandshakeTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+	if cfg.Proxy != "" {
+		proxyURL, _ := url.Parse(cfg.Proxy) // validated by validateProxy
+		rt.Proxy = http.ProxyURL(proxyURL)
+	}
+	if cfg.TLSConfig != nil {
+		rt.TLSClientConfig = cfg.TLSConfig.Clone()
+	}
+	return rt
+}
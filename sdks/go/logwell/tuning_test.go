@@ -0,0 +1,86 @@
+package logwell
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransportTuningDefaults(t *testing.T) {
+	rt := newTunedTransport(newDefaultConfig(validEndpoint(), validAPIKey()))
+	d := DefaultTransportTuning()
+
+	if rt.MaxIdleConnsPerHost != d.MaxIdleConnsPerHost || rt.MaxIdleConns != d.MaxIdleConns {
+		t.Errorf("idle conns = %d/%d, want %d/%d", rt.MaxIdleConnsPerHost, rt.MaxIdleConns, d.MaxIdleConnsPerHost, d.MaxIdleConns)
+	}
+	if rt.IdleConnTimeout != d.IdleConnTimeout || rt.TLSHandshakeTimeout != d.TLSHandshakeTimeout {
+		t.Errorf("timeouts = %v/%v, want %v/%v", rt.IdleConnTimeout, rt.TLSHandshakeTimeout, d.IdleConnTimeout, d.TLSHandshakeTimeout)
+	}
+	if !rt.ForceAttemptHTTP2 {
+		t.Error("ForceAttemptHTTP2 = false, want true")
+	}
+	if rt.Proxy == nil {
+		t.Error("Proxy = nil, want the environment's proxy settings")
+	}
+}
+
+func TestTransportTuningOverrides(t *testing.T) {
+	cfg := newDefaultConfig(validEndpoint(), validAPIKey())
+	WithTransportTuning(TransportTuning{
+		MaxIdleConnsPerHost: 64,
+		IdleConnTimeout:     time.Minute,
+		DisableHTTP2:        true,
+	})(cfg)
+	rt := newTunedTransport(cfg)
+
+	if rt.MaxIdleConnsPerHost != 64 || rt.IdleConnTimeout != time.Minute || rt.ForceAttemptHTTP2 {
+		t.Errorf("transport = %d, %v, http2 %v; want 64, 1m0s, false", rt.MaxIdleConnsPerHost, rt.IdleConnTimeout, rt.ForceAttemptHTTP2)
+	}
+	if rt.MaxIdleConns != DefaultTransportTuning().MaxIdleConns {
+		t.Errorf("MaxIdleConns = %d, want the default for a zero field", rt.MaxIdleConns)
+	}
+}
+
+// TestTransportTuningReusesConnections tests that sequential sends reuse
+// one keep-alive connection.
+func TestTransportTuningReusesConnections(t *testing.T) {
+	var conns atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(IngestResponse{Accepted: 1})
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	transport := newHTTPTransportFromConfig(newDefaultConfig(server.URL, validAPIKey()))
+	for range 5 {
+		if _, err := transport.send(context.Background(), []LogEntry{{Level: LevelInfo, Message: "test"}}); err != nil {
+			t.Fatalf("send() error = %v", err)
+		}
+	}
+	if got := conns.Load(); got != 1 {
+		t.Errorf("connections = %d, want 1", got)
+	}
+}
+
+// TestTransportTuningKeepsCustomTransport tests that a caller's Transport is
+// used as-is.
+func TestTransportTuningKeepsCustomTransport(t *testing.T) {
+	custom := &http.Transport{}
+	cfg := newDefaultConfig(validEndpoint(), validAPIKey())
+	WithHTTPClient(&http.Client{Transport: custom, Timeout: time.Second})(cfg)
+	transport := newHTTPTransportFromConfig(cfg)
+
+	if transport.httpClient.Transport != custom || transport.tuned != nil {
+		t.Error("custom Transport replaced, want it kept")
+	}
+}
@@ -1,6 +1,6 @@
 package logwell
 
-import "time"
+import "context"
 
 // LogLevel represents log severity levels matching the Logwell server.
 type LogLevel string
@@ -25,7 +25,8 @@ type LogEntry struct {
 	// Message is the log message content (required).
 	Message string `json:"message"`
 
-	// Timestamp is the ISO8601 timestamp. Auto-generated if not provided.
+	// Timestamp is the ISO8601 timestamp. Auto-generated, in the
+	// WithTimestampFormat format, if not provided.
 	Timestamp string `json:"timestamp,omitempty"`
 
 	// Service is the service name for this log entry.
@@ -39,8 +40,34 @@ type LogEntry struct {
 
 	// LineNumber is the line number where the log was called.
 	LineNumber int `json:"lineNumber,omitempty"`
+
+	// Seq is the entry's position in the order its client queued entries,
+	// starting at 1, when WithSequenceNumbers is enabled; 0 otherwise. It
+	// orders entries whose timestamps collide or skew.
+	Seq uint64 `json:"seq,omitempty"`
 }
 
+// Processor inspects an entry before it is queued. It may enrich or rewrite
+// the entry in place and returns false to drop it, which skips the rest of
+// the chain. The entry's Metadata map belongs to the entry and may be
+// modified (it is nil when there is no metadata), but nested values may be
+// shared with the caller and must be copied before changing them. The
+// entry is reused once the chain returns, so keep a copy (*entry) rather
+// than the pointer.
+//
+// Processors run on the logging goroutine, after the minimum level check
+// and before redaction, sampling, and rate limiting. They must be safe for
+// concurrent use.
+type Processor func(entry *LogEntry) bool
+
+// ContextExtractor returns metadata to attach to an entry logged with a
+// context, such as a request ID, tenant ID, auth subject, or trace ID
+// stored in ctx. It returns nil when ctx carries nothing of interest. The
+// returned map is only read. Extractors run on the logging goroutine for
+// each DebugCtx, InfoCtx, WarnCtx, ErrorCtx, and FatalCtx call that passes
+// the level check, and must be safe for concurrent use.
+type ContextExtractor func(ctx context.Context) M
+
 // IngestResponse represents the response from the Logwell ingest API.
 type IngestResponse struct {
 	// Accepted is the number of logs accepted.
@@ -51,10 +78,10 @@ type IngestResponse struct {
 
 	// Errors contains error messages for rejected logs.
 	Errors []string `json:"errors,omitempty"`
-}
 
-// now returns the current time formatted as ISO8601.
-// Used internally for timestamp generation.
-func now() string {
-	return time.Now().UTC().Format(time.RFC3339Nano)
+	// IDs are the IDs the server assigned to the accepted logs, in the
+	// order they were sent, for correlating application events with stored
+	// records. Empty when the server does not report them; the current
+	// Logwell server does not.
+	IDs []string `json:"ids,omitempty"`
 }
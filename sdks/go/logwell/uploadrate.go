@@ -0,0 +1,48 @@
+package logwell
+
+import (
+	"sync"
+	"time"
+)
+
+// uploadLimiter is a token bucket of request body bytes shared by every
+// send of a client (WithMaxUploadRate). The bucket holds one second of
+// bytes. A send may start whenever the bucket is not in debt, even if its
+// body is larger than the bucket; the debt it leaves delays the sends after
+// it, so the average rate stays at the limit.
+type uploadLimiter struct {
+	rate  float64 // bytes per second, also the burst
+	clock Clock
+
+	mu     sync.Mutex
+	bucket rateBucket
+}
+
+// newUploadLimiter creates a full limiter allowing rate bytes per second,
+// timed by clock.
+func newUploadLimiter(rate int, clock Clock) *uploadLimiter {
+	l := &uploadLimiter{rate: float64(rate), clock: clock}
+	l.bucket = rateBucket{tokens: l.rate, last: clock.Now()}
+	return l
+}
+
+// reserve spends n bytes and returns how long the caller must wait before
+// sending them: until the debt left by earlier sends is paid off.
+func (l *uploadLimiter) reserve(n int) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bucket.refill(l.clock.Now(), l.rate, l.rate)
+	var delay time.Duration
+	if l.bucket.tokens < 0 {
+		delay = time.Duration(-l.bucket.tokens / l.rate * float64(time.Second))
+	}
+	l.bucket.tokens -= float64(n)
+	return delay
+}
+
+// cancel returns n reserved bytes that were not sent.
+func (l *uploadLimiter) cancel(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bucket.tokens = min(l.rate, l.bucket.tokens+float64(n))
+}
@@ -0,0 +1,128 @@
+package logwell
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// skipClock fires timers at once, advancing its time by each timer's
+// duration, and records the durations.
+type skipClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *skipClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *skipClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.delays = append(c.delays, d)
+	c.mu.Unlock()
+	go f()
+	return stubTimer{}
+}
+
+func (c *skipClock) waits() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration(nil), c.delays...)
+}
+
+func TestUploadLimiter(t *testing.T) {
+	clock := &skipClock{now: time.Unix(0, 0)}
+	l := newUploadLimiter(1000, clock)
+
+	if d := l.reserve(600); d != 0 {
+		t.Errorf("reserve within burst = %v, want 0", d)
+	}
+	// A batch larger than what is left goes out and delays the next one.
+	if d := l.reserve(900); d != 0 {
+		t.Errorf("reserve into debt = %v, want 0", d)
+	}
+	if d := l.reserve(100); d != 500*time.Millisecond {
+		t.Errorf("reserve behind debt = %v, want 500ms", d)
+	}
+	l.cancel(100)
+	clock.now = clock.now.Add(500 * time.Millisecond)
+	if d := l.reserve(3000); d != 0 {
+		t.Errorf("reserve after refill = %v, want 0", d)
+	}
+	if d := l.reserve(1000); d != 3*time.Second {
+		t.Errorf("reserve behind a large batch = %v, want 3s", d)
+	}
+}
+
+// TestTransport_MaxUploadRate tests that sends wait for the upload rate
+// limit, counting every attempt's body.
+func TestTransport_MaxUploadRate(t *testing.T) {
+	var mu sync.Mutex
+	var sizes []int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sizes = append(sizes, r.ContentLength)
+		mu.Unlock()
+		w.Write([]byte(`{"accepted":1}`))
+	}))
+	defer server.Close()
+
+	clock := &skipClock{now: time.Unix(0, 0)}
+	cfg := newDefaultConfig(server.URL, validAPIKey())
+	WithMaxUploadRate(MinUploadRate)(cfg)
+	WithClock(clock)(cfg)
+	transport := newHTTPTransportFromConfig(cfg)
+	logs := []LogEntry{{Level: LevelInfo, Message: strings.Repeat("x", 700)}}
+
+	for range 3 {
+		if _, err := transport.sendWithRetry(context.Background(), logs); err != nil {
+			t.Fatalf("sendWithRetry() error = %v", err)
+		}
+	}
+	waits := clock.waits()
+	if len(sizes) != 3 || len(waits) != 1 {
+		t.Fatalf("requests = %v, waits = %v; want the third send to wait once", sizes, waits)
+	}
+	want := time.Duration(float64(2*sizes[0]-MinUploadRate) / MinUploadRate * float64(time.Second))
+	if waits[0] != want {
+		t.Errorf("wait = %v, want %v for %d bytes", waits[0], want, sizes[0])
+	}
+}
+
+// TestTransport_MaxUploadRateCanceled tests that a send canceled while
+// throttled gives its bytes back.
+func TestTransport_MaxUploadRateCanceled(t *testing.T) {
+	cfg := newDefaultConfig(validEndpoint(), validAPIKey())
+	WithMaxUploadRate(MinUploadRate)(cfg)
+	WithClock(stubClock{})(cfg)
+	transport := newHTTPTransportFromConfig(cfg)
+	transport.upload.reserve(4 * MinUploadRate)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := transport.sendWithRetry(ctx, []LogEntry{{Level: LevelInfo, Message: "throttled"}})
+	assertConfigError(t, err, ErrNetworkError)
+	if d := transport.upload.reserve(0); d != 3*time.Second {
+		t.Errorf("debt after a canceled send = %v, want 3s", d)
+	}
+}
+
+func TestMaxUploadRateValidation(t *testing.T) {
+	_, err := New(validEndpoint(), validAPIKey(), WithMaxUploadRate(MinUploadRate-1))
+	assertConfigError(t, err, ErrInvalidConfig)
+
+	client, err := New(validEndpoint(), validAPIKey(), WithMaxUploadRate(MinUploadRate))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	client.Shutdown(context.Background())
+}
@@ -0,0 +1,13 @@
+package logwell
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Version is the SDK version reported in the User-Agent header.
+const Version = "1.2.0"
+
+// userAgent identifies SDK traffic to proxies and the server, e.g.
+// "logwell-go/1.2.0 (go1.25.0; linux/amd64)".
+var userAgent = fmt.Sprintf("logwell-go/%s (%s; %s/%s)", Version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
@@ -0,0 +1,101 @@
+package logwell
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// maxWriterLine bounds the partial line a Writer buffers; a longer line is
+// logged in pieces.
+const maxWriterLine = 64 << 10
+
+// writerLevelRegex matches a level marker such as "ERROR:" or "[warn]" at
+// the start of a line, after an optional standard library log date and
+// time.
+var writerLevelRegex = regexp.MustCompile(
+	`^((?:\d{4}/\d{2}/\d{2} )?(?:\d{2}:\d{2}:\d{2}(?:\.\d+)? )?)` +
+		`(?i:\[(trace|debug|info|notice|warn|warning|error|err|fatal|panic|critical|crit)\]:?|(trace|debug|info|notice|warn|warning|error|err|fatal|panic|critical|crit):)\s*`)
+
+// writerLevels maps level markers to levels.
+var writerLevels = map[string]LogLevel{
+	"trace": LevelDebug, "debug": LevelDebug,
+	"info": LevelInfo, "notice": LevelInfo,
+	"warn": LevelWarn, "warning": LevelWarn,
+	"error": LevelError, "err": LevelError,
+	"fatal": LevelFatal, "panic": LevelFatal, "critical": LevelFatal, "crit": LevelFatal,
+}
+
+// logWriter is the io.Writer returned by Client.Writer.
+type logWriter struct {
+	client *Client
+	level  LogLevel
+
+	mu      sync.Mutex
+	partial []byte
+}
+
+// Writer returns an io.Writer that logs each line written to it as an
+// entry, for log.SetOutput or a third-party library's *log.Logger:
+//
+//	log.SetFlags(0) // entries carry their own timestamp
+//	log.SetOutput(client.Writer(logwell.LevelInfo))
+//
+// Lines are logged at level unless they start with a level marker such as
+// "ERROR:", "warn:", or "[DEBUG]" (ignoring case, and after a standard
+// library log date and time, if any), which sets the level and is removed
+// from the message. A FATAL marker logs at LevelFatal but does not exit;
+// that is left to log.Fatal. Empty lines are skipped, and a final line
+// without a newline is held until one is written. Writes never fail. Safe
+// for concurrent use.
+func (c *Client) Writer(level LogLevel) io.Writer {
+	return &logWriter{client: c, level: level}
+}
+
+// Write logs every complete line in p.
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data := p
+	if len(w.partial) > 0 {
+		w.partial = append(w.partial, p...)
+		data = w.partial
+	}
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(data[:i])
+		data = data[i+1:]
+	}
+	if len(data) >= maxWriterLine {
+		w.logLine(data)
+		data = nil
+	}
+	w.partial = append(w.partial[:0], data...)
+	return len(p), nil
+}
+
+// logLine logs one line, inferring its level from a leading marker.
+func (w *logWriter) logLine(line []byte) {
+	message := strings.TrimRight(string(line), "\r")
+	if strings.TrimSpace(message) == "" {
+		return
+	}
+	level := w.level
+	if m := writerLevelRegex.FindStringSubmatchIndex(message); m != nil {
+		var marker string
+		if m[4] >= 0 {
+			marker = message[m[4]:m[5]]
+		} else {
+			marker = message[m[6]:m[7]]
+		}
+		level = writerLevels[strings.ToLower(marker)]
+		message = message[:m[3]] + message[m[1]:]
+	}
+	w.client.log(level, message)
+}
@@ -0,0 +1,99 @@
+package logwell
+
+import (
+	"context"
+	"log"
+	"strings"
+	"testing"
+)
+
+// newWriterClient returns a client collecting its entries in got.
+func newWriterClient(t *testing.T, got *[]LogEntry) *Client {
+	t.Helper()
+	client, err := New(validEndpoint(), validAPIKey(), WithFatalBehavior(LogOnly),
+		WithTransport(transportFunc(func(_ context.Context, logs []LogEntry) (*IngestResponse, error) {
+			*got = append(*got, logs...)
+			return &IngestResponse{Accepted: len(logs)}, nil
+		})))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { client.Shutdown(context.Background()) })
+	return client
+}
+
+func TestClientWriter(t *testing.T) {
+	var got []LogEntry
+	client := newWriterClient(t, &got)
+	w := client.Writer(LevelInfo)
+
+	w.Write([]byte("plain line\nERROR: disk full\r\n\n[warn] slow query\n"))
+	w.Write([]byte("2024/01/15 10:30:00 debug: cache miss\n"))
+	w.Write([]byte("Error occurred without a colon\n"))
+	w.Write([]byte("FATAL: giving up\n"))
+	w.Write([]byte("split "))
+	w.Write([]byte("across writes"))
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(got) != 6 {
+		t.Fatalf("logged %d entries before the final newline, want 6: %+v", len(got), got)
+	}
+	w.Write([]byte("\n"))
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := []struct {
+		level   LogLevel
+		message string
+	}{
+		{LevelInfo, "plain line"},
+		{LevelError, "disk full"},
+		{LevelWarn, "slow query"},
+		{LevelDebug, "2024/01/15 10:30:00 cache miss"},
+		{LevelInfo, "Error occurred without a colon"},
+		{LevelFatal, "giving up"},
+		{LevelInfo, "split across writes"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("logged %d entries, want %d", len(got), len(want))
+	}
+	for i, e := range got {
+		if e.Level != want[i].level || e.Message != want[i].message {
+			t.Errorf("entry %d = %s %q, want %s %q", i, e.Level, e.Message, want[i].level, want[i].message)
+		}
+	}
+}
+
+func TestClientWriterStdLogger(t *testing.T) {
+	var got []LogEntry
+	client := newWriterClient(t, &got)
+
+	logger := log.New(client.Writer(LevelWarn), "", 0)
+	logger.Printf("retrying in %ds", 5)
+	logger.Print("INFO: connected")
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Level != LevelWarn || got[0].Message != "retrying in 5s" ||
+		got[1].Level != LevelInfo || got[1].Message != "connected" {
+		t.Errorf("logged %+v", got)
+	}
+}
+
+func TestClientWriterLongLine(t *testing.T) {
+	var got []LogEntry
+	client := newWriterClient(t, &got)
+
+	n, err := client.Writer(LevelInfo).Write([]byte(strings.Repeat("x", maxWriterLine)))
+	if n != maxWriterLine || err != nil {
+		t.Errorf("Write() = %d, %v", n, err)
+	}
+	if err := client.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Errorf("logged %d entries, want the overlong line logged without a newline", len(got))
+	}
+}